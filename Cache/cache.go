@@ -44,14 +44,15 @@ import (
 	"time"
 
 	chrono "github.com/DigiStratum/GoLib/Chrono"
-	cfg "github.com/DigiStratum/GoLib/Config"
+	"github.com/DigiStratum/GoLib/Data"
+	cfg "github.com/DigiStratum/GoLib/Data/config"
 	"github.com/DigiStratum/GoLib/Data/sizeable"
 )
 
 type expiringItems []*cacheItem
 
 type CacheIfc interface {
-	Configure(config cfg.ConfigIfc) error // cfg.ConfigurableIfc
+	Configure(config cfg.ConfigIfc) error
 	SetTimeSource(timeSource chrono.TimeSourceIfc)
 	IsEmpty() bool
 	Size() int64
@@ -70,6 +71,8 @@ type CacheIfc interface {
 }
 
 type Cache struct {
+	*cfg.Configurable
+
 	cache           map[string]*cacheItem
 	totalCountLimit int
 
@@ -96,6 +99,27 @@ type Cache struct {
 // Make a new one of these!
 func NewCache() *Cache {
 	cache := Cache{}
+	cache.Configurable = cfg.NewConfigurable(
+		cfg.NewConfigItem("newItemExpires").
+			SetType(cfg.CONFIG_ITEM_TYPE_INTEGER).
+			SetDescription("New items added to cache will expire in this count of seconds; 0 (default) = no expiration").
+			SetExample(data.NewInteger(3600)).
+			CaptureWith(cfg.CaptureInt64(&cache.newItemExpires)),
+
+		// When a limit is in place, the Least Recently Used (LRU) item will be evicted to make room for the new one
+		cfg.NewConfigItem("totalCountLimit").
+			SetType(cfg.CONFIG_ITEM_TYPE_INTEGER).
+			SetDescription("New items added to cache won't drive total count above this; 0 (default) = unlimited").
+			SetExample(data.NewInteger(1000)).
+			CaptureWith(cfg.CaptureInt(&cache.totalCountLimit)),
+
+		// When a limit is in place, the Least Recently Used (LRU) item(s) will be evicted to make room for the new one
+		cfg.NewConfigItem("totalSizeLimit").
+			SetType(cfg.CONFIG_ITEM_TYPE_INTEGER).
+			SetDescription("New items added to cache won't drive total size (bytes) of all items above this; 0 (default) = unlimited").
+			SetExample(data.NewInteger(10485760)).
+			CaptureWith(cfg.CaptureInt64(&cache.totalSizeLimit)),
+	)
 	cache.init()
 	return &cache
 }
@@ -104,40 +128,15 @@ func NewCache() *Cache {
 // cfg.ConfigurableIfc Public Interface
 // -------------------------------------------------------------------------------------------------
 
+// Configuration takes effect immediately; there is no separate Start() for a Cache, and Configure()
+// may be called again to change limits (those not in config are left as they were)
 func (r *Cache) Configure(config cfg.ConfigIfc) error {
 	if nil == config {
 		return fmt.Errorf("Cache.Configure() - Configuration was nil")
 	}
-
-	// New items added to cache will expire in this count of seconds; 0 (default) = no expiration
-	if config.Has("newItemExpires") {
-		newItemExpires := config.GetInt64("newItemExpires")
-		if nil != newItemExpires {
-			r.newItemExpires = *newItemExpires
-		}
-		//fmt.Printf("Cache::Configure() - newItemExpires = %d\n", r.newItemExpires)
-	}
-
-	// New items added to cache won't drive total count above this; 0 (default) = unlimited
-	// When a limit is in place, the Least Recently Used (LRU) item will be evicted to make room for the new one
-	if config.Has("totalCountLimit") {
-		totalCountLimit := config.GetInt64("totalCountLimit")
-		if nil != totalCountLimit {
-			r.totalCountLimit = int(*totalCountLimit)
-		}
-		//fmt.Printf("Cache::Configure() - totalCountLimit = %d\n", r.totalCountLimit)
-	}
-
-	// New items  added to cache we won't drive total size of all items above this; 0 = unlimited
-	// When a limit is in place, the Least Recently Used (LRU) item(s) will be evicted to make room for the new one
-	if config.Has("totalSizeLimit") {
-		totalSizeLimit := config.GetInt64("totalSizeLimit")
-		if nil != totalSizeLimit {
-			r.totalSizeLimit = *totalSizeLimit
-		}
-		//fmt.Printf("Cache::Configure() - totalSizeLimit = %d\n", r.totalSizeLimit)
+	if err := r.Configurable.Reconfigure(config); nil != err {
+		return fmt.Errorf("Cache.Configure() - %s", err.Error())
 	}
-
 	return nil
 }
 
@@ -14,7 +14,9 @@ import (
 	chrono "github.com/DigiStratum/GoLib/Chrono"
 	. "github.com/DigiStratum/GoLib/Testing"
 
-	cfg "github.com/DigiStratum/GoLib/Config"
+	legacy "github.com/DigiStratum/GoLib/Config"
+	"github.com/DigiStratum/GoLib/Data"
+	cfg "github.com/DigiStratum/GoLib/Data/config"
 	"github.com/DigiStratum/GoLib/Data/sizeable"
 	"github.com/DigiStratum/GoLib/Process/runnable"
 )
@@ -239,7 +241,8 @@ func TestThat_Cache_Set_CausesPruning_WhenCountOverLimit(t *testing.T) {
 	// Test
 	countLimit := 5
 	config := cfg.NewConfig()
-	config.Set("totalCountLimit", fmt.Sprintf("%d", countLimit))
+	config.PrepareObject()
+	config.SetObjectProperty("totalCountLimit", data.NewInteger(int64(countLimit)))
 	err := sut.Configure(config)
 	ExpectTrue((nil == err), t)
 
@@ -273,7 +276,8 @@ func TestThat_Cache_Set_CausesPruning_WhenSizeOverLimit(t *testing.T) {
 	contentFormat := "content--##"
 	sizeLimit := (count - 1) * int(sizeable.Size(contentFormat)+1) // Limit size at 10 chars * our count, less one
 	config := cfg.NewConfig()
-	config.Set("totalSizeLimit", fmt.Sprintf("%d", sizeLimit))
+	config.PrepareObject()
+	config.SetObjectProperty("totalSizeLimit", data.NewInteger(int64(sizeLimit)))
 	err := sut.Configure(config)
 	ExpectTrue((nil == err), t)
 
@@ -307,8 +311,9 @@ func TestThat_Cache_Set_CausesPruning_WhenBothOverLimit(t *testing.T) {
 	sizeLimit := (countLimit - 1) * int(sizeable.Size(contentFormat)+1) // Limit size at 10 chars * our count, less one
 
 	config := cfg.NewConfig()
-	config.Set("totalSizeLimit", fmt.Sprintf("%d", sizeLimit))
-	config.Set("totalCountLimit", fmt.Sprintf("%d", countLimit))
+	config.PrepareObject()
+	config.SetObjectProperty("totalSizeLimit", data.NewInteger(int64(sizeLimit)))
+	config.SetObjectProperty("totalCountLimit", data.NewInteger(int64(countLimit)))
 	err := sut.Configure(config)
 	ExpectTrue((nil == err), t)
 
@@ -357,7 +362,8 @@ func TestThat_Cache_Configure_PreventsSet_WhenEntryAddedExceedsLimit(t *testing.
 	// Test
 	sizeLimit := 5
 	config := cfg.NewConfig()
-	config.Set("totalSizeLimit", fmt.Sprintf("%d", sizeLimit))
+	config.PrepareObject()
+	config.SetObjectProperty("totalSizeLimit", data.NewInteger(int64(sizeLimit)))
 	err := sut.Configure(config)
 	ExpectTrue((nil == err), t)
 	val := "1234567890"
@@ -367,6 +373,64 @@ func TestThat_Cache_Configure_PreventsSet_WhenEntryAddedExceedsLimit(t *testing.
 	ExpectFalse(sut.Has("anykey"), t)
 }
 
+func TestThat_Cache_Configure_ChangesLimits_WhenCalledAgain(t *testing.T) {
+	// Setup
+	sut := NewCache()
+	defer sut.Close()
+	config1 := cfg.NewConfig()
+	config1.PrepareObject()
+	config1.SetObjectProperty("totalSizeLimit", data.NewInteger(5))
+	config2 := cfg.NewConfig()
+	config2.PrepareObject()
+	config2.SetObjectProperty("totalSizeLimit", data.NewInteger(50))
+
+	// Test
+	err1 := sut.Configure(config1)
+	res1 := sut.Set("anykey", "1234567890")
+	err2 := sut.Configure(config2)
+	res2 := sut.Set("anykey", "1234567890")
+
+	// Verify
+	ExpectNoError(err1, t)
+	ExpectFalse(res1, t)
+	ExpectNoError(err2, t)
+	ExpectTrue(res2, t)
+}
+
+func TestThat_Cache_Configure_AcceptsLegacyStringValues(t *testing.T) {
+	// Setup
+	sut := NewCache()
+	defer sut.Close()
+	legacyConfig := legacy.NewConfig()
+	legacyConfig.Set("totalSizeLimit", "5")
+	config, err := cfg.FromLegacyConfig(legacyConfig)
+	ExpectNoError(err, t)
+
+	// Test
+	err = sut.Configure(config)
+	res := sut.Set("anykey", "1234567890")
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectFalse(res, t)
+	ExpectInt(0, sut.Count(), t)
+}
+
+func TestThat_Cache_Configure_ReturnsError_ForWronglyTypedValue(t *testing.T) {
+	// Setup
+	sut := NewCache()
+	defer sut.Close()
+	config := cfg.NewConfig()
+	config.PrepareObject()
+	config.SetObjectProperty("totalSizeLimit", data.NewString("lots"))
+
+	// Test
+	err := sut.Configure(config)
+
+	// Verify
+	ExpectError(err, t)
+}
+
 func TestThat_Cache_Configure_AllowsSet_WhenEntryIsExactlyLimit(t *testing.T) {
 	// Setup
 	sut := NewCache()
@@ -376,7 +440,8 @@ func TestThat_Cache_Configure_AllowsSet_WhenEntryIsExactlyLimit(t *testing.T) {
 	content := "12345"
 	sizeLimit := sizeable.Size(content)
 	config := cfg.NewConfig()
-	config.Set("totalSizeLimit", fmt.Sprintf("%d", sizeLimit))
+	config.PrepareObject()
+	config.SetObjectProperty("totalSizeLimit", data.NewInteger(int64(sizeLimit)))
 	err := sut.Configure(config)
 	ExpectTrue((nil == err), t)
 
@@ -395,7 +460,8 @@ func TestThat_Cache_Configure_AllowsSet_WhenFullButEntryReplacesExisting(t *test
 	content := "12345"
 	sizeLimit := sizeable.Size(content)
 	config := cfg.NewConfig()
-	config.Set("totalSizeLimit", fmt.Sprintf("%d", sizeLimit))
+	config.PrepareObject()
+	config.SetObjectProperty("totalSizeLimit", data.NewInteger(int64(sizeLimit)))
 	err := sut.Configure(config)
 	ExpectTrue((nil == err), t)
 
@@ -568,7 +634,8 @@ func TestThat_Cache_pruneExpired_PurgesExpiredItems(t *testing.T) {
 	defer sut.Close()
 	ts := chrono.NewTimeSource()
 	config := cfg.NewConfig()
-	config.Set("newItemExpires", "100")
+	config.PrepareObject()
+	config.SetObjectProperty("newItemExpires", data.NewInteger(100))
 	err := sut.Configure(config)
 	ExpectTrue((nil == err), t)
 	sut.Set("expiredkey", "value1")
@@ -599,7 +666,8 @@ func TestThat_Cache_itemCanFit_ReturnsTrue_WhenItemSizeUnderOrAtLimit(t *testing
 	defer sut.Close()
 	var size int64 = 1000
 	config := cfg.NewConfig()
-	config.Set("totalSizeLimit", fmt.Sprintf("%d", size))
+	config.PrepareObject()
+	config.SetObjectProperty("totalSizeLimit", data.NewInteger(int64(size)))
 	err := sut.Configure(config)
 	ExpectTrue((nil == err), t)
 
@@ -614,7 +682,8 @@ func TestThat_Cache_itemCanFit_ReturnsFalse_WhenItemSizeOverLimit(t *testing.T)
 	defer sut.Close()
 	var size int64 = 1000
 	config := cfg.NewConfig()
-	config.Set("totalSizeLimit", fmt.Sprintf("%d", size))
+	config.PrepareObject()
+	config.SetObjectProperty("totalSizeLimit", data.NewInteger(int64(size)))
 	err := sut.Configure(config)
 	ExpectTrue((nil == err), t)
 
@@ -640,7 +709,8 @@ func TestThat_Cache_numToPrune_ReturnsZero_ForExistingItemKeyUnderSizeLimit(t *t
 	content := "12345"
 	size := sizeable.Size(content)
 	config := cfg.NewConfig()
-	config.Set("totalSizeLimit", fmt.Sprintf("%d", size+(size/2)))
+	config.PrepareObject()
+	config.SetObjectProperty("totalSizeLimit", data.NewInteger(int64(size+(size/2))))
 	err := sut.Configure(config)
 	ExpectTrue((nil == err), t)
 
@@ -658,7 +728,8 @@ func TestThat_Cache_numToPrune_CausesOneItemToBePruned_ForNewItemKeyUnderSizeLim
 	content := "12345"
 	size := sizeable.Size(content)
 	config := cfg.NewConfig()
-	config.Set("totalSizeLimit", fmt.Sprintf("%d", size+size/2)) // <- limit is too small to fit two of these...
+	config.PrepareObject()
+	config.SetObjectProperty("totalSizeLimit", data.NewInteger(int64(size+size/2))) // <- limit is too small to fit two of these...
 	err := sut.Configure(config)
 	ExpectTrue((nil == err), t)
 
@@ -681,7 +752,8 @@ func TestThat_Cache_pruneToLimits_DropsOneItem_ForNewItemKeyUnderSizeLimit(t *te
 	content := "12345"
 	size := sizeable.Size(content)
 	config := cfg.NewConfig()
-	config.Set("totalSizeLimit", fmt.Sprintf("%d", size+(size/2))) // <- not quite big enough to fit two of these...
+	config.PrepareObject()
+	config.SetObjectProperty("totalSizeLimit", data.NewInteger(int64(size+(size/2)))) // <- not quite big enough to fit two of these...
 	err := sut.Configure(config)
 	ExpectTrue((nil == err), t)
 	newKey := "newkey"
@@ -727,7 +799,8 @@ func TestThat_Cache_findUsageListElementByKey_BumpsFirstItem_CausingSecondOneToD
 	size := sizeable.Size(content)
 	sizeLimit := (size * 2) + (size / 2) // Big enough to hold two of these, but not three!
 	config := cfg.NewConfig()
-	config.Set("totalSizeLimit", fmt.Sprintf("%d", sizeLimit))
+	config.PrepareObject()
+	config.SetObjectProperty("totalSizeLimit", data.NewInteger(int64(sizeLimit)))
 	err := sut.Configure(config)
 	ExpectTrue((nil == err), t)
 
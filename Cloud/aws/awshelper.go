@@ -21,7 +21,8 @@ import (
         awssdksession "github.com/aws/aws-sdk-go/aws/session"
         awssdkcredentials "github.com/aws/aws-sdk-go/aws/credentials"
 
-	cfg "github.com/DigiStratum/GoLib/Config"
+	"github.com/DigiStratum/GoLib/Data"
+	cfg "github.com/DigiStratum/GoLib/Data/config"
 	"github.com/DigiStratum/GoLib/Process/startable"
 )
 
@@ -50,10 +51,24 @@ func NewAWSHelper() *aWSHelper {
 
 	// Declare Configuration
 	awsh.Configurable = cfg.NewConfigurable(
-		cfg.NewConfigItem("awsRegion").CaptureWith(awsh.captureConfigAwsRegion),
-		cfg.NewConfigItem("awsAccessKeyId").CaptureWith(awsh.captureConfigAwsAccessKeyId),
-		cfg.NewConfigItem("awsSecretAccessKeyId").CaptureWith(awsh.captureConfigAwsSecretAccessKeyId),
-		cfg.NewConfigItem("awsSessionToken").CaptureWith(awsh.captureConfigAwsSessionToken),
+		cfg.NewConfigItem("awsRegion").
+			SetType(cfg.CONFIG_ITEM_TYPE_STRING).
+			SetDescription("AWS Region for the session; the SDK's default if not set").
+			SetExample(data.NewString("us-west-1")).
+			CaptureWith(cfg.CaptureString(&awsh.awsRegion)),
+		cfg.NewConfigItem("awsAccessKeyId").
+			SetType(cfg.CONFIG_ITEM_TYPE_STRING).
+			SetDescription("Static credentials access key id; the SDK's credential chain if no static credentials are set").
+			CaptureWith(cfg.CaptureString(&awsh.awsAccessKeyId)),
+		cfg.NewConfigItem("awsSecretAccessKeyId").
+			SetType(cfg.CONFIG_ITEM_TYPE_STRING).
+			SetDescription("Static credentials secret access key").
+			SetExample(data.NewString("%secret:env/AWS_SECRET_ACCESS_KEY%")).
+			CaptureWith(cfg.CaptureString(&awsh.awsSecretAccessKeyId)),
+		cfg.NewConfigItem("awsSessionToken").
+			SetType(cfg.CONFIG_ITEM_TYPE_STRING).
+			SetDescription("Static credentials session token, for temporary credentials").
+			CaptureWith(cfg.CaptureString(&awsh.awsSessionToken)),
 	)
 
 	// Make Startable
@@ -84,26 +99,6 @@ func (r *aWSHelper) Configure(config cfg.ConfigIfc) error {
 	return r.Configurable.Configure(config)
 }
 
-func (r *aWSHelper) captureConfigAwsRegion(value string) error {
-	r.awsRegion = value
-	return nil
-}
-
-func (r *aWSHelper) captureConfigAwsAccessKeyId(value string) error {
-	r.awsAccessKeyId = value
-	return nil
-}
-
-func (r *aWSHelper) captureConfigAwsSecretAccessKeyId(value string) error {
-	r.awsSecretAccessKeyId = value
-	return nil
-}
-
-func (r *aWSHelper) captureConfigAwsSessionToken(value string) error {
-	r.awsSessionToken = value
-	return nil
-}
-
 // -------------------------------------------------------------------------------------------------
 // AWSHelperIfc
 // -------------------------------------------------------------------------------------------------
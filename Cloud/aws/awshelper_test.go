@@ -5,7 +5,8 @@ import(
 
 	. "github.com/DigiStratum/GoLib/Testing"
 
-	cfg "github.com/DigiStratum/GoLib/Config"
+	"github.com/DigiStratum/GoLib/Data"
+	cfg "github.com/DigiStratum/GoLib/Data/config"
 )
 
 func TestThat_AWSHelper_NewAWSHelper_ReturnsSomething(t *testing.T) {
@@ -31,10 +32,11 @@ func TestThat_AWSHelper_Configure_AppliesConfigSettings(t *testing.T) {
 	// Setup
 	sut := NewAWSHelper()
 	config := cfg.NewConfig()
-	config.Set("awsRegion", "awsRegion")
-	config.Set("awsAccessKeyId", "awsAccessKeyId")
-	config.Set("awsSecretAccessKeyId", "awsSecretAccessKeyId")
-	config.Set("awsSessionToken", "awsSessionToken")
+	config.PrepareObject().
+		SetObjectProperty("awsRegion", data.NewString("awsRegion")).
+		SetObjectProperty("awsAccessKeyId", data.NewString("awsAccessKeyId")).
+		SetObjectProperty("awsSecretAccessKeyId", data.NewString("awsSecretAccessKeyId")).
+		SetObjectProperty("awsSessionToken", data.NewString("awsSessionToken"))
 
 	// Test
 	err := sut.Configure(config)
@@ -3,7 +3,8 @@ package config
 
 /*
 
-DEPRECATED - See GoLib/Data/config replacement
+DEPRECATED - See GoLib/Data/config replacement; config.FromLegacyConfig() and config.ToLegacyConfig()
+there adapt Config data between the two while consumers migrate
 
 This Config class extends (embeds) our own HashMap with additional capabilities specific to the
 needs of managing simple configuration data for our purposes. You can load configuration data from
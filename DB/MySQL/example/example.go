@@ -28,7 +28,7 @@ import (
 	"fmt"
 	"os"
 
-	cfg "github.com/DigiStratum/GoLib/Config"
+	cfg "github.com/DigiStratum/GoLib/Data/config"
 	db "github.com/DigiStratum/GoLib/DB"
 	mysql "github.com/DigiStratum/GoLib/DB/MySQL"
 	dep "github.com/DigiStratum/GoLib/Dependencies"
@@ -36,13 +36,16 @@ import (
 
 func main() {
 	// Load configuration
-	config := cfg.NewConfig()
-	err := config.LoadFromJsonFile("example.config.json")
+	config, err := cfg.NewConfigLoader().WithFile("example.config.json").Load()
 	if nil != err {
 		dief("Error loading config JSON: %s", err)
 	}
+	dsnConfig := config.Select("db.dsn")
+	if nil == dsnConfig {
+		dief("Missing config: %s", "db.dsn")
+	}
 
-	dsn, err := getDSNFromConfig(config.GetSubsetConfig("db.dsn."))
+	dsn, err := getDSNFromConfig(cfg.FromDataValue(dsnConfig))
 	if nil != err {
 		dief("DSN Build error: %s", err)
 	}
@@ -63,7 +66,7 @@ func example_ConnectionPool(dsn db.DSN) {
 
 	// Get the connection from a MySQL connection pool
 	connFactory := mysql.NewMySQLConnectionFactory()
-	connPool := mysql.NewConnectionPool(dsn)
+	connPool := db.NewConnectionPool(dsn)
 	defer connPool.Close()
 	err := connPool.InjectDependencies(dep.NewDependencyInstance("ConnectionFactory", connFactory))
 	if nil != err {
@@ -81,7 +84,7 @@ func example_ConnectionPool(dsn db.DSN) {
 	defer conn.Release()
 
 	// Run a query through
-	query, err := conn.NewQuery(mysql.NewSQLQuery("SELECT id, task, due FROM todo;"))
+	query, err := conn.NewQuery(db.NewSQLQuery("SELECT id, task, due FROM todo;"))
 	if (nil != err) || (nil == query) {
 		dief("Query Setup Error: %s\n", err)
 	}
@@ -104,14 +107,14 @@ func example_ConnectionFactory(dsn db.DSN) {
 	}
 
 	// Wrap the raw connection
-	conn, err := mysql.NewConnection(dbconn)
+	conn, err := db.NewConnection(dbconn)
 	if nil != err {
 		dief("Error getting connection wrapper: %s\n", err)
 	}
 	defer conn.Close()
 
 	// Run a query through
-	query, err := conn.NewQuery(mysql.NewSQLQuery("SELECT id, task, due FROM todo;"))
+	query, err := conn.NewQuery(db.NewSQLQuery("SELECT id, task, due FROM todo;"))
 	if (nil != err) || (nil == query) {
 		dief("Query Setup Error: %s\n", err)
 	}
@@ -134,14 +137,14 @@ func example_Connection(dsn db.DSN) {
 	}
 
 	// Wrap the raw connection
-	conn, err := mysql.NewConnection(dbconn)
+	conn, err := db.NewConnection(dbconn)
 	if nil != err {
 		dief("Error getting connection wrapper: %s\n", err)
 	}
 	defer conn.Close()
 
 	// Run a query through
-	query, err := conn.NewQuery(mysql.NewSQLQuery("SELECT id, task, due FROM todo;"))
+	query, err := conn.NewQuery(db.NewSQLQuery("SELECT id, task, due FROM todo;"))
 	if (nil != err) || (nil == query) {
 		dief("Query Setup Error: %s\n", err)
 	}
@@ -160,7 +163,7 @@ type ResourceTask struct {
 
 type resourceTask struct {
 	resource ResourceTask
-	connPool mysql.ConnectionPoolIfc
+	connPool db.ConnectionPoolIfc
 }
 
 func (r resourceTask) GetAll() []resourceTask {
@@ -169,7 +172,7 @@ func (r resourceTask) GetAll() []resourceTask {
 		dief("%s", fmt.Sprintf("GetConnection Error: %s\n", err.Error()))
 	}
 	defer conn.Release()
-	query, err := conn.NewQuery(mysql.NewSQLQuery("SELECT id, task, due FROM todo;"))
+	query, err := conn.NewQuery(db.NewSQLQuery("SELECT id, task, due FROM todo;"))
 	results, err := query.RunReturnAll() // No args for this example
 	if nil != err {
 		dief("%s", fmt.Sprintf("Query Error: %s\n", err.Error()))
@@ -179,7 +182,7 @@ func (r resourceTask) GetAll() []resourceTask {
 	resourceTasks := make([]resourceTask, 0)
 	it := results.GetIterator()
 	for rr := it(); nil != rr; rr = it() {
-		if resultRow, ok := rr.(mysql.ResultRowIfc); ok {
+		if resultRow, ok := rr.(db.ResultRowIfc); ok {
 			rt := resourceTask{
 				resource: ResourceTask{
 					Id:   int(resultRow.Get("id").GetInt64Default(0)),
@@ -203,7 +206,7 @@ func example_ResourceObject(dsn db.DSN) {
 
 	// Get the connection from a MySQL connection pool
 	connFactory := mysql.NewMySQLConnectionFactory()
-	connPool := mysql.NewConnectionPool(dsn)
+	connPool := db.NewConnectionPool(dsn)
 	defer connPool.Close()
 	err := connPool.InjectDependencies(dep.NewDependencyInstance("ConnectionFactory", connFactory))
 	if nil != err {
@@ -236,15 +239,16 @@ func example_ResourceObject(dsn db.DSN) {
 
 func getDSNFromConfig(config cfg.ConfigIfc) (*db.DSN, error) {
 	requiredConfigKeys := []string{"User", "Passwd", "Net", "DBName"}
-	keys := config.GetKeys()
-	if !config.HasAll(requiredConfigKeys...) {
-		for _, key := range keys {
-			fmt.Printf("config key: %s\n", key)
+	if missing := config.GetMissing(requiredConfigKeys...); len(missing) > 0 {
+		for _, key := range missing {
+			fmt.Printf("missing config key: %s\n", key)
 		}
 		return nil, fmt.Errorf("Missing one or more required configuration keys")
 	}
 	dsnBuilder := db.BuildDSN()
-	dsnBuilder.Configure(config)
+	if err := dsnBuilder.Configure(config); nil != err {
+		return nil, fmt.Errorf("DSN Config error: %s", err)
+	}
 	dsn, err := dsnBuilder.Build()
 	if nil != err {
 		return nil, fmt.Errorf("DSN Build error: %s", err)
@@ -253,7 +257,7 @@ func getDSNFromConfig(config cfg.ConfigIfc) (*db.DSN, error) {
 	return dsn, nil
 }
 
-func runQueryReturnJson(query mysql.QueryIfc) string {
+func runQueryReturnJson(query db.QueryIfc) string {
 	results, err := query.RunReturnAll() // No args for this example
 	if nil != err {
 		dief("%s", fmt.Sprintf("Query Error: %s\n", err.Error()))
@@ -0,0 +1,63 @@
+package mysql
+
+/*
+
+The connection pool, queries, results and nullables that used to live here have moved up to the DB
+package, where they work with any database by way of a Dialect (see DB/dialect.go). These aliases keep
+existing consumers of this package building; new code should use the DB package directly.
+
+*/
+
+import (
+	"github.com/DigiStratum/GoLib/DB"
+)
+
+// Deprecated: use db.ConnectionCommonIfc
+type ConnectionCommonIfc = db.ConnectionCommonIfc
+// Deprecated: use db.ConnectionIfc
+type ConnectionIfc = db.ConnectionIfc
+// Deprecated: use db.ConnectionPoolIfc
+type ConnectionPoolIfc = db.ConnectionPoolIfc
+// Deprecated: use db.PooledConnectionIfc
+type PooledConnectionIfc = db.PooledConnectionIfc
+// Deprecated: use db.LeasedConnectionIfc
+type LeasedConnectionIfc = db.LeasedConnectionIfc
+// Deprecated: use db.LeasedConnectionsIfc
+type LeasedConnectionsIfc = db.LeasedConnectionsIfc
+// Deprecated: use db.QueryIfc
+type QueryIfc = db.QueryIfc
+// Deprecated: use db.SQLQueryIfc
+type SQLQueryIfc = db.SQLQueryIfc
+// Deprecated: use db.SQLQuery
+type SQLQuery = db.SQLQuery
+// Deprecated: use db.ResultIfc
+type ResultIfc = db.ResultIfc
+// Deprecated: use db.ResultSetIfc
+type ResultSetIfc = db.ResultSetIfc
+// Deprecated: use db.ResultRowIfc
+type ResultRowIfc = db.ResultRowIfc
+// Deprecated: use db.ResultRow
+type ResultRow = db.ResultRow
+
+const DEFAULT_MIN_CONNECTIONS = db.DEFAULT_MIN_CONNECTIONS
+const DEFAULT_MAX_CONNECTIONS = db.DEFAULT_MAX_CONNECTIONS
+const DEFAULT_MAX_IDLE = db.DEFAULT_MAX_IDLE
+
+// -------------------------------------------------------------------------------------------------
+// Factory Functions
+// -------------------------------------------------------------------------------------------------
+
+// Deprecated: use the same in the DB package
+var (
+	NewConnection = db.NewConnection
+	NewConnectionPool = db.NewConnectionPool
+	ConnectionPoolFromIfc = db.ConnectionPoolFromIfc
+	NewPooledConnection = db.NewPooledConnection
+	NewLeasedConnection = db.NewLeasedConnection
+	NewLeasedConnections = db.NewLeasedConnections
+	NewQuery = db.NewQuery
+	NewSQLQuery = db.NewSQLQuery
+	NewResult = db.NewResult
+	NewResultSet = db.NewResultSet
+	NewResultRow = db.NewResultRow
+)
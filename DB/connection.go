@@ -0,0 +1,253 @@
+package db
+
+/*
+
+DB Connection - sql driver abstraction
+
+Queries are written with '?' placeholders; we Rebind() them to the placeholder style of our Dialect
+(MySQL unless set otherwise) before preparing them.
+
+ref: https://github.com/go-sql-driver/mysql#interpolateparams
+ref: https://pkg.go.dev/database/sql#Tx.Stmt
+
+TODO:
+ * Should we force a single-statement transaction begin/commit for one-off queries run outside of
+   an explicit transaction?
+
+*/
+
+import (
+	"fmt"
+	"context"
+	"database/sql"
+)
+
+type ConnectionCommonIfc interface {
+	GetDialect() DialectIfc
+	InTransaction() bool
+	Begin() error
+	BeginTx(options *sql.TxOptions) error
+	Savepoint() (string, error)
+	ReleaseSavepoint(name string) error
+	RollbackToSavepoint(name string) error
+	NewQuery(query SQLQueryIfc) (QueryIfc, error)
+	Commit() error
+	Rollback() error
+	Exec(query SQLQueryIfc, args ...interface{}) (sql.Result, error)
+	Query(query SQLQueryIfc, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query SQLQueryIfc, args ...interface{}) *sql.Row
+}
+
+type ConnectionIfc interface {
+	IsConnected() bool
+	ConnectionCommonIfc
+}
+
+type connection struct {
+	conn			*sql.DB			// Read-Write Connection
+	dialect			DialectIfc		// The SQL Dialect of the database at the other end
+	transaction		*sql.Tx			// Our transaction, if we're in the middle of one
+	savepoints		[]string		// Names of the savepoints within our transaction, oldest first
+	// Local Cache structures, keyed on resolved SQL query text (see statementcache.go)
+	transactionStatements	*statementCache		// retains transaction-specific prepared statements
+	statements		*statementCache		// retains non-transaction prepared statements
+}
+
+// -------------------------------------------------------------------------------------------------
+// Factory Functions
+// -------------------------------------------------------------------------------------------------
+
+// Make a new one of these
+func NewConnection(conn *sql.DB) (*connection, error) {
+	if nil == conn { return nil, fmt.Errorf("Cannot wrap nil connection") }
+	connection := connection{
+		conn:			conn,
+		dialect:		GetDefaultDialect(),
+		statements:		newStatementCache(DEFAULT_STATEMENT_CACHE_SIZE),
+	}
+	return &connection, nil
+}
+
+// -------------------------------------------------------------------------------------------------
+// io.Closer
+// -------------------------------------------------------------------------------------------------
+
+// Drop this connection
+func (r *connection) Close() error {
+	// If we're not connected, nothing to do
+	if ! r.IsConnected() { return nil }
+	r.conn.Close()
+	r.conn = nil
+	return nil
+}
+
+// -------------------------------------------------------------------------------------------------
+// ConnectionIfc
+// -------------------------------------------------------------------------------------------------
+
+// Set the SQL Dialect of the database we are connected to (default is MySQL)
+func (r *connection) SetDialect(dialect DialectIfc) *connection {
+	if nil == dialect { dialect = GetDefaultDialect() }
+	r.dialect = dialect
+	return r
+}
+
+func (r connection) GetDialect() DialectIfc {
+	return r.dialect
+}
+
+// Check whether this connection is established
+func (r connection) IsConnected() bool {
+	if nil == r.conn { return false }
+	return nil == r.conn.Ping()
+}
+
+func (r connection) InTransaction() bool {
+	return nil != r.transaction
+}
+
+func (r *connection) Begin() error {
+	return r.BeginTx(nil)
+}
+
+// Begin a transaction with options (nil for the database's defaults) for isolation level, read-only
+func (r *connection) BeginTx(options *sql.TxOptions) error {
+	// If we're already in a Transaction...
+	if r.InTransaction() {
+		// Assume that the app has lost track of the Transaction, maybe lost the connection lease: reset!
+		err := r.Rollback()
+		if nil != err { return err }
+	}
+	var err error
+	r.transaction, err = r.conn.BeginTx(context.Background(), options)
+	// Reset the prepared statements (and savepoints) for a new transaction
+	if nil == err {
+		r.transactionStatements = newStatementCache(DEFAULT_STATEMENT_CACHE_SIZE)
+		r.savepoints = nil
+	}
+	return err
+}
+
+// Set a new savepoint within our transaction, returning its name for ReleaseSavepoint() or
+// RollbackToSavepoint(); names are by depth (sp_1, sp_2...) so they are unique within a transaction
+func (r *connection) Savepoint() (string, error) {
+	if ! r.InTransaction() { return "", fmt.Errorf("No active transaction!") }
+	name := fmt.Sprintf("sp_%d", len(r.savepoints) + 1)
+	if err := r.execTransaction("SAVEPOINT " + r.dialect.QuoteIdentifier(name)); nil != err { return "", err }
+	r.savepoints = append(r.savepoints, name)
+	return name, nil
+}
+
+// Keep the changes since the savepoint name, forgetting it (and any set since)
+func (r *connection) ReleaseSavepoint(name string) error {
+	index, err := r.findSavepoint(name)
+	if nil != err { return err }
+	if err := r.execTransaction("RELEASE SAVEPOINT " + r.dialect.QuoteIdentifier(name)); nil != err { return err }
+	r.savepoints = r.savepoints[:index]
+	return nil
+}
+
+// Undo the changes since the savepoint name, forgetting any savepoints set since (but not name itself)
+func (r *connection) RollbackToSavepoint(name string) error {
+	index, err := r.findSavepoint(name)
+	if nil != err { return err }
+	if err := r.execTransaction("ROLLBACK TO SAVEPOINT " + r.dialect.QuoteIdentifier(name)); nil != err { return err }
+	r.savepoints = r.savepoints[:index + 1]
+	return nil
+}
+
+func (r *connection) NewQuery(query SQLQueryIfc) (QueryIfc, error) {
+	return NewQuery(r, query)
+}
+
+func (r *connection) Commit() error {
+	if ! r.InTransaction() { return fmt.Errorf("No active transaction!") }
+	err := r.transaction.Commit()
+	r.transaction = nil
+	r.savepoints = nil
+	return err
+}
+
+func (r *connection) Rollback() error {
+	// Not in the middle of a Transaction? no-op, no-error!
+	if ! r.InTransaction() { return nil }
+	err := r.transaction.Rollback()
+	r.transaction = nil
+	r.savepoints = nil
+	return err
+}
+
+func (r connection) Exec(query SQLQueryIfc, args ...interface{}) (sql.Result, error) {
+	stmt, args, err := r.prepare(query, args)
+	if nil != err { return nil, err }
+	return stmt.Exec(args...)
+}
+
+func (r connection) Query(query SQLQueryIfc, args ...interface{}) (*sql.Rows, error) {
+	stmt, args, err := r.prepare(query, args)
+	if nil != err { return nil, err }
+	return stmt.Query(args...)
+}
+
+// Note: DB.(Stmt.)QueryRow always returns a non-nil value, but we return nil if query fails to resolve
+// or prepare; use Query() to get that error.
+func (r connection) QueryRow(query SQLQueryIfc, args ...interface{}) *sql.Row {
+	stmt, args, err := r.prepare(query, args)
+	if nil != err { return nil }
+	return stmt.QueryRow(args...)
+}
+
+// -------------------------------------------------------------------------------------------------
+// connection
+// -------------------------------------------------------------------------------------------------
+
+// Exec sql within our transaction as it is, without preparing it (not every statement, such as
+// SAVEPOINT, may be prepared)
+func (r connection) execTransaction(sql string) error {
+	_, err := r.transaction.Exec(sql)
+	return err
+}
+
+func (r connection) findSavepoint(name string) (int, error) {
+	if ! r.InTransaction() { return 0, fmt.Errorf("No active transaction!") }
+	for index, savepoint := range r.savepoints {
+		if savepoint == name { return index, nil }
+	}
+	return 0, fmt.Errorf("No savepoint '%s' in this transaction", name)
+}
+
+// Prepare query, resolved with args, returning the args to run it with; a SQLQueryWithArgsIfc may
+// change them as it resolves (e.g. flattening '???' sets)
+func (r connection) prepare(query SQLQueryIfc, args []interface{}) (*sql.Stmt, []interface{}, error) {
+	// Resolve the query
+	var sql string
+	var err error
+	if sqlQuery, ok := query.(*SQLQuery); ok {
+		// Our SQLQuery sees quoted text in the query as our Dialect does
+		sql, args, err = sqlQuery.resolveWithArgsFor(r.dialect, args...)
+	} else if queryWithArgs, ok := query.(SQLQueryWithArgsIfc); ok {
+		sql, args, err = queryWithArgs.ResolveWithArgs(args...)
+	} else {
+		sql, err = query.Resolve(args...)
+	}
+	if nil != err { return nil, nil, err }
+	sql = r.dialect.Rebind(sql)
+
+	if r.InTransaction() {
+		// If this query is already in the transaction's prepared statements...
+		if stmt, ok := r.transactionStatements.get(sql); ok {
+			return stmt, args, nil
+		}
+		stmt, err := r.transaction.Prepare(sql)
+		if nil == err { r.transactionStatements.put(sql, stmt) }
+		return stmt, args, err
+	}
+
+	// If this query is already in the non-transaction prepared statements...
+	if stmt, ok := r.statements.get(sql); ok {
+		return stmt, args, nil
+	}
+	stmt, err := r.conn.Prepare(sql)
+	if nil == err { r.statements.put(sql, stmt) }
+	return stmt, args, err
+}
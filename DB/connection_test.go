@@ -1,4 +1,4 @@
-package mysql
+package db_test
 
 import(
 	"fmt"
@@ -6,7 +6,7 @@ import(
 
         "github.com/DATA-DOG/go-sqlmock"
 
-	"github.com/DigiStratum/GoLib/DB"
+	. "github.com/DigiStratum/GoLib/DB"
 	. "github.com/DigiStratum/GoLib/Testing"
 	. "github.com/DigiStratum/GoLib/Testing/mocks"
 )
@@ -22,7 +22,7 @@ func TestThat_NewConnection_ReturnsError_WhenGivenNilConnection(t *testing.T) {
 
 func TestThat_NewConnection_ReturnsConnection_WhenGivenDBConnection(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	mockDBConnection, _ := NewMockDBConnection(driverName, dsn)
 
 	// Test
@@ -35,7 +35,7 @@ func TestThat_NewConnection_ReturnsConnection_WhenGivenDBConnection(t *testing.T
 
 func TestThat_Connection_IsConnected_ReturnsTrue_WhenConnected(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	mockDBConnection, _ := NewMockDBConnection(driverName, dsn)
 	sut, _ := NewConnection(mockDBConnection)
 
@@ -48,7 +48,7 @@ func TestThat_Connection_IsConnected_ReturnsTrue_WhenConnected(t *testing.T) {
 
 func TestThat_Connection_IsConnected_ReturnsFalse_WhenNotConnected(t *testing.T) {
 	// Setup
-	sut := connection{}
+	sut := Connection{}
 
 	// Test
 	res := sut.IsConnected()
@@ -59,7 +59,7 @@ func TestThat_Connection_IsConnected_ReturnsFalse_WhenNotConnected(t *testing.T)
 
 func TestThat_Connection_IsConnected_ReturnsFalse_WhenConnectedThenClosed(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	mockDBConnection, _ := NewMockDBConnection(driverName, dsn)
 	sut, _ := NewConnection(mockDBConnection)
 
@@ -82,7 +82,7 @@ func TestThat_Connection_IsConnected_ReturnsFalse_WhenConnectedThenClosed(t *tes
 
 func TestThat_Connection_InTransaction_ReturnsFalse_WhenNotInTransaction(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	mockDBConnection, _ := NewMockDBConnection(driverName, dsn)
 	sut, _ := NewConnection(mockDBConnection)
 
@@ -92,7 +92,7 @@ func TestThat_Connection_InTransaction_ReturnsFalse_WhenNotInTransaction(t *test
 
 func TestThat_Connection_InTransaction_ReturnsTrue_WhenInTransaction(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	mockDBConnection, _ := NewMockDBConnection(driverName, dsn)
 	mockDB := GetDBConnectionMockInfo(driverName, dsn)
 	(*mockDB.Mock).ExpectBegin()
@@ -104,7 +104,7 @@ func TestThat_Connection_InTransaction_ReturnsTrue_WhenInTransaction(t *testing.
 
 func TestThat_Connection_InTransaction_ReturnsFalse_WhenInTransactionThenRollback(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	mockDBConnection, _ := NewMockDBConnection(driverName, dsn)
 	mockDB := GetDBConnectionMockInfo(driverName, dsn)
 	(*mockDB.Mock).ExpectBegin()
@@ -117,7 +117,7 @@ func TestThat_Connection_InTransaction_ReturnsFalse_WhenInTransactionThenRollbac
 
 func TestThat_Connection_InTransaction_ReturnsFalse_WhenInTransactionThenCommit(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	mockDBConnection, _ := NewMockDBConnection(driverName, dsn)
 	mockDB := GetDBConnectionMockInfo(driverName, dsn)
 	(*mockDB.Mock).ExpectBegin()
@@ -130,7 +130,7 @@ func TestThat_Connection_InTransaction_ReturnsFalse_WhenInTransactionThenCommit(
 
 func TestThat_Connection_Begin_ReturnsNoError_WhenCalledTwice(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	mockDBConnection, _ := NewMockDBConnection(driverName, dsn)
 	mockDB := GetDBConnectionMockInfo(driverName, dsn)
 	(*mockDB.Mock).ExpectBegin()
@@ -145,7 +145,7 @@ func TestThat_Connection_Begin_ReturnsNoError_WhenCalledTwice(t *testing.T) {
 // ExpectBegin(), or both or neither; We want the result of Begin() on a Closed connection
 func TestThat_Connection_Begin_ReturnsError_WhenConnectionClosed(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	mockDBConnection, _ := NewMockDBConnection(driverName, dsn)
 	mockDB := GetDBConnectionMockInfo(driverName, dsn)
 	(*mockDB.Mock).ExpectBegin()
@@ -159,7 +159,7 @@ func TestThat_Connection_Begin_ReturnsError_WhenConnectionClosed(t *testing.T) {
 func TestThat_Connection_NewQuery_ReturnsQueryNoError(t *testing.T) {
 	// Setup
 	query := "bogus query"
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	mockDBConnection, _ := NewMockDBConnection(driverName, dsn)
 	mockDB := GetDBConnectionMockInfo(driverName, dsn)
 	(*mockDB.Mock).ExpectPrepare(query)
@@ -171,7 +171,7 @@ func TestThat_Connection_NewQuery_ReturnsQueryNoError(t *testing.T) {
 
 func TestThat_Connection_Commit_ReturnsError_WhenNotInTransaction(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	mockDBConnection, _ := NewMockDBConnection(driverName, dsn)
 	sut, _ := NewConnection(mockDBConnection)
 
@@ -181,7 +181,7 @@ func TestThat_Connection_Commit_ReturnsError_WhenNotInTransaction(t *testing.T)
 
 func TestThat_Connection_Commit_ReturnsError_WhenErrorOnTransactionCommit(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	mockDBConnection, _ := NewMockDBConnection(driverName, dsn)
 	mockDB := GetDBConnectionMockInfo(driverName, dsn)
 	(*mockDB.Mock).ExpectBegin()
@@ -195,7 +195,7 @@ func TestThat_Connection_Commit_ReturnsError_WhenErrorOnTransactionCommit(t *tes
 
 func TestThat_Connection_Commit_ReturnsNoError_WhenTransactionCommits(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	mockDBConnection, _ := NewMockDBConnection(driverName, dsn)
 	mockDB := GetDBConnectionMockInfo(driverName, dsn)
 	(*mockDB.Mock).ExpectBegin()
@@ -208,7 +208,7 @@ func TestThat_Connection_Commit_ReturnsNoError_WhenTransactionCommits(t *testing
 
 func TestThat_Connection_Rollback_ReturnsNoError_WhenNotInTransaction(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	mockDBConnection, _ := NewMockDBConnection(driverName, dsn)
 	sut, _ := NewConnection(mockDBConnection)
 
@@ -218,7 +218,7 @@ func TestThat_Connection_Rollback_ReturnsNoError_WhenNotInTransaction(t *testing
 
 func TestThat_Connection_Rollback_ReturnsError_WhenErrorOnTransactionRollback(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	mockDBConnection, _ := NewMockDBConnection(driverName, dsn)
 	mockDB := GetDBConnectionMockInfo(driverName, dsn)
 	(*mockDB.Mock).ExpectBegin()
@@ -232,7 +232,7 @@ func TestThat_Connection_Rollback_ReturnsError_WhenErrorOnTransactionRollback(t
 
 func TestThat_Connection_Rollback_ReturnsNoError_WhenTransactionRollsBack_OutsideTransaction(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	mockDBConnection, _ := NewMockDBConnection(driverName, dsn)
 	mockDB := GetDBConnectionMockInfo(driverName, dsn)
 	(*mockDB.Mock).ExpectRollback()
@@ -244,7 +244,7 @@ func TestThat_Connection_Rollback_ReturnsNoError_WhenTransactionRollsBack_Outsid
 
 func TestThat_Connection_Exec_ReturnsResultNoError_WithoutArgs_OutsideTransaction(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	mockDBConnection, _ := NewMockDBConnection(driverName, dsn)
 	mockDB := GetDBConnectionMockInfo(driverName, dsn)
 	var expectedInsertId int64 = 22
@@ -262,7 +262,7 @@ func TestThat_Connection_Exec_ReturnsResultNoError_WithoutArgs_OutsideTransactio
 
 func TestThat_Connection_Exec_ReturnsError_WithoutArgs_OutsideTransaction_WhenPrepareFails(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	mockDBConnection, _ := NewMockDBConnection(driverName, dsn)
 	mockDB := GetDBConnectionMockInfo(driverName, dsn)
 	expectedQuery := "bogus query"
@@ -277,7 +277,7 @@ func TestThat_Connection_Exec_ReturnsError_WithoutArgs_OutsideTransaction_WhenPr
 
 func TestThat_Connection_Exec_ReturnsResultNoError_WithoutArgs_InsideTransaction(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	mockDBConnection, _ := NewMockDBConnection(driverName, dsn)
 	mockDB := GetDBConnectionMockInfo(driverName, dsn)
 	(*mockDB.Mock).ExpectBegin()
@@ -296,7 +296,7 @@ func TestThat_Connection_Exec_ReturnsResultNoError_WithoutArgs_InsideTransaction
 
 func TestThat_Connection_Exec_ReturnsError_WithoutArgs_InsideTransaction_WhenPrepareFails(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	mockDBConnection, _ := NewMockDBConnection(driverName, dsn)
 	mockDB := GetDBConnectionMockInfo(driverName, dsn)
 	(*mockDB.Mock).ExpectBegin()
@@ -312,7 +312,7 @@ func TestThat_Connection_Exec_ReturnsError_WithoutArgs_InsideTransaction_WhenPre
 
 func TestThat_Connection_Exec_ReturnsResultNoError_WithArgs_OutsideTransaction(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	mockDBConnection, _ := NewMockDBConnection(driverName, dsn)
 	mockDB := GetDBConnectionMockInfo(driverName, dsn)
 	var expectedInsertId int64 = 22
@@ -331,7 +331,7 @@ func TestThat_Connection_Exec_ReturnsResultNoError_WithArgs_OutsideTransaction(t
 
 func TestThat_Connection_Exec_ReturnsError_WithArgs_OutsideTransaction_WhenPrepareFails(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	mockDBConnection, _ := NewMockDBConnection(driverName, dsn)
 	mockDB := GetDBConnectionMockInfo(driverName, dsn)
 	expectedQuery := "bogus query"
@@ -347,7 +347,7 @@ func TestThat_Connection_Exec_ReturnsError_WithArgs_OutsideTransaction_WhenPrepa
 
 func TestThat_Connection_Exec_ReturnsResultNoError_WithArgs_InsideTransaction(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	mockDBConnection, _ := NewMockDBConnection(driverName, dsn)
 	mockDB := GetDBConnectionMockInfo(driverName, dsn)
 	(*mockDB.Mock).ExpectBegin()
@@ -366,7 +366,7 @@ func TestThat_Connection_Exec_ReturnsResultNoError_WithArgs_InsideTransaction(t
 
 func TestThat_Connection_Exec_ReturnsError_WithArgs_InsideTransaction_WhenPrepareFails(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	mockDBConnection, _ := NewMockDBConnection(driverName, dsn)
 	mockDB := GetDBConnectionMockInfo(driverName, dsn)
 	(*mockDB.Mock).ExpectBegin()
@@ -382,7 +382,7 @@ func TestThat_Connection_Exec_ReturnsError_WithArgs_InsideTransaction_WhenPrepar
 
 func TestThat_Connection_Query_ReturnsRowsNoError_WithoutArgs_OutsideTransaction(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	mockDBConnection, _ := NewMockDBConnection(driverName, dsn)
 	mockDB := GetDBConnectionMockInfo(driverName, dsn)
 	expectedCols := []string{ "boguscol" }
@@ -399,7 +399,7 @@ func TestThat_Connection_Query_ReturnsRowsNoError_WithoutArgs_OutsideTransaction
 
 func TestThat_Connection_Query_ReturnsError_WithoutArgs_OutsideTransaction_WhenPrepareFails(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	mockDBConnection, _ := NewMockDBConnection(driverName, dsn)
 	mockDB := GetDBConnectionMockInfo(driverName, dsn)
 	expectedQuery := "bogus query"
@@ -414,7 +414,7 @@ func TestThat_Connection_Query_ReturnsError_WithoutArgs_OutsideTransaction_WhenP
 
 func TestThat_Connection_Query_ReturnsRowsNoError_WithoutArgs_InsideTransaction(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	mockDBConnection, _ := NewMockDBConnection(driverName, dsn)
 	mockDB := GetDBConnectionMockInfo(driverName, dsn)
 	(*mockDB.Mock).ExpectBegin()
@@ -432,7 +432,7 @@ func TestThat_Connection_Query_ReturnsRowsNoError_WithoutArgs_InsideTransaction(
 
 func TestThat_Connection_Query_ReturnsError_WithoutArgs_InsideTransaction_WhenPrepareFails(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	mockDBConnection, _ := NewMockDBConnection(driverName, dsn)
 	mockDB := GetDBConnectionMockInfo(driverName, dsn)
 	(*mockDB.Mock).ExpectBegin()
@@ -448,7 +448,7 @@ func TestThat_Connection_Query_ReturnsError_WithoutArgs_InsideTransaction_WhenPr
 
 func TestThat_Connection_Query_ReturnsRowsNoError_WithArgs_OutsideTransaction(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	mockDBConnection, _ := NewMockDBConnection(driverName, dsn)
 	mockDB := GetDBConnectionMockInfo(driverName, dsn)
 	expectedCols := []string{ "boguscol" }
@@ -466,7 +466,7 @@ func TestThat_Connection_Query_ReturnsRowsNoError_WithArgs_OutsideTransaction(t
 
 func TestThat_Connection_Query_ReturnsError_WithArgs_OutsideTransaction_WhenPrepareFails(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	mockDBConnection, _ := NewMockDBConnection(driverName, dsn)
 	mockDB := GetDBConnectionMockInfo(driverName, dsn)
 	expectedQuery := "bogus query"
@@ -482,7 +482,7 @@ func TestThat_Connection_Query_ReturnsError_WithArgs_OutsideTransaction_WhenPrep
 
 func TestThat_Connection_Query_ReturnsRowsNoError_WithArgs_InsideTransaction(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	mockDBConnection, _ := NewMockDBConnection(driverName, dsn)
 	mockDB := GetDBConnectionMockInfo(driverName, dsn)
 	(*mockDB.Mock).ExpectBegin()
@@ -500,7 +500,7 @@ func TestThat_Connection_Query_ReturnsRowsNoError_WithArgs_InsideTransaction(t *
 
 func TestThat_Connection_Query_ReturnsError_WithArgs_InsideTransaction_WhenPrepareFails(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	mockDBConnection, _ := NewMockDBConnection(driverName, dsn)
 	mockDB := GetDBConnectionMockInfo(driverName, dsn)
 	(*mockDB.Mock).ExpectBegin()
@@ -517,7 +517,7 @@ func TestThat_Connection_Query_ReturnsError_WithArgs_InsideTransaction_WhenPrepa
 
 func TestThat_Connection_QueryRow_ReturnsRow_WithoutArgs_OutsideTransaction(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	mockDBConnection, _ := NewMockDBConnection(driverName, dsn)
 	mockDB := GetDBConnectionMockInfo(driverName, dsn)
 	expectedCols := []string{ "boguscol" }
@@ -534,7 +534,7 @@ func TestThat_Connection_QueryRow_ReturnsRow_WithoutArgs_OutsideTransaction(t *t
 
 func TestThat_Connection_QueryRow_ReturnsRow_WithoutArgs_InsideTransaction(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	mockDBConnection, _ := NewMockDBConnection(driverName, dsn)
 	mockDB := GetDBConnectionMockInfo(driverName, dsn)
 	(*mockDB.Mock).ExpectBegin()
@@ -552,7 +552,7 @@ func TestThat_Connection_QueryRow_ReturnsRow_WithoutArgs_InsideTransaction(t *te
 
 func TestThat_Connection_QueryRow_ReturnsRow_WithArgs_OutsideTransaction(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	mockDBConnection, _ := NewMockDBConnection(driverName, dsn)
 	mockDB := GetDBConnectionMockInfo(driverName, dsn)
 	expectedCols := []string{ "boguscol" }
@@ -570,7 +570,7 @@ func TestThat_Connection_QueryRow_ReturnsRow_WithArgs_OutsideTransaction(t *test
 
 func TestThat_Connection_QueryRow_ReturnsRow_WithArgs_InsideTransaction(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	mockDBConnection, _ := NewMockDBConnection(driverName, dsn)
 	mockDB := GetDBConnectionMockInfo(driverName, dsn)
 	(*mockDB.Mock).ExpectBegin()
@@ -1,8 +1,9 @@
-package mysql
+package db_test
 
 import(
 	"testing"
 
+	. "github.com/DigiStratum/GoLib/DB"
 	. "github.com/DigiStratum/GoLib/Testing"
 	. "github.com/DigiStratum/GoLib/Testing/mocks"
 )
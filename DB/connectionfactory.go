@@ -12,3 +12,9 @@ type ConnectionFactoryIfc interface {
 	// Return interface instead of struct so that other implementations may satisfy
 	NewConnection(dsn DSNIfc) (*sql.DB, error)
 }
+
+// A ConnectionFactory which can name its sql driver, so that a ConnectionPool may find the Dialect for it
+type DriverConnectionFactoryIfc interface {
+	ConnectionFactoryIfc
+	GetDriverName() string
+}
@@ -1,7 +1,7 @@
-package mysql
+package db
 
 /*
-This Database Connection Pool establishes one or more persistent connections to a MySQL database given a configured DSN.
+This Database Connection Pool establishes one or more persistent connections to a database given a configured DSN.
 When a consumer requests a connection from the pool, we will attempt to provide one using multiple approaches, in the
 following order of priority:
 
@@ -15,11 +15,17 @@ including overall activity, maximum age of established connections, any sort of
 "dirty" (e.g some change has been made to transaction isolation mode, etc.) We could also take this opportunity to audit
 all of the open connections to see if any others have been sitting open and idle too long and need similar treatment.
 
+For now, a connection marked dirty (see pooledconnection.go) is closed when it is released and replaced as needed to keep
+min_connections open; the rest cycle back into the pool as they are.
+
+Connections speak the Dialect the pool is given, either with SetDialect() or as the optional "Dialect" dependency;
+failing that, the one registered for the driver of the ConnectionFactory (if it names one, as DBConnectionFactory does),
+else MySQL; see dialect.go.
+
 TODO:
  * Change mutex to go-routine+channel for multithreaded orchestration
  * Look more closely at sql.DB which is a connection pool natively; would it give us enough control/visibility over state?
    * ref: https://pkg.go.dev/database/sql#DB
- * Why is this in the MySQL package? There doesn't seem to be anything MySQL-specific. Move up to DB package if possible!
  * Refactor errors to use Logger.Error()
 
  FIXME:
@@ -32,15 +38,14 @@ import (
 	"fmt"
 	"errors"
 	"sync"
-	"strconv"
 
 	"github.com/DigiStratum/GoLib/Process/startable"
-	cfg "github.com/DigiStratum/GoLib/Config"
+	"github.com/DigiStratum/GoLib/Data"
+	cfg "github.com/DigiStratum/GoLib/Data/config"
 	dep "github.com/DigiStratum/GoLib/Dependencies"
-	"github.com/DigiStratum/GoLib/DB"
 )
 
-// A Connection Pool to maintain a set of one or more persistent connections to a MySQL database
+// A Connection Pool to maintain a set of one or more persistent connections to a database
 type ConnectionPoolIfc interface {
 	// Embedded interface(s)
 	startable.StartableIfc
@@ -51,6 +56,8 @@ type ConnectionPoolIfc interface {
 	GetConnection() (*leasedConnection, error)
 	Release(leaseKey int64) error
 	GetMaxIdle() int
+	SetDialect(dialect DialectIfc) *connectionPool
+	GetDialect() DialectIfc
 	Close() error
 }
 
@@ -61,8 +68,9 @@ type connectionPool struct {
 	*cfg.Configurable
 
 	// Our own properties
-	connectionFactory		db.ConnectionFactoryIfc
-	dsn				db.DSN
+	connectionFactory		ConnectionFactoryIfc
+	dsn				DSNIfc
+	dialect				DialectIfc
 	minConnections			int
 	maxConnections			int
 	maxIdle				int
@@ -79,7 +87,7 @@ const DEFAULT_MAX_IDLE = 60
 // Factory Functions
 // -------------------------------------------------------------------------------------------------
 
-func NewConnectionPool(dsn db.DSN) *connectionPool {
+func NewConnectionPool(dsn DSNIfc) *connectionPool {
 	cp := connectionPool{
 		// Config Defaults
 		minConnections:		DEFAULT_MIN_CONNECTIONS,
@@ -96,7 +104,14 @@ func NewConnectionPool(dsn db.DSN) *connectionPool {
 		dep.NewDependency("ConnectionFactory").SetRequired().CaptureWith(
 			func (instance interface{}) bool {
 				var ok bool
-				cp.connectionFactory, ok = instance.(db.ConnectionFactoryIfc)
+				cp.connectionFactory, ok = instance.(ConnectionFactoryIfc)
+				return ok
+			},
+		),
+		dep.NewDependency("Dialect").CaptureWith(
+			func (instance interface{}) bool {
+				dialect, ok := instance.(DialectIfc)
+				if ok { cp.SetDialect(dialect) }
 				return ok
 			},
 		),
@@ -104,9 +119,18 @@ func NewConnectionPool(dsn db.DSN) *connectionPool {
 
 	// Declare Configuration
 	cp.Configurable = cfg.NewConfigurable(
-		cfg.NewConfigItem("min_connections").CaptureWith(cp.captureConfigMinConnections),
-		cfg.NewConfigItem("max_connections").CaptureWith(cp.captureConfigMaxConnections),
-		cfg.NewConfigItem("max_idle").CaptureWith(cp.captureConfigMaxIdle),
+		cfg.NewConfigItem("min_connections").
+			SetType(cfg.CONFIG_ITEM_TYPE_INTEGER).
+			SetDescription(fmt.Sprintf("Connections to establish on Start() and keep open; at least 1 (default %d)", DEFAULT_MIN_CONNECTIONS)).
+			CaptureWith(cp.captureConfigMinConnections),
+		cfg.NewConfigItem("max_connections").
+			SetType(cfg.CONFIG_ITEM_TYPE_INTEGER).
+			SetDescription(fmt.Sprintf("Most connections the pool will open at once; at least min_connections (default %d)", DEFAULT_MAX_CONNECTIONS)).
+			CaptureWith(cp.captureConfigMaxConnections),
+		cfg.NewConfigItem("max_idle").
+			SetType(cfg.CONFIG_ITEM_TYPE_INTEGER).
+			SetDescription(fmt.Sprintf("Seconds a leased connection may sit idle before the pool may reclaim it; at least 1 (default %d)", DEFAULT_MAX_IDLE)).
+			CaptureWith(cp.captureConfigMaxIdle),
 	)
 
 	// Starters
@@ -156,6 +180,7 @@ func (r *connectionPool) Release(leaseKey int64) error {
 	if ! r.leasedConnections.Release(leaseKey) {
 		return errors.New(fmt.Sprintf("Pool contains no lease key = '%d'", leaseKey))
 	}
+	r.dropDirtyConnection(leaseKey)
 	return nil
 }
 
@@ -165,6 +190,20 @@ func (r *connectionPool) GetMaxIdle() int {
 	return r.maxIdle
 }
 
+// Set the SQL Dialect for new connections (nil to find it by driver name; see above); set it before Start()
+func (r *connectionPool) SetDialect(dialect DialectIfc) *connectionPool {
+	r.dialect = dialect
+	return r
+}
+
+func (r *connectionPool) GetDialect() DialectIfc {
+	if nil != r.dialect { return r.dialect }
+	if factory, ok := r.connectionFactory.(DriverConnectionFactoryIfc); ok {
+		if dialect := GetDialectForDriver(factory.GetDriverName()); nil != dialect { return dialect }
+	}
+	return GetDefaultDialect()
+}
+
 // -------------------------------------------------------------------------------------------------
 // io.Closer
 // -------------------------------------------------------------------------------------------------
@@ -175,7 +214,7 @@ func (r *connectionPool) Close() error {
 	r.mutex.Lock(); defer r.mutex.Unlock()
 
 	// Wipe the DSN to prevent new connections from being established
-	r.dsn = db.DSN{}
+	r.dsn = nil
 	r.connectionFactory = nil
 
 	// Drop all open leases
@@ -232,30 +271,30 @@ func (r *connectionPool) Configure(config cfg.ConfigIfc) error {
 // Config Capture Funcs
 // -----------------------------------------------
 
-func (r *connectionPool) captureConfigMinConnections(value string) error {
-	v, err := strconv.Atoi(value)
+func (r *connectionPool) captureConfigMinConnections(value data.DataValueIfc) error {
+	v, err := cfg.CoerceInteger(value)
 	if nil != err { return err }
-	r.minConnections = v
+	r.minConnections = int(v)
 	if r.minConnections < 1 { r.minConnections = 1 }
 	// If Min pushed above Max, then push Max up
 	if r.maxConnections < r.minConnections { r.maxConnections = r.minConnections }
 	return nil
 }
 
-func (r *connectionPool) captureConfigMaxConnections(value string) error {
-	v, err := strconv.Atoi(value)
+func (r *connectionPool) captureConfigMaxConnections(value data.DataValueIfc) error {
+	v, err := cfg.CoerceInteger(value)
 	if nil != err { return err }
-	r.maxConnections = v
+	r.maxConnections = int(v)
 	if r.maxConnections < 1 { r.maxConnections = 1 }
 	// If Max dropped below Min, then push Min down
 	if r.maxConnections < r.minConnections { r.minConnections = r.maxConnections }
 	return nil
 }
 
-func (r *connectionPool) captureConfigMaxIdle(value string) error {
-	v, err := strconv.Atoi(value)
+func (r *connectionPool) captureConfigMaxIdle(value data.DataValueIfc) error {
+	v, err := cfg.CoerceInteger(value)
 	if nil != err { return err }
-	r.maxIdle = v
+	r.maxIdle = int(v)
 	// Max seconds since lastActiveAt for leased connections: 1 <= max_idle
 	if r.maxIdle < 1 { r.maxIdle = 1 }
 	return nil
@@ -275,6 +314,7 @@ func (r *connectionPool) createNewConnection() *pooledConnection {
 	// Wrap the raw connection into a Connection
 	newConnection, err := NewConnection(conn)
 	if nil != err { return nil }
+	newConnection.SetDialect(r.GetDialect())
 	// Wrap the new connection into a pooled connection to maintain state
 	newPooledConnection, err := NewPooledConnection(newConnection, r)
 	if nil == err { r.connections = append(r.connections, newPooledConnection) }
@@ -303,6 +343,19 @@ func (r *connectionPool) closePooledConnection(pooledConnection PooledConnection
 	return fmt.Errorf("PooledConnection not closable")
 }
 
+// If the connection leased with leaseKey is dirty (e.g. its transaction isolation changed), close it
+// rather than lease it out again, replacing it if we drop below the minimum
+func (r *connectionPool) dropDirtyConnection(leaseKey int64) {
+	for index, connection := range r.connections {
+		if ! connection.MatchesLeaseKey(leaseKey) { continue }
+		if ! connection.IsDirty() { return }
+		_ = r.closePooledConnection(connection)
+		r.connections = append(r.connections[:index], r.connections[index + 1:]...)
+		r.establishMinConnections()
+		return
+	}
+}
+
 func (r *connectionPool) findAvailableConnection() *pooledConnection {
 	for _, connection := range (*r).connections {
 		if ! connection.IsLeased() { return connection }
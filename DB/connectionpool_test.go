@@ -1,20 +1,21 @@
-package mysql
+package db_test
 
 import(
-	"fmt"
+	"database/sql"
 	"testing"
 
-	"github.com/DigiStratum/GoLib/DB"
 	dep "github.com/DigiStratum/GoLib/Dependencies"
-	cfg "github.com/DigiStratum/GoLib/Config"
+	"github.com/DigiStratum/GoLib/Data"
+	cfg "github.com/DigiStratum/GoLib/Data/config"
 
+	. "github.com/DigiStratum/GoLib/DB"
 	. "github.com/DigiStratum/GoLib/Testing"
 	. "github.com/DigiStratum/GoLib/Testing/mocks"
 )
 
 func TestThat_NewConnectionPool_ReturnsSomething(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 
 	// Test
 	sut := NewConnectionPool(*dsn)
@@ -26,7 +27,7 @@ func TestThat_NewConnectionPool_ReturnsSomething(t *testing.T) {
 // StartableIfc, DependencyInjectableIfc
 func TestThat_ConnectionPool_Start_ReturnsError_ForMissingDependencies(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	sut := NewConnectionPool(*dsn)
 
 	// Test
@@ -50,7 +51,7 @@ func TestThat_ConnectionPool_Start_ReturnsError_ForMissingDependencies(t *testin
 
 func TestThat_ConnectionPool_InjectDependencies_ReturnsNoError_ForGoodDependencies(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	sut := NewConnectionPool(*dsn)
 
 	// Test
@@ -64,13 +65,14 @@ func TestThat_ConnectionPool_InjectDependencies_ReturnsNoError_ForGoodDependenci
 
 func TestThat_ConnectionPool_Configure_ReturnsNoError_ForEmptyConfig(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	sut := NewConnectionPool(*dsn)
 	sut.InjectDependencies(
 		dep.NewDependencyInstance("ConnectionFactory", NewMockDBConnectionFactory()),
 	)
 
 	config := cfg.NewConfig()
+	config.PrepareObject()
 
 	// Test
 	err := sut.Configure(config)
@@ -81,16 +83,17 @@ func TestThat_ConnectionPool_Configure_ReturnsNoError_ForEmptyConfig(t *testing.
 
 func TestThat_ConnectionPool_Configure_ReturnsNoError_ForKnownConfigKeys(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	sut := NewConnectionPool(*dsn)
 	sut.InjectDependencies(
 		dep.NewDependencyInstance("ConnectionFactory", NewMockDBConnectionFactory()),
 	)
 
 	config := cfg.NewConfig()
-	config.Set("min_connections", "1")
-	config.Set("max_connections", "1")
-	config.Set("max_idle", "1")
+	config.PrepareObject()
+	config.SetObjectProperty("min_connections", data.NewInteger(1))
+	config.SetObjectProperty("max_connections", data.NewInteger(1))
+	config.SetObjectProperty("max_idle", data.NewInteger(1))
 
 	// Test
 	err := sut.Configure(config)
@@ -101,16 +104,17 @@ func TestThat_ConnectionPool_Configure_ReturnsNoError_ForKnownConfigKeys(t *test
 
 func TestThat_ConnectionPool_GetConnection_ReturnsLeasedConnection_WhenOneAvailable(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	sut := NewConnectionPool(*dsn)
 	sut.InjectDependencies(
 		dep.NewDependencyInstance("ConnectionFactory", NewMockDBConnectionFactory()),
 	)
 
 	config := cfg.NewConfig()
-	config.Set("min_connections", "1")
-	config.Set("max_connections", "1")
-	config.Set("max_idle", "1")
+	config.PrepareObject()
+	config.SetObjectProperty("min_connections", data.NewInteger(1))
+	config.SetObjectProperty("max_connections", data.NewInteger(1))
+	config.SetObjectProperty("max_idle", data.NewInteger(1))
 	sut.Configure(config)
 	if ! ExpectNoError(sut.Start(), t) { return }
 
@@ -124,16 +128,17 @@ func TestThat_ConnectionPool_GetConnection_ReturnsLeasedConnection_WhenOneAvaila
 
 func TestThat_ConnectionPool_GetConnection_ReturnsError_WhenNoConnectionsAvailable(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	sut := NewConnectionPool(*dsn)
 	sut.InjectDependencies(
 		dep.NewDependencyInstance("ConnectionFactory", NewMockDBConnectionFactory()),
 	)
 
 	config := cfg.NewConfig()
-	config.Set("min_connections", "1")
-	config.Set("max_connections", "1")
-	config.Set("max_idle", "1")
+	config.PrepareObject()
+	config.SetObjectProperty("min_connections", data.NewInteger(1))
+	config.SetObjectProperty("max_connections", data.NewInteger(1))
+	config.SetObjectProperty("max_idle", data.NewInteger(1))
 	sut.Configure(config)
 	sut.Start()
 
@@ -150,16 +155,17 @@ func TestThat_ConnectionPool_GetConnection_ReturnsError_WhenNoConnectionsAvailab
 
 func TestThat_ConnectionPool_GetConnection_ReturnsLeasedConnection_WhenPreviouslyReleased(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	sut := NewConnectionPool(*dsn)
 	sut.InjectDependencies(
 		dep.NewDependencyInstance("ConnectionFactory", NewMockDBConnectionFactory()),
 	)
 
 	config := cfg.NewConfig()
-	config.Set("min_connections", "1")
-	config.Set("max_connections", "1")
-	config.Set("max_idle", "1")
+	config.PrepareObject()
+	config.SetObjectProperty("min_connections", data.NewInteger(1))
+	config.SetObjectProperty("max_connections", data.NewInteger(1))
+	config.SetObjectProperty("max_idle", data.NewInteger(1))
 	sut.Configure(config)
 	sut.Start()
 
@@ -176,15 +182,16 @@ func TestThat_ConnectionPool_GetConnection_ReturnsLeasedConnection_WhenPreviousl
 
 func TestThat_ConnectionPool_GetMaxIdle_ReturnsConfiguredValue(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	sut := NewConnectionPool(*dsn)
 	sut.InjectDependencies(
 		dep.NewDependencyInstance("ConnectionFactory", NewMockDBConnectionFactory()),
 	)
 
 	config := cfg.NewConfig()
+	config.PrepareObject()
 	expected := 33
-	config.Set("max_idle", fmt.Sprintf("%d", expected))
+	config.SetObjectProperty("max_idle", data.NewInteger(int64(expected)))
 	sut.Configure(config)
 	sut.Start()
 
@@ -197,7 +204,7 @@ func TestThat_ConnectionPool_GetMaxIdle_ReturnsConfiguredValue(t *testing.T) {
 
 func TestThat_ConnectionPool_Close_ClosesConnectionPool_WithoutError(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	sut := NewConnectionPool(*dsn)
 	sut.InjectDependencies(
 		dep.NewDependencyInstance("ConnectionFactory", NewMockDBConnectionFactory()),
@@ -211,3 +218,60 @@ func TestThat_ConnectionPool_Close_ClosesConnectionPool_WithoutError(t *testing.
 	if ! ExpectNoError(err, t) { return }
 }
 
+
+func TestThat_ConnectionPool_Release_ReplacesConnection_WhenDirty(t *testing.T) {
+	// Setup
+	dsn, _ := NewDSN("user:pass@tcp(host:334)/name")
+	sut := NewConnectionPool(*dsn)
+	sut.InjectDependencies(
+		dep.NewDependencyInstance("ConnectionFactory", NewMockDBConnectionFactory()),
+	)
+
+	config := cfg.NewConfig()
+	config.PrepareObject()
+	config.SetObjectProperty("min_connections", data.NewInteger(1))
+	config.SetObjectProperty("max_connections", data.NewInteger(1))
+	config.SetObjectProperty("max_idle", data.NewInteger(1))
+	sut.Configure(config)
+	if ! ExpectNoError(sut.Start(), t) { return }
+
+	// Test
+	conn1, _ := sut.GetConnection()
+	db1 := GetDBConnectionMockInfo("mockdriver", dsn).GetConn()
+	conn1.BeginTx(&sql.TxOptions{ Isolation: sql.LevelSerializable })
+	err1 := conn1.Release()
+	conn2, err2 := sut.GetConnection()
+	db2 := GetDBConnectionMockInfo("mockdriver", dsn).GetConn()
+	err3 := conn2.Release()
+	_, err4 := sut.GetConnection()
+	db3 := GetDBConnectionMockInfo("mockdriver", dsn).GetConn()
+
+	// Verify
+	ExpectNoError(err1, t)
+	ExpectNoError(err2, t)
+	ExpectNoError(err3, t)
+	ExpectNoError(err4, t)
+	ExpectTrue(db1 != db2, t)
+	ExpectTrue(db2 == db3, t)
+}
+
+func TestThat_ConnectionPool_GetDialect_FindsDialect_ForFactoryDriver(t *testing.T) {
+	// Setup
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
+	sut1 := NewConnectionPool(*dsn)
+	sut2 := NewConnectionPool(*dsn)
+	sut3 := NewConnectionPool(*dsn).SetDialect(NewPostgreSQLDialect())
+	sut4 := NewConnectionPool(*dsn)
+
+	// Test
+	sut1.InjectDependencies(dep.NewDependencyInstance("ConnectionFactory", NewDBConnectionFactory("sqlite3")))
+	sut2.InjectDependencies(dep.NewDependencyInstance("ConnectionFactory", NewMockDBConnectionFactory()))
+	sut3.InjectDependencies(dep.NewDependencyInstance("ConnectionFactory", NewDBConnectionFactory("sqlite3")))
+	sut4.InjectDependencies(dep.NewDependencyInstance("ConnectionFactory", NewDBConnectionFactory("odbc")))
+
+	// Verify
+	ExpectString("SQLite", sut1.GetDialect().GetName(), t)
+	ExpectString("MySQL", sut2.GetDialect().GetName(), t)
+	ExpectString("PostgreSQL", sut3.GetDialect().GetName(), t)
+	ExpectString("MySQL", sut4.GetDialect().GetName(), t)
+}
@@ -0,0 +1,142 @@
+package db
+
+/*
+
+A Cursor streams the rows of a query result one at a time, as ResultRows, straight from the database
+rather than loading them all into memory as a ResultSet does; for results too big to hold at once:
+
+	cursor, err := query.RunReturnCursor(args...)
+	if nil != err { return err }
+	defer cursor.Close()
+	for cursor.Next() {
+		row := cursor.Row()
+		...
+	}
+	if err := cursor.Err(); nil != err { return err }
+
+A Cursor is also Iterable, for anything that takes an IterableIfc; the iterator returns each
+*ResultRow and then nil, closing the Cursor once the rows run out (or fail; see Err()). A Cursor holds
+its database connection busy until it is closed, so always defer Close(), which is harmless if the
+Cursor is closed already; it also keeps the rows from leaking if the caller stops early or panics.
+See Query.RunIterate() to have the closing done for you.
+
+*/
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+
+	it "github.com/DigiStratum/GoLib/Data/iterable"
+)
+
+// Return from a RunIterate() func to stop iterating early, without error
+var ErrStopIteration = errors.New("Stop iteration")
+
+type CursorIfc interface {
+	// Embedded interface(s)
+	it.IterableIfc
+
+	// Our own interface
+	Next() bool
+	Row() *ResultRow
+	Err() error
+	Close() error
+}
+
+type Cursor struct {
+	mutex		sync.Mutex
+	rows		*sql.Rows
+	scanRow		func () (*ResultRow, error)
+	row		*ResultRow
+	err		error
+	closed		bool
+}
+
+// -------------------------------------------------------------------------------------------------
+// Factory Functions
+// -------------------------------------------------------------------------------------------------
+
+// Make a new Cursor over rows, which it now owns (and closes)
+func NewCursor(rows *sql.Rows) (*Cursor, error) {
+	if nil == rows { return nil, fmt.Errorf("NewCursor(): rows is nil") }
+	scanRow, err := newResultRowScanner(rows)
+	if nil != err {
+		rows.Close()
+		return nil, err
+	}
+	return &Cursor{
+		rows:		rows,
+		scanRow:	scanRow,
+	}, nil
+}
+
+// -------------------------------------------------------------------------------------------------
+// CursorIfc Public Interface
+// -------------------------------------------------------------------------------------------------
+
+// Advance to the next row; false (with the Cursor closed) when there are no more, or on error
+func (r *Cursor) Next() bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.row = nil
+	if r.closed { return false }
+	if ! r.rows.Next() {
+		r.err = r.rows.Err()
+		r.close()
+		return false
+	}
+	row, err := r.scanRow()
+	if nil != err {
+		r.err = err
+		r.close()
+		return false
+	}
+	r.row = row
+	return true
+}
+
+// Get the row Next() advanced to, or nil if there is none
+func (r *Cursor) Row() *ResultRow {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.row
+}
+
+// Get the error, if any, that stopped Next()
+func (r *Cursor) Err() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.err
+}
+
+// Close the rows, freeing the connection; safe to call more than once
+func (r *Cursor) Close() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.row = nil
+	return r.close()
+}
+
+// -------------------------------------------------------------------------------------------------
+// IterableIfc Public Interface
+// -------------------------------------------------------------------------------------------------
+
+// Iterate over the (remaining) rows; each *ResultRow, then nil
+func (r *Cursor) GetIterator() func () interface{} {
+	return func () interface{} {
+		if ! r.Next() { return nil }
+		return r.Row()
+	}
+}
+
+// -------------------------------------------------------------------------------------------------
+// Cursor Implementation
+// -------------------------------------------------------------------------------------------------
+
+func (r *Cursor) close() error {
+	if r.closed { return nil }
+	r.closed = true
+	return r.rows.Close()
+}
@@ -0,0 +1,106 @@
+package db_test
+
+import(
+	"fmt"
+	"testing"
+
+        "github.com/DATA-DOG/go-sqlmock"
+
+	. "github.com/DigiStratum/GoLib/DB"
+	. "github.com/DigiStratum/GoLib/Testing"
+)
+
+// A query for count rows of ids 1..count, which expects its rows to be closed
+func newIterateQuery(t *testing.T, count int) (QueryIfc, sqlmock.Sqlmock) {
+	conn, mock, err := sqlmock.New()
+	if nil != err { t.Fatal(err) }
+	rows := sqlmock.NewRows([]string{ "id" })
+	for id := 1; id <= count; id++ { rows.AddRow(fmt.Sprintf("%d", id)) }
+	mock.ExpectPrepare("SELECT").ExpectQuery().WillReturnRows(rows).RowsWillBeClosed()
+	connection, _ := NewConnection(conn)
+	query, _ := connection.NewQuery(NewSQLQuery("SELECT id FROM todo"))
+	return query, mock
+}
+
+func TestThat_NewCursor_ReturnsError_WhenGivenNilRows(t *testing.T) {
+	// Test
+	sut, err := NewCursor(nil)
+
+	// Verify
+	ExpectTrue(nil == sut, t)
+	ExpectError(err, t)
+}
+
+func TestThat_Cursor_Next_StreamsRows_ThenCloses(t *testing.T) {
+	// Setup
+	query, mock := newIterateQuery(t, 3)
+	var sut CursorIfc
+	sut, err := query.RunReturnCursor() // <- ensures that we satisfy our interface
+	ExpectNoError(err, t)
+
+	// Test
+	ids := ""
+	for sut.Next() { ids += *sut.Row().Get("id").GetString() }
+
+	// Verify
+	ExpectString("123", ids, t)
+	ExpectNoError(sut.Err(), t)
+	ExpectTrue(nil == sut.Row(), t)
+	ExpectFalse(sut.Next(), t)
+	ExpectNoError(mock.ExpectationsWereMet(), t)
+}
+
+func TestThat_Cursor_Close_ClosesRows_WhenStoppedEarly(t *testing.T) {
+	// Setup
+	query, mock := newIterateQuery(t, 3)
+	sut, _ := query.RunReturnCursor()
+
+	// Test
+	ExpectTrue(sut.Next(), t)
+	err1 := sut.Close()
+	err2 := sut.Close()
+
+	// Verify
+	ExpectNoError(err1, t)
+	ExpectNoError(err2, t)
+	ExpectFalse(sut.Next(), t)
+	ExpectNoError(mock.ExpectationsWereMet(), t)
+}
+
+func TestThat_Cursor_GetIterator_ReturnsEachRow_ThenNil(t *testing.T) {
+	// Setup
+	query, mock := newIterateQuery(t, 2)
+	sut, _ := query.RunReturnCursor()
+
+	// Test
+	it := sut.GetIterator()
+	count := 0
+	for value := it(); nil != value; value = it() {
+		_, ok := value.(*ResultRow)
+		ExpectTrue(ok, t)
+		count++
+	}
+
+	// Verify
+	ExpectInt(2, count, t)
+	ExpectNoError(mock.ExpectationsWereMet(), t)
+}
+
+func TestThat_Cursor_Next_ReturnsFalseWithError_WhenRowFails(t *testing.T) {
+	// Setup
+	conn, mock, _ := sqlmock.New()
+	rows := sqlmock.NewRows([]string{ "id" }).AddRow("1").AddRow("2").RowError(1, fmt.Errorf("boom"))
+	mock.ExpectPrepare("SELECT").ExpectQuery().WillReturnRows(rows).RowsWillBeClosed()
+	connection, _ := NewConnection(conn)
+	query, _ := connection.NewQuery(NewSQLQuery("SELECT id FROM todo"))
+	sut, _ := query.RunReturnCursor()
+
+	// Test
+	count := 0
+	for sut.Next() { count++ }
+
+	// Verify
+	ExpectInt(1, count, t)
+	ExpectError(sut.Err(), t)
+	ExpectNoError(mock.ExpectationsWereMet(), t)
+}
@@ -39,3 +39,11 @@ func DBConnectionFactryFromIfc(i interface{}) (DBConnectionFactoryIfc, error) {
 func (r *DBConnectionFactory) NewConnection(dsn DSNIfc) (*sql.DB, error) {
 	return NewDBConnection(r.driver, dsn)
 }
+
+// -------------------------------------------------------------------------------------------------
+// DriverConnectionFactoryIfc Public Interface
+// -------------------------------------------------------------------------------------------------
+
+func (r *DBConnectionFactory) GetDriverName() string {
+	return r.driver
+}
@@ -0,0 +1,219 @@
+package db
+
+/*
+
+A Dialect covers the differences in SQL between one database and the next, so that everything else in
+this DB layer (the connection pool, queries, results, nullables) works the same with any of them:
+
+	* Placeholders for prepared statement args: '?' (MySQL, SQLite) or '$1, $2...' (PostgreSQL)
+	* Quoting of identifiers: `name` (MySQL) or "name" (SQLite, PostgreSQL)
+	* Escaping within strings: 'it''s' (all), or 'it\'s' too (MySQL only; 'C:\' is a whole string
+	  elsewhere)
+	* LIMIT/OFFSET syntax, including an OFFSET without a LIMIT
+	* Upserts: ON DUPLICATE KEY UPDATE (MySQL) or ON CONFLICT ... DO UPDATE (SQLite, PostgreSQL)
+	* Getting the ID of the last row inserted
+
+Queries are written with '?' placeholders as always; a Connection Rebind()s them to its Dialect's own
+style before preparing them. Connections use the MySQL Dialect unless told otherwise, so that MySQL
+consumers need not change a thing.
+
+Dialects are registered by driver name (see RegisterDialect() to add more), so that a ConnectionPool
+which isn't given a Dialect uses the one registered for the driver of its DBConnectionFactory. For
+SQLite, that's just:
+
+	pool := db.NewConnectionPool(db.NewDriverDSN("file:app.db"))
+	pool.InjectDependencies(
+		dep.NewDependencyInstance("ConnectionFactory", db.NewDBConnectionFactory("sqlite3")),
+	)
+
+SetDialect() picks one explicitly, e.g. for a driver registered under a name we don't know.
+
+The SQLite driver itself (e.g. github.com/mattn/go-sqlite3, which registers "sqlite3") is left to the
+application to import, just as go-sql-driver/mysql is for MySQL; since this module doesn't depend on
+one, the SQLite and PostgreSQL Dialects are only tested here against sqlmock, not a real database.
+
+*/
+
+import (
+	"fmt"
+	"sync"
+	"strings"
+)
+
+type DialectIfc interface {
+	GetName() string
+	GetDriverName() string
+
+	// Placeholder for the arg at position (1-based)
+	Placeholder(position int) string
+	// Query with '?' placeholders rewritten in our own style
+	Rebind(query string) string
+	// Does a backslash escape the character after it within a quoted string (not an identifier)?
+	BackslashEscapesStrings() bool
+	// Identifier (table, column, or qualified "schema.table.column") quoted for use in SQL
+	QuoteIdentifier(identifier string) string
+	// LIMIT/OFFSET clause (with a leading space) for limit rows (0 = all) after offset; "" for neither
+	Limit(limit, offset int) string
+	// INSERT of columns into table which updates updateColumns (all but conflictColumns if nil)
+	// instead when a row already exists with the same conflictColumns (or unique key, for MySQL)
+	Upsert(table string, columns, conflictColumns, updateColumns []string) string
+	// Must Upsert() be given conflictColumns? (MySQL goes by the table's unique keys instead)
+	UpsertNeedsConflictColumns() bool
+
+	// Does sql.Result.LastInsertId() work? If not, add InsertReturning() to the INSERT and read the ID
+	SupportsLastInsertId() bool
+	// Clause (with a leading space) to add to an INSERT to return column as a result row; "" if none needed
+	InsertReturning(column string) string
+	// Query for the ID of the last row inserted on the same connection
+	LastInsertIdQuery() string
+}
+
+var dialects map[string]DialectIfc
+var dialectsMutex sync.RWMutex
+
+func init() {
+	dialects = make(map[string]DialectIfc)
+	RegisterDialect(NewMySQLDialect(), "mysql")
+	RegisterDialect(NewSQLiteDialect(), "sqlite3", "sqlite")
+	RegisterDialect(NewPostgreSQLDialect(), "postgres", "pgx")
+}
+
+// -------------------------------------------------------------------------------------------------
+// Factory Functions
+// -------------------------------------------------------------------------------------------------
+
+// Register dialect for each of driverNames (and its own driver name)
+func RegisterDialect(dialect DialectIfc, driverNames ...string) {
+	if nil == dialect { return }
+	dialectsMutex.Lock()
+	defer dialectsMutex.Unlock()
+	dialects[strings.ToLower(dialect.GetDriverName())] = dialect
+	for _, driverName := range driverNames { dialects[strings.ToLower(driverName)] = dialect }
+}
+
+// Get the Dialect registered for driverName, or nil if there is none
+func GetDialectForDriver(driverName string) DialectIfc {
+	dialectsMutex.RLock()
+	defer dialectsMutex.RUnlock()
+	if dialect, ok := dialects[strings.ToLower(driverName)]; ok { return dialect }
+	return nil
+}
+
+// The Dialect for anything that has not been given one
+func GetDefaultDialect() DialectIfc {
+	return NewMySQLDialect()
+}
+
+// -------------------------------------------------------------------------------------------------
+// Dialect Implementation
+// -------------------------------------------------------------------------------------------------
+
+// Quote each dot-separated part of identifier with quote, doubling any quote within; "*" stays as is
+func quoteIdentifierWith(identifier, quote string) string {
+	parts := strings.Split(identifier, ".")
+	for index, part := range parts {
+		if "*" == part { continue }
+		parts[index] = quote + strings.ReplaceAll(part, quote, quote + quote) + quote
+	}
+	return strings.Join(parts, ".")
+}
+
+func quoteIdentifiersWith(identifiers []string, quote string) []string {
+	quoted := make([]string, len(identifiers))
+	for index, identifier := range identifiers { quoted[index] = quoteIdentifierWith(identifier, quote) }
+	return quoted
+}
+
+// "(?, ?, ?)" for count args in dialect's placeholder style
+func placeholderList(dialect DialectIfc, count int) string {
+	placeholders := make([]string, count)
+	for index := range placeholders { placeholders[index] = dialect.Placeholder(index + 1) }
+	return "(" + strings.Join(placeholders, ", ") + ")"
+}
+
+// INSERT INTO table (columns...) VALUES (placeholders...)
+func formatInsert(dialect DialectIfc, table string, columns []string) string {
+	quotedColumns := make([]string, len(columns))
+	for index, column := range columns { quotedColumns[index] = dialect.QuoteIdentifier(column) }
+	return fmt.Sprintf(
+		"INSERT INTO %s (%s) VALUES %s",
+		dialect.QuoteIdentifier(table),
+		strings.Join(quotedColumns, ", "),
+		placeholderList(dialect, len(columns)),
+	)
+}
+
+// The columns to update for an upsert: updateColumns if given, else all columns but conflictColumns
+func getUpsertUpdateColumns(columns, conflictColumns, updateColumns []string) []string {
+	if nil != updateColumns { return updateColumns }
+	conflicts := make(map[string]bool)
+	for _, column := range conflictColumns { conflicts[column] = true }
+	updates := make([]string, 0, len(columns))
+	for _, column := range columns {
+		if ! conflicts[column] { updates = append(updates, column) }
+	}
+	return updates
+}
+
+// Replace each '?' placeholder in query with numbered ones (prefix + 1, prefix + 2...), leaving
+// quoted strings, quoted identifiers and comments (as dialect sees them) alone
+func rebindNumbered(dialect DialectIfc, query, prefix string) string {
+	var sb strings.Builder
+	position := 0
+	for index := 0; index < len(query); {
+		if end := skipQuotedText(dialect, query, index); end > index {
+			sb.WriteString(query[index:end])
+			index = end
+			continue
+		}
+		if '?' == query[index] {
+			position++
+			sb.WriteString(fmt.Sprintf("%s%d", prefix, position))
+		} else {
+			sb.WriteByte(query[index])
+		}
+		index++
+	}
+	return sb.String()
+}
+
+// The index just past the quoted string, quoted identifier or comment starting at index in query, or
+// index itself if there is none there
+func skipQuotedText(dialect DialectIfc, query string, index int) int {
+	c := query[index]
+	switch {
+		case '`' == c:
+			return findQuoteEnd(query, index, c, false)
+		case ('\'' == c) || ('"' == c):
+			// '"' quotes a string for MySQL, which escapes with backslashes, but an identifier for
+			// the others, which don't
+			return findQuoteEnd(query, index, c, dialect.BackslashEscapesStrings())
+		case ('-' == c) && strings.HasPrefix(query[index:], "--"):
+			end := strings.IndexByte(query[index:], '\n')
+			if end < 0 { return len(query) }
+			return index + end
+		case ('/' == c) && strings.HasPrefix(query[index:], "/*"):
+			end := strings.Index(query[index + 2:], "*/")
+			if end < 0 { return len(query) }
+			return index + 2 + end + 2
+	}
+	return index
+}
+
+// The index just past the quote closing the one at start; a doubled quote is an escaped one, as is
+// one preceded by a backslash if backslashEscapes
+func findQuoteEnd(query string, start int, quote byte, backslashEscapes bool) int {
+	for index := start + 1; index < len(query); index++ {
+		if backslashEscapes && ('\\' == query[index]) {
+			index++
+			continue
+		}
+		if quote != query[index] { continue }
+		if (index + 1 < len(query)) && (quote == query[index + 1]) {
+			index++
+			continue
+		}
+		return index + 1
+	}
+	return len(query)
+}
@@ -0,0 +1,197 @@
+package db
+
+import(
+	"testing"
+
+        "github.com/DATA-DOG/go-sqlmock"
+
+	. "github.com/DigiStratum/GoLib/Testing"
+)
+
+func TestThat_GetDialectForDriver_ReturnsRegisteredDialects(t *testing.T) {
+	// Verify
+	ExpectString("MySQL", GetDialectForDriver("mysql").GetName(), t)
+	ExpectString("SQLite", GetDialectForDriver("sqlite3").GetName(), t)
+	ExpectString("SQLite", GetDialectForDriver("sqlite").GetName(), t)
+	ExpectString("PostgreSQL", GetDialectForDriver("postgres").GetName(), t)
+	ExpectString("PostgreSQL", GetDialectForDriver("PGX").GetName(), t)
+	ExpectTrue(nil == GetDialectForDriver("bogus"), t)
+}
+
+func TestThat_RegisterDialect_AddsDialect_ForDriverNames(t *testing.T) {
+	// Setup
+	dialect := NewPostgreSQLDialect()
+
+	// Test
+	RegisterDialect(dialect, "testdriver")
+
+	// Verify
+	ExpectTrue(dialect == GetDialectForDriver("testdriver"), t)
+}
+
+func TestThat_GetDefaultDialect_ReturnsMySQL(t *testing.T) {
+	// Verify
+	ExpectString("MySQL", GetDefaultDialect().GetName(), t)
+}
+
+func TestThat_Dialect_QuoteIdentifier_QuotesEachPart(t *testing.T) {
+	// Setup
+	var mysql DialectIfc = NewMySQLDialect() // <- ensures that we satisfy our interface
+	var sqlite DialectIfc = NewSQLiteDialect()
+	var postgres DialectIfc = NewPostgreSQLDialect()
+
+	// Verify
+	ExpectString("`todo`", mysql.QuoteIdentifier("todo"), t)
+	ExpectString("`app`.`todo`.*", mysql.QuoteIdentifier("app.todo.*"), t)
+	ExpectString("`we``ird`", mysql.QuoteIdentifier("we`ird"), t)
+	ExpectString("\"app\".\"todo\"", sqlite.QuoteIdentifier("app.todo"), t)
+	ExpectString("\"we\"\"ird\"", postgres.QuoteIdentifier("we\"ird"), t)
+}
+
+func TestThat_Dialect_Limit_ReturnsClauseForEachCombination(t *testing.T) {
+	// Setup
+	mysql := NewMySQLDialect()
+	sqlite := NewSQLiteDialect()
+	postgres := NewPostgreSQLDialect()
+
+	// Verify
+	ExpectString("", mysql.Limit(0, 0), t)
+	ExpectString(" LIMIT 10", mysql.Limit(10, 0), t)
+	ExpectString(" LIMIT 10 OFFSET 20", mysql.Limit(10, 20), t)
+	ExpectString(" LIMIT " + MYSQL_NO_LIMIT + " OFFSET 20", mysql.Limit(0, 20), t)
+	ExpectString(" LIMIT -1 OFFSET 20", sqlite.Limit(0, 20), t)
+	ExpectString(" LIMIT 10 OFFSET 20", sqlite.Limit(10, 20), t)
+	ExpectString(" OFFSET 20", postgres.Limit(0, 20), t)
+	ExpectString(" LIMIT 10", postgres.Limit(10, 0), t)
+}
+
+func TestThat_MySQLDialect_Upsert_ReturnsOnDuplicateKeyUpdate(t *testing.T) {
+	// Setup
+	sut := NewMySQLDialect()
+	columns := []string{ "id", "task", "due" }
+
+	// Test
+	all := sut.Upsert("todo", columns, []string{ "id" }, nil)
+	some := sut.Upsert("todo", columns, []string{ "id" }, []string{ "due" })
+	none := sut.Upsert("todo", columns, []string{ "id" }, []string{})
+
+	// Verify
+	ExpectString("INSERT INTO `todo` (`id`, `task`, `due`) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE `task` = VALUES(`task`), `due` = VALUES(`due`)", all, t)
+	ExpectString("INSERT INTO `todo` (`id`, `task`, `due`) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE `due` = VALUES(`due`)", some, t)
+	ExpectString("INSERT INTO `todo` (`id`, `task`, `due`) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE `id` = `id`", none, t)
+}
+
+func TestThat_SQLiteDialect_Upsert_ReturnsOnConflict(t *testing.T) {
+	// Setup
+	sut := NewSQLiteDialect()
+	columns := []string{ "id", "task" }
+
+	// Test
+	update := sut.Upsert("todo", columns, []string{ "id" }, nil)
+	nothing := sut.Upsert("todo", columns, []string{ "id" }, []string{})
+
+	// Verify
+	ExpectString("INSERT INTO \"todo\" (\"id\", \"task\") VALUES (?, ?) ON CONFLICT (\"id\") DO UPDATE SET \"task\" = excluded.\"task\"", update, t)
+	ExpectString("INSERT INTO \"todo\" (\"id\", \"task\") VALUES (?, ?) ON CONFLICT (\"id\") DO NOTHING", nothing, t)
+}
+
+func TestThat_PostgreSQLDialect_Upsert_ReturnsOnConflictWithNumberedPlaceholders(t *testing.T) {
+	// Setup
+	sut := NewPostgreSQLDialect()
+
+	// Test
+	res := sut.Upsert("todo", []string{ "id", "task" }, []string{ "id" }, nil)
+
+	// Verify
+	ExpectString("INSERT INTO \"todo\" (\"id\", \"task\") VALUES ($1, $2) ON CONFLICT (\"id\") DO UPDATE SET \"task\" = excluded.\"task\"", res, t)
+}
+
+func TestThat_PostgreSQLDialect_Rebind_NumbersPlaceholders_OutsideQuotesAndComments(t *testing.T) {
+	// Setup
+	sut := NewPostgreSQLDialect()
+	query := "SELECT '?', \"a?\" FROM t -- why?\nWHERE a = ? /* or? */ AND b = 'it''s?' AND c = ?"
+
+	// Test
+	res := sut.Rebind(query)
+
+	// Verify
+	ExpectString("SELECT '?', \"a?\" FROM t -- why?\nWHERE a = $1 /* or? */ AND b = 'it''s?' AND c = $2", res, t)
+}
+
+func TestThat_PostgreSQLDialect_Rebind_DoesNotTreatBackslashAsEscape(t *testing.T) {
+	// Setup
+	sut := NewPostgreSQLDialect()
+	query := "SELECT * FROM t WHERE a = 'C:\\' AND \"b\\\" = ?"
+
+	// Test
+	res := sut.Rebind(query)
+
+	// Verify
+	ExpectString("SELECT * FROM t WHERE a = 'C:\\' AND \"b\\\" = $1", res, t)
+	ExpectTrue(NewMySQLDialect().BackslashEscapesStrings(), t)
+	ExpectFalse(NewSQLiteDialect().BackslashEscapesStrings(), t)
+	ExpectFalse(sut.BackslashEscapesStrings(), t)
+}
+
+func TestThat_MySQLDialect_Rebind_LeavesQueryAlone(t *testing.T) {
+	// Setup
+	sut := NewMySQLDialect()
+	query := "SELECT * FROM t WHERE a = ? AND b = ?"
+
+	// Verify
+	ExpectString(query, sut.Rebind(query), t)
+}
+
+func TestThat_PostgreSQLDialect_LastInsertId_RequiresReturning(t *testing.T) {
+	// Setup
+	sut := NewPostgreSQLDialect()
+
+	// Verify
+	ExpectFalse(sut.SupportsLastInsertId(), t)
+	ExpectString(" RETURNING \"id\"", sut.InsertReturning("id"), t)
+	ExpectTrue(NewMySQLDialect().SupportsLastInsertId(), t)
+	ExpectString("", NewMySQLDialect().InsertReturning("id"), t)
+}
+
+func TestThat_Connection_GetDialect_ReturnsMySQL_ByDefault(t *testing.T) {
+	// Setup
+	conn, _, _ := sqlmock.New()
+	sut, _ := NewConnection(conn)
+
+	// Test
+	res := sut.GetDialect()
+
+	// Verify
+	ExpectString("MySQL", res.GetName(), t)
+}
+
+func TestThat_Connection_Exec_RebindsQuery_ForDialect(t *testing.T) {
+	// Setup
+	conn, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	mock.ExpectPrepare("UPDATE t SET a = $1 WHERE b = $2").ExpectExec().
+		WithArgs(1, 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	sut, _ := NewConnection(conn)
+	sut.SetDialect(NewPostgreSQLDialect())
+
+	// Test
+	_, err := sut.Exec(NewSQLQuery("UPDATE t SET a = ? WHERE b = ?"), 1, 2)
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectNoError(mock.ExpectationsWereMet(), t)
+}
+
+func TestThat_ConnectionPool_SetDialect_DefaultsToMySQL_WhenNil(t *testing.T) {
+	// Setup
+	sut := NewConnectionPool(NewDriverDSN("file:app.db"))
+
+	// Test
+	sut.SetDialect(NewSQLiteDialect())
+	sqlite := sut.GetDialect()
+	sut.SetDialect(nil)
+
+	// Verify
+	ExpectString("SQLite", sqlite.GetName(), t)
+	ExpectString("MySQL", sut.GetDialect().GetName(), t)
+}
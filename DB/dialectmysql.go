@@ -0,0 +1,94 @@
+package db
+
+/*
+
+The MySQL (and MariaDB) Dialect, for github.com/go-sql-driver/mysql
+
+*/
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The largest LIMIT MySQL accepts, which is how it says "no limit"
+const MYSQL_NO_LIMIT = "18446744073709551615"
+
+type MySQLDialect struct { }
+
+// -------------------------------------------------------------------------------------------------
+// Factory Functions
+// -------------------------------------------------------------------------------------------------
+
+func NewMySQLDialect() *MySQLDialect {
+	return &MySQLDialect{}
+}
+
+// -------------------------------------------------------------------------------------------------
+// DialectIfc Public Interface
+// -------------------------------------------------------------------------------------------------
+
+func (r *MySQLDialect) GetName() string {
+	return "MySQL"
+}
+
+func (r *MySQLDialect) GetDriverName() string {
+	return "mysql"
+}
+
+func (r *MySQLDialect) Placeholder(position int) string {
+	return "?"
+}
+
+func (r *MySQLDialect) Rebind(query string) string {
+	return query
+}
+
+func (r *MySQLDialect) BackslashEscapesStrings() bool {
+	return true
+}
+
+func (r *MySQLDialect) QuoteIdentifier(identifier string) string {
+	return quoteIdentifierWith(identifier, "`")
+}
+
+func (r *MySQLDialect) Limit(limit, offset int) string {
+	if offset > 0 {
+		if limit > 0 { return fmt.Sprintf(" LIMIT %d OFFSET %d", limit, offset) }
+		return fmt.Sprintf(" LIMIT %s OFFSET %d", MYSQL_NO_LIMIT, offset)
+	}
+	if limit > 0 { return fmt.Sprintf(" LIMIT %d", limit) }
+	return ""
+}
+
+// MySQL decides what conflicts by the table's unique keys, so conflictColumns only rule out updates
+func (r *MySQLDialect) Upsert(table string, columns, conflictColumns, updateColumns []string) string {
+	updates := getUpsertUpdateColumns(columns, conflictColumns, updateColumns)
+	assignments := make([]string, 0, len(updates))
+	for _, column := range updates {
+		quoted := r.QuoteIdentifier(column)
+		assignments = append(assignments, fmt.Sprintf("%s = VALUES(%s)", quoted, quoted))
+	}
+	// Nothing to update? Assign the first column to itself so that the existing row stays as is
+	if (0 == len(assignments)) && (len(columns) > 0) {
+		quoted := r.QuoteIdentifier(columns[0])
+		assignments = append(assignments, fmt.Sprintf("%s = %s", quoted, quoted))
+	}
+	return formatInsert(r, table, columns) + " ON DUPLICATE KEY UPDATE " + strings.Join(assignments, ", ")
+}
+
+func (r *MySQLDialect) UpsertNeedsConflictColumns() bool {
+	return false
+}
+
+func (r *MySQLDialect) SupportsLastInsertId() bool {
+	return true
+}
+
+func (r *MySQLDialect) InsertReturning(column string) string {
+	return ""
+}
+
+func (r *MySQLDialect) LastInsertIdQuery() string {
+	return "SELECT LAST_INSERT_ID()"
+}
@@ -0,0 +1,81 @@
+package db
+
+/*
+
+The PostgreSQL Dialect, for github.com/lib/pq ("postgres") or github.com/jackc/pgx ("pgx")
+
+PostgreSQL drivers can't report the ID of the last row inserted through sql.Result, so add
+InsertReturning("id") to the INSERT and read the ID from the row it returns instead.
+
+*/
+
+import (
+	"fmt"
+)
+
+type PostgreSQLDialect struct { }
+
+// -------------------------------------------------------------------------------------------------
+// Factory Functions
+// -------------------------------------------------------------------------------------------------
+
+func NewPostgreSQLDialect() *PostgreSQLDialect {
+	return &PostgreSQLDialect{}
+}
+
+// -------------------------------------------------------------------------------------------------
+// DialectIfc Public Interface
+// -------------------------------------------------------------------------------------------------
+
+func (r *PostgreSQLDialect) GetName() string {
+	return "PostgreSQL"
+}
+
+func (r *PostgreSQLDialect) GetDriverName() string {
+	return "postgres"
+}
+
+func (r *PostgreSQLDialect) Placeholder(position int) string {
+	return fmt.Sprintf("$%d", position)
+}
+
+func (r *PostgreSQLDialect) Rebind(query string) string {
+	return rebindNumbered(r, query, "$")
+}
+
+func (r *PostgreSQLDialect) BackslashEscapesStrings() bool {
+	return false
+}
+
+func (r *PostgreSQLDialect) QuoteIdentifier(identifier string) string {
+	return quoteIdentifierWith(identifier, "\"")
+}
+
+func (r *PostgreSQLDialect) Limit(limit, offset int) string {
+	if offset > 0 {
+		if limit > 0 { return fmt.Sprintf(" LIMIT %d OFFSET %d", limit, offset) }
+		return fmt.Sprintf(" OFFSET %d", offset)
+	}
+	if limit > 0 { return fmt.Sprintf(" LIMIT %d", limit) }
+	return ""
+}
+
+func (r *PostgreSQLDialect) Upsert(table string, columns, conflictColumns, updateColumns []string) string {
+	return formatOnConflictUpsert(r, table, columns, conflictColumns, updateColumns)
+}
+
+func (r *PostgreSQLDialect) UpsertNeedsConflictColumns() bool {
+	return true
+}
+
+func (r *PostgreSQLDialect) SupportsLastInsertId() bool {
+	return false
+}
+
+func (r *PostgreSQLDialect) InsertReturning(column string) string {
+	return " RETURNING " + r.QuoteIdentifier(column)
+}
+
+func (r *PostgreSQLDialect) LastInsertIdQuery() string {
+	return "SELECT lastval()"
+}
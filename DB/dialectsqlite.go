@@ -0,0 +1,102 @@
+package db
+
+/*
+
+The SQLite Dialect, for github.com/mattn/go-sqlite3 ("sqlite3") or modernc.org/sqlite ("sqlite");
+upserts require SQLite 3.24 or newer.
+
+SQLite accepts MySQL style `quoting` too, but "quoting" is the standard, so that is what we produce.
+
+*/
+
+import (
+	"fmt"
+	"strings"
+)
+
+type SQLiteDialect struct { }
+
+// -------------------------------------------------------------------------------------------------
+// Factory Functions
+// -------------------------------------------------------------------------------------------------
+
+func NewSQLiteDialect() *SQLiteDialect {
+	return &SQLiteDialect{}
+}
+
+// -------------------------------------------------------------------------------------------------
+// DialectIfc Public Interface
+// -------------------------------------------------------------------------------------------------
+
+func (r *SQLiteDialect) GetName() string {
+	return "SQLite"
+}
+
+func (r *SQLiteDialect) GetDriverName() string {
+	return "sqlite3"
+}
+
+func (r *SQLiteDialect) Placeholder(position int) string {
+	return "?"
+}
+
+func (r *SQLiteDialect) Rebind(query string) string {
+	return query
+}
+
+func (r *SQLiteDialect) BackslashEscapesStrings() bool {
+	return false
+}
+
+func (r *SQLiteDialect) QuoteIdentifier(identifier string) string {
+	return quoteIdentifierWith(identifier, "\"")
+}
+
+func (r *SQLiteDialect) Limit(limit, offset int) string {
+	if offset > 0 {
+		if limit > 0 { return fmt.Sprintf(" LIMIT %d OFFSET %d", limit, offset) }
+		return fmt.Sprintf(" LIMIT -1 OFFSET %d", offset)
+	}
+	if limit > 0 { return fmt.Sprintf(" LIMIT %d", limit) }
+	return ""
+}
+
+func (r *SQLiteDialect) Upsert(table string, columns, conflictColumns, updateColumns []string) string {
+	return formatOnConflictUpsert(r, table, columns, conflictColumns, updateColumns)
+}
+
+func (r *SQLiteDialect) UpsertNeedsConflictColumns() bool {
+	return true
+}
+
+func (r *SQLiteDialect) SupportsLastInsertId() bool {
+	return true
+}
+
+func (r *SQLiteDialect) InsertReturning(column string) string {
+	return ""
+}
+
+func (r *SQLiteDialect) LastInsertIdQuery() string {
+	return "SELECT last_insert_rowid()"
+}
+
+// -------------------------------------------------------------------------------------------------
+// SQLiteDialect Implementation
+// -------------------------------------------------------------------------------------------------
+
+// INSERT ... ON CONFLICT (conflictColumns) DO UPDATE SET column = excluded.column..., as SQLite and
+// PostgreSQL both have it
+func formatOnConflictUpsert(dialect DialectIfc, table string, columns, conflictColumns, updateColumns []string) string {
+	quotedConflicts := make([]string, len(conflictColumns))
+	for index, column := range conflictColumns { quotedConflicts[index] = dialect.QuoteIdentifier(column) }
+	upsert := formatInsert(dialect, table, columns) + " ON CONFLICT (" + strings.Join(quotedConflicts, ", ") + ")"
+	updates := getUpsertUpdateColumns(columns, conflictColumns, updateColumns)
+	if 0 == len(updates) { return upsert + " DO NOTHING" }
+	assignments := make([]string, len(updates))
+	for index, column := range updates {
+		quoted := dialect.QuoteIdentifier(column)
+		assignments[index] = fmt.Sprintf("%s = excluded.%s", quoted, quoted)
+	}
+	return upsert + " DO UPDATE SET " + strings.Join(assignments, ", ")
+}
@@ -31,6 +31,7 @@ type DSN struct {
 // Factory functions
 // -------------------------------------------------------------------------------------------------
 
+// Make a new DSN for MySQL; error if dsn is not one that go-sql-driver/mysql understands
 func NewDSN(dsn string) (*DSN, error) {
 	dsnConfig, err := mysql.ParseDSN(dsn)
 	if nil != err { return nil, err }
@@ -40,6 +41,13 @@ func NewDSN(dsn string) (*DSN, error) {
 	}, nil
 }
 
+// Make a new DSN for any other driver (e.g. "file:app.db" for SQLite), taken as is
+func NewDriverDSN(dsn string) *DSN {
+	return &DSN{
+		dsnString:	dsn,
+	}
+}
+
 // -------------------------------------------------------------------------------------------------
 // DSNIfc
 // -------------------------------------------------------------------------------------------------
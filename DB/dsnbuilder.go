@@ -2,15 +2,21 @@ package db
 
 /*
 
+DSNBuilder may be configured with the go-sql-driver/mysql Config field names (User, Passwd, Net, Addr,
+DBName, Timeout, ParseTime, etc) as typed config items: strings, integers, durations and booleans,
+respectively. Any other top level config value is treated as a name=value connection Param.
+
 ref: https://github.com/go-sql-driver/mysql/blob/master/dsn.go
 */
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/go-sql-driver/mysql"
 
-	cfg "github.com/DigiStratum/GoLib/Config"
+	"github.com/DigiStratum/GoLib/Data"
+	cfg "github.com/DigiStratum/GoLib/Data/config"
 )
 
 type DSNBuilderIfc interface {
@@ -44,7 +50,9 @@ type DSNBuilderIfc interface {
 }
 
 type DSNBuilder struct {
+	*cfg.Configurable
 	dsnConfig		*mysql.Config
+	configNames		map[string]bool	// Names of our declared ConfigItems; anything else is a Param
 }
 
 // -------------------------------------------------------------------------------------------------
@@ -52,71 +60,141 @@ type DSNBuilder struct {
 // -------------------------------------------------------------------------------------------------
 
 func BuildDSN() *DSNBuilder {
-	return &DSNBuilder{
+	r := &DSNBuilder{
 		dsnConfig:	mysql.NewConfig(),
+		configNames:	make(map[string]bool),
+	}
+	configItems := []cfg.ConfigItemIfc{
+		cfg.NewConfigItem("User").
+			SetType(cfg.CONFIG_ITEM_TYPE_STRING).
+			SetDescription("Username").
+			CaptureWith(cfg.CaptureString(&r.dsnConfig.User)),
+		cfg.NewConfigItem("Passwd").
+			SetType(cfg.CONFIG_ITEM_TYPE_STRING).
+			SetDescription("Password (requires User)").
+			CaptureWith(cfg.CaptureString(&r.dsnConfig.Passwd)),
+		cfg.NewConfigItem("Net").
+			SetType(cfg.CONFIG_ITEM_TYPE_STRING).
+			SetDescription("Network type").
+			CaptureWith(cfg.CaptureString(&r.dsnConfig.Net)),
+		cfg.NewConfigItem("Addr").
+			SetType(cfg.CONFIG_ITEM_TYPE_STRING).
+			SetDescription("Network address (requires Net)").
+			CaptureWith(cfg.CaptureString(&r.dsnConfig.Addr)),
+		cfg.NewConfigItem("DBName").
+			SetType(cfg.CONFIG_ITEM_TYPE_STRING).
+			SetDescription("Database name").
+			CaptureWith(cfg.CaptureString(&r.dsnConfig.DBName)),
+		cfg.NewConfigItem("Collation").
+			SetType(cfg.CONFIG_ITEM_TYPE_STRING).
+			SetDescription("Connection collation").
+			CaptureWith(cfg.CaptureString(&r.dsnConfig.Collation)),
+		cfg.NewConfigItem("Loc").
+			SetType(cfg.CONFIG_ITEM_TYPE_STRING).
+			SetDescription("Location for time.Time values, as an IANA Time Zone name").
+			CaptureWith(r.captureConfigLoc),
+		cfg.NewConfigItem("MaxAllowedPacket").
+			SetType(cfg.CONFIG_ITEM_TYPE_INTEGER).
+			SetDescription("Max packet size allowed").
+			CaptureWith(cfg.CaptureInt(&r.dsnConfig.MaxAllowedPacket)),
+		cfg.NewConfigItem("ServerPubKey").
+			SetType(cfg.CONFIG_ITEM_TYPE_STRING).
+			SetDescription("Server public key name").
+			CaptureWith(cfg.CaptureString(&r.dsnConfig.ServerPubKey)),
+		cfg.NewConfigItem("TLSConfig").
+			SetType(cfg.CONFIG_ITEM_TYPE_STRING).
+			SetDescription("TLS configuration name").
+			CaptureWith(cfg.CaptureString(&r.dsnConfig.TLSConfig)),
+		cfg.NewConfigItem("Timeout").
+			SetType(cfg.CONFIG_ITEM_TYPE_DURATION).
+			SetDescription("Dial timeout").
+			CaptureWith(cfg.CaptureDuration(&r.dsnConfig.Timeout)),
+		cfg.NewConfigItem("ReadTimeout").
+			SetType(cfg.CONFIG_ITEM_TYPE_DURATION).
+			SetDescription("I/O read timeout").
+			CaptureWith(cfg.CaptureDuration(&r.dsnConfig.ReadTimeout)),
+		cfg.NewConfigItem("WriteTimeout").
+			SetType(cfg.CONFIG_ITEM_TYPE_DURATION).
+			SetDescription("I/O write timeout").
+			CaptureWith(cfg.CaptureDuration(&r.dsnConfig.WriteTimeout)),
+		cfg.NewConfigItem("AllowAllFiles").
+			SetType(cfg.CONFIG_ITEM_TYPE_BOOLEAN).
+			SetDescription("Allow all files to be used with LOAD DATA LOCAL INFILE").
+			CaptureWith(cfg.CaptureBoolean(&r.dsnConfig.AllowAllFiles)),
+		cfg.NewConfigItem("AllowCleartextPasswords").
+			SetType(cfg.CONFIG_ITEM_TYPE_BOOLEAN).
+			SetDescription("Allows the cleartext client side plugin").
+			CaptureWith(cfg.CaptureBoolean(&r.dsnConfig.AllowCleartextPasswords)),
+		cfg.NewConfigItem("AllowNativePasswords").
+			SetType(cfg.CONFIG_ITEM_TYPE_BOOLEAN).
+			SetDescription("Allows the native password authentication method").
+			CaptureWith(cfg.CaptureBoolean(&r.dsnConfig.AllowNativePasswords)),
+		cfg.NewConfigItem("AllowOldPasswords").
+			SetType(cfg.CONFIG_ITEM_TYPE_BOOLEAN).
+			SetDescription("Allows the old insecure password method").
+			CaptureWith(cfg.CaptureBoolean(&r.dsnConfig.AllowOldPasswords)),
+		cfg.NewConfigItem("CheckConnLiveness").
+			SetType(cfg.CONFIG_ITEM_TYPE_BOOLEAN).
+			SetDescription("Check connections for liveness before using them").
+			CaptureWith(cfg.CaptureBoolean(&r.dsnConfig.CheckConnLiveness)),
+		cfg.NewConfigItem("ClientFoundRows").
+			SetType(cfg.CONFIG_ITEM_TYPE_BOOLEAN).
+			SetDescription("Return number of matching rows instead of rows changed").
+			CaptureWith(cfg.CaptureBoolean(&r.dsnConfig.ClientFoundRows)),
+		cfg.NewConfigItem("ColumnsWithAlias").
+			SetType(cfg.CONFIG_ITEM_TYPE_BOOLEAN).
+			SetDescription("Prepend table alias to column names").
+			CaptureWith(cfg.CaptureBoolean(&r.dsnConfig.ColumnsWithAlias)),
+		cfg.NewConfigItem("InterpolateParams").
+			SetType(cfg.CONFIG_ITEM_TYPE_BOOLEAN).
+			SetDescription("Interpolate placeholders into query string").
+			CaptureWith(cfg.CaptureBoolean(&r.dsnConfig.InterpolateParams)),
+		cfg.NewConfigItem("MultiStatements").
+			SetType(cfg.CONFIG_ITEM_TYPE_BOOLEAN).
+			SetDescription("Allow multiple statements in one query").
+			CaptureWith(cfg.CaptureBoolean(&r.dsnConfig.MultiStatements)),
+		cfg.NewConfigItem("ParseTime").
+			SetType(cfg.CONFIG_ITEM_TYPE_BOOLEAN).
+			SetDescription("Parse time values to time.Time").
+			CaptureWith(cfg.CaptureBoolean(&r.dsnConfig.ParseTime)),
+		cfg.NewConfigItem("RejectReadOnly").
+			SetType(cfg.CONFIG_ITEM_TYPE_BOOLEAN).
+			SetDescription("Reject read-only connections").
+			CaptureWith(cfg.CaptureBoolean(&r.dsnConfig.RejectReadOnly)),
 	}
+	for _, configItem := range configItems { r.configNames[configItem.GetSelector()] = true }
+	r.Configurable = cfg.NewConfigurable(configItems...)
+	return r
 }
 
 // -------------------------------------------------------------------------------------------------
 // ConfigurableIfc Public Interface
 // -------------------------------------------------------------------------------------------------
 
+// Configuration takes effect immediately so that subsequent Set*() calls override it; Configure() may
+// be called again, overriding whatever it sets in turn
 func (r *DSNBuilder) Configure(config cfg.ConfigIfc) error {
-	keys := config.GetKeys()
+	if err := r.Configurable.Reconfigure(config); nil != err { return err }
+
+	// Anything else to be treated as a name-value Param
+	if (nil == config) || ! config.IsObject() { return nil }
 	params := make(map[string]string)
-	for _, key := range keys {
-		v := config.Get(key)
-		if nil == v { continue }
-		value := *v
-		boolValue := config.GetBool(key)
-
-		switch (key) {
-			case "User": r.SetUser(value)
-			case "Passwd": r.SetPasswd(value)
-			case "Net": r.SetNet(value)
-			case "Addr": r.SetAddr(value)
-			case "DBName": r.SetDBName(value)
-			case "Collation": r.SetCollation(value)
-			case "Loc":
-				loc, err := time.LoadLocation(value)
-				if nil != err { return err }
-				r.SetLoc(loc)
-			case "MaxAllowedPacket":
-				int64Value := config.GetInt64(key);
-				if nil == int64Value { continue }
-				r.SetMaxAllowedPacket(int(*int64Value))
-			case "ServerPubKey": r.SetServerPubKey(value)
-			case "TLSConfig": r.SetTLSConfig(value)
-			case "Timeout":
-				dur, err := time.ParseDuration(value)
-				if nil != err { return err }
-				r.SetTimeout(dur)
-			case "ReadTimeout":
-				dur, err := time.ParseDuration(value)
-				if nil != err { return err }
-				r.SetReadTimeout(dur)
-			case "WriteTimeout":
-				dur, err := time.ParseDuration(value)
-				if nil != err { return err }
-				r.SetWriteTimeout(dur)
-			case "AllowAllFiles": r.SetAllowAllFiles(boolValue)
-			case "AllowCleartextPasswords": r.SetAllowCleartextPasswords(boolValue)
-			case "AllowNativePasswords": r.SetAllowNativePasswords(boolValue)
-			case "AllowOldPasswords": r.SetAllowOldPasswords(boolValue)
-			case "CheckConnLiveness": r.SetCheckConnLiveness(boolValue)
-			case "ClientFoundRows": r.SetClientFoundRows(boolValue)
-			case "ColumnsWithAlias": r.SetColumnsWithAlias(boolValue)
-			case "InterpolateParams": r.SetInterpolateParams(boolValue)
-			case "MultiStatements": r.SetMultiStatements(boolValue)
-			case "ParseTime": r.SetParseTime(boolValue)
-			case "RejectReadOnly": r.SetRejectReadOnly(boolValue)
-			default:
-				// Anything else to be treated as a name-value Param
-				params[key] = value
-		}
+	for _, name := range config.GetObjectProperties() {
+		if r.configNames[name] { continue }
+		value, err := cfg.CoerceString(config.GetObjectProperty(name))
+		if nil != err { return fmt.Errorf("DSNBuilder.Configure(): Param '%s': %s", name, err.Error()) }
+		params[name] = value
 	}
 	if len(params) > 0 { r.SetParams(params) }
+	return nil
+}
 
+func (r *DSNBuilder) captureConfigLoc(value data.DataValueIfc) error {
+	name, err := cfg.CoerceString(value)
+	if nil != err { return err }
+	loc, err := time.LoadLocation(name)
+	if nil != err { return err }
+	r.SetLoc(loc)
 	return nil
 }
 
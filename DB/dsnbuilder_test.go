@@ -1,6 +1,7 @@
 package db
 
 import(
+	gojson "encoding/json"
 	"time"
 	"testing"
 	"math/big"
@@ -8,6 +9,8 @@ import(
 	"crypto/tls"
 
 	"github.com/go-sql-driver/mysql"
+	"github.com/DigiStratum/GoLib/Data"
+	cfg "github.com/DigiStratum/GoLib/Data/config"
 	. "github.com/DigiStratum/GoLib/Testing"
 )
 
@@ -441,3 +444,126 @@ func TestThat_DSNBuilder_SetRejectReadOnly_AddsRejectReadOnlyToDSN(t *testing.T)
 
 
 
+
+func TestThat_DSNBuilder_Configure_AppliesTypedConfigItems(t *testing.T) {
+	// Setup
+	var sut *DSNBuilder = BuildDSN()
+	config := cfg.NewConfig()
+	config.PrepareObject().
+		SetObjectProperty("User", data.NewString("testuser")).
+		SetObjectProperty("Net", data.NewString("tcp")).
+		SetObjectProperty("Addr", data.NewString("dbhost:3306")).
+		SetObjectProperty("DBName", data.NewString("testdb")).
+		SetObjectProperty("Timeout", data.NewString("5s")).
+		SetObjectProperty("ParseTime", data.NewBoolean(true))
+
+	// Test
+	err := sut.Configure(config)
+	actual, buildErr := sut.Build()
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectNoError(buildErr, t)
+	ExpectNonNil(actual, t)
+	ExpectString("testuser@tcp(dbhost:3306)/testdb?parseTime=true&timeout=5s", actual.ToString(), t)
+}
+
+func TestThat_DSNBuilder_Configure_TreatsUnknownConfigAsParams(t *testing.T) {
+	// Setup
+	var sut *DSNBuilder = BuildDSN()
+	config := cfg.NewConfig()
+	config.PrepareObject().
+		SetObjectProperty("charset", data.NewString("utf8mb4"))
+
+	// Test
+	err := sut.Configure(config)
+	actual, buildErr := sut.Build()
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectNoError(buildErr, t)
+	ExpectString("/?charset=utf8mb4", actual.ToString(), t)
+}
+
+func TestThat_DSNBuilder_Configure_OverridesPreviousConfigure_WhenCalledAgain(t *testing.T) {
+	// Setup
+	var sut *DSNBuilder = BuildDSN()
+	config1 := cfg.NewConfig()
+	config1.PrepareObject().
+		SetObjectProperty("User", data.NewString("user1")).
+		SetObjectProperty("DBName", data.NewString("db1"))
+	config2 := cfg.NewConfig()
+	config2.PrepareObject().
+		SetObjectProperty("DBName", data.NewString("db2"))
+
+	// Test
+	err1 := sut.Configure(config1)
+	err2 := sut.Configure(config2)
+	actual, buildErr := sut.Build()
+
+	// Verify
+	ExpectNoError(err1, t)
+	ExpectNoError(err2, t)
+	ExpectNoError(buildErr, t)
+	ExpectString("user1@/db2", actual.ToString(), t)
+}
+
+func TestThat_DSNBuilder_Configure_ReturnsError_ForWronglyTypedValue(t *testing.T) {
+	// Setup
+	var sut *DSNBuilder = BuildDSN()
+	config := cfg.NewConfig()
+	config.PrepareObject().
+		SetObjectProperty("ParseTime", data.NewString("sometimes"))
+
+	// Test
+	err := sut.Configure(config)
+
+	// Verify
+	ExpectError(err, t)
+}
+
+func TestThat_DSNBuilder_ConfigReference_Schema_Accepts_native_config_values(t *testing.T) {
+	// Setup
+	config := cfg.NewConfig()
+	config.PrepareObject().
+		SetObjectProperty("User", data.NewString("testuser")).
+		SetObjectProperty("MaxAllowedPacket", data.NewInteger(4194304)).
+		SetObjectProperty("Timeout", data.NewInteger(5)).
+		SetObjectProperty("ReadTimeout", data.NewString("30s")).
+		SetObjectProperty("ParseTime", data.NewBoolean(true)).
+		SetObjectProperty("RejectReadOnly", data.NewBoolean(true))
+
+	// Test
+	schemaJson, err := cfg.NewConfigReference().AddConfigurable("", BuildDSN()).ToJsonSchema()
+	var schema map[string]interface{}
+	unmarshalErr := gojson.Unmarshal([]byte(schemaJson), &schema)
+	configureErr := BuildDSN().Configure(config)
+
+	// Verify
+	ExpectNoError(err, t)
+	if ! ExpectNoError(unmarshalErr, t) { return }
+	ExpectNoError(configureErr, t)
+	properties := schema["properties"].(map[string]interface{})
+	for _, name := range config.GetObjectProperties() {
+		property, ok := properties[name].(map[string]interface{})
+		if ! ExpectTrue(ok, t) { continue }
+		if ! schemaTypeAccepts(property["type"], config.GetObjectProperty(name)) {
+			t.Errorf("Schema type %v for '%s' rejects %s", property["type"], name, config.GetObjectProperty(name).ToJson())
+		}
+	}
+}
+
+// Does the JSON Schema type (a name, or a list of them) accept dataValue?
+func schemaTypeAccepts(schemaType interface{}, dataValue *data.DataValue) bool {
+	names := []interface{}{ schemaType }
+	if list, ok := schemaType.([]interface{}); ok { names = list }
+	for _, name := range names {
+		switch name {
+			case "string": if dataValue.IsString() { return true }
+			case "integer": if dataValue.IsInteger() { return true }
+			case "number": if dataValue.IsInteger() || dataValue.IsFloat() { return true }
+			case "boolean": if dataValue.IsBoolean() { return true }
+		}
+	}
+	return false
+}
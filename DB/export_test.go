@@ -0,0 +1,7 @@
+package db
+
+// Our tests are in package db_test because Testing/mocks imports us; these let them name our types
+
+type Connection = connection
+type PooledConnection = pooledConnection
+type LeasedConnection = leasedConnection
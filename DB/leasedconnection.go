@@ -1,4 +1,4 @@
-package mysql
+package db
 
 /*
 A Leased Connection wraps a pooled DB connection with an internally managed lease key. If we lose our lease due to
@@ -9,7 +9,7 @@ connection, with each method being a pass-through based on keyed access to the u
 
 import (
 	"fmt"
-	db "database/sql"
+	"database/sql"
 )
 
 type LeasedConnectionIfc interface {
@@ -64,6 +64,10 @@ func (r *leasedConnection) Connect() error {
 	return fmt.Errorf("Leased connection - no state changes allowed")
 }
 
+func (r *leasedConnection) GetDialect() DialectIfc {
+	return r.pooledConnection.GetDialect()
+}
+
 func (r *leasedConnection) InTransaction() bool {
 	if ! r.pooledConnection.MatchesLeaseKey(r.leaseKey) { return false }
 	return r.pooledConnection.InTransaction()
@@ -74,6 +78,26 @@ func (r *leasedConnection) Begin() error {
 	return r.pooledConnection.Begin()
 }
 
+func (r *leasedConnection) BeginTx(options *sql.TxOptions) error {
+	if ! r.pooledConnection.MatchesLeaseKey(r.leaseKey) { return errNoLease() }
+	return r.pooledConnection.BeginTx(options)
+}
+
+func (r *leasedConnection) Savepoint() (string, error) {
+	if ! r.pooledConnection.MatchesLeaseKey(r.leaseKey) { return "", errNoLease() }
+	return r.pooledConnection.Savepoint()
+}
+
+func (r *leasedConnection) ReleaseSavepoint(name string) error {
+	if ! r.pooledConnection.MatchesLeaseKey(r.leaseKey) { return errNoLease() }
+	return r.pooledConnection.ReleaseSavepoint(name)
+}
+
+func (r *leasedConnection) RollbackToSavepoint(name string) error {
+	if ! r.pooledConnection.MatchesLeaseKey(r.leaseKey) { return errNoLease() }
+	return r.pooledConnection.RollbackToSavepoint(name)
+}
+
 func (r *leasedConnection) NewQuery(query SQLQueryIfc) (QueryIfc, error) {
 	if ! r.pooledConnection.MatchesLeaseKey(r.leaseKey) { return nil, fmt.Errorf("No Leased Connection!") }
 	// Feed NewQuery() our *leasedConnection so it doesn't have direct access to underlying pooledConnection
@@ -90,17 +114,17 @@ func (r *leasedConnection) Rollback() error {
 	return r.pooledConnection.Rollback()
 }
 
-func (r *leasedConnection) Exec(query SQLQueryIfc, args ...interface{}) (db.Result, error) {
+func (r *leasedConnection) Exec(query SQLQueryIfc, args ...interface{}) (sql.Result, error) {
 	if ! r.pooledConnection.MatchesLeaseKey(r.leaseKey) { return nil, errNoLease() }
 	return r.pooledConnection.Exec(query, args...)
 }
 
-func (r *leasedConnection) Query(query SQLQueryIfc, args ...interface{}) (*db.Rows, error) {
+func (r *leasedConnection) Query(query SQLQueryIfc, args ...interface{}) (*sql.Rows, error) {
 	if ! r.pooledConnection.MatchesLeaseKey(r.leaseKey) { return nil, errNoLease() }
 	return r.pooledConnection.Query(query, args...)
 }
 
-func (r *leasedConnection) QueryRow(query SQLQueryIfc, args ...interface{}) *db.Row {
+func (r *leasedConnection) QueryRow(query SQLQueryIfc, args ...interface{}) *sql.Row {
 	if ! r.pooledConnection.MatchesLeaseKey(r.leaseKey) { return nil }
 	return r.pooledConnection.QueryRow(query, args...)
 }
@@ -1,19 +1,20 @@
-package mysql
+package db_test
 
 import(
 	"testing"
 
-	"github.com/DigiStratum/GoLib/DB"
 	dep "github.com/DigiStratum/GoLib/Dependencies"
-	cfg "github.com/DigiStratum/GoLib/Config"
+	"github.com/DigiStratum/GoLib/Data"
+	cfg "github.com/DigiStratum/GoLib/Data/config"
 
+	. "github.com/DigiStratum/GoLib/DB"
 	. "github.com/DigiStratum/GoLib/Testing"
 	. "github.com/DigiStratum/GoLib/Testing/mocks"
 )
 
 func TestThat_NewLeasedConnection_ReturnsSomething(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	connectionPool := NewConnectionPool(*dsn)
 	connectionFactory := NewMockDBConnectionFactory()
 	connectionPool.InjectDependencies(
@@ -21,9 +22,10 @@ func TestThat_NewLeasedConnection_ReturnsSomething(t *testing.T) {
 	)
 
 	config := cfg.NewConfig()
-	config.Set("min_connections", "1")
-	config.Set("max_connections", "1")
-	config.Set("max_idle", "1")
+	config.PrepareObject()
+	config.SetObjectProperty("min_connections", data.NewInteger(1))
+	config.SetObjectProperty("max_connections", data.NewInteger(1))
+	config.SetObjectProperty("max_idle", data.NewInteger(1))
 	connectionPool.Configure(config)
 
 	conn, _ := connectionFactory.NewConnection(dsn)
@@ -233,8 +235,8 @@ func TestThat_LeasedConnection_ConnectionIfc_QueryRow_ReturnsError_ForInvalidLea
 	if ! ExpectNil(res, t) { return }
 }
 
-func getGoodLeasedConnection(t *testing.T) (*leasedConnection, error) {
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+func getGoodLeasedConnection(t *testing.T) (*LeasedConnection, error) {
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	connectionPool := NewConnectionPool(*dsn)
 	connectionFactory := NewMockDBConnectionFactory()
 	connectionPool.InjectDependencies(
@@ -242,9 +244,10 @@ func getGoodLeasedConnection(t *testing.T) (*leasedConnection, error) {
 	)
 
 	config := cfg.NewConfig()
-	config.Set("min_connections", "1")
-	config.Set("max_connections", "1")
-	config.Set("max_idle", "1")
+	config.PrepareObject()
+	config.SetObjectProperty("min_connections", data.NewInteger(1))
+	config.SetObjectProperty("max_connections", data.NewInteger(1))
+	config.SetObjectProperty("max_idle", data.NewInteger(1))
 	connectionPool.Configure(config)
 	err := connectionPool.Start()
 	ExpectNoError(err, t)
@@ -1,12 +1,13 @@
-package mysql
+package db_test
 
 import(
 	"testing"
 
-	"github.com/DigiStratum/GoLib/DB"
 	dep "github.com/DigiStratum/GoLib/Dependencies"
-	cfg "github.com/DigiStratum/GoLib/Config"
+	"github.com/DigiStratum/GoLib/Data"
+	cfg "github.com/DigiStratum/GoLib/Data/config"
 
+	. "github.com/DigiStratum/GoLib/DB"
 	. "github.com/DigiStratum/GoLib/Testing"
 	. "github.com/DigiStratum/GoLib/Testing/mocks"
 )
@@ -70,8 +71,8 @@ func TestThat_LeasedConnections_Release_ReturnsFalse_ForBadLeaseKey(t *testing.T
 	ExpectFalse(result, t)
 }
 
-func getPooledConnection() *pooledConnection {
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+func getPooledConnection() *PooledConnection {
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	connectionPool := NewConnectionPool(*dsn)
 	connectionFactory := NewMockDBConnectionFactory()
 	connectionPool.InjectDependencies(
@@ -79,9 +80,10 @@ func getPooledConnection() *pooledConnection {
 	)
 
 	config := cfg.NewConfig()
-	config.Set("min_connections", "1")
-	config.Set("max_connections", "1")
-	config.Set("max_idle", "1")
+	config.PrepareObject()
+	config.SetObjectProperty("min_connections", data.NewInteger(1))
+	config.SetObjectProperty("max_connections", data.NewInteger(1))
+	config.SetObjectProperty("max_idle", data.NewInteger(1))
 	conn, _ := connectionFactory.NewConnection(dsn)
 	newConnection, _ := NewConnection(conn)
 	newPooledConnection, _ := NewPooledConnection(newConnection, connectionPool)
@@ -1,10 +1,14 @@
-package mysql
+package db
 
 /*
 
 A Pooled Connection wraps a raw DB connection with additional metadata to manage leasing
 
-TODO: Add support for restoring the state of the connection in the event that we capture changes like transaction isolation, etc.
+A Pooled Connection is marked dirty when its state changes in a way that could leak into the next lease, such as beginning a
+transaction with a non-default isolation level; the pool closes a dirty connection when it is released rather than leasing
+it out again.
+
+TODO: Add support for restoring the state of the connection instead, in the event that we capture changes like transaction isolation, etc.
 
 */
 
@@ -31,6 +35,10 @@ type PooledConnectionIfc interface {
 	Touch()
 	IsExpired() bool
 
+	// State
+	MarkDirty()
+	IsDirty() bool
+
 	ConnectionCommonIfc
 }
 
@@ -42,6 +50,7 @@ type pooledConnection struct {
 	lastLeasedAt		int64			// Last time this connection was leased out
 	isLeased		bool			// Is this connection currently leased out?
 	leaseKey		int64			// This is the lease key for the current lease holder
+	isDirty			bool			// Has our state changed (e.g. transaction isolation) since we connected?
 	mutex			sync.Mutex
 }
 
@@ -128,11 +137,26 @@ func (r *pooledConnection) IsExpired() bool {
 	return r.lastActiveAt + maxIdle < now
 }
 
+// State
+func (r *pooledConnection) MarkDirty() { r.isDirty = true }
+func (r *pooledConnection) IsDirty() bool { return r.isDirty }
+
+func (r *pooledConnection) GetDialect() DialectIfc { return r.connection.GetDialect() }
+
 // Transactions
 func (r *pooledConnection) InTransaction() bool { return r.connection.InTransaction() }
 func (r *pooledConnection) Rollback() error { return r.connection.Rollback() }
 func (r *pooledConnection) Begin() error { return r.connection.Begin() }
 func (r *pooledConnection) Commit() error { r.Touch(); return r.connection.Commit() }
+func (r *pooledConnection) Savepoint() (string, error) { r.Touch(); return r.connection.Savepoint() }
+func (r *pooledConnection) ReleaseSavepoint(name string) error { r.Touch(); return r.connection.ReleaseSavepoint(name) }
+func (r *pooledConnection) RollbackToSavepoint(name string) error { return r.connection.RollbackToSavepoint(name) }
+
+// A transaction with a non-default isolation level leaves us dirty
+func (r *pooledConnection) BeginTx(options *sql.TxOptions) error {
+	if (nil != options) && (sql.LevelDefault != options.Isolation) { r.MarkDirty() }
+	return r.connection.BeginTx(options)
+}
 
 // Operations
 func (r *pooledConnection) NewQuery(query SQLQueryIfc) (QueryIfc, error) { r.Touch(); return r.connection.NewQuery(query) }
@@ -1,13 +1,14 @@
-package mysql
+package db_test
 
 import(
 	"testing"
 
-	"github.com/DigiStratum/GoLib/DB"
 	dep "github.com/DigiStratum/GoLib/Dependencies"
+	. "github.com/DigiStratum/GoLib/DB"
 	. "github.com/DigiStratum/GoLib/Testing"
 	. "github.com/DigiStratum/GoLib/Testing/mocks"
-	cfg "github.com/DigiStratum/GoLib/Config"
+	"github.com/DigiStratum/GoLib/Data"
+	cfg "github.com/DigiStratum/GoLib/Data/config"
 )
 
 func TestThat_NewPooledConnection_ReturnsSomething_WithoutError(t *testing.T) {
@@ -67,9 +68,9 @@ func TestThat_PooledConnection_IsConnected_ReturnsFalse_ForBadConnection(t *test
 	ExpectFalse(actual, t)
 }
 
-func getGoodNewPooledConnection() (*pooledConnection, error) {
+func getGoodNewPooledConnection() (*PooledConnection, error) {
 	// Make a new ConnectionPool
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	connectionPool := NewConnectionPool(*dsn)
 
 	// Inject ConnectionFactoryIfc
@@ -79,9 +80,10 @@ func getGoodNewPooledConnection() (*pooledConnection, error) {
 	)
 
 	config := cfg.NewConfig()
-	config.Set("min_connections", "1")
-	config.Set("max_connections", "1")
-	config.Set("max_idle", "1")
+	config.PrepareObject()
+	config.SetObjectProperty("min_connections", data.NewInteger(1))
+	config.SetObjectProperty("max_connections", data.NewInteger(1))
+	config.SetObjectProperty("max_idle", data.NewInteger(1))
 	connectionPool.Configure(config)
 
 	conn, _ := connectionFactory.NewConnection(dsn)
@@ -90,7 +92,7 @@ func getGoodNewPooledConnection() (*pooledConnection, error) {
 	return NewPooledConnection(newConnection, connectionPool)
 }
 
-func getNilNewPooledConnection() (*pooledConnection, error) {
-	return &pooledConnection{}, nil
+func getNilNewPooledConnection() (*PooledConnection, error) {
+	return &PooledConnection{}, nil
 }
 
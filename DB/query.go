@@ -1,10 +1,10 @@
-package mysql
+package db
 
 /*
 
 A Query is attached to a database connection.
 
-The job of the Query interface is to abstract the MySQL interface technicalities away from the
+The job of the Query interface is to abstract the database interface technicalities away from the
 consumer.
 
 We can add other RunReturn{type}() variants for datetime, float, etc. as needed.
@@ -26,9 +26,9 @@ receive some indicator that this is needed.
 import (
 	"fmt"
 
-	db "database/sql"
+	"database/sql"
 
-	nullables "github.com/DigiStratum/GoLib/DB/MySQL/nullables"
+	"github.com/DigiStratum/GoLib/DB/nullables"
 )
 
 type QueryIfc interface {
@@ -39,6 +39,9 @@ type QueryIfc interface {
 	RunReturnOne(args ...interface{}) (*ResultRow, error)
 	RunReturnAll(args ...interface{}) (*resultSet, error)
 	RunReturnSome(max int, args ...interface{}) (*resultSet, error)
+	RunReturnRows(args ...interface{}) (*sql.Rows, error)
+	RunIterate(each func (row *ResultRow) error, args ...interface{}) error
+	RunReturnCursor(args ...interface{}) (*Cursor, error)
 }
 
 type query struct {
@@ -74,13 +77,15 @@ func (r query) Run(args ...interface{}) (*result, error) {
 // Run this sqlQuery against the supplied database Connection with the provided sqlQuery arguments
 // This variant returns only a single value (any type pointed at by receiver) as the only column
 // of the only row of the result
+// (Query rather than QueryRow, which would leave us no way to tell a failure to resolve or prepare
+// from there being no rows)
 func (r query) RunReturnValue(receiver interface{}, args ...interface{}) error {
-	if row := r.connection.QueryRow(r.sqlQuery, args...); nil != row {
-		err := row.Scan(receiver)
-		if db.ErrNoRows == err { return nil }
-		return err
-	}
-	return nil
+	rows, err := r.connection.Query(r.sqlQuery, args...)
+	if nil != err { return err }
+	defer rows.Close()
+	if ! rows.Next() { return rows.Err() }
+	if err := rows.Scan(receiver); nil != err { return err }
+	return rows.Close()
 }
 
 // Run this sqlQuery against the supplied database Connection with the provided sqlQuery arguments
@@ -116,6 +121,13 @@ func (r query) RunReturnAll(args ...interface{}) (*resultSet, error) {
 	return r.RunReturnSome(0, args...)
 }
 
+// Run this sqlQuery against the supplied database Connection with the provided sqlQuery arguments
+// This variant returns the database/sql Rows as they are, for the caller to scan and Close(); see
+// RunInto() for scanning them into structs
+func (r query) RunReturnRows(args ...interface{}) (*sql.Rows, error) {
+	return r.connection.Query(r.sqlQuery, args...)
+}
+
 // Run this sqlQuery against the supplied database Connection with the provided sqlQuery arguments
 // This variant returns a set of result rows up to the max count specified where 0=unlimited (all)
 // ref: https://kylewbanks.com/blog/sqlQuery-result-to-map-in-golang
@@ -123,41 +135,79 @@ func (r query) RunReturnSome(max int, args ...interface{}) (*resultSet, error) {
 	// Even if max == 1 we use Query() instead of QueryRow() to leverage the same Scan converter(s)
 	rows, err := r.connection.Query(r.sqlQuery, args...)
 	// If the sqlQuery returned no results, handle it specifically...
-	if db.ErrNoRows == err { return nil, nil }
+	if sql.ErrNoRows == err { return nil, nil }
 	if nil != err { return nil, err }
 	if nil != rows { defer rows.Close() }
 
-	// Return a slice of values and pointers to those values for Scan() to map result into
-	makeScanReceiver := func(size int) (*[]string, *[]interface{}) {
-		columnPointers := make([]interface{}, size)
-		columns := make([]string, size)
-		for i, _ := range columns { columnPointers[i] = &columns[i] }
-		return &columns, &columnPointers
-	}
-
-	// Create our map, and retrieve the value for each column from the pointers,
-	// slice, storing it in the map with the name of the column as the key.
-	// Note: names and values array len() must match. If they don't, then the Universe is off balance
-	convertScanReceiverToResultRow := func(names, values *[]string) *ResultRow {
-		result := NewResultRow()
-		for i, name := range *names {
-			nullableValue := nullables.NewNullable((*values)[i])
-			result.Set(name, *nullableValue)
-		}
-		return result
-	}
-
 	// Process all the rows of the sqlQuery result
 	results := NewResultSet()
-	cols, _ := rows.Columns()
+	scanRow, err := newResultRowScanner(rows)
+	if nil != err { return nil, err }
 	num := 0
 	for rows.Next() {
 		num++
-		columnValues, columnPointers := makeScanReceiver(len(cols))
-		if err := rows.Scan(*columnPointers...); err != nil { return nil, err }
-		result := convertScanReceiverToResultRow(&cols, columnValues)
+		result, err := scanRow()
+		if err != nil { return nil, err }
 		results.Add(result)
 		if (max > 0) && (num >= max) { break }
 	}
 	return results, nil
 }
+
+// Run this sqlQuery against the supplied database Connection with the provided sqlQuery arguments
+// This variant streams the result rows to each, one at a time, rather than loading them all into
+// memory; each may return ErrStopIteration to stop early without error, or any other error to stop
+// and return it. The rows are closed however we stop, even if each panics.
+func (r query) RunIterate(each func (row *ResultRow) error, args ...interface{}) error {
+	if nil == each { return fmt.Errorf("Query.RunIterate(): each is nil") }
+	rows, err := r.connection.Query(r.sqlQuery, args...)
+	if sql.ErrNoRows == err { return nil }
+	if nil != err { return err }
+	defer rows.Close()
+	scanRow, err := newResultRowScanner(rows)
+	if nil != err { return err }
+	for rows.Next() {
+		row, err := scanRow()
+		if nil != err { return err }
+		if err := each(row); nil != err {
+			if ErrStopIteration == err { return nil }
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// Run this sqlQuery against the supplied database Connection with the provided sqlQuery arguments
+// This variant returns a Cursor to stream the result rows from, one at a time; Close() it when done
+func (r query) RunReturnCursor(args ...interface{}) (*Cursor, error) {
+	rows, err := r.connection.Query(r.sqlQuery, args...)
+	if nil != err { return nil, err }
+	return NewCursor(rows)
+}
+
+// -------------------------------------------------------------------------------------------------
+// query Implementation
+// -------------------------------------------------------------------------------------------------
+
+// A func to scan the current row of rows into a ResultRow of Nullables by column name
+func newResultRowScanner(rows *sql.Rows) (func () (*ResultRow, error), error) {
+	cols, err := rows.Columns()
+	if nil != err { return nil, err }
+	return func () (*ResultRow, error) {
+		// A slice of values and pointers to those values for Scan() to map result into
+		columns := make([]string, len(cols))
+		columnPointers := make([]interface{}, len(cols))
+		for i := range columns { columnPointers[i] = &columns[i] }
+		if err := rows.Scan(columnPointers...); err != nil { return nil, err }
+
+		// Create our map, and retrieve the value for each column from the pointers,
+		// slice, storing it in the map with the name of the column as the key.
+		// Note: names and values array len() must match. If they don't, then the Universe is off balance
+		result := NewResultRow()
+		for i, name := range cols {
+			nullableValue := nullables.NewNullable(columns[i])
+			result.Set(name, *nullableValue)
+		}
+		return result, nil
+	}, nil
+}
@@ -1,4 +1,4 @@
-package mysql
+package db_test
 
 import(
 	"fmt"
@@ -6,7 +6,7 @@ import(
 
 	"github.com/DATA-DOG/go-sqlmock"
 
-	"github.com/DigiStratum/GoLib/DB"
+	. "github.com/DigiStratum/GoLib/DB"
 	. "github.com/DigiStratum/GoLib/Testing"
 	. "github.com/DigiStratum/GoLib/Testing/mocks"
 )
@@ -49,7 +49,7 @@ func TestThat_Query_NewQuery_ReturnsSomething_WhenGivenGoodParams(t *testing.T)
 
 func TestThat_Query_Run_ReturnsResult_WithoutError(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	mockDBConnection, _ := getGoodNewConnection()
 	mockDB := GetDBConnectionMockInfo(driverName, dsn)
 	var expectedInsertId int64 = 22
@@ -73,7 +73,7 @@ func TestThat_Query_Run_ReturnsResult_WithoutError(t *testing.T) {
 
 func TestThat_Query_Run_ReturnsError_WhenQueryExecutionFailsWithError(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	mockDBConnection, _ := getGoodNewConnection()
 	mockDB := GetDBConnectionMockInfo(driverName, dsn)
 	expectedQuery := "bogus query"
@@ -94,7 +94,7 @@ func TestThat_Query_Run_ReturnsError_WhenQueryExecutionFailsWithError(t *testing
 
 func TestThat_Query_RunReturnInt_ReturnsInt_WithoutError(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	mockDBConnection, _ := getGoodNewConnection()
 	mockDB := GetDBConnectionMockInfo(driverName, dsn)
 	mock := mockDB.GetMock()
@@ -121,7 +121,7 @@ func TestThat_Query_RunReturnInt_ReturnsInt_WithoutError(t *testing.T) {
 
 func TestThat_Query_RunReturnInt_ReturnsError_WhenQueryExecutionFailsWithError(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	mockDBConnection, _ := getGoodNewConnection()
 	mockDB := GetDBConnectionMockInfo(driverName, dsn)
 	mock := mockDB.GetMock()
@@ -143,9 +143,24 @@ func TestThat_Query_RunReturnInt_ReturnsError_WhenQueryExecutionFailsWithError(t
 	ExpectNoError((*mock).ExpectationsWereMet(), t)
 }
 
+func TestThat_Query_RunReturnInt_ReturnsError_WhenSetArgsMismatched(t *testing.T) {
+	// Setup
+	conn, mock, _ := sqlmock.New()
+	connection, _ := NewConnection(conn)
+	sut, _ := NewQuery(connection, NewSQLQuery("SELECT COUNT(*) FROM todo WHERE id IN (???)"))
+
+	// Test
+	actual, err := sut.RunReturnInt(5)
+
+	// Verify
+	ExpectNil(actual, t)
+	ExpectError(err, t)
+	ExpectNoError(mock.ExpectationsWereMet(), t)
+}
+
 func TestThat_Query_RunReturnString_ReturnsString_WithoutError(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	mockDBConnection, _ := getGoodNewConnection()
 	mockDB := GetDBConnectionMockInfo(driverName, dsn)
 	mock := mockDB.GetMock()
@@ -172,7 +187,7 @@ func TestThat_Query_RunReturnString_ReturnsString_WithoutError(t *testing.T) {
 
 func TestThat_Query_RunReturnString_ReturnsError_WhenQueryExecutionFailsWithError(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	mockDBConnection, _ := getGoodNewConnection()
 	mockDB := GetDBConnectionMockInfo(driverName, dsn)
 	mock := mockDB.GetMock()
@@ -196,7 +211,7 @@ func TestThat_Query_RunReturnString_ReturnsError_WhenQueryExecutionFailsWithErro
 
 func TestThat_Query_RunReturnOne_ReturnsResultRow_WithoutError(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	mockDBConnection, _ := getGoodNewConnection()
 	mockDB := GetDBConnectionMockInfo(driverName, dsn)
 	mock := mockDB.GetMock()
@@ -236,7 +251,7 @@ func TestThat_Query_RunReturnOne_ReturnsResultRow_WithoutError(t *testing.T) {
 
 func TestThat_Query_RunReturnOne_ReturnsError_WhenQueryExecutionFailsWithError(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	mockDBConnection, _ := getGoodNewConnection()
 	mockDB := GetDBConnectionMockInfo(driverName, dsn)
 	mock := mockDB.GetMock()
@@ -260,7 +275,7 @@ func TestThat_Query_RunReturnOne_ReturnsError_WhenQueryExecutionFailsWithError(t
 
 func TestThat_Query_RunReturnAll_ReturnsResultRows_WithoutError(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	mockDBConnection, _ := getGoodNewConnection()
 	mockDB := GetDBConnectionMockInfo(driverName, dsn)
 	mock := mockDB.GetMock()
@@ -309,7 +324,7 @@ func TestThat_Query_RunReturnAll_ReturnsResultRows_WithoutError(t *testing.T) {
 
 func TestThat_Query_RunReturnAll_ReturnsError_WhenQueryExecutionFailsWithError(t *testing.T) {
 	// Setup
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	mockDBConnection, _ := getGoodNewConnection()
 	mockDB := GetDBConnectionMockInfo(driverName, dsn)
 	mock := mockDB.GetMock()
@@ -331,9 +346,82 @@ func TestThat_Query_RunReturnAll_ReturnsError_WhenQueryExecutionFailsWithError(t
 	ExpectNoError((*mock).ExpectationsWereMet(), t)
 }
 
-func getGoodNewConnection() (*connection, error) {
-	dsn, _ := db.NewDSN("user:pass@tcp(host:333)/name")
+func getGoodNewConnection() (*Connection, error) {
+	dsn, _ := NewDSN("user:pass@tcp(host:333)/name")
 	mockDBConnection, _ := NewMockDBConnection(driverName, dsn)
 	return NewConnection(mockDBConnection)
 }
 
+
+func TestThat_Query_RunIterate_StreamsEveryRow(t *testing.T) {
+	// Setup
+	sut, mock := newIterateQuery(t, 3)
+
+	// Test
+	ids := ""
+	err := sut.RunIterate(func (row *ResultRow) error {
+		ids += *row.Get("id").GetString()
+		return nil
+	})
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectString("123", ids, t)
+	ExpectNoError(mock.ExpectationsWereMet(), t)
+}
+
+func TestThat_Query_RunIterate_StopsEarly_WithoutError(t *testing.T) {
+	// Setup
+	sut, mock := newIterateQuery(t, 3)
+
+	// Test
+	count := 0
+	err := sut.RunIterate(func (row *ResultRow) error {
+		count++
+		if 2 == count { return ErrStopIteration }
+		return nil
+	})
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectInt(2, count, t)
+	ExpectNoError(mock.ExpectationsWereMet(), t)
+}
+
+func TestThat_Query_RunIterate_ReturnsError_AndClosesRows_WhenEachFails(t *testing.T) {
+	// Setup
+	sut, mock := newIterateQuery(t, 3)
+	expectedErr := fmt.Errorf("export failed")
+
+	// Test
+	err := sut.RunIterate(func (row *ResultRow) error { return expectedErr })
+
+	// Verify
+	ExpectTrue(expectedErr == err, t)
+	ExpectNoError(mock.ExpectationsWereMet(), t)
+}
+
+func TestThat_Query_RunIterate_ClosesRows_WhenEachPanics(t *testing.T) {
+	// Setup
+	sut, mock := newIterateQuery(t, 3)
+
+	// Test
+	func () {
+		defer func () { recover() }()
+		sut.RunIterate(func (row *ResultRow) error { panic("boom") })
+	}()
+
+	// Verify
+	ExpectNoError(mock.ExpectationsWereMet(), t)
+}
+
+func TestThat_Query_RunIterate_ReturnsError_WhenEachNil(t *testing.T) {
+	// Setup
+	sut, _ := newIterateQuery(t, 1)
+
+	// Test
+	err := sut.RunIterate(nil)
+
+	// Verify
+	ExpectError(err, t)
+}
@@ -0,0 +1,504 @@
+package db
+
+/*
+
+A QueryBuilder builds SELECT, INSERT, UPDATE, DELETE and upsert statements a piece at a time, quoting
+every table and column name with its Dialect and putting every value into args behind a '?'
+placeholder, so that neither can be used to inject SQL. It implements SQLQueryIfc, so it runs like any
+other query; just give the Query the args the builder collected:
+
+	builder := db.NewQueryBuilder().
+		Select("id", "task", "due").
+		From("todo").
+		Where(db.Eq("done", false), db.Lt("due", deadline)).
+		Or(db.IsNull("due")).
+		OrderBy("due DESC", "id").
+		Limit(10)
+	query, err := conn.NewQuery(builder)
+	results, err := query.RunReturnAll(builder.GetArgs()...)
+
+	-> SELECT `id`, `task`, `due` FROM `todo` WHERE (`done` = ? AND `due` < ?) OR `due` IS NULL
+	   ORDER BY `due` DESC, `id` ASC LIMIT 10
+
+	builder := db.NewQueryBuilder().Update("todo").Set("done", true).Where(db.In("id", ids))
+	builder := db.NewQueryBuilder().Upsert("todo", "id").Set("id", 7).Set("task", "Buy milk")
+
+Names may be qualified ("todo.id") and may carry an alias ("todo AS t", "COUNT(*) AS total");
+COUNT, SUM, AVG, MIN and MAX of a column (or of *) may be selected, grouped, ordered and compared in
+HAVING too. ORDER BY names may end with ASC or DESC. Anything else is quoted as a name, whatever it
+looks like, so there is no way to get raw SQL in; use SQLQuery for SQL that the builder can't express.
+
+The Dialect (MySQL by default; see SetDialect()) decides the quoting, the placeholder style, the
+LIMIT/OFFSET syntax and the upsert syntax, so build with the same Dialect as the Connection has.
+Upserts need the conflict columns for all but MySQL, which goes by the table's unique keys instead.
+
+Mistakes (an unsupported operator, a SELECT without a table...) are kept until Resolve() (or ToSQL())
+reports the first of them, so the calls may be chained without checking each one.
+
+*/
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+type QueryBuilderIfc interface {
+	SQLQueryIfc
+	SetDialect(dialect DialectIfc) *QueryBuilder
+	GetDialect() DialectIfc
+
+	// Statements
+	Select(columns ...string) *QueryBuilder
+	Distinct() *QueryBuilder
+	Insert(table string) *QueryBuilder
+	Update(table string) *QueryBuilder
+	Delete(table string) *QueryBuilder
+	Upsert(table string, conflictColumns ...string) *QueryBuilder
+
+	// Clauses
+	From(table string) *QueryBuilder
+	Join(table, leftColumn, rightColumn string) *QueryBuilder
+	LeftJoin(table, leftColumn, rightColumn string) *QueryBuilder
+	Where(conditions ...ConditionIfc) *QueryBuilder
+	And(conditions ...ConditionIfc) *QueryBuilder
+	Or(conditions ...ConditionIfc) *QueryBuilder
+	GroupBy(columns ...string) *QueryBuilder
+	Having(conditions ...ConditionIfc) *QueryBuilder
+	OrderBy(columns ...string) *QueryBuilder
+	Limit(limit int) *QueryBuilder
+	Offset(offset int) *QueryBuilder
+	Set(column string, value interface{}) *QueryBuilder
+	SetValues(values map[string]interface{}) *QueryBuilder
+	UpdateOnConflict(columns ...string) *QueryBuilder
+
+	// Results
+	ToSQL() (string, []interface{}, error)
+	GetArgs() []interface{}
+}
+
+type QueryBuilderStatement int
+
+const (
+	QUERY_BUILDER_NONE QueryBuilderStatement = iota
+	QUERY_BUILDER_SELECT
+	QUERY_BUILDER_INSERT
+	QUERY_BUILDER_UPDATE
+	QUERY_BUILDER_DELETE
+	QUERY_BUILDER_UPSERT
+)
+
+type queryBuilderJoin struct {
+	kind			string
+	table			string
+	leftColumn		string
+	rightColumn		string
+}
+
+type QueryBuilder struct {
+	dialect			DialectIfc
+	statement		QueryBuilderStatement
+	err			error
+	distinct		bool
+	columns			[]string
+	table			string
+	joins			[]queryBuilderJoin
+	where			ConditionIfc
+	groupBy			[]string
+	having			ConditionIfc
+	orderBy			[]string
+	limit			int
+	offset			int
+	setColumns		[]string
+	setValues		[]interface{}
+	conflictColumns		[]string
+	updateColumns		[]string
+}
+
+// AGGREGATE([DISTINCT] name)
+var queryBuilderAggregatePattern = regexp.MustCompile(`(?i)^(COUNT|SUM|AVG|MIN|MAX)\(\s*(DISTINCT\s+)?(.+?)\s*\)$`)
+// name AS alias
+var queryBuilderAliasPattern = regexp.MustCompile(`(?i)^(.+?)\s+AS\s+(.+)$`)
+// name ASC|DESC
+var queryBuilderDirectionPattern = regexp.MustCompile(`(?i)^(.+?)\s+(ASC|DESC)$`)
+
+// -------------------------------------------------------------------------------------------------
+// Factory Functions
+// -------------------------------------------------------------------------------------------------
+
+func NewQueryBuilder() *QueryBuilder {
+	return &QueryBuilder{
+		dialect:		GetDefaultDialect(),
+		columns:		make([]string, 0),
+		joins:			make([]queryBuilderJoin, 0),
+		groupBy:		make([]string, 0),
+		orderBy:		make([]string, 0),
+		setColumns:		make([]string, 0),
+		setValues:		make([]interface{}, 0),
+	}
+}
+
+// -------------------------------------------------------------------------------------------------
+// SQLQueryIfc Public Interface
+// -------------------------------------------------------------------------------------------------
+
+// Resolve the SQL; args, if any are given, must be as many as GetArgs() returns
+func (r *QueryBuilder) Resolve(args ...interface{}) (string, error) {
+	if nil == r { return "", fmt.Errorf("QueryBuilder is nil, nothing to resolve") }
+	sql, builtArgs, err := r.ToSQL()
+	if nil != err { return "", err }
+	if (len(args) > 0) && (len(args) != len(builtArgs)) {
+		return "", fmt.Errorf("QueryBuilder.Resolve(): Query has %d placeholders, but %d args were given", len(builtArgs), len(args))
+	}
+	return sql, nil
+}
+
+// -------------------------------------------------------------------------------------------------
+// QueryBuilderIfc Public Interface
+// -------------------------------------------------------------------------------------------------
+
+// Set the Dialect to build for (default is MySQL)
+func (r *QueryBuilder) SetDialect(dialect DialectIfc) *QueryBuilder {
+	if nil == dialect { dialect = GetDefaultDialect() }
+	r.dialect = dialect
+	return r
+}
+
+func (r *QueryBuilder) GetDialect() DialectIfc {
+	return r.dialect
+}
+
+// SELECT columns (* if none)
+func (r *QueryBuilder) Select(columns ...string) *QueryBuilder {
+	r.setStatement(QUERY_BUILDER_SELECT, "Select")
+	r.columns = append(r.columns, columns...)
+	return r
+}
+
+// SELECT DISTINCT
+func (r *QueryBuilder) Distinct() *QueryBuilder {
+	r.distinct = true
+	return r
+}
+
+// INSERT INTO table the columns and values given with Set()
+func (r *QueryBuilder) Insert(table string) *QueryBuilder {
+	r.setStatement(QUERY_BUILDER_INSERT, "Insert")
+	r.table = table
+	return r
+}
+
+// UPDATE table with the columns and values given with Set()
+func (r *QueryBuilder) Update(table string) *QueryBuilder {
+	r.setStatement(QUERY_BUILDER_UPDATE, "Update")
+	r.table = table
+	return r
+}
+
+// DELETE FROM table
+func (r *QueryBuilder) Delete(table string) *QueryBuilder {
+	r.setStatement(QUERY_BUILDER_DELETE, "Delete")
+	r.table = table
+	return r
+}
+
+// INSERT INTO table the columns and values given with Set(), or update the row which conflicts on
+// conflictColumns (or, for MySQL, any unique key) with them instead; see UpdateOnConflict()
+func (r *QueryBuilder) Upsert(table string, conflictColumns ...string) *QueryBuilder {
+	r.setStatement(QUERY_BUILDER_UPSERT, "Upsert")
+	r.table = table
+	r.conflictColumns = append([]string{}, conflictColumns...)
+	return r
+}
+
+// The table to SELECT FROM
+func (r *QueryBuilder) From(table string) *QueryBuilder {
+	r.table = table
+	return r
+}
+
+// JOIN table ON leftColumn = rightColumn
+func (r *QueryBuilder) Join(table, leftColumn, rightColumn string) *QueryBuilder {
+	return r.addJoin("JOIN", table, leftColumn, rightColumn)
+}
+
+// LEFT JOIN table ON leftColumn = rightColumn
+func (r *QueryBuilder) LeftJoin(table, leftColumn, rightColumn string) *QueryBuilder {
+	return r.addJoin("LEFT JOIN", table, leftColumn, rightColumn)
+}
+
+// WHERE all of conditions, and any conditions given before
+func (r *QueryBuilder) Where(conditions ...ConditionIfc) *QueryBuilder {
+	r.where = appendConditions(r.where, "AND", conditions)
+	return r
+}
+
+// Same as Where(), for readability when chained after it
+func (r *QueryBuilder) And(conditions ...ConditionIfc) *QueryBuilder {
+	return r.Where(conditions...)
+}
+
+// WHERE the conditions given before OR all of conditions
+func (r *QueryBuilder) Or(conditions ...ConditionIfc) *QueryBuilder {
+	r.where = appendConditions(r.where, "OR", conditions)
+	return r
+}
+
+func (r *QueryBuilder) GroupBy(columns ...string) *QueryBuilder {
+	r.groupBy = append(r.groupBy, columns...)
+	return r
+}
+
+// HAVING all of conditions, and any conditions given before
+func (r *QueryBuilder) Having(conditions ...ConditionIfc) *QueryBuilder {
+	r.having = appendConditions(r.having, "AND", conditions)
+	return r
+}
+
+// ORDER BY columns, each optionally followed by ASC (the default) or DESC
+func (r *QueryBuilder) OrderBy(columns ...string) *QueryBuilder {
+	r.orderBy = append(r.orderBy, columns...)
+	return r
+}
+
+// At most limit rows (0 = all)
+func (r *QueryBuilder) Limit(limit int) *QueryBuilder {
+	if limit < 0 { r.addError("Limit", fmt.Errorf("Limit must not be negative, got %d", limit)) }
+	r.limit = limit
+	return r
+}
+
+// Skip the first offset rows
+func (r *QueryBuilder) Offset(offset int) *QueryBuilder {
+	if offset < 0 { r.addError("Offset", fmt.Errorf("Offset must not be negative, got %d", offset)) }
+	r.offset = offset
+	return r
+}
+
+// Set column to value for an INSERT, UPDATE or upsert; setting a column again replaces its value
+func (r *QueryBuilder) Set(column string, value interface{}) *QueryBuilder {
+	for index, setColumn := range r.setColumns {
+		if setColumn != column { continue }
+		r.setValues[index] = value
+		return r
+	}
+	r.setColumns = append(r.setColumns, column)
+	r.setValues = append(r.setValues, value)
+	return r
+}
+
+// Set() each of values, in column name order
+func (r *QueryBuilder) SetValues(values map[string]interface{}) *QueryBuilder {
+	columns := make([]string, 0, len(values))
+	for column := range values { columns = append(columns, column) }
+	sort.Strings(columns)
+	for _, column := range columns { r.Set(column, values[column]) }
+	return r
+}
+
+// The columns for an upsert to update when the row exists already (default is all but the conflict
+// columns; none at all leaves the existing row as it is)
+func (r *QueryBuilder) UpdateOnConflict(columns ...string) *QueryBuilder {
+	r.updateColumns = append([]string{}, columns...)
+	return r
+}
+
+// Build the SQL and the args for its placeholders, or else a non-nil error
+func (r *QueryBuilder) ToSQL() (string, []interface{}, error) {
+	if nil != r.err { return "", nil, r.err }
+	switch r.statement {
+		case QUERY_BUILDER_SELECT: return r.buildSelect()
+		case QUERY_BUILDER_INSERT: return r.buildInsert()
+		case QUERY_BUILDER_UPDATE: return r.buildUpdate()
+		case QUERY_BUILDER_DELETE: return r.buildDelete()
+		case QUERY_BUILDER_UPSERT: return r.buildUpsert()
+	}
+	return "", nil, fmt.Errorf("QueryBuilder.ToSQL(): Nothing to build; start with Select(), Insert(), Update(), Delete() or Upsert()")
+}
+
+// Get the args for the placeholders in our SQL, in order (nil if the SQL can't be built)
+func (r *QueryBuilder) GetArgs() []interface{} {
+	_, args, err := r.ToSQL()
+	if nil != err { return nil }
+	return args
+}
+
+// -------------------------------------------------------------------------------------------------
+// QueryBuilder Implementation
+// -------------------------------------------------------------------------------------------------
+
+// Keep the first error only; the rest may well follow from it
+func (r *QueryBuilder) addError(method string, err error) {
+	if nil == r.err { r.err = fmt.Errorf("QueryBuilder.%s(): %s", method, err.Error()) }
+}
+
+func (r *QueryBuilder) setStatement(statement QueryBuilderStatement, method string) {
+	if (QUERY_BUILDER_NONE != r.statement) && (statement != r.statement) {
+		r.addError(method, fmt.Errorf("One statement per builder; this one is built already"))
+		return
+	}
+	r.statement = statement
+}
+
+func (r *QueryBuilder) addJoin(kind, table, leftColumn, rightColumn string) *QueryBuilder {
+	r.joins = append(r.joins, queryBuilderJoin{
+		kind:		kind,
+		table:		table,
+		leftColumn:	leftColumn,
+		rightColumn:	rightColumn,
+	})
+	return r
+}
+
+func (r *QueryBuilder) requireTable(method string) error {
+	if 0 == len(strings.TrimSpace(r.table)) {
+		return fmt.Errorf("QueryBuilder.%s(): No table given", method)
+	}
+	return nil
+}
+
+func (r *QueryBuilder) requireSet(method string) error {
+	if 0 == len(r.setColumns) {
+		return fmt.Errorf("QueryBuilder.%s(): No columns given with Set()", method)
+	}
+	return nil
+}
+
+// Only a SELECT may have joins, grouping, ordering, limits...
+func (r *QueryBuilder) requireSelectOnly(method string) error {
+	if (len(r.joins) > 0) || (len(r.groupBy) > 0) || (nil != r.having) || (len(r.orderBy) > 0) || (r.limit > 0) || (r.offset > 0) || r.distinct {
+		return fmt.Errorf("QueryBuilder.%s(): Joins, GroupBy, Having, OrderBy, Limit, Offset and Distinct are for Select() only", method)
+	}
+	return nil
+}
+
+func (r *QueryBuilder) buildSelect() (string, []interface{}, error) {
+	if err := r.requireTable("Select"); nil != err { return "", nil, err }
+	if len(r.setColumns) > 0 { return "", nil, fmt.Errorf("QueryBuilder.Select(): Set() is not for Select()") }
+	var sb strings.Builder
+	args := make([]interface{}, 0)
+	sb.WriteString("SELECT ")
+	if r.distinct { sb.WriteString("DISTINCT ") }
+	if 0 == len(r.columns) {
+		sb.WriteString("*")
+	} else {
+		sb.WriteString(formatColumns(r.dialect, r.columns))
+	}
+	sb.WriteString(" FROM " + formatColumn(r.dialect, r.table))
+	for _, join := range r.joins {
+		sb.WriteString(fmt.Sprintf(
+			" %s %s ON %s = %s",
+			join.kind,
+			formatColumn(r.dialect, join.table),
+			formatColumn(r.dialect, join.leftColumn),
+			formatColumn(r.dialect, join.rightColumn),
+		))
+	}
+	if err := appendConditionClause(&sb, &args, r.dialect, " WHERE ", r.where); nil != err { return "", nil, err }
+	if len(r.groupBy) > 0 { sb.WriteString(" GROUP BY " + formatColumns(r.dialect, r.groupBy)) }
+	if err := appendConditionClause(&sb, &args, r.dialect, " HAVING ", r.having); nil != err { return "", nil, err }
+	if len(r.orderBy) > 0 {
+		orderBy := make([]string, len(r.orderBy))
+		for index, column := range r.orderBy { orderBy[index] = formatOrderBy(r.dialect, column) }
+		sb.WriteString(" ORDER BY " + strings.Join(orderBy, ", "))
+	}
+	sb.WriteString(r.dialect.Limit(r.limit, r.offset))
+	return r.dialect.Rebind(sb.String()), args, nil
+}
+
+func (r *QueryBuilder) buildInsert() (string, []interface{}, error) {
+	if err := r.requireTable("Insert"); nil != err { return "", nil, err }
+	if err := r.requireSet("Insert"); nil != err { return "", nil, err }
+	if err := r.requireSelectOnly("Insert"); nil != err { return "", nil, err }
+	if nil != r.where { return "", nil, fmt.Errorf("QueryBuilder.Insert(): Where() is not for Insert()") }
+	return formatInsert(r.dialect, r.table, r.setColumns), append([]interface{}{}, r.setValues...), nil
+}
+
+func (r *QueryBuilder) buildUpdate() (string, []interface{}, error) {
+	if err := r.requireTable("Update"); nil != err { return "", nil, err }
+	if err := r.requireSet("Update"); nil != err { return "", nil, err }
+	if err := r.requireSelectOnly("Update"); nil != err { return "", nil, err }
+	assignments := make([]string, len(r.setColumns))
+	for index, column := range r.setColumns { assignments[index] = formatColumn(r.dialect, column) + " = ?" }
+	var sb strings.Builder
+	args := append([]interface{}{}, r.setValues...)
+	sb.WriteString("UPDATE " + formatColumn(r.dialect, r.table) + " SET " + strings.Join(assignments, ", "))
+	if err := appendConditionClause(&sb, &args, r.dialect, " WHERE ", r.where); nil != err { return "", nil, err }
+	return r.dialect.Rebind(sb.String()), args, nil
+}
+
+func (r *QueryBuilder) buildDelete() (string, []interface{}, error) {
+	if err := r.requireTable("Delete"); nil != err { return "", nil, err }
+	if err := r.requireSelectOnly("Delete"); nil != err { return "", nil, err }
+	if len(r.setColumns) > 0 { return "", nil, fmt.Errorf("QueryBuilder.Delete(): Set() is not for Delete()") }
+	var sb strings.Builder
+	args := make([]interface{}, 0)
+	sb.WriteString("DELETE FROM " + formatColumn(r.dialect, r.table))
+	if err := appendConditionClause(&sb, &args, r.dialect, " WHERE ", r.where); nil != err { return "", nil, err }
+	return r.dialect.Rebind(sb.String()), args, nil
+}
+
+func (r *QueryBuilder) buildUpsert() (string, []interface{}, error) {
+	if err := r.requireTable("Upsert"); nil != err { return "", nil, err }
+	if err := r.requireSet("Upsert"); nil != err { return "", nil, err }
+	if err := r.requireSelectOnly("Upsert"); nil != err { return "", nil, err }
+	if nil != r.where { return "", nil, fmt.Errorf("QueryBuilder.Upsert(): Where() is not for Upsert()") }
+	if (0 == len(r.conflictColumns)) && r.dialect.UpsertNeedsConflictColumns() {
+		return "", nil, fmt.Errorf("QueryBuilder.Upsert(): The %s dialect needs conflict columns", r.dialect.GetName())
+	}
+	sql := r.dialect.Upsert(r.table, r.setColumns, r.conflictColumns, r.updateColumns)
+	return sql, append([]interface{}{}, r.setValues...), nil
+}
+
+// Add conditions to existing with conjunction; all of conditions must be true
+func appendConditions(existing ConditionIfc, conjunction string, conditions []ConditionIfc) ConditionIfc {
+	added := newConditionGroup("AND", conditions)
+	if 0 == len(added.conditions) { return existing }
+	if nil == existing { return added }
+	// AND onto an AND group just adds to it, rather than nesting another level of parentheses
+	if group, ok := existing.(*conditionGroup); ok && (conjunction == group.conjunction) {
+		return newConditionGroup(conjunction, append(append([]ConditionIfc{}, group.conditions...), added))
+	}
+	return newConditionGroup(conjunction, []ConditionIfc{ existing, added })
+}
+
+// Write prefix and condition to sb, and its args to args, unless there is no condition
+func appendConditionClause(sb *strings.Builder, args *[]interface{}, dialect DialectIfc, prefix string, condition ConditionIfc) error {
+	if nil == condition { return nil }
+	sql, conditionArgs, err := condition.ToSQL(dialect)
+	if nil != err { return fmt.Errorf("QueryBuilder.ToSQL(): %s", err.Error()) }
+	if 0 == len(sql) { return nil }
+	sb.WriteString(prefix + sql)
+	*args = append(*args, conditionArgs...)
+	return nil
+}
+
+func formatColumns(dialect DialectIfc, columns []string) string {
+	formatted := make([]string, len(columns))
+	for index, column := range columns { formatted[index] = formatColumn(dialect, column) }
+	return strings.Join(formatted, ", ")
+}
+
+// A name (maybe qualified) or aggregate of one, maybe with an alias, quoted for dialect
+func formatColumn(dialect DialectIfc, column string) string {
+	column = strings.TrimSpace(column)
+	if matches := queryBuilderAliasPattern.FindStringSubmatch(column); nil != matches {
+		return formatColumn(dialect, matches[1]) + " AS " + dialect.QuoteIdentifier(strings.TrimSpace(matches[2]))
+	}
+	if matches := queryBuilderAggregatePattern.FindStringSubmatch(column); nil != matches {
+		distinct := ""
+		if len(matches[2]) > 0 { distinct = "DISTINCT " }
+		return strings.ToUpper(matches[1]) + "(" + distinct + dialect.QuoteIdentifier(matches[3]) + ")"
+	}
+	return dialect.QuoteIdentifier(column)
+}
+
+// A formatColumn() with ASC or DESC
+func formatOrderBy(dialect DialectIfc, column string) string {
+	column = strings.TrimSpace(column)
+	if matches := queryBuilderDirectionPattern.FindStringSubmatch(column); nil != matches {
+		return formatColumn(dialect, matches[1]) + " " + strings.ToUpper(matches[2])
+	}
+	return formatColumn(dialect, column) + " ASC"
+}
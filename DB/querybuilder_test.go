@@ -0,0 +1,336 @@
+package db_test
+
+import(
+	"testing"
+
+        "github.com/DATA-DOG/go-sqlmock"
+
+	. "github.com/DigiStratum/GoLib/DB"
+	. "github.com/DigiStratum/GoLib/Testing"
+)
+
+func TestThat_NewQueryBuilder_ReturnsSomething(t *testing.T) {
+	// Test
+	var sut QueryBuilderIfc = NewQueryBuilder() // <- ensures that we satisfy our interface
+
+	// Verify
+	ExpectNonNil(sut, t)
+	ExpectString("MySQL", sut.GetDialect().GetName(), t)
+}
+
+func TestThat_QueryBuilder_Resolve_ReturnsError_WhenNothingToBuild(t *testing.T) {
+	// Setup
+	sut := NewQueryBuilder()
+
+	// Test
+	_, err := sut.Resolve()
+
+	// Verify
+	ExpectError(err, t)
+}
+
+func TestThat_QueryBuilder_Select_ReturnsAllColumns_WhenNoneGiven(t *testing.T) {
+	// Setup
+	sut := NewQueryBuilder().Select().From("todo")
+
+	// Test
+	sql, args, err := sut.ToSQL()
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectString("SELECT * FROM `todo`", sql, t)
+	ExpectInt(0, len(args), t)
+}
+
+func TestThat_QueryBuilder_Select_ReturnsQueryWithPlaceholdersAndArgs(t *testing.T) {
+	// Setup
+	sut := NewQueryBuilder().
+		Select("id", "task", "due").
+		From("todo").
+		Where(Eq("done", false), Lt("due", 100)).
+		Or(IsNull("due")).
+		OrderBy("due DESC", "id").
+		Limit(10).
+		Offset(20)
+
+	// Test
+	sql, args, err := sut.ToSQL()
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectString("SELECT `id`, `task`, `due` FROM `todo` WHERE (`done` = ? AND `due` < ?) OR `due` IS NULL ORDER BY `due` DESC, `id` ASC LIMIT 10 OFFSET 20", sql, t)
+	ExpectInt(2, len(args), t)
+	ExpectTrue(false == args[0], t)
+	ExpectTrue(100 == args[1], t)
+}
+
+func TestThat_QueryBuilder_Select_ReturnsJoinsGroupingAndAggregates(t *testing.T) {
+	// Setup
+	sut := NewQueryBuilder().
+		Select("u.name", "COUNT(*) AS total").
+		Distinct().
+		From("user AS u").
+		Join("todo AS t", "t.user_id", "u.id").
+		LeftJoin("tag", "tag.todo_id", "t.id").
+		Where(Between("t.due", 1, 2), Like("t.task", "%milk%"), In("t.status", []string{ "open", "late" })).
+		GroupBy("u.name").
+		Having(Gt("COUNT(*)", 3))
+
+	// Test
+	sql, args, err := sut.ToSQL()
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectString("SELECT DISTINCT `u`.`name`, COUNT(*) AS `total` FROM `user` AS `u` JOIN `todo` AS `t` ON `t`.`user_id` = `u`.`id` LEFT JOIN `tag` ON `tag`.`todo_id` = `t`.`id` WHERE `t`.`due` BETWEEN ? AND ? AND `t`.`task` LIKE ? AND `t`.`status` IN (?, ?) GROUP BY `u`.`name` HAVING COUNT(*) > ?", sql, t)
+	ExpectInt(6, len(args), t)
+	ExpectTrue("late" == args[4], t)
+	ExpectTrue(3 == args[5], t)
+}
+
+func TestThat_QueryBuilder_Select_QuotesInjectionAttemptsAsNames(t *testing.T) {
+	// Setup
+	sut := NewQueryBuilder().Select("id`; DROP TABLE todo; --").From("todo")
+
+	// Test
+	sql, _, err := sut.ToSQL()
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectString("SELECT `id``; DROP TABLE todo; --` FROM `todo`", sql, t)
+}
+
+func TestThat_QueryBuilder_Select_ReturnsError_WhenOperatorUnsupported(t *testing.T) {
+	// Setup
+	sut := NewQueryBuilder().Select().From("todo").Where(Compare("id", "= 1 OR 1 =", 1))
+
+	// Test
+	_, err := sut.Resolve()
+
+	// Verify
+	ExpectError(err, t)
+}
+
+func TestThat_QueryBuilder_Select_ReturnsError_WhenNoTable(t *testing.T) {
+	// Setup
+	sut := NewQueryBuilder().Select("id")
+
+	// Test
+	_, err := sut.Resolve()
+
+	// Verify
+	ExpectError(err, t)
+}
+
+func TestThat_QueryBuilder_Select_UsesDialect(t *testing.T) {
+	// Setup
+	sut := NewQueryBuilder().
+		SetDialect(NewPostgreSQLDialect()).
+		Select("id").
+		From("todo").
+		Where(Eq("user_id", 5), NotIn("id", 1, 2)).
+		Offset(20)
+
+	// Test
+	sql, args, err := sut.ToSQL()
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectString("SELECT \"id\" FROM \"todo\" WHERE \"user_id\" = $1 AND \"id\" NOT IN ($2, $3) OFFSET 20", sql, t)
+	ExpectInt(3, len(args), t)
+}
+
+func TestThat_QueryBuilder_Where_MatchesNothing_WhenInSetEmpty(t *testing.T) {
+	// Setup
+	sut := NewQueryBuilder().Select("id").From("todo").Where(In("id", []int{}))
+
+	// Test
+	sql, args, err := sut.ToSQL()
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectString("SELECT `id` FROM `todo` WHERE 1 = 0", sql, t)
+	ExpectInt(0, len(args), t)
+}
+
+func TestThat_QueryBuilder_Where_TreatsUUIDAsOneValue_WhenInSingleArg(t *testing.T) {
+	// Setup
+	uuid := [16]byte{ 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16 }
+	sut := NewQueryBuilder().Select("id").From("todo").Where(In("uuid", uuid))
+
+	// Test
+	sql, args, err := sut.ToSQL()
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectString("SELECT `id` FROM `todo` WHERE `uuid` IN (?)", sql, t)
+	ExpectInt(1, len(args), t)
+}
+
+func TestThat_QueryBuilder_Where_ReturnsIsNull_WhenComparedWithNil(t *testing.T) {
+	// Setup
+	sut := NewQueryBuilder().Select("id").From("todo").Where(Eq("due", nil), NotEq("done", nil))
+
+	// Test
+	sql, args, err := sut.ToSQL()
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectString("SELECT `id` FROM `todo` WHERE `due` IS NULL AND `done` IS NOT NULL", sql, t)
+	ExpectInt(0, len(args), t)
+}
+
+func TestThat_QueryBuilder_Where_NestsGroups(t *testing.T) {
+	// Setup
+	sut := NewQueryBuilder().Select("id").From("todo").
+		Where(Or(Eq("a", 1), And(Eq("b", 2), Eq("c", 3)))).
+		And(Eq("d", 4))
+
+	// Test
+	sql, args, err := sut.ToSQL()
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectString("SELECT `id` FROM `todo` WHERE (`a` = ? OR (`b` = ? AND `c` = ?)) AND `d` = ?", sql, t)
+	ExpectInt(4, len(args), t)
+}
+
+func TestThat_QueryBuilder_Insert_ReturnsPlaceholdersForSetValues(t *testing.T) {
+	// Setup
+	sut := NewQueryBuilder().Insert("todo").Set("task", "Buy milk").Set("due", 100).Set("task", "Buy bread")
+
+	// Test
+	sql, args, err := sut.ToSQL()
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectString("INSERT INTO `todo` (`task`, `due`) VALUES (?, ?)", sql, t)
+	ExpectInt(2, len(args), t)
+	ExpectTrue("Buy bread" == args[0], t)
+}
+
+func TestThat_QueryBuilder_Insert_ReturnsError_WhenNothingSet(t *testing.T) {
+	// Setup
+	sut := NewQueryBuilder().Insert("todo")
+
+	// Test
+	_, _, err := sut.ToSQL()
+
+	// Verify
+	ExpectError(err, t)
+}
+
+func TestThat_QueryBuilder_Update_ReturnsSetArgsBeforeWhereArgs(t *testing.T) {
+	// Setup
+	sut := NewQueryBuilder().Update("todo").SetValues(map[string]interface{}{ "due": 5, "done": true }).Where(In("id", 7, 8))
+
+	// Test
+	sql, args, err := sut.ToSQL()
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectString("UPDATE `todo` SET `done` = ?, `due` = ? WHERE `id` IN (?, ?)", sql, t)
+	ExpectInt(4, len(args), t)
+	ExpectTrue(true == args[0], t)
+	ExpectTrue(8 == args[3], t)
+}
+
+func TestThat_QueryBuilder_Update_ReturnsError_WhenLimited(t *testing.T) {
+	// Setup
+	sut := NewQueryBuilder().Update("todo").Set("done", true).Limit(1)
+
+	// Test
+	_, _, err := sut.ToSQL()
+
+	// Verify
+	ExpectError(err, t)
+}
+
+func TestThat_QueryBuilder_Delete_ReturnsWhere(t *testing.T) {
+	// Setup
+	sut := NewQueryBuilder().Delete("todo").Where(Lt("due", 5))
+
+	// Test
+	sql, args, err := sut.ToSQL()
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectString("DELETE FROM `todo` WHERE `due` < ?", sql, t)
+	ExpectInt(1, len(args), t)
+}
+
+func TestThat_QueryBuilder_Upsert_ReturnsDialectUpsert(t *testing.T) {
+	// Setup
+	mysql := NewQueryBuilder().Upsert("todo", "id").Set("id", 7).Set("task", "Buy milk")
+	sqlite := NewQueryBuilder().SetDialect(NewSQLiteDialect()).Upsert("todo", "id").Set("id", 7).Set("task", "Buy milk").UpdateOnConflict()
+
+	// Test
+	mysqlSQL, mysqlArgs, mysqlErr := mysql.ToSQL()
+	sqliteSQL, _, sqliteErr := sqlite.ToSQL()
+
+	// Verify
+	ExpectNoError(mysqlErr, t)
+	ExpectString("INSERT INTO `todo` (`id`, `task`) VALUES (?, ?) ON DUPLICATE KEY UPDATE `task` = VALUES(`task`)", mysqlSQL, t)
+	ExpectInt(2, len(mysqlArgs), t)
+	ExpectNoError(sqliteErr, t)
+	ExpectString("INSERT INTO \"todo\" (\"id\", \"task\") VALUES (?, ?) ON CONFLICT (\"id\") DO NOTHING", sqliteSQL, t)
+}
+
+func TestThat_QueryBuilder_Upsert_ReturnsError_WithoutConflictColumns_ExceptForMySQL(t *testing.T) {
+	// Setup
+	mysql := NewQueryBuilder().Upsert("todo").Set("id", 7).Set("task", "Buy milk")
+	sqlite := NewQueryBuilder().SetDialect(NewSQLiteDialect()).Upsert("todo").Set("id", 7).Set("task", "Buy milk")
+	postgres := NewQueryBuilder().SetDialect(NewPostgreSQLDialect()).Upsert("todo").Set("id", 7).Set("task", "Buy milk")
+
+	// Test
+	_, _, mysqlErr := mysql.ToSQL()
+	sqliteSQL, _, sqliteErr := sqlite.ToSQL()
+	postgresSQL, _, postgresErr := postgres.ToSQL()
+
+	// Verify
+	ExpectNoError(mysqlErr, t)
+	ExpectError(sqliteErr, t)
+	ExpectString("", sqliteSQL, t)
+	ExpectError(postgresErr, t)
+	ExpectString("", postgresSQL, t)
+}
+
+func TestThat_QueryBuilder_ReturnsError_WhenStatementChanged(t *testing.T) {
+	// Setup
+	sut := NewQueryBuilder().Select("id").From("todo").Delete("todo")
+
+	// Test
+	_, _, err := sut.ToSQL()
+
+	// Verify
+	ExpectError(err, t)
+}
+
+func TestThat_QueryBuilder_Resolve_ReturnsError_WhenArgCountMismatched(t *testing.T) {
+	// Setup
+	sut := NewQueryBuilder().Delete("todo").Where(Eq("id", 1))
+
+	// Test
+	_, err := sut.Resolve(1, 2)
+
+	// Verify
+	ExpectError(err, t)
+}
+
+func TestThat_QueryBuilder_RunsAsQuery_WithArgs(t *testing.T) {
+	// Setup
+	conn, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	mock.ExpectPrepare("SELECT `id` FROM `todo` WHERE `id` IN (?, ?)").ExpectQuery().
+		WithArgs(7, 8).
+		WillReturnRows(sqlmock.NewRows([]string{ "id" }).AddRow("7").AddRow("8"))
+	connection, _ := NewConnection(conn)
+	sut := NewQueryBuilder().Select("id").From("todo").Where(In("id", []int{ 7, 8 }))
+	query, _ := connection.NewQuery(sut)
+
+	// Test
+	results, err := query.RunReturnAll(sut.GetArgs()...)
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectInt(2, results.Len(), t)
+	ExpectNoError(mock.ExpectationsWereMet(), t)
+}
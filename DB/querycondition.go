@@ -0,0 +1,223 @@
+package db
+
+/*
+
+Conditions for the WHERE and HAVING clauses of a QueryBuilder (see querybuilder.go). Each renders
+itself as SQL with '?' placeholders for its values, along with the args for them, so that a value
+never becomes a part of the SQL itself; column names are quoted by the Dialect:
+
+	db.Eq("status", "open")					`status` = ?
+	db.Compare("due", "<", deadline)			`due` < ?
+	db.In("id", ids)					`id` IN (?, ?, ?)
+	db.Between("due", start, end)				`due` BETWEEN ? AND ?
+	db.Like("task", "%milk%")				`task` LIKE ?
+	db.Or(db.IsNull("due"), db.Gt("due", now))		(`due` IS NULL OR `due` > ?)
+
+In() (and NotIn()) take values as separate args or as a single slice. An empty set matches nothing
+(and NotIn() of one, everything) rather than producing the invalid "IN ()". Comparing with a nil value
+by "=" or "!=" becomes IS NULL or IS NOT NULL, since "= NULL" matches nothing.
+
+*/
+
+import (
+	"fmt"
+	"strings"
+)
+
+type ConditionIfc interface {
+	// SQL for the condition with '?' placeholders, and the args for them, or else a non-nil error
+	ToSQL(dialect DialectIfc) (string, []interface{}, error)
+}
+
+type comparisonCondition struct {
+	column			string
+	operator		string
+	value			interface{}
+}
+
+type inCondition struct {
+	column			string
+	negate			bool
+	values			[]interface{}
+}
+
+type betweenCondition struct {
+	column			string
+	negate			bool
+	low			interface{}
+	high			interface{}
+}
+
+type nullCondition struct {
+	column			string
+	negate			bool
+}
+
+type conditionGroup struct {
+	conjunction		string
+	conditions		[]ConditionIfc
+}
+
+// The operators Compare() accepts; anything else could be used to slip SQL in
+var comparisonOperators = map[string]bool{
+	"=": true, "!=": true, "<>": true, "<": true, "<=": true, ">": true, ">=": true,
+	"LIKE": true, "NOT LIKE": true,
+}
+
+// -------------------------------------------------------------------------------------------------
+// Factory Functions
+// -------------------------------------------------------------------------------------------------
+
+// column operator value, where operator is one of =, !=, <>, <, <=, >, >=, LIKE, NOT LIKE
+func Compare(column, operator string, value interface{}) ConditionIfc {
+	return &comparisonCondition{
+		column:		column,
+		operator:	strings.ToUpper(strings.Join(strings.Fields(operator), " ")),
+		value:		value,
+	}
+}
+
+func Eq(column string, value interface{}) ConditionIfc { return Compare(column, "=", value) }
+func NotEq(column string, value interface{}) ConditionIfc { return Compare(column, "!=", value) }
+func Lt(column string, value interface{}) ConditionIfc { return Compare(column, "<", value) }
+func Lte(column string, value interface{}) ConditionIfc { return Compare(column, "<=", value) }
+func Gt(column string, value interface{}) ConditionIfc { return Compare(column, ">", value) }
+func Gte(column string, value interface{}) ConditionIfc { return Compare(column, ">=", value) }
+func Like(column string, pattern interface{}) ConditionIfc { return Compare(column, "LIKE", pattern) }
+func NotLike(column string, pattern interface{}) ConditionIfc { return Compare(column, "NOT LIKE", pattern) }
+
+// column IN (values...); values may be given as separate args or as a single slice
+func In(column string, values ...interface{}) ConditionIfc {
+	return &inCondition{
+		column:		column,
+		values:		flattenConditionValues(values),
+	}
+}
+
+// column NOT IN (values...); values may be given as separate args or as a single slice
+func NotIn(column string, values ...interface{}) ConditionIfc {
+	return &inCondition{
+		column:		column,
+		negate:		true,
+		values:		flattenConditionValues(values),
+	}
+}
+
+// column BETWEEN low AND high (inclusive)
+func Between(column string, low, high interface{}) ConditionIfc {
+	return &betweenCondition{
+		column:		column,
+		low:		low,
+		high:		high,
+	}
+}
+
+func NotBetween(column string, low, high interface{}) ConditionIfc {
+	return &betweenCondition{
+		column:		column,
+		negate:		true,
+		low:		low,
+		high:		high,
+	}
+}
+
+func IsNull(column string) ConditionIfc {
+	return &nullCondition{
+		column:		column,
+	}
+}
+
+func IsNotNull(column string) ConditionIfc {
+	return &nullCondition{
+		column:		column,
+		negate:		true,
+	}
+}
+
+// All of conditions must be true; nil conditions are ignored
+func And(conditions ...ConditionIfc) ConditionIfc {
+	return newConditionGroup("AND", conditions)
+}
+
+// Any of conditions must be true; nil conditions are ignored
+func Or(conditions ...ConditionIfc) ConditionIfc {
+	return newConditionGroup("OR", conditions)
+}
+
+// -------------------------------------------------------------------------------------------------
+// ConditionIfc Public Interface
+// -------------------------------------------------------------------------------------------------
+
+func (r *comparisonCondition) ToSQL(dialect DialectIfc) (string, []interface{}, error) {
+	if ! comparisonOperators[r.operator] {
+		return "", nil, fmt.Errorf("Compare(): Unsupported operator '%s' for column '%s'", r.operator, r.column)
+	}
+	if nil == r.value {
+		switch r.operator {
+			case "=": return (&nullCondition{ column: r.column }).ToSQL(dialect)
+			case "!=", "<>": return (&nullCondition{ column: r.column, negate: true }).ToSQL(dialect)
+		}
+	}
+	return formatColumn(dialect, r.column) + " " + r.operator + " ?", []interface{}{ r.value }, nil
+}
+
+func (r *inCondition) ToSQL(dialect DialectIfc) (string, []interface{}, error) {
+	// "IN ()" is not valid SQL; nothing is in an empty set
+	if 0 == len(r.values) {
+		if r.negate { return "1 = 1", []interface{}{}, nil }
+		return "1 = 0", []interface{}{}, nil
+	}
+	operator := " IN "
+	if r.negate { operator = " NOT IN " }
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(r.values)), ", ")
+	args := append([]interface{}{}, r.values...)
+	return formatColumn(dialect, r.column) + operator + "(" + placeholders + ")", args, nil
+}
+
+func (r *betweenCondition) ToSQL(dialect DialectIfc) (string, []interface{}, error) {
+	operator := " BETWEEN "
+	if r.negate { operator = " NOT BETWEEN " }
+	return formatColumn(dialect, r.column) + operator + "? AND ?", []interface{}{ r.low, r.high }, nil
+}
+
+func (r *nullCondition) ToSQL(dialect DialectIfc) (string, []interface{}, error) {
+	if r.negate { return formatColumn(dialect, r.column) + " IS NOT NULL", []interface{}{}, nil }
+	return formatColumn(dialect, r.column) + " IS NULL", []interface{}{}, nil
+}
+
+// Our conditions joined by our conjunction, those which are groups of their own in parentheses
+func (r *conditionGroup) ToSQL(dialect DialectIfc) (string, []interface{}, error) {
+	parts := make([]string, 0, len(r.conditions))
+	args := make([]interface{}, 0)
+	for _, condition := range r.conditions {
+		sql, conditionArgs, err := condition.ToSQL(dialect)
+		if nil != err { return "", nil, err }
+		if 0 == len(sql) { continue }
+		if group, ok := condition.(*conditionGroup); ok && (len(group.conditions) > 1) { sql = "(" + sql + ")" }
+		parts = append(parts, sql)
+		args = append(args, conditionArgs...)
+	}
+	return strings.Join(parts, " " + r.conjunction + " "), args, nil
+}
+
+// -------------------------------------------------------------------------------------------------
+// Condition Implementation
+// -------------------------------------------------------------------------------------------------
+
+func newConditionGroup(conjunction string, conditions []ConditionIfc) *conditionGroup {
+	group := conditionGroup{
+		conjunction:	conjunction,
+		conditions:	make([]ConditionIfc, 0, len(conditions)),
+	}
+	for _, condition := range conditions {
+		if nil != condition { group.conditions = append(group.conditions, condition) }
+	}
+	return &group
+}
+
+// A single set (see getSetArg()) of values is the values themselves
+func flattenConditionValues(values []interface{}) []interface{} {
+	if 1 != len(values) { return values }
+	if set, ok := getSetArg(values[0]); ok { return set }
+	return values
+}
@@ -1,4 +1,4 @@
-package mysql
+package db
 
 /*
 Simple abstraction for database query results that prevent the caller from having to pull in Mysql database driver, etc.
@@ -6,7 +6,7 @@ This type of abstraction is important to support other types of database in the
 */
 
 import (
-	db "database/sql"
+	"database/sql"
 )
 
 type ResultIfc interface {
@@ -15,7 +15,7 @@ type ResultIfc interface {
 }
 
 type result struct {
-	res		db.Result
+	res		sql.Result
 	lastInsertId	*int64
 	rowsAffected	*int64
 }
@@ -24,7 +24,7 @@ type result struct {
 // Factory functions
 // -------------------------------------------------------------------------------------------------
 
-func NewResult(res db.Result) *result {
+func NewResult(res sql.Result) *result {
 	if nil == res { return nil }
 	return &result{
 		res:	res,
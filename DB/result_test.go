@@ -1,4 +1,4 @@
-package mysql
+package db_test
 
 import(
 	"fmt"
@@ -6,6 +6,7 @@ import(
 
         "github.com/DATA-DOG/go-sqlmock"
 
+	. "github.com/DigiStratum/GoLib/DB"
 	. "github.com/DigiStratum/GoLib/Testing"
 )
 
@@ -1,7 +1,7 @@
-package mysql
+package db
 
 /*
-A single result row from a MySQL query result set
+A single result row from a database query result set
 
 TODO:
  * See if there is a way to encode each Nullable value as it's native JSON data type instead of
@@ -16,9 +16,11 @@ TODO:
 */
 
 import (
+	"time"
 	"encoding/json"
 
-	nullables "github.com/DigiStratum/GoLib/DB/MySQL/nullables"
+	"github.com/DigiStratum/GoLib/Data"
+	"github.com/DigiStratum/GoLib/DB/nullables"
 )
 
 type ResultRowIfc interface {
@@ -27,6 +29,7 @@ type ResultRowIfc interface {
 	Has(field string) bool
 	Fields() []string
 	ToJson() (*string, error)
+	ToDataValue() *data.DataValue
 }
 
 // Non-exported structure with exported properties that we can serialize
@@ -86,6 +89,15 @@ func (r ResultRow) ToJson() (*string, error) {
 	return &jsonString, nil
 }
 
+// Convert this ResultRow to a DataValue object with one property per field
+func (r ResultRow) ToDataValue() *data.DataValue {
+	dataValue := data.NewObject()
+	for field, value := range r.props.values {
+		dataValue.SetObjectProperty(field, nullableToDataValue(value))
+	}
+	return dataValue
+}
+
 // -------------------------------------------------------------------------------------------------
 // encoding/json.Marshaler Public Interface
 // -------------------------------------------------------------------------------------------------
@@ -95,3 +107,20 @@ func (r ResultRow) ToJson() (*string, error) {
 func (r ResultRow) MarshalJSON() ([]byte, error) {
 	return json.Marshal(r.props.values)
 }
+
+// -------------------------------------------------------------------------------------------------
+// ResultRow Private Implementation
+// -------------------------------------------------------------------------------------------------
+
+// Map each Nullable type onto the nearest DataType; times become strings as with MarshalJSON()
+func nullableToDataValue(value *nullables.Nullable) *data.DataValue {
+	if (nil == value) || value.IsNil() { return data.NewNull() }
+	switch value.GetType() {
+		case nullables.NULLABLE_INT64: return data.NewInteger(*value.GetInt64())
+		case nullables.NULLABLE_BOOL: return data.NewBoolean(*value.GetBool())
+		case nullables.NULLABLE_FLOAT64: return data.NewFloat(*value.GetFloat64())
+		case nullables.NULLABLE_STRING: return data.NewString(*value.GetString())
+		case nullables.NULLABLE_TIME: return data.NewString(value.GetTime().Format(time.RFC3339))
+	}
+	return data.NewNull()
+}
@@ -1,11 +1,12 @@
-package mysql
+package db_test
 
 import(
 	"testing"
 	"encoding/json"
 
-	nulls "github.com/DigiStratum/GoLib/DB/MySQL/nullables"
+	nulls "github.com/DigiStratum/GoLib/DB/nullables"
 
+	. "github.com/DigiStratum/GoLib/DB"
 	. "github.com/DigiStratum/GoLib/Testing"
 )
 
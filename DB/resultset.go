@@ -1,4 +1,4 @@
-package mysql
+package db
 
 /*
 
@@ -7,8 +7,11 @@ TODO:
 */
 
 import (
+	"io"
 	"encoding/json"
 
+	"github.com/DigiStratum/GoLib/Data"
+	"github.com/DigiStratum/GoLib/Data/csv"
 	it "github.com/DigiStratum/GoLib/Data/iterable"
 )
 
@@ -24,6 +27,8 @@ type ResultSetIfc interface {
 	IsFinalized() bool
 	Finalize()
 	ToJson() (*string, error)
+	ToDataValue() *data.DataValue
+	ToCsv(writer io.Writer, columns ...string) error
 }
 
 type resultSet struct {
@@ -80,6 +85,20 @@ func (r resultSet) ToJson() (*string, error) {
 	return &jsonString, nil
 }
 
+// Convert this resultSet to a DataValue array of objects, one per ResultRow
+func (r resultSet) ToDataValue() *data.DataValue {
+	dataValue := data.NewArray()
+	for _, resultRow := range r.results {
+		dataValue.AppendArrayValue(resultRow.ToDataValue())
+	}
+	return dataValue
+}
+
+// Write this resultSet as CSV with a header record; columns are in the order given, else sorted
+func (r resultSet) ToCsv(writer io.Writer, columns ...string) error {
+	return csv.NewCsvWriter(writer).SetColumns(columns...).Write(r.ToDataValue())
+}
+
 // -------------------------------------------------------------------------------------------------
 // encoding/json.Marshaler
 // -------------------------------------------------------------------------------------------------
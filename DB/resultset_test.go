@@ -1,9 +1,11 @@
-package mysql
+package db_test
 
 import(
+	"strings"
 	"testing"
 
-	"github.com/DigiStratum/GoLib/DB/MySQL/nullables"
+	"github.com/DigiStratum/GoLib/DB/nullables"
+	. "github.com/DigiStratum/GoLib/DB"
 	. "github.com/DigiStratum/GoLib/Testing"
 )
 
@@ -124,3 +126,40 @@ func TestThat_ResultSet_ToJson_ReturnsPopulatedStateObject_WhenRowsSet(t *testin
 	ExpectNoError(err, t)
 	ExpectString("[{\"id\":111}]", *actualJson, t)
 }
+
+func TestThat_ResultSet_ToDataValue_ReturnsArrayOfObjects(t *testing.T) {
+	// Setup
+	sut := NewResultSet()
+	resultRow := NewResultRow()
+	resultRow.Set("id", *nullables.NewNullable(111))
+	resultRow.Set("name", *nullables.NewNullable("one"))
+	resultRow.Set("gone", *nullables.NewNullable(nil))
+	sut.Add(resultRow)
+
+	// Test
+	actual := sut.ToDataValue()
+
+	// Verify
+	if ! ExpectNonNil(actual, t) { return }
+	ExpectInt(1, actual.GetArraySize(), t)
+	ExpectInt64(111, actual.Select("[0].id").GetInteger(), t)
+	ExpectString("one", actual.Select("[0].name").GetString(), t)
+	ExpectTrue(actual.Select("[0].gone").IsNull(), t)
+}
+
+func TestThat_ResultSet_ToCsv_WritesColumnsInRequestedOrder(t *testing.T) {
+	// Setup
+	sut := NewResultSet()
+	resultRow := NewResultRow()
+	resultRow.Set("id", *nullables.NewNullable(111))
+	resultRow.Set("name", *nullables.NewNullable("one, two"))
+	sut.Add(resultRow)
+	var sb strings.Builder
+
+	// Test
+	err := sut.ToCsv(&sb, "name", "id")
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectString("name,id\n\"one, two\",111\n", sb.String(), t)
+}
@@ -0,0 +1,267 @@
+package db
+
+/*
+
+Scan query results straight into structs (or maps, or single values) rather than unpacking a
+ResultSet of Nullables one field at a time:
+
+	type Todo struct {
+		Id		int64		`db:"id"`
+		Task		string		`db:"task"`
+		Due		*time.Time	`db:"due"`	// NULL -> nil
+		Notes		sql.NullString	`db:"notes"`	// NULL -> Valid == false
+		Audit				// Embedded: its fields are ours too
+		Internal	string		`db:"-"`	// Never scanned
+	}
+
+	query, err := conn.NewQuery(db.NewSQLQuery("SELECT * FROM todo WHERE done = ?"))
+	todos, err := db.RunInto[Todo](query, false)		// []Todo
+	todo, err := db.RunOneInto[Todo](query, false)		// *Todo, nil if there are no rows
+	counts, err := db.RunInto[int64](countQuery)		// One column: []int64
+	rows, err := db.RunInto[map[string]interface{}](query)	// Column name -> value
+
+Columns map to fields by their `db:"column"` tag, or else by field name (case insensitively, and as
+snake_case: "DueDate" matches "duedate" and "due_date"). Fields of embedded structs (other than those
+which scan themselves, such as time.Time) map as if they were our own; ours win over theirs. Columns
+with no field are skipped, so SELECT * works with a struct for only some of the columns.
+
+Values are scanned by database/sql, so a field may be anything it can scan into: a NULL needs a
+pointer (nil for NULL) or a sql.Null* type, and a type implementing sql.Scanner scans itself. For
+time.Time with MySQL, include parseTime=true in the DSN.
+
+*/
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// Where to find the field for a column within a struct, by index at each level of embedding
+type structFieldPath []int
+
+var structFieldPaths sync.Map	// reflect.Type -> map[string]structFieldPath
+
+var scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+var timeType = reflect.TypeOf(time.Time{})
+
+// -------------------------------------------------------------------------------------------------
+// Scan Functions
+// -------------------------------------------------------------------------------------------------
+
+// Run query with args and scan all of the result rows into Ts
+func RunInto[T any](query QueryIfc, args ...interface{}) ([]T, error) {
+	if nil == query { return nil, fmt.Errorf("RunInto(): query is nil") }
+	rows, err := query.RunReturnRows(args...)
+	if nil != err { return nil, err }
+	defer rows.Close()
+	return ScanRowsInto[T](rows)
+}
+
+// Run query with args and scan the first result row into a T; nil if there are no rows
+func RunOneInto[T any](query QueryIfc, args ...interface{}) (*T, error) {
+	if nil == query { return nil, fmt.Errorf("RunOneInto(): query is nil") }
+	rows, err := query.RunReturnRows(args...)
+	if nil != err { return nil, err }
+	defer rows.Close()
+	scanRow, err := newRowScanner[T](rows)
+	if nil != err { return nil, err }
+	if ! rows.Next() { return nil, rows.Err() }
+	value, err := scanRow()
+	if nil != err { return nil, err }
+	return &value, nil
+}
+
+// Scan all of (the rest of) rows into Ts; the caller still owns rows and must Close() them
+func ScanRowsInto[T any](rows *sql.Rows) ([]T, error) {
+	if nil == rows { return nil, fmt.Errorf("ScanRowsInto(): rows is nil") }
+	scanRow, err := newRowScanner[T](rows)
+	if nil != err { return nil, err }
+	values := make([]T, 0)
+	for rows.Next() {
+		value, err := scanRow()
+		if nil != err { return nil, err }
+		values = append(values, value)
+	}
+	return values, rows.Err()
+}
+
+// -------------------------------------------------------------------------------------------------
+// Scan Implementation
+// -------------------------------------------------------------------------------------------------
+
+// A func to scan the current row of rows into a new T, for T a struct (or pointer to one), a map of
+// strings to values, or else any single value that database/sql can scan into
+func newRowScanner[T any](rows *sql.Rows) (func () (T, error), error) {
+	columns, err := rows.Columns()
+	if nil != err { return nil, err }
+	targetType := reflect.TypeOf((*T)(nil)).Elem()
+
+	switch {
+		case (reflect.Map == targetType.Kind()) && (reflect.String == targetType.Key().Kind()):
+			return newMapRowScanner[T](rows, columns, targetType), nil
+
+		case isStructTarget(targetType):
+			return newStructRowScanner[T](rows, columns, targetType), nil
+
+		case (reflect.Pointer == targetType.Kind()) && isStructTarget(targetType.Elem()):
+			return newStructRowScanner[T](rows, columns, targetType.Elem()), nil
+	}
+
+	// Any other T is a single value
+	if 1 != len(columns) {
+		return nil, fmt.Errorf("RunInto(): Scanning into %s needs exactly one column, got %d", targetType.String(), len(columns))
+	}
+	return func () (T, error) {
+		var value T
+		err := rows.Scan(&value)
+		return value, err
+	}, nil
+}
+
+// Is targetType a struct whose fields are to be scanned (rather than one which scans itself)?
+func isStructTarget(targetType reflect.Type) bool {
+	if reflect.Struct != targetType.Kind() { return false }
+	if timeType == targetType { return false }
+	return ! reflect.PointerTo(targetType).Implements(scannerType)
+}
+
+// Scan rows into structs of structType (T being either the struct or a pointer to one)
+func newStructRowScanner[T any](rows *sql.Rows, columns []string, structType reflect.Type) func () (T, error) {
+	paths := getStructFieldPaths(structType)
+	columnPaths := make([]structFieldPath, len(columns))
+	used := make(map[string]bool)
+	for index, column := range columns {
+		name := strings.ToLower(column)
+		// Same column twice (e.g. "id" from both sides of a join)? The first one gets the field
+		if used[name] { continue }
+		used[name] = true
+		columnPaths[index] = paths[name]
+	}
+	return func () (T, error) {
+		var target T
+		structValue := reflect.New(structType)
+		pointers := make([]interface{}, len(columns))
+		for index, path := range columnPaths {
+			if nil == path {
+				pointers[index] = new(interface{})
+				continue
+			}
+			pointers[index] = getStructField(structValue.Elem(), path).Addr().Interface()
+		}
+		if err := rows.Scan(pointers...); nil != err { return target, err }
+		if reflect.Pointer == reflect.TypeOf(&target).Elem().Kind() {
+			reflect.ValueOf(&target).Elem().Set(structValue)
+		} else {
+			reflect.ValueOf(&target).Elem().Set(structValue.Elem())
+		}
+		return target, nil
+	}
+}
+
+// Scan rows into maps of column names to values; []byte values (text, for many drivers) are strings
+func newMapRowScanner[T any](rows *sql.Rows, columns []string, mapType reflect.Type) func () (T, error) {
+	return func () (T, error) {
+		var target T
+		values := make([]interface{}, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for index := range values { pointers[index] = &values[index] }
+		if err := rows.Scan(pointers...); nil != err { return target, err }
+		mapValue := reflect.MakeMapWithSize(mapType, len(columns))
+		for index, column := range columns {
+			value := values[index]
+			if bytes, ok := value.([]byte); ok { value = string(bytes) }
+			elemValue := reflect.New(mapType.Elem()).Elem()
+			if nil != value {
+				converted := reflect.ValueOf(value)
+				if ! converted.Type().AssignableTo(mapType.Elem()) {
+					if ! converted.Type().ConvertibleTo(mapType.Elem()) {
+						return target, fmt.Errorf("RunInto(): Column '%s' value of type %s does not fit in %s", column, converted.Type().String(), mapType.String())
+					}
+					converted = converted.Convert(mapType.Elem())
+				}
+				elemValue.Set(converted)
+			}
+			mapValue.SetMapIndex(reflect.ValueOf(column).Convert(mapType.Key()), elemValue)
+		}
+		reflect.ValueOf(&target).Elem().Set(mapValue)
+		return target, nil
+	}
+}
+
+// The field at path within structValue, allocating any embedded struct pointers along the way
+func getStructField(structValue reflect.Value, path structFieldPath) reflect.Value {
+	value := structValue
+	for depth, index := range path {
+		value = value.Field(index)
+		if (depth < len(path) - 1) && (reflect.Pointer == value.Kind()) {
+			if value.IsNil() { value.Set(reflect.New(value.Type().Elem())) }
+			value = value.Elem()
+		}
+	}
+	return value
+}
+
+// Lower case column names to the paths of the fields of structType for them (cached per type)
+func getStructFieldPaths(structType reflect.Type) map[string]structFieldPath {
+	if paths, ok := structFieldPaths.Load(structType); ok { return paths.(map[string]structFieldPath) }
+	paths := make(map[string]structFieldPath)
+	addStructFieldPaths(structType, structFieldPath{}, paths, make(map[reflect.Type]bool))
+	structFieldPaths.Store(structType, paths)
+	return paths
+}
+
+// Our own fields first, then those of embedded structs, so that ours win (as with Go's own promotion)
+func addStructFieldPaths(structType reflect.Type, prefix structFieldPath, paths map[string]structFieldPath, seen map[reflect.Type]bool) {
+	if seen[structType] { return }
+	seen[structType] = true
+	embedded := make([]reflect.StructField, 0)
+	for index := 0; index < structType.NumField(); index++ {
+		field := structType.Field(index)
+		tag, hasTag := field.Tag.Lookup("db")
+		if "-" == tag { continue }
+		fieldType := field.Type
+		if reflect.Pointer == fieldType.Kind() { fieldType = fieldType.Elem() }
+		if field.Anonymous && ! hasTag && isStructTarget(fieldType) {
+			embedded = append(embedded, field)
+			continue
+		}
+		if ! field.IsExported() { continue }
+		path := append(append(structFieldPath{}, prefix...), index)
+		names := []string{ strings.ToLower(tag) }
+		if 0 == len(tag) { names = []string{ strings.ToLower(field.Name), toSnakeCase(field.Name) } }
+		for _, name := range names {
+			if _, ok := paths[name]; ! ok { paths[name] = path }
+		}
+	}
+	for _, field := range embedded {
+		fieldType := field.Type
+		if reflect.Pointer == fieldType.Kind() {
+			// An unexported embedded struct pointer can't be allocated for us
+			if ! field.IsExported() { continue }
+			fieldType = fieldType.Elem()
+		}
+		addStructFieldPaths(fieldType, append(append(structFieldPath{}, prefix...), field.Index[0]), paths, seen)
+	}
+}
+
+// "DueDate" -> "due_date", "UserID" -> "user_id"
+func toSnakeCase(name string) string {
+	var sb strings.Builder
+	runes := []rune(name)
+	for index, c := range runes {
+		if unicode.IsUpper(c) {
+			if (index > 0) && (unicode.IsLower(runes[index - 1]) || ((index + 1 < len(runes)) && unicode.IsLower(runes[index + 1]) && unicode.IsUpper(runes[index - 1]))) {
+				sb.WriteRune('_')
+			}
+			sb.WriteRune(unicode.ToLower(c))
+			continue
+		}
+		sb.WriteRune(c)
+	}
+	return sb.String()
+}
@@ -0,0 +1,188 @@
+package db_test
+
+import(
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+        "github.com/DATA-DOG/go-sqlmock"
+
+	. "github.com/DigiStratum/GoLib/DB"
+	. "github.com/DigiStratum/GoLib/Testing"
+)
+
+type scanIntoAudit struct {
+	CreatedBy	string
+	UpdatedAt	*time.Time
+}
+
+type scanIntoName struct {
+	value		string
+}
+
+// A custom scanner: upper cases whatever it is given
+func (r *scanIntoName) Scan(src interface{}) error {
+	switch v := src.(type) {
+		case string: r.value = strings.ToUpper(v)
+		case []byte: r.value = strings.ToUpper(string(v))
+		case nil: r.value = ""
+		default: return fmt.Errorf("scanIntoName: unexpected %T", src)
+	}
+	return nil
+}
+
+type scanIntoTodo struct {
+	scanIntoAudit
+	Id		int64			`db:"id"`
+	Task		string			`db:"task"`
+	Due		*time.Time		`db:"due"`
+	Notes		sql.NullString		`db:"notes"`
+	Owner		scanIntoName		`db:"owner"`
+	DueDate		string
+	Internal	string			`db:"-"`
+}
+
+func newScanIntoQuery(t *testing.T, columns []string, values ...[]interface{}) (QueryIfc, sqlmock.Sqlmock) {
+	conn, mock, err := sqlmock.New()
+	if nil != err { t.Fatal(err) }
+	rows := sqlmock.NewRows(columns)
+	for _, row := range values {
+		driverValues := make([]driver.Value, len(row))
+		for index, value := range row { driverValues[index] = value }
+		rows.AddRow(driverValues...)
+	}
+	mock.ExpectPrepare("SELECT").ExpectQuery().WillReturnRows(rows)
+	connection, _ := NewConnection(conn)
+	query, _ := connection.NewQuery(NewSQLQuery("SELECT * FROM todo"))
+	return query, mock
+}
+
+func TestThat_RunInto_ScansRowsIntoStructs(t *testing.T) {
+	// Setup
+	due := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	query, mock := newScanIntoQuery(t,
+		[]string{ "id", "task", "due", "notes", "owner", "due_date", "createdby", "updated_at", "extra" },
+		[]interface{}{ int64(1), "Buy milk", due, "2%", "alice", "soon", "bob", due, "ignored" },
+		[]interface{}{ int64(2), "Buy bread", nil, nil, nil, "", "carol", nil, nil },
+	)
+
+	// Test
+	todos, err := RunInto[scanIntoTodo](query)
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectInt(2, len(todos), t)
+	ExpectTrue(int64(1) == todos[0].Id, t)
+	ExpectString("Buy milk", todos[0].Task, t)
+	ExpectTrue(nil != todos[0].Due && due.Equal(*todos[0].Due), t)
+	ExpectTrue(todos[0].Notes.Valid, t)
+	ExpectString("2%", todos[0].Notes.String, t)
+	ExpectString("ALICE", todos[0].Owner.value, t)
+	ExpectString("soon", todos[0].DueDate, t)
+	ExpectString("bob", todos[0].CreatedBy, t)
+	ExpectTrue(nil != todos[0].UpdatedAt, t)
+	ExpectTrue(nil == todos[1].Due, t)
+	ExpectFalse(todos[1].Notes.Valid, t)
+	ExpectTrue(nil == todos[1].UpdatedAt, t)
+	ExpectNoError(mock.ExpectationsWereMet(), t)
+}
+
+func TestThat_RunInto_ReturnsError_WhenNullScannedIntoNonNullable(t *testing.T) {
+	// Setup
+	query, _ := newScanIntoQuery(t, []string{ "id", "task" }, []interface{}{ int64(1), nil })
+
+	// Test
+	_, err := RunInto[scanIntoTodo](query)
+
+	// Verify
+	ExpectError(err, t)
+}
+
+func TestThat_RunInto_ScansRowsIntoStructPointers(t *testing.T) {
+	// Setup
+	query, _ := newScanIntoQuery(t, []string{ "id" }, []interface{}{ int64(3) })
+
+	// Test
+	todos, err := RunInto[*scanIntoTodo](query)
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectInt(1, len(todos), t)
+	ExpectTrue(int64(3) == todos[0].Id, t)
+}
+
+func TestThat_RunInto_ScansSingleColumnIntoValues(t *testing.T) {
+	// Setup
+	query, _ := newScanIntoQuery(t, []string{ "id" }, []interface{}{ int64(3) }, []interface{}{ int64(4) })
+
+	// Test
+	ids, err := RunInto[int64](query)
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectInt(2, len(ids), t)
+	ExpectTrue(int64(4) == ids[1], t)
+}
+
+func TestThat_RunInto_ReturnsError_WhenValueTargetGivenManyColumns(t *testing.T) {
+	// Setup
+	query, _ := newScanIntoQuery(t, []string{ "id", "task" }, []interface{}{ int64(3), "x" })
+
+	// Test
+	_, err := RunInto[int64](query)
+
+	// Verify
+	ExpectError(err, t)
+}
+
+func TestThat_RunInto_ScansRowsIntoMaps(t *testing.T) {
+	// Setup
+	query, _ := newScanIntoQuery(t, []string{ "id", "task", "due" }, []interface{}{ int64(3), []byte("Buy milk"), nil })
+
+	// Test
+	rows, err := RunInto[map[string]interface{}](query)
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectInt(1, len(rows), t)
+	ExpectTrue(int64(3) == rows[0]["id"], t)
+	ExpectTrue("Buy milk" == rows[0]["task"], t)
+	value, ok := rows[0]["due"]
+	ExpectTrue(ok && (nil == value), t)
+}
+
+func TestThat_RunOneInto_ReturnsFirstRow(t *testing.T) {
+	// Setup
+	query, _ := newScanIntoQuery(t, []string{ "id" }, []interface{}{ int64(3) }, []interface{}{ int64(4) })
+
+	// Test
+	todo, err := RunOneInto[scanIntoTodo](query)
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectTrue(nil != todo, t)
+	ExpectTrue(int64(3) == todo.Id, t)
+}
+
+func TestThat_RunOneInto_ReturnsNil_WhenNoRows(t *testing.T) {
+	// Setup
+	query, _ := newScanIntoQuery(t, []string{ "id" })
+
+	// Test
+	todo, err := RunOneInto[scanIntoTodo](query)
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectTrue(nil == todo, t)
+}
+
+func TestThat_RunInto_ReturnsError_WhenQueryNil(t *testing.T) {
+	// Test
+	_, err := RunInto[scanIntoTodo](nil)
+
+	// Verify
+	ExpectError(err, t)
+}
@@ -0,0 +1,290 @@
+package db
+
+/*
+A SQL Query is Runnable if it implements the SQLQueryIfc interface. SQLQuery is a default implementation
+of this that supports a raw query pass-through. QueryBuilder (see querybuilder.go) is another, which
+builds the query from its parts, independent of the Query runner.
+
+SQLQuery resolves two macro tokens before the query is prepared:
+
+ * '???' expands to as many '?' placeholders as there are values in the slice arg at its position, for
+   IN () lists of any size; the slice is flattened into the args for the prepared statement. Each
+   '???' must have a slice arg (other than []byte, which is a single value) and each '?' must not.
+   Sets are only looked for when the query has a '???', so queries without one take any args. A
+   slice of bytes (such as json.RawMessage, or a [16]byte UUID) or a driver.Valuer is never a set.
+   An empty slice expands to NULL, so "IN (???)" matches nothing rather than being a syntax error;
+   "NOT IN (???)" would then match nothing too, where it should match everything, so an empty set
+   there is an error: check for an empty set first (or see NotIn() in querycondition.go).
+
+ * '{{name}}' is replaced by a literal, for query variance that placeholders can't give, such as
+   the name of a table. Literals are unescaped, so each must be allowed first with AllowLiteral(),
+   and only the values allowed for it may be substituted. Give the value with an arg made by
+   Literal(), anywhere among the args (it doesn't take up a placeholder), or a default value with
+   SetLiteral():
+
+	query := db.NewSQLQuery("SELECT * FROM {{table}} WHERE id IN (???) AND status = ?").
+		AllowLiteral("table", "todo", "todo_archive").
+		SetLiteral("table", "todo")
+	q, err := conn.NewQuery(query)
+	results, err := q.RunReturnAll(db.Literal("table", "todo_archive"), ids, "open")
+
+	-> SELECT * FROM todo_archive WHERE id IN (?, ?, ?) AND status = ?
+
+Tokens within quoted strings, quoted identifiers and comments are left alone; these are found as the
+Dialect of the Connection running the query sees them (MySQL's, which lets a backslash escape a quote
+within a string, when resolved on its own). When there are '???'
+sets, args must match the placeholders one for one; otherwise they pass through to the driver as they are.
+Allow and set literals before the query is used; SQLQuery is not safe to change while it is in use.
+
+*/
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+type SQLQueryIfc interface {
+	// Resolve the query as a string or else a non-nil error
+	Resolve(args ... interface{}) (string, error)
+}
+
+// A SQLQuery which changes the args as it resolves (see ResolveWithArgs()); Connections prepare with
+// the args it returns rather than those they were given
+type SQLQueryWithArgsIfc interface {
+	SQLQueryIfc
+	// Resolve the query as a string, with the args to run it with, or else a non-nil error
+	ResolveWithArgs(args ... interface{}) (string, []interface{}, error)
+}
+
+type SQLQuery struct {
+	query		string
+	literals	map[string]map[string]bool	// Allowed values by literal name
+	defaults	map[string]string		// Default values by literal name
+}
+
+// A value for a '{{name}}' literal, given as an arg
+type LiteralArg struct {
+	Name		string
+	Value		string
+}
+
+func NewSQLQuery(query string) *SQLQuery {
+	return &SQLQuery{
+		query:		query,
+		literals:	make(map[string]map[string]bool),
+		defaults:	make(map[string]string),
+	}
+}
+
+// Make an arg with value for the '{{name}}' literal
+func Literal(name, value string) *LiteralArg {
+	return &LiteralArg{
+		Name:		name,
+		Value:		value,
+	}
+}
+
+// -------------------------------------------------------------------------------------------------
+// SQLQueryIfc Public Interface
+// -------------------------------------------------------------------------------------------------
+
+/*
+Resolve this query object as a flat SQL statement ready for execution
+Supplied args are same as those that will be fed to a prepared statement. These can be used to hint
+our resolver here with macro tokens that will sub in the correct number of placeholders for prepared
+statements, etc.
+
+Using Pointer Receiver because we we don't want the caller to have to dereference a pointer produced
+by NewSQLQuery().
+*/
+func (r *SQLQuery) Resolve(args ... interface{}) (string,  error) {
+	sql, _, err := r.ResolveWithArgs(args...)
+	return sql, err
+}
+
+// -------------------------------------------------------------------------------------------------
+// SQLQueryWithArgsIfc Public Interface
+// -------------------------------------------------------------------------------------------------
+
+// Resolve, also returning the args for the prepared statement: slices flattened, literals removed
+func (r *SQLQuery) ResolveWithArgs(args ... interface{}) (string, []interface{}, error) {
+	return r.resolveWithArgsFor(GetDefaultDialect(), args...)
+}
+
+// -------------------------------------------------------------------------------------------------
+// SQLQuery Public Interface
+// -------------------------------------------------------------------------------------------------
+
+// Allow the '{{name}}' literal in our query, with any of values (and nothing else) substituted for it
+func (r *SQLQuery) AllowLiteral(name string, values ...string) *SQLQuery {
+	if nil == r.literals { r.literals = make(map[string]map[string]bool) }
+	if _, ok := r.literals[name]; ! ok { r.literals[name] = make(map[string]bool) }
+	for _, value := range values { r.literals[name][value] = true }
+	return r
+}
+
+// Substitute value for the '{{name}}' literal unless a Literal() arg says otherwise
+func (r *SQLQuery) SetLiteral(name, value string) *SQLQuery {
+	if nil == r.defaults { r.defaults = make(map[string]string) }
+	r.defaults[name] = value
+	return r
+}
+
+// -------------------------------------------------------------------------------------------------
+// SQLQuery Private Implementation
+// -------------------------------------------------------------------------------------------------
+
+// Resolve, seeing quoted text in our query as dialect does
+func (r *SQLQuery) resolveWithArgsFor(dialect DialectIfc, args ... interface{}) (string, []interface{}, error) {
+	if nil == r { return "", nil, fmt.Errorf("SQLQuery is nil, nothing to resolve") }
+	if len(r.query) == 0 { return "", nil, fmt.Errorf("Query is empty") }
+	// TODO: do some basic syntax/token/placeholder checks on query
+	sql, resolvedArgs, err := r.resolveMacroTokens(dialect, args...)
+	if nil != err { return "", nil, err }
+	return *sql, resolvedArgs, nil
+}
+
+// Resolve the '???' and '{{name}}' macro tokens within our query with args
+func (r SQLQuery) resolveMacroTokens(dialect DialectIfc, args ... interface{}) (*string, []interface{}, error) {
+	// Literals are not placeholder args; pull them out
+	literals := make(map[string]string)
+	for name, value := range r.defaults { literals[name] = value }
+	placeholderArgs := make([]interface{}, 0, len(args))
+	for _, arg := range args {
+		if literal, ok := arg.(*LiteralArg); ok && (nil != literal) {
+			literals[literal.Name] = literal.Value
+			continue
+		}
+		placeholderArgs = append(placeholderArgs, arg)
+	}
+
+	// Split the query into text (with literals substituted) and the placeholders between
+	texts, placeholders, err := r.splitPlaceholders(dialect, literals)
+	if nil != err { return nil, nil, err }
+	setCount := 0
+	for _, placeholder := range placeholders {
+		if "???" == placeholder { setCount++ }
+	}
+
+	// No sets? Then the args pass straight through, as many as there are, slices and all
+	if 0 == setCount {
+		finalQuery := strings.Join(texts, "?")
+		return &finalQuery, placeholderArgs, nil
+	}
+
+	setArgCount := 0
+	for _, arg := range placeholderArgs {
+		if _, isSet := getSetArg(arg); isSet { setArgCount++ }
+	}
+	if setCount != setArgCount {
+		return nil, nil, fmt.Errorf("SQLQuery.Resolve(): Query has %d '???' placeholders, but %d set (slice) args were given", setCount, setArgCount)
+	}
+	if len(placeholders) != len(placeholderArgs) {
+		return nil, nil, fmt.Errorf("SQLQuery.Resolve(): Query has %d placeholders, but %d args were given", len(placeholders), len(placeholderArgs))
+	}
+	var sb strings.Builder
+	resolvedArgs := make([]interface{}, 0, len(placeholderArgs))
+	for index, placeholder := range placeholders {
+		sb.WriteString(texts[index])
+		arg := placeholderArgs[index]
+		set, isSet := getSetArg(arg)
+		if "?" == placeholder {
+			if isSet { return nil, nil, fmt.Errorf("SQLQuery.Resolve(): Set arg for '?' placeholder #%d; use '???' for sets", index + 1) }
+			sb.WriteString("?")
+			resolvedArgs = append(resolvedArgs, arg)
+			continue
+		}
+		if ! isSet { return nil, nil, fmt.Errorf("SQLQuery.Resolve(): Expected a set (slice) arg for '???' placeholder #%d", index + 1) }
+		// An empty set must still be valid SQL; "IN (NULL)" matches nothing, as it should, but so
+		// would "NOT IN (NULL)", which should match everything
+		if 0 == len(set) {
+			if isNotInList(texts[index]) {
+				return nil, nil, fmt.Errorf("SQLQuery.Resolve(): Empty set for NOT IN '???' placeholder #%d; check for an empty set first", index + 1)
+			}
+			sb.WriteString("NULL")
+			continue
+		}
+		sb.WriteString(strings.TrimSuffix(strings.Repeat("?, ", len(set)), ", "))
+		resolvedArgs = append(resolvedArgs, set...)
+	}
+	sb.WriteString(texts[len(placeholders)])
+	finalQuery := sb.String()
+	return &finalQuery, resolvedArgs, nil
+}
+
+// Split our query around its '?' and '???' placeholders (so there is one more text than there are
+// placeholders), substituting literals into the text as we go
+func (r SQLQuery) splitPlaceholders(dialect DialectIfc, literals map[string]string) ([]string, []string, error) {
+	texts := make([]string, 0)
+	placeholders := make([]string, 0)
+	var sb strings.Builder
+	query := r.query
+	for index := 0; index < len(query); {
+		if end := skipQuotedText(dialect, query, index); end > index {
+			sb.WriteString(query[index:end])
+			index = end
+			continue
+		}
+
+		// '{{name}}' literal?
+		if strings.HasPrefix(query[index:], "{{") {
+			end := strings.Index(query[index:], "}}")
+			if end < 0 { return nil, nil, fmt.Errorf("SQLQuery.Resolve(): Unterminated '{{' literal") }
+			value, err := r.getLiteral(strings.TrimSpace(query[index + 2:index + end]), literals)
+			if nil != err { return nil, nil, err }
+			sb.WriteString(value)
+			index += end + 2
+			continue
+		}
+
+		if '?' != query[index] {
+			sb.WriteByte(query[index])
+			index++
+			continue
+		}
+
+		count := 1
+		for (index + count < len(query)) && ('?' == query[index + count]) { count++ }
+		if (1 != count) && (3 != count) {
+			return nil, nil, fmt.Errorf("SQLQuery.Resolve(): Expected '?' or '???' placeholder, got '%s'", strings.Repeat("?", count))
+		}
+		texts = append(texts, sb.String())
+		sb.Reset()
+		placeholders = append(placeholders, query[index:index + count])
+		index += count
+	}
+	texts = append(texts, sb.String())
+	return texts, placeholders, nil
+}
+
+// The value to substitute for the named literal, so long as it is allowed
+func (r SQLQuery) getLiteral(name string, literals map[string]string) (string, error) {
+	allowed, ok := r.literals[name]
+	if ! ok { return "", fmt.Errorf("SQLQuery.Resolve(): Literal '{{%s}}' is not allowed", name) }
+	value, ok := literals[name]
+	if ! ok { return "", fmt.Errorf("SQLQuery.Resolve(): No value for literal '{{%s}}'", name) }
+	if ! allowed[value] { return "", fmt.Errorf("SQLQuery.Resolve(): Value '%s' is not allowed for literal '{{%s}}'", value, name) }
+	return value, nil
+}
+
+// Does text (up to a placeholder) end with "NOT IN (", in any case and spacing?
+func isNotInList(text string) bool {
+	words := strings.Fields(strings.ToUpper(strings.ReplaceAll(text, "(", " ( ")))
+	count := len(words)
+	return (count >= 3) && ("NOT" == words[count - 3]) && ("IN" == words[count - 2]) && ("(" == words[count - 1])
+}
+
+// The values of arg, if it is a set: a slice or array, but not of bytes (such as []byte,
+// json.RawMessage or a [16]byte UUID) nor a driver.Valuer, each of which is a single value
+func getSetArg(arg interface{}) ([]interface{}, bool) {
+	if nil == arg { return nil, false }
+	if _, ok := arg.(driver.Valuer); ok { return nil, false }
+	value := reflect.ValueOf(arg)
+	if (reflect.Slice != value.Kind()) && (reflect.Array != value.Kind()) { return nil, false }
+	if reflect.Uint8 == value.Type().Elem().Kind() { return nil, false }
+	set := make([]interface{}, value.Len())
+	for index := range set { set[index] = value.Index(index).Interface() }
+	return set, true
+}
@@ -0,0 +1,264 @@
+package db_test
+
+import(
+	"database/sql/driver"
+	"encoding/json"
+	"strings"
+	"testing"
+
+        "github.com/DATA-DOG/go-sqlmock"
+
+	. "github.com/DigiStratum/GoLib/DB"
+	. "github.com/DigiStratum/GoLib/Testing"
+)
+
+func TestThat_NewSQLQuery_ReturnsSomething(t *testing.T) {
+	// Test
+	var sut *SQLQuery = NewSQLQuery("bogus query")
+
+	// Verify
+	ExpectNonNil(sut, t)
+}
+
+func TestThat_SQLQuery_Resolve_ReturnsError_WhenNil(t *testing.T) {
+	// Setup
+	var sut SQLQuery
+
+	// Test
+	_, err := sut.Resolve()
+
+	// Verify
+	ExpectError(err, t)
+}
+
+func TestThat_SQLQuery_Resolve_ReturnsQuery_NoError(t *testing.T) {
+	// Setup
+	expectedQuery := "bogus query"
+	sut := NewSQLQuery(expectedQuery)
+
+	// Test
+	actual, err := sut.Resolve()
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectString(expectedQuery, actual, t)
+}
+
+
+func TestThat_SQLQuery_ResolveWithArgs_PassesArgsThrough_WhenNoSets(t *testing.T) {
+	// Setup
+	var sut SQLQueryWithArgsIfc = NewSQLQuery("SELECT * FROM todo WHERE id = ?") // <- ensures that we satisfy our interface
+
+	// Test
+	actual, args, err := sut.ResolveWithArgs(7)
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectString("SELECT * FROM todo WHERE id = ?", actual, t)
+	ExpectInt(1, len(args), t)
+	ExpectTrue(7 == args[0], t)
+}
+
+func TestThat_SQLQuery_ResolveWithArgs_ExpandsSetsAndFlattensArgs(t *testing.T) {
+	// Setup
+	sut := NewSQLQuery("SELECT * FROM todo WHERE user_id = ? AND id IN (???) AND status IN (???)")
+
+	// Test
+	actual, args, err := sut.ResolveWithArgs(5, []int{ 1, 2, 3 }, []string{ "open" })
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectString("SELECT * FROM todo WHERE user_id = ? AND id IN (?, ?, ?) AND status IN (?)", actual, t)
+	ExpectInt(5, len(args), t)
+	ExpectTrue(5 == args[0], t)
+	ExpectTrue(3 == args[3], t)
+	ExpectTrue("open" == args[4], t)
+}
+
+func TestThat_SQLQuery_ResolveWithArgs_ExpandsEmptySetToNull(t *testing.T) {
+	// Setup
+	sut := NewSQLQuery("SELECT * FROM todo WHERE id IN (???)")
+
+	// Test
+	actual, args, err := sut.ResolveWithArgs([]int{})
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectString("SELECT * FROM todo WHERE id IN (NULL)", actual, t)
+	ExpectInt(0, len(args), t)
+}
+
+func TestThat_SQLQuery_ResolveWithArgs_TreatsBytesAsOneValue(t *testing.T) {
+	// Setup
+	sut := NewSQLQuery("SELECT * FROM todo WHERE hash = ? AND id IN (???)")
+
+	// Test
+	_, args, err := sut.ResolveWithArgs([]byte("abc"), []int{ 1 })
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectInt(2, len(args), t)
+}
+
+func TestThat_SQLQuery_ResolveWithArgs_ReturnsError_WhenSetCountMismatched(t *testing.T) {
+	// Setup
+	sut := NewSQLQuery("SELECT * FROM todo WHERE id IN (???) AND user_id IN (???)")
+
+	// Test
+	_, _, err1 := sut.ResolveWithArgs([]int{ 1 })
+	_, _, err2 := sut.ResolveWithArgs()
+
+	// Verify
+	ExpectError(err1, t)
+	ExpectError(err2, t)
+}
+
+func TestThat_SQLQuery_ResolveWithArgs_PassesSliceArgsThrough_WhenQueryHasNoSets(t *testing.T) {
+	// Setup
+	sut := NewSQLQuery("SELECT * FROM todo WHERE id = ?")
+
+	// Test
+	actual, args, err := sut.ResolveWithArgs([]int{ 1, 2 })
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectString("SELECT * FROM todo WHERE id = ?", actual, t)
+	ExpectInt(1, len(args), t)
+}
+
+// A driver.Valuer which happens to be a slice
+type valuerList []string
+
+func (r valuerList) Value() (driver.Value, error) { return strings.Join(r, ","), nil }
+
+func TestThat_SQLQuery_ResolveWithArgs_TreatsByteSlicesAndValuersAsOneValue(t *testing.T) {
+	// Setup
+	uuid := [16]byte{ 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16 }
+	raw := json.RawMessage("{}")
+	list := valuerList{ "a", "b" }
+
+	// Test
+	_, args1, err1 := NewSQLQuery("SELECT * FROM t WHERE id = ?").ResolveWithArgs(raw)
+	_, args2, err2 := NewSQLQuery("SELECT * FROM t WHERE id = ?").ResolveWithArgs(uuid)
+	_, args3, err3 := NewSQLQuery("SELECT * FROM t WHERE doc = ? AND uuid = ? AND tags = ? AND id IN (???)").
+		ResolveWithArgs(raw, uuid, list, []int{ 1, 2 })
+
+	// Verify
+	ExpectNoError(err1, t)
+	ExpectInt(1, len(args1), t)
+	ExpectNoError(err2, t)
+	ExpectInt(1, len(args2), t)
+	ExpectNoError(err3, t)
+	ExpectInt(5, len(args3), t)
+	ExpectTrue(uuid == args3[1], t)
+}
+
+func TestThat_SQLQuery_ResolveWithArgs_ReturnsError_ForEmptySetWithNotIn(t *testing.T) {
+	// Setup
+	sut := NewSQLQuery("SELECT * FROM todo WHERE id not in(???) AND user_id IN (???)")
+
+	// Test
+	_, _, err1 := sut.ResolveWithArgs([]int{}, []int{ 1 })
+	actual, _, err2 := sut.ResolveWithArgs([]int{ 1 }, []int{})
+
+	// Verify
+	ExpectError(err1, t)
+	ExpectNoError(err2, t)
+	ExpectString("SELECT * FROM todo WHERE id not in(?) AND user_id IN (NULL)", actual, t)
+}
+
+func TestThat_SQLQuery_ResolveWithArgs_ReturnsError_WhenSetArgOutOfPlace(t *testing.T) {
+	// Setup
+	sut := NewSQLQuery("SELECT * FROM todo WHERE id IN (???) AND user_id = ?")
+
+	// Test
+	_, _, err := sut.ResolveWithArgs(5, []int{ 1 })
+
+	// Verify
+	ExpectError(err, t)
+}
+
+func TestThat_SQLQuery_ResolveWithArgs_IgnoresTokensInQuotesAndComments(t *testing.T) {
+	// Setup
+	sut := NewSQLQuery("SELECT '???', '{{x}}' FROM todo /* ??? */ WHERE id IN (???)")
+
+	// Test
+	actual, args, err := sut.ResolveWithArgs([]int{ 1, 2 })
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectString("SELECT '???', '{{x}}' FROM todo /* ??? */ WHERE id IN (?, ?)", actual, t)
+	ExpectInt(2, len(args), t)
+}
+
+func TestThat_SQLQuery_ResolveWithArgs_SubstitutesAllowedLiterals(t *testing.T) {
+	// Setup
+	sut := NewSQLQuery("SELECT * FROM {{table}} WHERE id = ? ORDER BY id {{ direction }}").
+		AllowLiteral("table", "todo", "todo_archive").
+		AllowLiteral("direction", "ASC", "DESC").
+		SetLiteral("table", "todo").
+		SetLiteral("direction", "ASC")
+
+	// Test
+	defaulted, defaultedArgs, err1 := sut.ResolveWithArgs(7)
+	given, givenArgs, err2 := sut.ResolveWithArgs(Literal("table", "todo_archive"), 7, Literal("direction", "DESC"))
+
+	// Verify
+	ExpectNoError(err1, t)
+	ExpectString("SELECT * FROM todo WHERE id = ? ORDER BY id ASC", defaulted, t)
+	ExpectInt(1, len(defaultedArgs), t)
+	ExpectNoError(err2, t)
+	ExpectString("SELECT * FROM todo_archive WHERE id = ? ORDER BY id DESC", given, t)
+	ExpectInt(1, len(givenArgs), t)
+}
+
+func TestThat_SQLQuery_ResolveWithArgs_ReturnsError_WhenLiteralNotAllowed(t *testing.T) {
+	// Setup
+	sut := NewSQLQuery("SELECT * FROM {{table}}").AllowLiteral("table", "todo")
+
+	// Test
+	_, _, err1 := sut.ResolveWithArgs(Literal("table", "todo; DROP TABLE todo"))
+	_, _, err2 := sut.ResolveWithArgs()
+	_, _, err3 := NewSQLQuery("SELECT * FROM {{table}}").ResolveWithArgs(Literal("table", "todo"))
+
+	// Verify
+	ExpectError(err1, t)
+	ExpectError(err2, t)
+	ExpectError(err3, t)
+}
+
+func TestThat_Connection_Query_PreparesWithResolvedArgs(t *testing.T) {
+	// Setup
+	conn, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	mock.ExpectPrepare("SELECT id FROM todo_archive WHERE id IN (?, ?)").ExpectQuery().
+		WithArgs(7, 8).
+		WillReturnRows(sqlmock.NewRows([]string{ "id" }).AddRow("7").AddRow("8"))
+	connection, _ := NewConnection(conn)
+	sut := NewSQLQuery("SELECT id FROM {{table}} WHERE id IN (???)").AllowLiteral("table", "todo_archive")
+	query, _ := connection.NewQuery(sut)
+
+	// Test
+	results, err := query.RunReturnAll(Literal("table", "todo_archive"), []int{ 7, 8 })
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectInt(2, results.Len(), t)
+	ExpectNoError(mock.ExpectationsWereMet(), t)
+}
+
+func TestThat_Connection_Exec_ResolvesSQLQuery_WithBackslashRulesOfDialect(t *testing.T) {
+	// Setup
+	conn, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	mock.ExpectPrepare(`DELETE FROM t WHERE a = 'C:\' AND b IN ($1, $2)`).ExpectExec().
+		WithArgs(1, 2).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	sut, _ := NewConnection(conn)
+	sut.SetDialect(NewPostgreSQLDialect())
+
+	// Test
+	_, err := sut.Exec(NewSQLQuery(`DELETE FROM t WHERE a = 'C:\' AND b IN (???)`), []int{ 1, 2 })
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectNoError(mock.ExpectationsWereMet(), t)
+}
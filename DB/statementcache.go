@@ -0,0 +1,79 @@
+package db
+
+/*
+
+A bounded cache of prepared statements, keyed on resolved SQL query text. A query which resolves to
+different SQL as its args vary (such as a '???' set, or a QueryBuilder In(), of a different size each
+time) adds a statement for each; so that these don't pile up for the life of the connection, the
+least recently used statement is closed and dropped once the cache is full.
+
+database/sql defers closing a statement until any Rows from it are closed; a transaction's own
+statements are closed at once, though, so read Rows from within a transaction before running a great
+many other queries in it.
+
+*/
+
+import (
+	"container/list"
+	"database/sql"
+)
+
+const DEFAULT_STATEMENT_CACHE_SIZE = 100
+
+type statementCache struct {
+	limit		int				// Most statements to keep before closing the least recently used
+	statements	map[string]*list.Element	// Our elements by SQL query text
+	usage		*list.List			// Our statementCacheEntries, most recently used first
+}
+
+type statementCacheEntry struct {
+	sql		string
+	stmt		*sql.Stmt
+}
+
+// -------------------------------------------------------------------------------------------------
+// Factory Functions
+// -------------------------------------------------------------------------------------------------
+
+func newStatementCache(limit int) *statementCache {
+	if limit < 1 { limit = 1 }
+	return &statementCache{
+		limit:		limit,
+		statements:	make(map[string]*list.Element),
+		usage:		list.New(),
+	}
+}
+
+// -------------------------------------------------------------------------------------------------
+// statementCache Implementation
+// -------------------------------------------------------------------------------------------------
+
+// Get the statement prepared for sql, if we have one
+func (r *statementCache) get(sql string) (*sql.Stmt, bool) {
+	element, ok := r.statements[sql]
+	if ! ok { return nil, false }
+	r.usage.MoveToFront(element)
+	return element.Value.(*statementCacheEntry).stmt, true
+}
+
+// Keep stmt, prepared for sql, closing the least recently used statement if we're then over our limit
+func (r *statementCache) put(sql string, stmt *sql.Stmt) {
+	if element, ok := r.statements[sql]; ok {
+		entry := element.Value.(*statementCacheEntry)
+		if entry.stmt != stmt { entry.stmt.Close() }
+		entry.stmt = stmt
+		r.usage.MoveToFront(element)
+		return
+	}
+	r.statements[sql] = r.usage.PushFront(&statementCacheEntry{ sql: sql, stmt: stmt })
+	for r.usage.Len() > r.limit {
+		oldest := r.usage.Back()
+		entry := r.usage.Remove(oldest).(*statementCacheEntry)
+		delete(r.statements, entry.sql)
+		entry.stmt.Close()
+	}
+}
+
+func (r *statementCache) size() int {
+	return r.usage.Len()
+}
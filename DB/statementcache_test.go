@@ -0,0 +1,61 @@
+package db
+
+import(
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	. "github.com/DigiStratum/GoLib/Testing"
+)
+
+func TestThat_statementCache_put_ClosesLeastRecentlyUsedStatement_WhenOverLimit(t *testing.T) {
+	// Setup
+	conn, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if nil != err { t.Fatal(err) }
+	mock.ExpectPrepare("SELECT 1")
+	mock.ExpectPrepare("SELECT 2").WillBeClosed()
+	mock.ExpectPrepare("SELECT 3")
+	sut := newStatementCache(2)
+	stmt1, _ := conn.Prepare("SELECT 1")
+	stmt2, _ := conn.Prepare("SELECT 2")
+	stmt3, _ := conn.Prepare("SELECT 3")
+
+	// Test
+	sut.put("SELECT 1", stmt1)
+	sut.put("SELECT 2", stmt2)
+	sut.get("SELECT 1")
+	sut.put("SELECT 3", stmt3)
+	_, ok1 := sut.get("SELECT 1")
+	_, ok2 := sut.get("SELECT 2")
+	_, ok3 := sut.get("SELECT 3")
+
+	// Verify
+	ExpectInt(2, sut.size(), t)
+	ExpectTrue(ok1, t)
+	ExpectFalse(ok2, t)
+	ExpectTrue(ok3, t)
+	ExpectNoError(mock.ExpectationsWereMet(), t)
+}
+
+func TestThat_Connection_Exec_KeepsStatementCacheBounded_ForSetsOfManySizes(t *testing.T) {
+	// Setup
+	conn, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+	if nil != err { t.Fatal(err) }
+	mock.MatchExpectationsInOrder(false)
+	sut, _ := NewConnection(conn)
+	query := NewSQLQuery("DELETE FROM todo WHERE id IN (???)")
+	sizes := DEFAULT_STATEMENT_CACHE_SIZE + 10
+	for size := 1; size <= sizes; size++ {
+		mock.ExpectPrepare("DELETE FROM todo").ExpectExec().WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+
+	// Test
+	for size := 1; size <= sizes; size++ {
+		ids := make([]interface{}, size)
+		for index := range ids { ids[index] = index }
+		if _, err := sut.Exec(query, ids); nil != err { t.Fatal(err) }
+	}
+
+	// Verify
+	ExpectInt(DEFAULT_STATEMENT_CACHE_SIZE, sut.statements.size(), t)
+}
@@ -0,0 +1,204 @@
+package db
+
+/*
+
+Run a func within a transaction, committed if it returns nil and rolled back if it returns an error
+(or panics, after which the panic carries on):
+
+	err := db.WithTransaction(conn, nil, func (tx db.ConnectionCommonIfc) error {
+		if _, err := tx.Exec(debit, from, amount); nil != err { return err }
+		_, err := tx.Exec(credit, to, amount)
+		return err
+	})
+
+When MySQL picks the transaction as the victim of a deadlock (error 1213) or gives up waiting for a
+lock (1205), the whole transaction is rolled back and run again, after a backoff which doubles (with
+some jitter) each time, up to a limit; the func must therefore be safe to run more than once, doing
+nothing outside the transaction that it can't do again. The last error is returned once the retries
+run out. Options set the isolation level, read-only, and the retries:
+
+	options := db.NewTransactionOptions().
+		SetIsolation(sql.LevelSerializable).
+		SetMaxRetries(5)
+
+A call to WithTransaction() for a connection already in a transaction (such as tx, from within the
+func) nests within it as a SAVEPOINT: its changes are rolled back to the savepoint if it fails, while
+those of the outer transaction stand, and committed only when the outer transaction is. Nested calls
+are not retried on their own (a deadlock rolls back the whole transaction in MySQL anyway), and their
+options are ignored; return the error from the outer func to have the whole transaction retried.
+
+A pooled connection which begins a transaction with a non-default isolation level is marked dirty,
+so that the pool closes it once released rather than leasing it out again; see pooledconnection.go.
+
+*/
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// MySQL errors after which a transaction may succeed if run again
+const MYSQL_ER_LOCK_WAIT_TIMEOUT = 1205
+const MYSQL_ER_LOCK_DEADLOCK = 1213
+
+const DEFAULT_TRANSACTION_MAX_RETRIES = 3
+const DEFAULT_TRANSACTION_BACKOFF = 50 * time.Millisecond
+const DEFAULT_TRANSACTION_MAX_BACKOFF = time.Second
+
+type TransactionOptions struct {
+	isolation		sql.IsolationLevel
+	readOnly		bool
+	maxRetries		int
+	backoff			time.Duration
+	maxBackoff		time.Duration
+	isRetryable		func (err error) bool
+}
+
+// -------------------------------------------------------------------------------------------------
+// Factory Functions
+// -------------------------------------------------------------------------------------------------
+
+func NewTransactionOptions() *TransactionOptions {
+	return &TransactionOptions{
+		isolation:		sql.LevelDefault,
+		maxRetries:		DEFAULT_TRANSACTION_MAX_RETRIES,
+		backoff:		DEFAULT_TRANSACTION_BACKOFF,
+		maxBackoff:		DEFAULT_TRANSACTION_MAX_BACKOFF,
+		isRetryable:		IsRetryableTransactionError,
+	}
+}
+
+// -------------------------------------------------------------------------------------------------
+// TransactionOptions Public Interface
+// -------------------------------------------------------------------------------------------------
+
+// Isolation level for the transaction; sql.LevelDefault (the default) for the database's own
+func (r *TransactionOptions) SetIsolation(isolation sql.IsolationLevel) *TransactionOptions {
+	r.isolation = isolation
+	return r
+}
+
+func (r *TransactionOptions) SetReadOnly(readOnly bool) *TransactionOptions {
+	r.readOnly = readOnly
+	return r
+}
+
+// Times to run the transaction again after a retryable error; 0 for never
+func (r *TransactionOptions) SetMaxRetries(maxRetries int) *TransactionOptions {
+	if maxRetries < 0 { maxRetries = 0 }
+	r.maxRetries = maxRetries
+	return r
+}
+
+// Wait before the first retry, doubling for each after, but never longer than maxBackoff
+func (r *TransactionOptions) SetBackoff(backoff, maxBackoff time.Duration) *TransactionOptions {
+	if backoff < 0 { backoff = 0 }
+	if maxBackoff < backoff { maxBackoff = backoff }
+	r.backoff = backoff
+	r.maxBackoff = maxBackoff
+	return r
+}
+
+// Decide which errors are worth a retry; nil for IsRetryableTransactionError()
+func (r *TransactionOptions) SetIsRetryable(isRetryable func (err error) bool) *TransactionOptions {
+	if nil == isRetryable { isRetryable = IsRetryableTransactionError }
+	r.isRetryable = isRetryable
+	return r
+}
+
+// -------------------------------------------------------------------------------------------------
+// Transaction Functions
+// -------------------------------------------------------------------------------------------------
+
+// Run fn within a transaction on conn (or a savepoint, if conn is in one already) with options (nil
+// for the defaults); see above
+func WithTransaction(conn ConnectionCommonIfc, options *TransactionOptions, fn func (tx ConnectionCommonIfc) error) error {
+	if nil == conn { return fmt.Errorf("WithTransaction(): conn is nil") }
+	if nil == fn { return fmt.Errorf("WithTransaction(): fn is nil") }
+	if conn.InTransaction() { return runSavepoint(conn, fn) }
+	if nil == options { options = NewTransactionOptions() }
+
+	backoff := options.backoff
+	for attempt := 0; ; attempt++ {
+		err := runTransaction(conn, options, fn)
+		if (nil == err) || (attempt >= options.maxRetries) || ! options.isRetryable(err) { return err }
+		// Jitter keeps transactions which deadlocked each other from retrying in lockstep
+		wait := backoff
+		if backoff > 1 { wait += time.Duration(rand.Int63n(int64(backoff / 2) + 1)) }
+		time.Sleep(wait)
+		backoff *= 2
+		if backoff > options.maxBackoff { backoff = options.maxBackoff }
+	}
+}
+
+// Is err one after which a transaction may succeed if run again: a MySQL deadlock or lock wait timeout?
+func IsRetryableTransactionError(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	if ! errors.As(err, &mysqlErr) { return false }
+	return (MYSQL_ER_LOCK_DEADLOCK == mysqlErr.Number) || (MYSQL_ER_LOCK_WAIT_TIMEOUT == mysqlErr.Number)
+}
+
+// -------------------------------------------------------------------------------------------------
+// Transaction Implementation
+// -------------------------------------------------------------------------------------------------
+
+func (r *TransactionOptions) getTxOptions() *sql.TxOptions {
+	if (sql.LevelDefault == r.isolation) && ! r.readOnly { return nil }
+	return &sql.TxOptions{
+		Isolation:	r.isolation,
+		ReadOnly:	r.readOnly,
+	}
+}
+
+// Run fn once within a new transaction, committed if it returns nil, else rolled back
+func runTransaction(conn ConnectionCommonIfc, options *TransactionOptions, fn func (tx ConnectionCommonIfc) error) error {
+	if err := conn.BeginTx(options.getTxOptions()); nil != err { return err }
+	defer func () {
+		if p := recover(); nil != p {
+			if conn.InTransaction() { _ = conn.Rollback() }
+			panic(p)
+		}
+	}()
+	if err := fn(conn); nil != err {
+		// fn may have ended the transaction itself
+		if ! conn.InTransaction() { return err }
+		if rollbackErr := conn.Rollback(); nil != rollbackErr {
+			return fmt.Errorf("WithTransaction(): Rollback failed (%s) after error: %w", rollbackErr.Error(), err)
+		}
+		return err
+	}
+	if ! conn.InTransaction() { return fmt.Errorf("WithTransaction(): Transaction ended before it could be committed") }
+	return conn.Commit()
+}
+
+// Run fn once within a new savepoint of the transaction conn is in, released if it returns nil, else
+// rolled back to
+func runSavepoint(conn ConnectionCommonIfc, fn func (tx ConnectionCommonIfc) error) error {
+	name, err := conn.Savepoint()
+	if nil != err { return err }
+	defer func () {
+		if p := recover(); nil != p {
+			if conn.InTransaction() { _ = rollbackToSavepoint(conn, name) }
+			panic(p)
+		}
+	}()
+	if err := fn(conn); nil != err {
+		if ! conn.InTransaction() { return err }
+		if rollbackErr := rollbackToSavepoint(conn, name); nil != rollbackErr {
+			return fmt.Errorf("WithTransaction(): Rollback to savepoint failed (%s) after error: %w", rollbackErr.Error(), err)
+		}
+		return err
+	}
+	return conn.ReleaseSavepoint(name)
+}
+
+// Undo the changes since savepoint name, then release it, since we're done with it
+func rollbackToSavepoint(conn ConnectionCommonIfc, name string) error {
+	if err := conn.RollbackToSavepoint(name); nil != err { return err }
+	return conn.ReleaseSavepoint(name)
+}
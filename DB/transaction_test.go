@@ -0,0 +1,284 @@
+package db_test
+
+import(
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+        "github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+
+	. "github.com/DigiStratum/GoLib/DB"
+	. "github.com/DigiStratum/GoLib/Testing"
+)
+
+func newTransactionConnection(t *testing.T) (ConnectionIfc, sqlmock.Sqlmock) {
+	conn, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if nil != err { t.Fatal(err) }
+	connection, _ := NewConnection(conn)
+	return connection, mock
+}
+
+// Options which retry without waiting around
+func newFastRetryOptions() *TransactionOptions {
+	return NewTransactionOptions().SetBackoff(time.Millisecond, time.Millisecond)
+}
+
+func TestThat_WithTransaction_ReturnsError_WhenGivenNilConnOrFn(t *testing.T) {
+	// Setup
+	connection, _ := newTransactionConnection(t)
+
+	// Test
+	err1 := WithTransaction(nil, nil, func (tx ConnectionCommonIfc) error { return nil })
+	err2 := WithTransaction(connection, nil, nil)
+
+	// Verify
+	ExpectError(err1, t)
+	ExpectError(err2, t)
+}
+
+func TestThat_WithTransaction_Commits_WhenFnSucceeds(t *testing.T) {
+	// Setup
+	sut, mock := newTransactionConnection(t)
+	mock.ExpectBegin()
+	mock.ExpectPrepare("UPDATE todo SET done = 1").ExpectExec().WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	// Test
+	err := WithTransaction(sut, nil, func (tx ConnectionCommonIfc) error {
+		_, err := tx.Exec(NewSQLQuery("UPDATE todo SET done = 1"))
+		return err
+	})
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectFalse(sut.InTransaction(), t)
+	ExpectNoError(mock.ExpectationsWereMet(), t)
+}
+
+func TestThat_WithTransaction_RollsBack_WhenFnReturnsError(t *testing.T) {
+	// Setup
+	sut, mock := newTransactionConnection(t)
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+	expected := fmt.Errorf("Nope")
+
+	// Test
+	err := WithTransaction(sut, nil, func (tx ConnectionCommonIfc) error { return expected })
+
+	// Verify
+	ExpectTrue(expected == err, t)
+	ExpectFalse(sut.InTransaction(), t)
+	ExpectNoError(mock.ExpectationsWereMet(), t)
+}
+
+func TestThat_WithTransaction_RollsBack_ThenPanics_WhenFnPanics(t *testing.T) {
+	// Setup
+	sut, mock := newTransactionConnection(t)
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+	var recovered interface{}
+
+	// Test
+	func () {
+		defer func () { recovered = recover() }()
+		WithTransaction(sut, nil, func (tx ConnectionCommonIfc) error { panic("Oops") })
+	}()
+
+	// Verify
+	ExpectTrue("Oops" == recovered, t)
+	ExpectFalse(sut.InTransaction(), t)
+	ExpectNoError(mock.ExpectationsWereMet(), t)
+}
+
+func TestThat_WithTransaction_Retries_AfterDeadlock(t *testing.T) {
+	// Setup
+	sut, mock := newTransactionConnection(t)
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+	calls := 0
+
+	// Test
+	err := WithTransaction(sut, newFastRetryOptions(), func (tx ConnectionCommonIfc) error {
+		calls++
+		if 1 == calls { return &mysql.MySQLError{ Number: MYSQL_ER_LOCK_DEADLOCK, Message: "Deadlock found" } }
+		return nil
+	})
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectInt(2, calls, t)
+	ExpectNoError(mock.ExpectationsWereMet(), t)
+}
+
+func TestThat_WithTransaction_ReturnsLastError_WhenRetriesRunOut(t *testing.T) {
+	// Setup
+	sut, mock := newTransactionConnection(t)
+	for attempt := 0; attempt < 2; attempt++ {
+		mock.ExpectBegin()
+		mock.ExpectRollback()
+	}
+	calls := 0
+
+	// Test
+	err := WithTransaction(sut, newFastRetryOptions().SetMaxRetries(1), func (tx ConnectionCommonIfc) error {
+		calls++
+		return &mysql.MySQLError{ Number: MYSQL_ER_LOCK_WAIT_TIMEOUT, Message: "Lock wait timeout exceeded" }
+	})
+
+	// Verify
+	ExpectTrue(IsRetryableTransactionError(err), t)
+	ExpectInt(2, calls, t)
+	ExpectNoError(mock.ExpectationsWereMet(), t)
+}
+
+func TestThat_WithTransaction_DoesNotRetry_OtherErrors(t *testing.T) {
+	// Setup
+	sut, mock := newTransactionConnection(t)
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+	calls := 0
+
+	// Test
+	err := WithTransaction(sut, newFastRetryOptions(), func (tx ConnectionCommonIfc) error {
+		calls++
+		return &mysql.MySQLError{ Number: 1062, Message: "Duplicate entry" }
+	})
+
+	// Verify
+	ExpectError(err, t)
+	ExpectInt(1, calls, t)
+	ExpectNoError(mock.ExpectationsWereMet(), t)
+}
+
+func TestThat_WithTransaction_ReleasesSavepoint_WhenNestedFnSucceeds(t *testing.T) {
+	// Setup
+	sut, mock := newTransactionConnection(t)
+	mock.ExpectBegin()
+	mock.ExpectExec("SAVEPOINT `sp_1`").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("RELEASE SAVEPOINT `sp_1`").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	// Test
+	err := WithTransaction(sut, nil, func (tx ConnectionCommonIfc) error {
+		return WithTransaction(tx, nil, func (tx ConnectionCommonIfc) error { return nil })
+	})
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectNoError(mock.ExpectationsWereMet(), t)
+}
+
+func TestThat_WithTransaction_RollsBackToSavepoint_WhenNestedFnFails(t *testing.T) {
+	// Setup
+	sut, mock := newTransactionConnection(t)
+	mock.ExpectBegin()
+	mock.ExpectExec("SAVEPOINT `sp_1`").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("SAVEPOINT `sp_2`").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("ROLLBACK TO SAVEPOINT `sp_2`").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("RELEASE SAVEPOINT `sp_2`").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("RELEASE SAVEPOINT `sp_1`").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	expected := fmt.Errorf("Nope")
+	var nestedErr error
+
+	// Test
+	err := WithTransaction(sut, nil, func (tx ConnectionCommonIfc) error {
+		return WithTransaction(tx, nil, func (tx ConnectionCommonIfc) error {
+			// The inner failure is handled here, so the outer transaction carries on
+			nestedErr = WithTransaction(tx, nil, func (tx ConnectionCommonIfc) error { return expected })
+			return nil
+		})
+	})
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectTrue(expected == nestedErr, t)
+	ExpectNoError(mock.ExpectationsWereMet(), t)
+}
+
+func TestThat_WithTransaction_RollsBackToSavepoint_ThenPanics_WhenNestedFnPanics(t *testing.T) {
+	// Setup
+	sut, mock := newTransactionConnection(t)
+	mock.ExpectBegin()
+	mock.ExpectExec("SAVEPOINT `sp_1`").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("ROLLBACK TO SAVEPOINT `sp_1`").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("RELEASE SAVEPOINT `sp_1`").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+	var recovered interface{}
+
+	// Test
+	func () {
+		defer func () { recovered = recover() }()
+		WithTransaction(sut, nil, func (tx ConnectionCommonIfc) error {
+			return WithTransaction(tx, nil, func (tx ConnectionCommonIfc) error { panic("Oops") })
+		})
+	}()
+
+	// Verify
+	ExpectTrue("Oops" == recovered, t)
+	ExpectFalse(sut.InTransaction(), t)
+	ExpectNoError(mock.ExpectationsWereMet(), t)
+}
+
+func TestThat_Connection_Savepoint_ReturnsError_WhenNotInTransaction(t *testing.T) {
+	// Setup
+	sut, _ := newTransactionConnection(t)
+
+	// Test
+	name, err1 := sut.Savepoint()
+	err2 := sut.ReleaseSavepoint("sp_1")
+	err3 := sut.RollbackToSavepoint("sp_1")
+
+	// Verify
+	ExpectString("", name, t)
+	ExpectError(err1, t)
+	ExpectError(err2, t)
+	ExpectError(err3, t)
+}
+
+func TestThat_Connection_ReleaseSavepoint_ReturnsError_ForUnknownSavepoint(t *testing.T) {
+	// Setup
+	sut, mock := newTransactionConnection(t)
+	mock.ExpectBegin()
+	sut.Begin()
+
+	// Test
+	err := sut.ReleaseSavepoint("sp_1")
+
+	// Verify
+	ExpectError(err, t)
+	ExpectNoError(mock.ExpectationsWereMet(), t)
+}
+
+func TestThat_IsRetryableTransactionError_ReturnsTrue_OnlyForDeadlockAndLockWaitTimeout(t *testing.T) {
+	// Setup
+	deadlock := &mysql.MySQLError{ Number: MYSQL_ER_LOCK_DEADLOCK }
+	lockWaitTimeout := &mysql.MySQLError{ Number: MYSQL_ER_LOCK_WAIT_TIMEOUT }
+	duplicate := &mysql.MySQLError{ Number: 1062 }
+
+	// Verify
+	ExpectTrue(IsRetryableTransactionError(deadlock), t)
+	ExpectTrue(IsRetryableTransactionError(lockWaitTimeout), t)
+	ExpectTrue(IsRetryableTransactionError(fmt.Errorf("Wrapped: %w", deadlock)), t)
+	ExpectFalse(IsRetryableTransactionError(duplicate), t)
+	ExpectFalse(IsRetryableTransactionError(fmt.Errorf("Nope")), t)
+	ExpectFalse(IsRetryableTransactionError(nil), t)
+}
+
+func TestThat_PooledConnection_BeginTx_MarksDirty_ForNonDefaultIsolation(t *testing.T) {
+	// Setup
+	sut1, _ := getGoodNewPooledConnection()
+	sut2, _ := getGoodNewPooledConnection()
+
+	// Test
+	sut1.BeginTx(&sql.TxOptions{ Isolation: sql.LevelSerializable })
+	sut2.BeginTx(&sql.TxOptions{ ReadOnly: true })
+
+	// Verify
+	ExpectTrue(sut1.IsDirty(), t)
+	ExpectFalse(sut2.IsDirty(), t)
+}
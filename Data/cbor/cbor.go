@@ -0,0 +1,357 @@
+// DigiStratum GoLib - CBOR
+package cbor
+
+/*
+
+Encode a DataValue to CBOR (Concise Binary Object Representation), and decode CBOR back into a
+DataValue.
+
+DataType mapping:
+
+  NULL    <=> simple value null (undefined is also accepted when decoding)
+  BOOLEAN <=> simple values false|true
+  INTEGER <=> major type 0 (unsigned) or 1 (negative) integer
+  FLOAT   <=> float 32 if that is lossless, else float 64 (float 16 is also accepted when decoding)
+  STRING  <=> major type 3, text string
+  BINARY  <=> major type 2, byte string
+  ARRAY   <=> major type 4, array
+  OBJECT  <=> major type 5, map (keys written sorted, so equal values encode identically)
+
+Encoding always uses definite lengths with the shortest argument encoding (RFC 8949 section 4.1,
+"preferred serialization"). Decoding also accepts indefinite lengths. Tags (major type 6) are
+decoded by discarding the tag number and decoding the tagged item itself; e.g. a tag 1 epoch date
+decodes as just the number. Decoding fails for map keys that are not text strings, for integers
+outside the int64 range, and for simple values other than false, true, null and undefined.
+
+Ref: https://www.rfc-editor.org/rfc/rfc8949
+
+*/
+
+import(
+	"fmt"
+	"math"
+	"sort"
+	"encoding/binary"
+
+	"github.com/DigiStratum/GoLib/Data"
+)
+
+// Nesting limit for decoding so that hostile input cannot exhaust the stack
+const CBOR_MAX_DEPTH = 512
+
+const (
+	cborMajorUnsigned	byte = 0
+	cborMajorNegative	byte = 1
+	cborMajorBytes		byte = 2
+	cborMajorText		byte = 3
+	cborMajorArray		byte = 4
+	cborMajorMap		byte = 5
+	cborMajorTag		byte = 6
+	cborMajorSimple		byte = 7
+
+	cborIndefinite		byte = 31
+	cborBreak		byte = 0xff
+)
+
+type CborIfc interface {
+	Encode(dataValue *data.DataValue) ([]byte, error)
+	Decode(encoded []byte) (*data.DataValue, error)
+}
+
+type cbor struct { }
+
+// Decoding state for a single Decode() call
+type cborDecoder struct {
+	buf	[]byte
+	pos	int
+}
+
+// -------------------------------------------------------------------------------------------------
+// Factory Functions
+// -------------------------------------------------------------------------------------------------
+
+func NewCbor() *cbor {
+	return &cbor{}
+}
+
+// -------------------------------------------------------------------------------------------------
+// CborIfc
+// -------------------------------------------------------------------------------------------------
+
+func (r *cbor) Encode(dataValue *data.DataValue) ([]byte, error) {
+	if nil == dataValue { return nil, fmt.Errorf("cbor.Encode(): nil DataValue") }
+	return r.encode(make([]byte, 0, 64), dataValue)
+}
+
+func (r *cbor) Decode(encoded []byte) (*data.DataValue, error) {
+	if 0 == len(encoded) { return nil, fmt.Errorf("cbor.Decode(): nothing to decode") }
+	decoder := cborDecoder{ buf: encoded }
+	dataValue, err := decoder.decode(0)
+	if nil != err { return nil, fmt.Errorf("cbor.Decode(): %s", err.Error()) }
+	if decoder.pos != len(encoded) {
+		return nil, fmt.Errorf("cbor.Decode(): %d unexpected trailing bytes", len(encoded) - decoder.pos)
+	}
+	return dataValue, nil
+}
+
+// -------------------------------------------------------------------------------------------------
+// cbor encoding
+// -------------------------------------------------------------------------------------------------
+
+func (r *cbor) encode(buf []byte, dataValue *data.DataValue) ([]byte, error) {
+	switch dataValue.GetType() {
+		case data.DATA_TYPE_NULL:
+			return append(buf, 0xf6), nil
+
+		case data.DATA_TYPE_BOOLEAN:
+			if dataValue.GetBoolean() { return append(buf, 0xf5), nil }
+			return append(buf, 0xf4), nil
+
+		case data.DATA_TYPE_INTEGER:
+			value := dataValue.GetInteger()
+			if value >= 0 { return r.encodeHead(buf, cborMajorUnsigned, uint64(value)), nil }
+			// Negative integers are encoded as -1 - n, which is the bitwise complement
+			return r.encodeHead(buf, cborMajorNegative, uint64(^value)), nil
+
+		case data.DATA_TYPE_FLOAT:
+			value := dataValue.GetFloat()
+			if float64(float32(value)) == value {
+				return binary.BigEndian.AppendUint32(append(buf, 0xfa), math.Float32bits(float32(value))), nil
+			}
+			// NaN never equals itself, so it lands here along with everything needing 64 bits
+			return binary.BigEndian.AppendUint64(append(buf, 0xfb), math.Float64bits(value)), nil
+
+		case data.DATA_TYPE_STRING:
+			value := dataValue.GetString()
+			return append(r.encodeHead(buf, cborMajorText, uint64(len(value))), value...), nil
+
+		case data.DATA_TYPE_BINARY:
+			value := dataValue.GetBinary()
+			return append(r.encodeHead(buf, cborMajorBytes, uint64(len(value))), value...), nil
+
+		case data.DATA_TYPE_ARRAY:
+			size := dataValue.GetArraySize()
+			buf = r.encodeHead(buf, cborMajorArray, uint64(size))
+			var err error
+			for index := 0; index < size; index++ {
+				buf, err = r.encode(buf, dataValue.GetArrayValue(index)) // <- BEWARE: recursion!
+				if nil != err { return nil, err }
+			}
+			return buf, nil
+
+		case data.DATA_TYPE_OBJECT:
+			names := dataValue.GetObjectProperties()
+			sort.Strings(names)
+			buf = r.encodeHead(buf, cborMajorMap, uint64(len(names)))
+			var err error
+			for _, name := range names {
+				buf = append(r.encodeHead(buf, cborMajorText, uint64(len(name))), name...)
+				buf, err = r.encode(buf, dataValue.GetObjectProperty(name)) // <- BEWARE: recursion!
+				if nil != err { return nil, err }
+			}
+			return buf, nil
+	}
+	return nil, fmt.Errorf("cbor.Encode(): unsupported DataType '%s'", dataValue.GetType().ToString())
+}
+
+// Initial byte (major type + additional info) followed by the shortest argument that fits
+func (r *cbor) encodeHead(buf []byte, major byte, argument uint64) []byte {
+	major = major << 5
+	switch {
+		case argument < 24: return append(buf, major | byte(argument))
+		case argument <= math.MaxUint8: return append(buf, major | 24, byte(argument))
+		case argument <= math.MaxUint16: return binary.BigEndian.AppendUint16(append(buf, major | 25), uint16(argument))
+		case argument <= math.MaxUint32: return binary.BigEndian.AppendUint32(append(buf, major | 26), uint32(argument))
+	}
+	return binary.BigEndian.AppendUint64(append(buf, major | 27), argument)
+}
+
+// -------------------------------------------------------------------------------------------------
+// cborDecoder
+// -------------------------------------------------------------------------------------------------
+
+func (r *cborDecoder) decode(depth int) (*data.DataValue, error) {
+	if depth > CBOR_MAX_DEPTH { return nil, fmt.Errorf("nesting exceeds %d levels", CBOR_MAX_DEPTH) }
+	initial, err := r.readByte()
+	if nil != err { return nil, err }
+	major := initial >> 5
+	info := initial & 0x1f
+
+	// Floats and simple values interpret their argument differently from everything else
+	if cborMajorSimple == major { return r.decodeSimple(info) }
+
+	if cborIndefinite == info {
+		switch major {
+			case cborMajorBytes, cborMajorText: return r.decodeIndefiniteString(major)
+			case cborMajorArray: return r.decodeArray(-1, depth)
+			case cborMajorMap: return r.decodeMap(-1, depth)
+		}
+		return nil, fmt.Errorf("indefinite length is not valid for major type %d", major)
+	}
+
+	argument, err := r.readArgument(info)
+	if nil != err { return nil, err }
+
+	switch major {
+		case cborMajorUnsigned:
+			if argument > math.MaxInt64 { return nil, fmt.Errorf("unsigned integer %d overflows int64", argument) }
+			return data.NewInteger(int64(argument)), nil
+
+		case cborMajorNegative:
+			if argument > math.MaxInt64 { return nil, fmt.Errorf("negative integer -1-%d overflows int64", argument) }
+			return data.NewInteger(-1 - int64(argument)), nil
+
+		case cborMajorBytes:
+			value, err := r.readBytes(argument)
+			if nil != err { return nil, err }
+			// Copy so that the DataValue does not pin (or get changed along with) the caller's buffer
+			return data.NewBinary(append([]byte{}, value...)), nil
+
+		case cborMajorText:
+			value, err := r.readBytes(argument)
+			if nil != err { return nil, err }
+			return data.NewString(string(value)), nil
+
+		case cborMajorArray:
+			if argument > uint64(len(r.buf) - r.pos) { return nil, fmt.Errorf("array size %d exceeds remaining input", argument) }
+			return r.decodeArray(int(argument), depth)
+
+		case cborMajorMap:
+			if argument > uint64(len(r.buf) - r.pos) { return nil, fmt.Errorf("map size %d exceeds remaining input", argument) }
+			return r.decodeMap(int(argument), depth)
+	}
+
+	// cborMajorTag: the tag number is in the argument; we keep only the tagged item
+	return r.decode(depth + 1) // <- BEWARE: recursion!
+}
+
+func (r *cborDecoder) decodeSimple(info byte) (*data.DataValue, error) {
+	switch info {
+		case 20: return data.NewBoolean(false), nil
+		case 21: return data.NewBoolean(true), nil
+		case 22, 23: return data.NewNull(), nil
+
+		case 25:
+			bits, err := r.readUint(2)
+			if nil != err { return nil, err }
+			return data.NewFloat(halfToFloat64(uint16(bits))), nil
+
+		case 26:
+			bits, err := r.readUint(4)
+			if nil != err { return nil, err }
+			return data.NewFloat(float64(math.Float32frombits(uint32(bits)))), nil
+
+		case 27:
+			bits, err := r.readUint(8)
+			if nil != err { return nil, err }
+			return data.NewFloat(math.Float64frombits(bits)), nil
+
+		case cborIndefinite:
+			return nil, fmt.Errorf("unexpected break at offset %d", r.pos - 1)
+	}
+	return nil, fmt.Errorf("unsupported simple value (additional info %d) at offset %d", info, r.pos - 1)
+}
+
+// A sequence of definite length chunks of the same major type, terminated by a break
+func (r *cborDecoder) decodeIndefiniteString(major byte) (*data.DataValue, error) {
+	value := make([]byte, 0)
+	for {
+		initial, err := r.readByte()
+		if nil != err { return nil, err }
+		if cborBreak == initial { break }
+		if ((initial >> 5) != major) || (cborIndefinite == (initial & 0x1f)) {
+			return nil, fmt.Errorf("invalid chunk in indefinite length string at offset %d", r.pos - 1)
+		}
+		size, err := r.readArgument(initial & 0x1f)
+		if nil != err { return nil, err }
+		chunk, err := r.readBytes(size)
+		if nil != err { return nil, err }
+		value = append(value, chunk...)
+	}
+	if cborMajorText == major { return data.NewString(string(value)), nil }
+	return data.NewBinary(value), nil
+}
+
+// A negative size means indefinite length; read until the break
+func (r *cborDecoder) decodeArray(size int, depth int) (*data.DataValue, error) {
+	dataValue := data.NewArray()
+	for index := 0; (size < 0) || (index < size); index++ {
+		if (size < 0) && r.atBreak() { break }
+		value, err := r.decode(depth + 1) // <- BEWARE: recursion!
+		if nil != err { return nil, err }
+		dataValue.AppendArrayValue(value)
+	}
+	return dataValue, nil
+}
+
+// A negative size means indefinite length; read until the break
+func (r *cborDecoder) decodeMap(size int, depth int) (*data.DataValue, error) {
+	dataValue := data.NewObject()
+	for index := 0; (size < 0) || (index < size); index++ {
+		if (size < 0) && r.atBreak() { break }
+		key, err := r.decode(depth + 1) // <- BEWARE: recursion!
+		if nil != err { return nil, err }
+		if ! key.IsString() { return nil, fmt.Errorf("map key is %s; only text strings are supported", key.GetType().ToString()) }
+		value, err := r.decode(depth + 1) // <- BEWARE: recursion!
+		if nil != err { return nil, err }
+		dataValue.SetObjectProperty(key.GetString(), value)
+	}
+	return dataValue, nil
+}
+
+// Consume the break byte if that is what's next
+func (r *cborDecoder) atBreak() bool {
+	if (r.pos < len(r.buf)) && (cborBreak == r.buf[r.pos]) {
+		r.pos++
+		return true
+	}
+	return false
+}
+
+func (r *cborDecoder) readArgument(info byte) (uint64, error) {
+	switch {
+		case info < 24: return uint64(info), nil
+		case info <= 27: return r.readUint(1 << (info - 24))
+	}
+	return 0, fmt.Errorf("reserved additional info %d at offset %d", info, r.pos - 1)
+}
+
+func (r *cborDecoder) readByte() (byte, error) {
+	if r.pos >= len(r.buf) { return 0, fmt.Errorf("unexpected end of input") }
+	value := r.buf[r.pos]
+	r.pos++
+	return value, nil
+}
+
+func (r *cborDecoder) readBytes(size uint64) ([]byte, error) {
+	if size > uint64(len(r.buf) - r.pos) {
+		return nil, fmt.Errorf("need %d bytes at offset %d; only %d remain", size, r.pos, len(r.buf) - r.pos)
+	}
+	value := r.buf[r.pos:r.pos + int(size)]
+	r.pos += int(size)
+	return value, nil
+}
+
+// Read a big-endian unsigned integer of width bytes (1, 2, 4 or 8)
+func (r *cborDecoder) readUint(width int) (uint64, error) {
+	bytes, err := r.readBytes(uint64(width))
+	if nil != err { return 0, err }
+	var value uint64
+	for _, b := range bytes { value = (value << 8) | uint64(b) }
+	return value, nil
+}
+
+// IEEE 754 half precision to float64; ref: RFC 8949 Appendix D
+func halfToFloat64(half uint16) float64 {
+	exponent := int((half >> 10) & 0x1f)
+	mantissa := float64(half & 0x3ff)
+	var value float64
+	switch exponent {
+		case 0: value = math.Ldexp(mantissa, -24)
+		case 31:
+			if 0 == mantissa { value = math.Inf(1) } else { value = math.NaN() }
+		default: value = math.Ldexp(mantissa + 1024, exponent - 25)
+	}
+	if 0 != (half & 0x8000) { return -value }
+	return value
+}
@@ -0,0 +1,176 @@
+package cbor
+
+/*
+
+Unit Tests for cbor
+
+*/
+
+import(
+	"math"
+	"bytes"
+	"testing"
+	"encoding/hex"
+
+	"github.com/DigiStratum/GoLib/Data"
+	. "github.com/DigiStratum/GoLib/Testing"
+)
+
+func TestThat_Cbor_NewCbor_ReturnsInstance(t *testing.T) {
+	// Setup
+	var sut CborIfc = NewCbor() // <- ensures that we satisfy our interface
+
+	// Verify
+	ExpectNonNil(sut, t)
+}
+
+func TestThat_Cbor_Encode_ReturnsError_ForNilAndInvalidValues(t *testing.T) {
+	// Setup
+	sut := NewCbor()
+
+	// Test
+	actual1, err1 := sut.Encode(nil)
+	actual2, err2 := sut.Encode(data.NewDataValue())
+
+	// Verify
+	ExpectTrue(nil == actual1, t)
+	ExpectError(err1, t)
+	ExpectTrue(nil == actual2, t)
+	ExpectError(err2, t)
+}
+
+// Examples from RFC 8949 Appendix A
+func TestThat_Cbor_Encode_MatchesRfcExamples(t *testing.T) {
+	// Setup
+	sut := NewCbor()
+	cases := []struct {
+		value		*data.DataValue
+		expected	string
+	}{
+		{ data.NewInteger(0), "00" },
+		{ data.NewInteger(23), "17" },
+		{ data.NewInteger(24), "1818" },
+		{ data.NewInteger(1000000), "1a000f4240" },
+		{ data.NewInteger(1000000000000), "1b000000e8d4a51000" },
+		{ data.NewInteger(-1), "20" },
+		{ data.NewInteger(-1000), "3903e7" },
+		{ data.NewFloat(100000.0), "fa47c35000" },
+		{ data.NewFloat(1.1), "fb3ff199999999999a" },
+		{ data.NewBoolean(false), "f4" },
+		{ data.NewBoolean(true), "f5" },
+		{ data.NewNull(), "f6" },
+		{ data.NewBinary([]byte{ 0x01, 0x02, 0x03, 0x04 }), "4401020304" },
+		{ data.NewString("IETF"), "6449455446" },
+		{ data.NewString("ü"), "62c3bc" },
+		{ data.NewArray().AppendArrayValue(data.NewInteger(1)).AppendArrayValue(data.NewInteger(2)), "820102" },
+		{ data.NewObject().SetObjectProperty("b", data.NewInteger(2)).SetObjectProperty("a", data.NewInteger(1)), "a2616101616202" },
+	}
+
+	// Test / Verify
+	for _, c := range cases {
+		actual, err := sut.Encode(c.value)
+		ExpectNoError(err, t)
+		ExpectString(c.expected, hex.EncodeToString(actual), t)
+	}
+}
+
+func TestThat_Cbor_Decode_MatchesRfcExamples(t *testing.T) {
+	// Setup
+	sut := NewCbor()
+	cases := map[string]string{
+		"f90000":		"0",
+		"f93c00":		"1",
+		"f97bff":		"65504",
+		"f9c400":		"-4",
+		"f7":			"null",
+		"c074323031332d30332d32315432303a30343a30305a": "\"2013-03-21T20:04:00Z\"",
+		"c11a514b67b0":		"1363896240",
+		"7f657374726561646d696e67ff":	"\"streaming\"",
+		"9f018202039f0405ffff":	"[1,[2,3],[4,5]]",
+	}
+
+	// Test / Verify
+	for source, expected := range cases {
+		encoded, _ := hex.DecodeString(source)
+		actual, err := sut.Decode(encoded)
+		if ! ExpectNoError(err, t) { t.Logf("source: %s", source); continue }
+		ExpectString(expected, actual.ToJson(), t)
+	}
+
+	// Map property order is not stable, so we check the indefinite length map by selector instead
+	encoded, _ := hex.DecodeString("bf61610161629f0203ffff")
+	actual, err := sut.Decode(encoded)
+	if ! ExpectNoError(err, t) { return }
+	ExpectInt64(1, actual.Select("a").GetInteger(), t)
+	ExpectInt64(3, actual.Select("b[1]").GetInteger(), t)
+}
+
+func TestThat_Cbor_Decode_RoundTripsEveryDataType(t *testing.T) {
+	// Setup
+	sut := NewCbor()
+	source := data.NewObject().
+		SetObjectProperty("null", data.NewNull()).
+		SetObjectProperty("bool", data.NewBoolean(true)).
+		SetObjectProperty("min", data.NewInteger(math.MinInt64)).
+		SetObjectProperty("max", data.NewInteger(math.MaxInt64)).
+		SetObjectProperty("float", data.NewFloat(-2.79e-4)).
+		SetObjectProperty("inf", data.NewFloat(math.Inf(-1))).
+		SetObjectProperty("text", data.NewString("hello")).
+		SetObjectProperty("bin", data.NewBinary(bytes.Repeat([]byte{ 0xfe }, 300))).
+		SetObjectProperty("array", data.NewArray().AppendArrayValue(data.NewObject()))
+
+	// Test
+	encoded, err := sut.Encode(source)
+	if ! ExpectNoError(err, t) { return }
+	actual, err := sut.Decode(encoded)
+
+	// Verify
+	if ! ExpectNoError(err, t) { return }
+	ExpectTrue(actual.Select("null").IsNull(), t)
+	ExpectTrue(actual.Select("bool").GetBoolean(), t)
+	ExpectInt64(math.MinInt64, actual.Select("min").GetInteger(), t)
+	ExpectInt64(math.MaxInt64, actual.Select("max").GetInteger(), t)
+	ExpectFloat64(-2.79e-4, actual.Select("float").GetFloat(), t)
+	ExpectTrue(math.IsInf(actual.Select("inf").GetFloat(), -1), t)
+	ExpectString("hello", actual.Select("text").GetString(), t)
+	ExpectTrue(bytes.Equal(source.Select("bin").GetBinary(), actual.Select("bin").GetBinary()), t)
+	ExpectTrue(actual.Select("array[0]").IsObject(), t)
+}
+
+func TestThat_Cbor_Decode_ReturnsError_ForBadInput(t *testing.T) {
+	// Setup
+	sut := NewCbor()
+	cases := []string{
+		"",			// nothing
+		"1c",			// reserved additional info
+		"6449",			// truncated text
+		"a10101",		// non-text map key
+		"f8ff",			// unsupported simple value
+		"ff",			// unexpected break
+		"f6f6",			// trailing bytes
+		"3bffffffffffffffff",	// negative integer overflows int64
+		"9bffffffffffffffff",	// array size beyond input
+		"5f6161ff",		// text chunk inside indefinite bytes
+	}
+
+	// Test / Verify
+	for _, c := range cases {
+		encoded, _ := hex.DecodeString(c)
+		actual, err := sut.Decode(encoded)
+		if ! ExpectError(err, t) { t.Logf("source: %s", c) }
+		ExpectNil(actual, t)
+	}
+}
+
+func TestThat_Cbor_Decode_ReturnsError_ForExcessiveNesting(t *testing.T) {
+	// Setup
+	sut := NewCbor()
+	encoded := append(bytes.Repeat([]byte{ 0x81 }, CBOR_MAX_DEPTH + 1), 0xf6)
+
+	// Test
+	actual, err := sut.Decode(encoded)
+
+	// Verify
+	ExpectError(err, t)
+	ExpectNil(actual, t)
+}
@@ -7,13 +7,19 @@ General purpose structured configuration data
 This next generation Configuration object supports structured data by embedding GoLib/Data/DataValue
 as the underlying storage model.
 
-We support a string dereferencing model to pull other values into the current value for string
-building. Dereferencing only works for strings at this time because DataValue does not support
-this intrinsically - you can't have, for example, an Integer data type that is represented as a
-"%intvalue%" selector which is a String data type (see related TODO below). Dereferencing is
-based on delimiter encapsulated identifiers which are handled as DataValue selectors. These can
-be nested such that multiple reference Configs can cross-reference each other up to a maximum
-reference depth to prevent runaway recursion.
+We support a string dereferencing model to pull other values into the current value. References
+are delimiter encapsulated identifiers which are handled as DataValue selectors; they are looked up
+in the reference Configs passed to Dereference(), in order, and then in this Config itself. The
+referenced values may themselves contain references, which are resolved in turn, up to a maximum
+reference depth; a reference cycle (a => b => a) is reported as an error with the reference chain.
+
+ * A reference within a larger string is replaced with the referenced value's string form:
+   "http://%db.host%:%db.port%/" => "http://localhost:3306/"
+ * A string that is nothing but a single reference to an object or array is replaced with a copy of
+   that whole subtree: "%db.primary%" => { "host": ..., "port": ... }
+ * A reference may end with a type cast, ':integer', ':float', ':boolean' or ':json', to convert the
+   referenced value to that DataType. A string that is nothing but a single cast reference takes on
+   that type: "%db.port:integer%" => 3306 (rather than "3306"); ':json' parses a string of JSON
 
 TODO:
  * Consider a configurable logger - if we wanted Config to log warnings/errors via logger, but
@@ -21,19 +27,21 @@ TODO:
    which implies the need for a third resource upon which both depend. what is it? Some kind of
    separate ConfigurableLoggerIfc, a higher level construct which depends on both, but upon which
    neither depend, perhaps...
- * Add support for dereferencing other data types as they become available via Iterator, at least
-   string would make sense, but Iterator only supports Array|Object currently.
- * Add support for casting dereferenced values to a non-String. e.g. %intvalue:integer% to cause
-   the result of the dereferenced string to be stored as a NewInteger({parsed intvalue}) instead
-   of storing back as a string.
+ * Dereference values nested within objects and arrays, not just our own top level values
 
 */
 
 import (
 	"fmt"
+	"math"
+	"sort"
+	"errors"
+	"strconv"
 	"strings"
 
 	"github.com/DigiStratum/GoLib/Data"
+	"github.com/DigiStratum/GoLib/Data/json"
+	"github.com/DigiStratum/GoLib/Redactor"
 )
 
 // Sane defaults
@@ -41,6 +49,14 @@ const DEFAULT_MAX_REFERENCE_DEPTH		= 10
 const DEFAULT_REFERENCE_DELIMITER_OPENER	= '%'
 const DEFAULT_REFERENCE_DELIMITER_CLOSER	= '%'
 
+// Type casts for dereferenced values, e.g. %port:integer%
+const (
+	REFERENCE_CAST_INTEGER	= "integer"
+	REFERENCE_CAST_FLOAT	= "float"
+	REFERENCE_CAST_BOOLEAN	= "boolean"
+	REFERENCE_CAST_JSON	= "json"
+)
+
 type ConfigIfc interface {
 	data.DataValueIfc
 
@@ -49,8 +65,11 @@ type ConfigIfc interface {
 
 	DereferenceString(str string) (*string, int)
 	Dereference(referenceConfigs ...ConfigIfc) int
+	GetDereferenceError() error
+	ResolveSecrets(registry SecretProviderRegistryIfc) error
 	MergeConfig(config ConfigIfc) *Config
 	CloneConfig() *Config
+	GetSource(selector string) string
 }
 
 type Config struct {
@@ -59,6 +78,9 @@ type Config struct {
 	refDepthMax		int
 	refDelimOpener		byte
 	refDelimCloser		byte
+	derefErr		error
+	secrets			[]string	// Resolved secret values, to be redacted from our output
+	sources			map[string]string	// Key is selector of each loaded value, see ConfigLoader
 }
 
 // -------------------------------------------------------------------------------------------------
@@ -97,105 +119,346 @@ func (r *Config) SetDelimiters(opener, closer byte) *Config {
 	return r
 }
 
-// Dereference any %selector% references our keys in supplied string; returns dereferenced string
+// Dereference any %selector% references to our keys in supplied string; returns dereferenced string
 // and num substitutions
 func (r *Config) DereferenceString(str string) (*string, int) {
-	selectors, err := r.getReferenceSelectorsFromString(str)
-	if nil != err {
+	value, subs, err := r.resolveString(str, []ConfigIfc{ r }, []configReference{})
+	if (nil != err) || (0 == subs) {
 		// TODO: Log the error or pass it back to the caller
 		return &str, 0
 	}
-	subs := 0
-	for _, selector := range selectors {
-		value := r.Select(selector)
-		if nil == value {  continue }
-
-		ref := fmt.Sprintf("%c%s%c", r.refDelimOpener, selector, r.refDelimCloser)
-		str = strings.Replace(str, ref, value.ToString(), -1)
-		subs++
-	}
-	return &str, subs
+	result := value.ToString()
+	return &result, subs
 }
 
-// Dereference values with %reference% selectors against referenceConfig(s); returns num substitutions
-// This is a multple-pass iteration dereference; if subs comes out > 0 then an additional pass may
-// be called for to see if more subs are possible (think of subtitutions that themselves contain
-// additional keys needing deferencing), so we make another pass up to a configured max depth.
-// TODO: Technically max passes is not "depth" since this is non-recursive; rename to "derefPassMax"
+// Dereference values with %reference% selectors against referenceConfig(s) and ourselves; returns
+// num substitutions. Values that cannot be dereferenced (cycles, failed casts, too deep) are left
+// as they were, and the reason is available from GetDereferenceError()
 // TODO: It doesn't seem like the return value int actually provides any utility value. Maybe just
 // return self and set immutable - should only need to call this once. Perform any mutations/merges
 // needed before Dereferencing, and then it's baked, no more changes!
 func (r *Config) Dereference(referenceConfigs ...ConfigIfc) int {
-	referenceDepth := 0
+	lookups := append(append([]ConfigIfc{}, referenceConfigs...), r)
+	errs := make([]error, 0)
 	subs := 0
-	for (r.refDepthMax > referenceDepth) {
-		referenceDepth++
-		passSubs := r.dereferencePass(referenceConfigs...)
-		if 0 == passSubs { break }
-		subs += passSubs
+	dereferenceOne := func (selector string, value *data.DataValue) *data.DataValue {
+		if (nil == value) || ! value.IsString() { return nil }
+		resolved, valueSubs, err := r.resolveString(
+			value.GetString(), lookups, []configReference{ { config: r, selector: selector } },
+		)
+		if nil != err { errs = append(errs, err) }
+		if (nil != err) || (0 == valueSubs) { return nil }
+		subs += valueSubs
+		return resolved
 	}
+
+	switch r.GetType() {
+		case data.DATA_TYPE_OBJECT:
+			// Sorted only so that any errors come out in a predictable order
+			properties := r.GetObjectProperties()
+			sort.Strings(properties)
+			for _, property := range properties {
+				resolved := dereferenceOne(property, r.GetObjectProperty(property))
+				if nil != resolved { r.SetObjectProperty(property, resolved) }
+			}
+
+		case data.DATA_TYPE_ARRAY:
+			for index := 0; index < r.GetArraySize(); index++ {
+				resolved := dereferenceOne(fmt.Sprintf("[%d]", index), r.GetArrayValue(index))
+				if nil != resolved { r.ReplaceArrayValue(index, resolved) }
+			}
+	}
+	r.derefErr = errors.Join(errs...)
 	return subs
 }
 
+// Get the error(s) from the last Dereference(), or nil if there were none
+func (r *Config) GetDereferenceError() error {
+	return r.derefErr
+}
+
 // Merge properties of passed config into our own embedded data
 func (r *Config) MergeConfig(config ConfigIfc) *Config {
 	r.DataValue.Merge(config)
+	// Sources for the properties that we just replaced are no longer valid; take theirs instead
+	if mergeConfig, ok := config.(*Config); ok && (nil != mergeConfig) {
+		for _, secret := range mergeConfig.secrets { r.addSecret(secret) }
+		for _, property := range mergeConfig.GetObjectProperties() {
+			r.dropSources(property)
+			for selector, source := range mergeConfig.sources {
+				if (selector != property) && ! strings.HasPrefix(selector, property + ".") { continue }
+				r.setSource(selector, source)
+			}
+		}
+	}
 	return r
 }
 
 func (r *Config) CloneConfig() *Config {
-	return &Config{
+	clone := &Config{
 		DataValue:		r.DataValue.Clone(),
 		refDepthMax:		r.refDepthMax,
 		refDelimOpener:		r.refDelimOpener,
 		refDelimCloser:		r.refDelimCloser,
 	}
+	for selector, source := range r.sources { clone.setSource(selector, source) }
+	clone.secrets = append([]string{}, r.secrets...)
+	return clone
+}
+
+// Get the source that the value at selector was loaded from (see ConfigLoader), or empty string if
+// unknown; values within an object or array report the source of their nearest recorded ancestor
+func (r *Config) GetSource(selector string) string {
+	for len(selector) > 0 {
+		if source, ok := r.sources[selector]; ok { return source }
+		index := strings.LastIndexAny(selector, ".[")
+		if index < 0 { break }
+		selector = selector[:index]
+	}
+	return ""
+}
+
+// Replace any %secret:provider/path% references in our string values, at any depth, with secrets
+// from the registry (or our singleton registry if nil); see secretprovider.go
+func (r *Config) ResolveSecrets(registry SecretProviderRegistryIfc) error {
+	if nil == registry { registry = GetSecretProviderRegistry() }
+	return r.resolveSecretsIn(r.DataValue, "", registry)
+}
+
+// -------------------------------------------------------------------------------------------------
+// DataValueIfc overrides
+// -------------------------------------------------------------------------------------------------
+
+// Resolved secrets are redacted; Select() the value itself to get at a secret
+func (r *Config) ToString() string {
+	if 0 == len(r.secrets) { return r.DataValue.ToString() }
+	return r.redact(r.DataValue.Clone()).ToString()
+}
+
+// Resolved secrets are redacted; Select() the value itself to get at a secret
+func (r *Config) ToJson() string {
+	if 0 == len(r.secrets) { return r.DataValue.ToJson() }
+	return r.redact(r.DataValue.Clone()).ToJson()
 }
 
 // -------------------------------------------------------------------------------------------------
 // Config implementation
 // -------------------------------------------------------------------------------------------------
 
-func (r *Config) dereferencePass(referenceConfigs ...ConfigIfc) int {
+func (r *Config) resolveSecretsIn(value *data.DataValue, selector string, registry SecretProviderRegistryIfc) error {
+	resolveOne := func (childSelector string, child *data.DataValue) (*data.DataValue, error) {
+		if ! child.IsString() {
+			return nil, r.resolveSecretsIn(child, childSelector, registry) // <- BEWARE: recursion!
+		}
+		str := child.GetString()
+		references, err := r.getReferenceSelectorsFromString(str)
+		if nil != err { return nil, nil }
+		resolved := false
+		for _, reference := range references {
+			if ! strings.HasPrefix(reference, SECRET_REFERENCE_PREFIX) { continue }
+			secret, err := registry.GetSecret(reference)
+			if nil != err { return nil, fmt.Errorf("Config.ResolveSecrets(): '%s': %s", childSelector, err.Error()) }
+			str = strings.Replace(str, r.wrapReference(reference), secret, 1)
+			r.addSecret(secret)
+			resolved = true
+		}
+		if ! resolved { return nil, nil }
+		return data.NewString(str), nil
+	}
+
+	switch value.GetType() {
+		case data.DATA_TYPE_OBJECT:
+			for _, property := range value.GetObjectProperties() {
+				childSelector := property
+				if len(selector) > 0 { childSelector = selector + "." + property }
+				resolved, err := resolveOne(childSelector, value.GetObjectProperty(property))
+				if nil != err { return err }
+				if nil != resolved { value.SetObjectProperty(property, resolved) }
+			}
+
+		case data.DATA_TYPE_ARRAY:
+			for index := 0; index < value.GetArraySize(); index++ {
+				resolved, err := resolveOne(fmt.Sprintf("%s[%d]", selector, index), value.GetArrayValue(index))
+				if nil != err { return err }
+				if nil != resolved { value.ReplaceArrayValue(index, resolved) }
+			}
+	}
+	return nil
+}
+
+func (r *Config) addSecret(secret string) {
+	if 0 == len(secret) { return }
+	redactor.GetRedactor().AddSecret(secret)
+	for _, existing := range r.secrets {
+		if existing == secret { return }
+	}
+	r.secrets = append(r.secrets, secret)
+	// Longest first so that a secret containing another is redacted whole
+	sort.SliceStable(r.secrets, func(i, j int) bool { return len(r.secrets[i]) > len(r.secrets[j]) })
+}
+
+// Replace our secrets in the strings of value, at any depth, with a redaction marker
+func (r *Config) redact(value *data.DataValue) *data.DataValue {
+	switch value.GetType() {
+		case data.DATA_TYPE_STRING:
+			str := value.GetString()
+			for _, secret := range r.secrets { str = strings.ReplaceAll(str, secret, redactor.REDACTED) }
+			return data.NewString(str)
+
+		case data.DATA_TYPE_OBJECT:
+			for _, property := range value.GetObjectProperties() {
+				value.SetObjectProperty(property, r.redact(value.GetObjectProperty(property))) // <- BEWARE: recursion!
+			}
+
+		case data.DATA_TYPE_ARRAY:
+			for index := 0; index < value.GetArraySize(); index++ {
+				value.ReplaceArrayValue(index, r.redact(value.GetArrayValue(index))) // <- BEWARE: recursion!
+			}
+	}
+	return value
+}
+
+func (r *Config) setSource(selector, source string) {
+	if nil == r.sources { r.sources = make(map[string]string) }
+	r.sources[selector] = source
+}
+
+// Forget the source of the value at selector and everything beneath it
+func (r *Config) dropSources(selector string) {
+	for existing := range r.sources {
+		if (existing == selector) || strings.HasPrefix(existing, selector + ".") { delete(r.sources, existing) }
+	}
+}
+
+// One link in a chain of references: the selector that was found in this config
+type configReference struct {
+	config		ConfigIfc
+	selector	string
+}
+
+// Resolve the references in str; a string that is only one reference may resolve to any DataType
+func (r *Config) resolveString(str string, lookups []ConfigIfc, chain []configReference) (*data.DataValue, int, error) {
+	selectors, err := r.getReferenceSelectorsFromString(str)
+	// Unmatched delimiters are just part of the string
+	if (nil != err) || (0 == len(selectors)) { return data.NewString(str), 0, nil }
+
+	// A whole-value reference keeps the referenced value's DataType if it is an object, array, or cast
+	if (1 == len(selectors)) && (str == r.wrapReference(selectors[0])) {
+		value, subs, err := r.resolveReference(selectors[0], lookups, chain)
+		if (nil != err) || (nil == value) { return data.NewString(str), 0, err }
+		if _, cast := splitReferenceCast(selectors[0]); (len(cast) > 0) || value.IsObject() || value.IsArray() {
+			return value, subs, nil
+		}
+		return data.NewString(value.ToString()), subs, nil
+	}
+
 	subs := 0
-	for _, referenceConfig := range referenceConfigs {
-		switch r.GetType() {
-			case data.DATA_TYPE_OBJECT:
-				it := r.GetIterator()
-				for kvpi := it(); nil != kvpi; kvpi = it() {
-					if kvp, ok := kvpi.(data.KeyValuePair); ok {
-						tstr, drsubs := r.dereferenceOne(kvp.Value.GetString(), referenceConfig)
-						if (nil == tstr) || (0 == drsubs) { continue }
-						r.SetObjectProperty(kvp.Key, data.NewString(*tstr))
-						subs = subs + drsubs
-					}
-				}
-
-			case data.DATA_TYPE_ARRAY:
-				it := r.GetIterator()
-				for ivpi := it(); nil != ivpi; ivpi = it() {
-					if ivp, ok := ivpi.(data.IndexValuePair); ok {
-						tstr, drsubs := r.dereferenceOne(ivp.Value.GetString(), referenceConfig)
-						if (nil == tstr) || (0 == drsubs) { continue }
-						r.ReplaceArrayValue(ivp.Index, data.NewString(*tstr))
-						subs = subs + drsubs
-					}
-				}
+	for _, selector := range selectors {
+		value, valueSubs, err := r.resolveReference(selector, lookups, chain)
+		if nil != err { return nil, 0, err }
+		if nil == value { continue }
+		str = strings.Replace(str, r.wrapReference(selector), value.ToString(), 1)
+		subs += valueSubs
+	}
+	return data.NewString(str), subs, nil
+}
+
+// Resolve one reference (sans delimiters) to a copy of the value it refers to, or nil if not found
+func (r *Config) resolveReference(reference string, lookups []ConfigIfc, chain []configReference) (*data.DataValue, int, error) {
+	selector, cast := splitReferenceCast(reference)
+	for _, lookup := range lookups {
+		value := lookup.Select(selector)
+		if nil == value { continue }
+
+		link := configReference{ config: lookup, selector: selector }
+		linkChain := append(append([]configReference{}, chain...), link)
+		for _, previous := range chain {
+			if previous == link {
+				return nil, 0, fmt.Errorf("Config.Dereference(): Reference cycle: %s", formatReferenceChain(linkChain))
+			}
+		}
+		if len(linkChain) > r.refDepthMax {
+			return nil, 0, fmt.Errorf(
+				"Config.Dereference(): Maximum reference depth (%d) exceeded: %s",
+				r.refDepthMax, formatReferenceChain(linkChain),
+			)
+		}
+
+		subs := 1
+		if value.IsString() {
+			resolved, valueSubs, err := r.resolveString(value.GetString(), lookups, linkChain) // <- BEWARE: recursion!
+			if nil != err { return nil, 0, err }
+			value = resolved
+			subs += valueSubs
+		} else { value = value.Clone() }
+
+		if len(cast) > 0 {
+			var err error
+			if value, err = castReferenceValue(value, cast); nil != err {
+				return nil, 0, fmt.Errorf("Config.Dereference(): %s: %s", formatReferenceChain(linkChain), err.Error())
+			}
 		}
+		return value, subs, nil
 	}
-	return subs
+	return nil, 0, nil
+}
+
+func (r *Config) wrapReference(reference string) string {
+	return fmt.Sprintf("%c%s%c", r.refDelimOpener, reference, r.refDelimCloser)
+}
+
+// Separate any recognized type cast from the end of the reference
+func splitReferenceCast(reference string) (string, string) {
+	index := strings.LastIndex(reference, ":")
+	if index < 0 { return reference, "" }
+	switch cast := reference[index + 1:]; cast {
+		case REFERENCE_CAST_INTEGER, REFERENCE_CAST_FLOAT, REFERENCE_CAST_BOOLEAN, REFERENCE_CAST_JSON:
+			return reference[:index], cast
+	}
+	return reference, ""
 }
 
-func (r *Config) dereferenceOne(before string, referenceConfig ConfigIfc) (*string, int) {
-	tstr, subs := referenceConfig.DereferenceString(before)
-	// If referenceConfig has/changes nothing...
-	if (nil == tstr) || (before == *tstr) || (0 == subs) {
-		// What if we try to dereference against ourselves?
-		tstr, subs = r.DereferenceString(before)
-		// Nothing to do if nothing changed...
-		if (nil == tstr) || (before == *tstr) || (0 == subs) { return nil, 0 }
+func formatReferenceChain(chain []configReference) string {
+	selectors := make([]string, len(chain))
+	for index, link := range chain { selectors[index] = link.selector }
+	return strings.Join(selectors, " -> ")
+}
+
+func castReferenceValue(value *data.DataValue, cast string) (*data.DataValue, error) {
+	str := strings.TrimSpace(value.ToString())
+	switch cast {
+		case REFERENCE_CAST_INTEGER:
+			switch value.GetType() {
+				case data.DATA_TYPE_INTEGER: return value, nil
+				case data.DATA_TYPE_FLOAT:
+					f := value.GetFloat()
+					if f == math.Trunc(f) { return data.NewInteger(int64(f)), nil }
+				case data.DATA_TYPE_STRING:
+					if i, err := strconv.ParseInt(str, 10, 64); nil == err { return data.NewInteger(i), nil }
+			}
+
+		case REFERENCE_CAST_FLOAT:
+			switch value.GetType() {
+				case data.DATA_TYPE_FLOAT: return value, nil
+				case data.DATA_TYPE_INTEGER: return data.NewFloat(float64(value.GetInteger())), nil
+				case data.DATA_TYPE_STRING:
+					if f, err := strconv.ParseFloat(str, 64); nil == err { return data.NewFloat(f), nil }
+			}
+
+		case REFERENCE_CAST_BOOLEAN:
+			switch value.GetType() {
+				case data.DATA_TYPE_BOOLEAN: return value, nil
+				case data.DATA_TYPE_STRING:
+					if b, err := strconv.ParseBool(str); nil == err { return data.NewBoolean(b), nil }
+			}
+
+		case REFERENCE_CAST_JSON:
+			if ! value.IsString() { return value, nil }
+			jsonValue, err := json.NewJson(&str).ToDataValue()
+			if nil == err { return jsonValue, nil }
+			return nil, fmt.Errorf("cannot cast %s value '%s' to json: %s", value.GetType().ToString(), str, err.Error())
 	}
-	return tstr, subs
+	return nil, fmt.Errorf("cannot cast %s value '%s' to %s", value.GetType().ToString(), str, cast)
 }
 
 func (r *Config) getReferenceSelectorsFromString(str string) ([]string, error) {
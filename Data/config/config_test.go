@@ -9,6 +9,7 @@ TODO:
 */
 
 import(
+	"strings"
 	"testing"
 
 	"github.com/DigiStratum/GoLib/Data"
@@ -245,6 +246,129 @@ func TestThat_Config_Dereference_iterates_for_object_few_reference_configs(t *te
 	if ! ExpectString("Greetings, Earthling - your lucky numbers are 333 and 3.14159: true %invalid%%[2]%!", actualValue.ToString(), t) { return }
 }
 
+// Typed and whole-value references
+
+func TestThat_Config_Dereference_casts_whole_value_references(t *testing.T) {
+	// Setup
+	sut := NewConfig()
+	sut.PrepareObject().
+		SetObjectProperty("port", data.NewString("3306")).
+		SetObjectProperty("ratio", data.NewInteger(2)).
+		SetObjectProperty("enabled", data.NewString("true")).
+		SetObjectProperty("tags", data.NewString(`["a","b"]`)).
+		SetObjectProperty("typed-port", data.NewString("%port:integer%")).
+		SetObjectProperty("typed-ratio", data.NewString("%ratio:float%")).
+		SetObjectProperty("typed-enabled", data.NewString("%enabled:boolean%")).
+		SetObjectProperty("typed-tags", data.NewString("%tags:json%")).
+		SetObjectProperty("untyped-port", data.NewString("%typed-port%")).
+		SetObjectProperty("embedded", data.NewString("port=%port:integer%"))
+
+	// Test
+	actual := sut.Dereference()
+
+	// Verify
+	ExpectNoError(sut.GetDereferenceError(), t)
+	ExpectInt(6, actual, t)
+	ExpectTrue(sut.Select("typed-port").IsInteger(), t)
+	ExpectInt(3306, int(sut.Select("typed-port").GetInteger()), t)
+	ExpectTrue(sut.Select("typed-ratio").IsFloat(), t)
+	ExpectTrue(sut.Select("typed-enabled").GetBoolean(), t)
+	ExpectTrue(sut.Select("typed-tags").IsArray(), t)
+	ExpectString("b", sut.Select("typed-tags[1]").GetString(), t)
+	ExpectString("3306", sut.Select("untyped-port").GetString(), t)
+	ExpectString("port=3306", sut.Select("embedded").GetString(), t)
+}
+
+func TestThat_Config_Dereference_copies_whole_value_subtree_references(t *testing.T) {
+	// Setup
+	sut := NewConfig()
+	sut.PrepareObject().
+		SetObjectProperty("replica", data.NewString("%primary%")).
+		SetObjectProperty("hosts", data.NewString("%ref-hosts%")).
+		SetObjectProperty("greeting", data.NewString("hosts: %ref-hosts%"))
+	ref := NewConfig()
+	ref.PrepareObject().
+		SetObjectProperty("primary", data.NewString("%db%")).
+		SetObjectProperty("db", data.NewObject().SetObjectProperty("host", data.NewString("localhost"))).
+		SetObjectProperty("ref-hosts", data.NewArray().AppendArrayValue(data.NewString("a")))
+
+	// Test
+	actual := sut.Dereference(ref)
+
+	// Verify
+	ExpectNoError(sut.GetDereferenceError(), t)
+	ExpectInt(4, actual, t)
+	ExpectString("localhost", sut.Select("replica.host").GetString(), t)
+	ExpectString(`["a"]`, sut.Select("hosts").ToJson(), t)
+	ExpectString(`hosts: ["a"]`, sut.Select("greeting").GetString(), t)
+
+	// The copy is independent of the original
+	sut.Select("replica").SetObjectProperty("host", data.NewString("elsewhere"))
+	ExpectString("localhost", ref.Select("db.host").GetString(), t)
+}
+
+func TestThat_Config_Dereference_reports_failed_casts(t *testing.T) {
+	// Setup
+	sut := NewConfig()
+	sut.PrepareObject().
+		SetObjectProperty("name", data.NewString("bob")).
+		SetObjectProperty("pi", data.NewFloat(3.5)).
+		SetObjectProperty("bad-integer", data.NewString("%name:integer%")).
+		SetObjectProperty("bad-fraction", data.NewString("%pi:integer%")).
+		SetObjectProperty("bad-json", data.NewString("%name:json%")).
+		SetObjectProperty("good", data.NewString("%pi:float%"))
+
+	// Test
+	actual := sut.Dereference()
+
+	// Verify
+	ExpectInt(1, actual, t)
+	err := sut.GetDereferenceError()
+	if ! ExpectError(err, t) { return }
+	ExpectTrue(strings.Contains(err.Error(), "bad-integer -> name"), t)
+	ExpectTrue(strings.Contains(err.Error(), "bad-fraction -> pi"), t)
+	ExpectTrue(strings.Contains(err.Error(), "bad-json -> name"), t)
+	ExpectString("%name:integer%", sut.Select("bad-integer").GetString(), t)
+}
+
+func TestThat_Config_Dereference_reports_cycles_with_reference_chain(t *testing.T) {
+	// Setup
+	sut := NewConfig()
+	sut.PrepareObject().
+		SetObjectProperty("a", data.NewString("x%b%")).
+		SetObjectProperty("b", data.NewString("%c%")).
+		SetObjectProperty("c", data.NewString("%a%")).
+		SetObjectProperty("d", data.NewString("fine"))
+
+	// Test
+	actual := sut.Dereference()
+
+	// Verify
+	ExpectInt(0, actual, t)
+	err := sut.GetDereferenceError()
+	if ! ExpectError(err, t) { return }
+	ExpectTrue(strings.Contains(err.Error(), "Reference cycle: a -> b -> c -> a"), t)
+	ExpectString("x%b%", sut.Select("a").GetString(), t)
+}
+
+func TestThat_Config_Dereference_reports_exceeding_max_depth(t *testing.T) {
+	// Setup
+	sut := NewConfig().SetMaxDepth(2)
+	sut.PrepareObject().
+		SetObjectProperty("a", data.NewString("%b%")).
+		SetObjectProperty("b", data.NewString("%c%")).
+		SetObjectProperty("c", data.NewString("end"))
+
+	// Test
+	sut.Dereference()
+
+	// Verify
+	err := sut.GetDereferenceError()
+	if ! ExpectError(err, t) { return }
+	ExpectTrue(strings.Contains(err.Error(), "a -> b -> c"), t)
+	ExpectString("end", sut.Select("b").GetString(), t)
+}
+
 // MergeConfig
 
 func TestThat_Config_MergeConfig_returns_original_for_nil(t *testing.T) {
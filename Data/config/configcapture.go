@@ -0,0 +1,172 @@
+package config
+
+/*
+
+Typed capture funcs for ConfigItems, so that a Configurable can declare what type of value it wants
+and get it, ready to use, in a field of its own:
+
+	config.NewConfigItem("maxConnections").
+		SetDefault(data.NewInteger(10)).
+		CaptureWith(config.CaptureInt(&r.maxConnections))
+
+Each type is also accepted in the string form that legacy (string-only) config data would have used,
+so "10" captures as an integer just as 10 does. Custom capture funcs can use the Coerce*() funcs to
+do the same before applying their own rules.
+
+Durations may be strings for time.ParseDuration() ("1m30s") or numbers of seconds.
+
+*/
+
+import (
+	"fmt"
+	"math"
+	"time"
+	"strconv"
+	"strings"
+
+	"github.com/DigiStratum/GoLib/Data"
+)
+
+// -------------------------------------------------------------------------------------------------
+// Capture Funcs
+// -------------------------------------------------------------------------------------------------
+
+func CaptureString(target *string) CaptureFunc {
+	return func (dataValue data.DataValueIfc) error {
+		value, err := CoerceString(dataValue)
+		if nil != err { return err }
+		*target = value
+		return nil
+	}
+}
+
+func CaptureInt(target *int) CaptureFunc {
+	return func (dataValue data.DataValueIfc) error {
+		value, err := CoerceInteger(dataValue)
+		if nil != err { return err }
+		if (value < math.MinInt) || (value > math.MaxInt) {
+			return fmt.Errorf("Integer value %d is out of range", value)
+		}
+		*target = int(value)
+		return nil
+	}
+}
+
+func CaptureInt64(target *int64) CaptureFunc {
+	return func (dataValue data.DataValueIfc) error {
+		value, err := CoerceInteger(dataValue)
+		if nil != err { return err }
+		*target = value
+		return nil
+	}
+}
+
+func CaptureFloat(target *float64) CaptureFunc {
+	return func (dataValue data.DataValueIfc) error {
+		value, err := CoerceFloat(dataValue)
+		if nil != err { return err }
+		*target = value
+		return nil
+	}
+}
+
+func CaptureBoolean(target *bool) CaptureFunc {
+	return func (dataValue data.DataValueIfc) error {
+		value, err := CoerceBoolean(dataValue)
+		if nil != err { return err }
+		*target = value
+		return nil
+	}
+}
+
+func CaptureDuration(target *time.Duration) CaptureFunc {
+	return func (dataValue data.DataValueIfc) error {
+		value, err := CoerceDuration(dataValue)
+		if nil != err { return err }
+		*target = value
+		return nil
+	}
+}
+
+// -------------------------------------------------------------------------------------------------
+// Coercion Funcs
+// -------------------------------------------------------------------------------------------------
+
+// Strings as they are, other scalars in their string form, and null as the empty string
+func CoerceString(dataValue data.DataValueIfc) (string, error) {
+	if nil == dataValue { return "", fmt.Errorf("Expected string value, got nil") }
+	switch dataValue.GetType() {
+		case data.DATA_TYPE_STRING: return dataValue.GetString(), nil
+		case data.DATA_TYPE_NULL: return "", nil
+		case data.DATA_TYPE_INTEGER, data.DATA_TYPE_FLOAT, data.DATA_TYPE_BOOLEAN:
+			return dataValue.ToString(), nil
+	}
+	return "", unexpectedValueError("string", dataValue)
+}
+
+// Integers, floats with no fractional part, and strings of base 10 integers
+func CoerceInteger(dataValue data.DataValueIfc) (int64, error) {
+	if nil == dataValue { return 0, fmt.Errorf("Expected integer value, got nil") }
+	switch dataValue.GetType() {
+		case data.DATA_TYPE_INTEGER: return dataValue.GetInteger(), nil
+		case data.DATA_TYPE_FLOAT:
+			value := dataValue.GetFloat()
+			if (value == math.Trunc(value)) && (value >= math.MinInt64) && (value < math.MaxInt64) {
+				return int64(value), nil
+			}
+		case data.DATA_TYPE_STRING:
+			value, err := strconv.ParseInt(strings.TrimSpace(dataValue.GetString()), 10, 64)
+			if nil == err { return value, nil }
+	}
+	return 0, unexpectedValueError("integer", dataValue)
+}
+
+// Floats, integers, and strings of either
+func CoerceFloat(dataValue data.DataValueIfc) (float64, error) {
+	if nil == dataValue { return 0, fmt.Errorf("Expected float value, got nil") }
+	switch dataValue.GetType() {
+		case data.DATA_TYPE_FLOAT: return dataValue.GetFloat(), nil
+		case data.DATA_TYPE_INTEGER: return float64(dataValue.GetInteger()), nil
+		case data.DATA_TYPE_STRING:
+			value, err := strconv.ParseFloat(strings.TrimSpace(dataValue.GetString()), 64)
+			if nil == err { return value, nil }
+	}
+	return 0, unexpectedValueError("float", dataValue)
+}
+
+// Booleans and strings that strconv.ParseBool() understands ("true", "false", "1", "0", etc)
+func CoerceBoolean(dataValue data.DataValueIfc) (bool, error) {
+	if nil == dataValue { return false, fmt.Errorf("Expected boolean value, got nil") }
+	switch dataValue.GetType() {
+		case data.DATA_TYPE_BOOLEAN: return dataValue.GetBoolean(), nil
+		case data.DATA_TYPE_STRING:
+			value, err := strconv.ParseBool(strings.TrimSpace(dataValue.GetString()))
+			if nil == err { return value, nil }
+	}
+	return false, unexpectedValueError("boolean", dataValue)
+}
+
+// Strings that time.ParseDuration() understands, or numbers of seconds
+func CoerceDuration(dataValue data.DataValueIfc) (time.Duration, error) {
+	if nil == dataValue { return 0, fmt.Errorf("Expected duration value, got nil") }
+	switch dataValue.GetType() {
+		case data.DATA_TYPE_INTEGER: return time.Duration(dataValue.GetInteger()) * time.Second, nil
+		case data.DATA_TYPE_FLOAT: return time.Duration(dataValue.GetFloat() * float64(time.Second)), nil
+		case data.DATA_TYPE_STRING:
+			str := strings.TrimSpace(dataValue.GetString())
+			if value, err := time.ParseDuration(str); nil == err { return value, nil }
+			if seconds, err := strconv.ParseInt(str, 10, 64); nil == err {
+				return time.Duration(seconds) * time.Second, nil
+			}
+	}
+	return 0, unexpectedValueError("duration", dataValue)
+}
+
+func unexpectedValueError(expected string, dataValue data.DataValueIfc) error {
+	return fmt.Errorf(
+		"Expected %s value, got: (%s) '%s'",
+		expected,
+		dataValue.GetType().ToString(),
+		dataValue.ToString(),
+	)
+}
@@ -0,0 +1,198 @@
+package config
+
+import(
+	"testing"
+	"time"
+
+	"github.com/DigiStratum/GoLib/Data"
+
+	. "github.com/DigiStratum/GoLib/Testing"
+)
+
+// Capture Funcs
+
+func TestThat_CaptureString_captures_scalars_as_strings(t *testing.T) {
+	// Setup
+	var actual string
+	sut := CaptureString(&actual)
+
+	// Test
+	err1 := sut(data.NewString("text"))
+	actual1 := actual
+	err2 := sut(data.NewInteger(42))
+	actual2 := actual
+	err3 := sut(data.NewObject())
+
+	// Verify
+	ExpectNoError(err1, t)
+	ExpectString("text", actual1, t)
+	ExpectNoError(err2, t)
+	ExpectString("42", actual2, t)
+	ExpectError(err3, t)
+	ExpectString("42", actual, t) // <- Unchanged by the failed capture
+}
+
+func TestThat_CaptureInt_captures_integers_and_their_string_forms(t *testing.T) {
+	// Setup
+	var actual int
+	sut := CaptureInt(&actual)
+
+	// Test
+	err1 := sut(data.NewInteger(10))
+	actual1 := actual
+	err2 := sut(data.NewString(" 20 "))
+	actual2 := actual
+	err3 := sut(data.NewFloat(30.0))
+	actual3 := actual
+
+	// Verify
+	ExpectNoError(err1, t)
+	ExpectInt(10, actual1, t)
+	ExpectNoError(err2, t)
+	ExpectInt(20, actual2, t)
+	ExpectNoError(err3, t)
+	ExpectInt(30, actual3, t)
+}
+
+func TestThat_CaptureInt64_returns_error_for_non_integers(t *testing.T) {
+	// Setup
+	var actual int64
+	sut := CaptureInt64(&actual)
+
+	for _, dataValue := range []*data.DataValue{
+		data.NewFloat(1.5), data.NewString("ten"), data.NewBoolean(true), data.NewNull(), data.NewArray(),
+	} {
+		// Test
+		err := sut(dataValue)
+
+		// Verify
+		ExpectError(err, t)
+	}
+	ExpectInt64(0, actual, t)
+}
+
+func TestThat_CaptureFloat_captures_floats_integers_and_their_string_forms(t *testing.T) {
+	// Setup
+	var actual float64
+	sut := CaptureFloat(&actual)
+
+	// Test
+	err1 := sut(data.NewFloat(1.5))
+	actual1 := actual
+	err2 := sut(data.NewInteger(2))
+	actual2 := actual
+	err3 := sut(data.NewString("2.5"))
+	actual3 := actual
+	err4 := sut(data.NewBoolean(true))
+
+	// Verify
+	ExpectNoError(err1, t)
+	ExpectTrue(1.5 == actual1, t)
+	ExpectNoError(err2, t)
+	ExpectTrue(2.0 == actual2, t)
+	ExpectNoError(err3, t)
+	ExpectTrue(2.5 == actual3, t)
+	ExpectError(err4, t)
+}
+
+func TestThat_CaptureBoolean_captures_booleans_and_their_string_forms(t *testing.T) {
+	// Setup
+	var actual bool
+	sut := CaptureBoolean(&actual)
+
+	// Test
+	err1 := sut(data.NewBoolean(true))
+	actual1 := actual
+	err2 := sut(data.NewString("false"))
+	actual2 := actual
+	err3 := sut(data.NewString("maybe"))
+
+	// Verify
+	ExpectNoError(err1, t)
+	ExpectTrue(actual1, t)
+	ExpectNoError(err2, t)
+	ExpectFalse(actual2, t)
+	ExpectError(err3, t)
+}
+
+func TestThat_CaptureDuration_captures_duration_strings_and_seconds(t *testing.T) {
+	// Setup
+	var actual time.Duration
+	sut := CaptureDuration(&actual)
+
+	// Test
+	err1 := sut(data.NewString("1m30s"))
+	actual1 := actual
+	err2 := sut(data.NewInteger(5))
+	actual2 := actual
+	err3 := sut(data.NewString("7"))
+	actual3 := actual
+	err4 := sut(data.NewFloat(0.5))
+	actual4 := actual
+	err5 := sut(data.NewString("soon"))
+
+	// Verify
+	ExpectNoError(err1, t)
+	ExpectTrue(90 * time.Second == actual1, t)
+	ExpectNoError(err2, t)
+	ExpectTrue(5 * time.Second == actual2, t)
+	ExpectNoError(err3, t)
+	ExpectTrue(7 * time.Second == actual3, t)
+	ExpectNoError(err4, t)
+	ExpectTrue(500 * time.Millisecond == actual4, t)
+	ExpectError(err5, t)
+}
+
+// Coercion Funcs
+
+func TestThat_Coerce_funcs_return_errors_for_nil(t *testing.T) {
+	// Test
+	_, err1 := CoerceString(nil)
+	_, err2 := CoerceInteger(nil)
+	_, err3 := CoerceFloat(nil)
+	_, err4 := CoerceBoolean(nil)
+	_, err5 := CoerceDuration(nil)
+
+	// Verify
+	ExpectError(err1, t)
+	ExpectError(err2, t)
+	ExpectError(err3, t)
+	ExpectError(err4, t)
+	ExpectError(err5, t)
+}
+
+func TestThat_CoerceString_returns_empty_string_for_null(t *testing.T) {
+	// Test
+	actual, err := CoerceString(data.NewNull())
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectString("", actual, t)
+}
+
+// Configurable
+
+func TestThat_Configurable_Start_captures_typed_values_and_defaults(t *testing.T) {
+	// Setup
+	var port int
+	var host string
+	var timeout time.Duration
+	sut := NewConfigurable(
+		NewConfigItem("db.port").CaptureWith(CaptureInt(&port)),
+		NewConfigItem("db.host").SetDefault(data.NewString("localhost")).CaptureWith(CaptureString(&host)),
+		NewConfigItem("db.timeout").CaptureWith(CaptureDuration(&timeout)), // <- Missing, no default
+	)
+	config := NewConfig()
+	config.PrepareObject().
+		SetObjectProperty("db", data.NewObject().SetObjectProperty("port", data.NewInteger(3306)))
+	sut.Configure(config)
+
+	// Test
+	err := sut.Start()
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectInt(3306, port, t)
+	ExpectString("localhost", host, t)
+	ExpectTrue(0 == timeout, t)
+}
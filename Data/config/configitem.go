@@ -4,11 +4,19 @@ package config
 A support structure for Configuration Item declaration/handling
 
 We can supply ConfigItem's to NewConfigurable so that Configure() can be passed the custom handlers needed.
+See configcapture.go for typed capture funcs.
+
+A ConfigItem may also describe itself with a type, a description, and an example value so that we can
+generate reference documentation for a set of Configurables (see ConfigReference):
+
+	config.NewConfigItem("maxConnections").
+		SetType(config.CONFIG_ITEM_TYPE_INTEGER).
+		SetDescription("Most connections the pool will open at once").
+		SetDefault(data.NewInteger(10)).
+		CaptureWith(config.CaptureInt(&r.maxConnections))
 
 TODO:
  * Need test coverage for this mess
- * Add support for type? All incoming config values are arbitrary strings, no type enforcement
- * Are there any useful type-conversion helpers for capture funcs?
 */
 
 import (
@@ -34,6 +42,15 @@ type ConfigItemIfc interface {
 	Validate(dataValue data.DataValueIfc) error
 
 	SetDefault(dataValue data.DataValueIfc) *configItem
+	HasDefault() bool
+	GetDefault() data.DataValueIfc
+
+	SetType(itemType ConfigItemType) *configItem
+	GetType() ConfigItemType
+	SetDescription(description string) *configItem
+	GetDescription() string
+	SetExample(dataValue data.DataValueIfc) *configItem
+	GetExample() data.DataValueIfc
 }
 
 type configItem struct {
@@ -43,6 +60,9 @@ type configItem struct {
 	isRequired	bool
 	captureFunc	CaptureFunc
 	validateFunc	ValidateFunc
+	itemType	ConfigItemType
+	description	string
+	example		data.DataValueIfc
 }
 
 // -------------------------------------------------------------------------------------------------
@@ -81,6 +101,16 @@ func (r *configItem) SetDefault(dataValue data.DataValueIfc) *configItem {
 	return r
 }
 
+func (r *configItem) HasDefault() bool {
+	return r.hasDefault
+}
+
+// Get the default value, or nil if there is none
+func (r *configItem) GetDefault() data.DataValueIfc {
+	if ! r.hasDefault { return nil }
+	return r.defaultValue
+}
+
 func (r *configItem) CanCapture() bool {
 	return nil != r.captureFunc
 }
@@ -139,3 +169,37 @@ func (r *configItem) Validate(dataValue data.DataValueIfc) error {
 	return r.validateFunc(dataValue)
 }
 
+// Documentation
+// -----------------------------------------------
+
+func (r *configItem) SetType(itemType ConfigItemType) *configItem {
+	r.itemType = itemType
+	return r
+}
+
+// Get the declared type; if none was declared, the type of our default value will do
+func (r *configItem) GetType() ConfigItemType {
+	if (CONFIG_ITEM_TYPE_ANY == r.itemType) && r.hasDefault && (nil != r.defaultValue) {
+		return ConfigItemTypeFromDataType(r.defaultValue.GetType())
+	}
+	return r.itemType
+}
+
+func (r *configItem) SetDescription(description string) *configItem {
+	r.description = description
+	return r
+}
+
+func (r *configItem) GetDescription() string {
+	return r.description
+}
+
+func (r *configItem) SetExample(dataValue data.DataValueIfc) *configItem {
+	r.example = dataValue
+	return r
+}
+
+// Get the example value, or nil if there is none
+func (r *configItem) GetExample() data.DataValueIfc {
+	return r.example
+}
@@ -205,3 +205,52 @@ func TestThat_ConfigItem_Validate_Passes_Through_ValidateFunc_Error(t *testing.T
 	if ! ExpectNoError(sut.Validate(nil), t) { return }
 }
 
+
+// Documentation
+
+func TestThat_ConfigItem_GetType_Returns_any_by_default(t *testing.T) {
+	// Setup
+	sut := NewConfigItem("")
+
+	// Verify
+	if ! ExpectTrue(CONFIG_ITEM_TYPE_ANY == sut.GetType(), t) { return }
+	if ! ExpectString("any", sut.GetType().ToString(), t) { return }
+}
+
+func TestThat_ConfigItem_GetType_Returns_type_of_default_when_not_declared(t *testing.T) {
+	// Setup
+	sut := NewConfigItem("").SetDefault(data.NewInteger(10))
+
+	// Verify
+	if ! ExpectTrue(CONFIG_ITEM_TYPE_INTEGER == sut.GetType(), t) { return }
+}
+
+func TestThat_ConfigItem_GetType_Returns_declared_type_over_type_of_default(t *testing.T) {
+	// Setup
+	sut := NewConfigItem("").
+		SetDefault(data.NewString("30s")).
+		SetType(CONFIG_ITEM_TYPE_DURATION)
+
+	// Verify
+	if ! ExpectTrue(CONFIG_ITEM_TYPE_DURATION == sut.GetType(), t) { return }
+}
+
+func TestThat_ConfigItem_documentation_getters_return_what_was_set(t *testing.T) {
+	// Setup
+	sut := NewConfigItem("")
+
+	// Verify
+	if ! ExpectFalse(sut.HasDefault(), t) { return }
+	if ! ExpectTrue(nil == sut.GetDefault(), t) { return }
+	if ! ExpectTrue(nil == sut.GetExample(), t) { return }
+	if ! ExpectString("", sut.GetDescription(), t) { return }
+
+	// Test
+	sut.SetDefault(data.NewInteger(1)).SetExample(data.NewInteger(2)).SetDescription("described")
+
+	// Verify
+	if ! ExpectTrue(sut.HasDefault(), t) { return }
+	if ! ExpectInt64(1, sut.GetDefault().GetInteger(), t) { return }
+	if ! ExpectInt64(2, sut.GetExample().GetInteger(), t) { return }
+	if ! ExpectString("described", sut.GetDescription(), t) { return }
+}
@@ -0,0 +1,47 @@
+package config
+
+import (
+	"github.com/DigiStratum/GoLib/Data"
+)
+
+// The type of value a ConfigItem expects; documentation for humans and JSON Schema alike (see
+// ConfigReference) and a match for the typed capture funcs (see configcapture.go)
+type ConfigItemType int
+
+const (
+	CONFIG_ITEM_TYPE_ANY ConfigItemType = iota
+	CONFIG_ITEM_TYPE_STRING
+	CONFIG_ITEM_TYPE_INTEGER
+	CONFIG_ITEM_TYPE_FLOAT
+	CONFIG_ITEM_TYPE_BOOLEAN
+	CONFIG_ITEM_TYPE_DURATION
+	CONFIG_ITEM_TYPE_OBJECT
+	CONFIG_ITEM_TYPE_ARRAY
+)
+
+func (r ConfigItemType) ToString() string {
+	switch r {
+		case CONFIG_ITEM_TYPE_ANY: return "any"
+		case CONFIG_ITEM_TYPE_STRING: return "string"
+		case CONFIG_ITEM_TYPE_INTEGER: return "integer"
+		case CONFIG_ITEM_TYPE_FLOAT: return "float"
+		case CONFIG_ITEM_TYPE_BOOLEAN: return "boolean"
+		case CONFIG_ITEM_TYPE_DURATION: return "duration"
+		case CONFIG_ITEM_TYPE_OBJECT: return "object"
+		case CONFIG_ITEM_TYPE_ARRAY: return "array"
+	}
+	return ""
+}
+
+// The ConfigItemType for values of the given DataType; there is no duration DataType
+func ConfigItemTypeFromDataType(dataType data.DataType) ConfigItemType {
+	switch dataType {
+		case data.DATA_TYPE_STRING: return CONFIG_ITEM_TYPE_STRING
+		case data.DATA_TYPE_INTEGER: return CONFIG_ITEM_TYPE_INTEGER
+		case data.DATA_TYPE_FLOAT: return CONFIG_ITEM_TYPE_FLOAT
+		case data.DATA_TYPE_BOOLEAN: return CONFIG_ITEM_TYPE_BOOLEAN
+		case data.DATA_TYPE_OBJECT: return CONFIG_ITEM_TYPE_OBJECT
+		case data.DATA_TYPE_ARRAY: return CONFIG_ITEM_TYPE_ARRAY
+	}
+	return CONFIG_ITEM_TYPE_ANY
+}
@@ -0,0 +1,264 @@
+package config
+
+/*
+
+ConfigLoader stacks configuration sources in a set order of precedence, lowest to highest:
+
+ 1. Built-in defaults (WithDefaults)
+ 2. JSON/YAML files, in the order they were added (WithFile, WithOptionalFile)
+ 3. Environment variables with a prefix (WithEnv), e.g. with prefix "APP", APP_DB__HOST=x sets
+    db.host; a double underscore separates nesting levels, names are lower-cased
+ 4. Command line flags (WithFlags), e.g. --db.host=x, or just --verbose for true; a value must be
+    joined to its flag with '=' (--offset=-5), since "--verbose file.txt" can't tell a value from a
+    positional argument. Anything else, including an arg like "-5", is a positional argument
+
+The order that the With*() methods are called in does not matter. Sources are merged value by value
+so that, for example, a file may override db.host while db.port keeps its default; objects are
+merged, but anything else (including arrays) replaces what came before it. Environment and flag
+values are strings, so they are converted to the first DataType that they look like (see
+Data/inferdatavalue.go), except that an empty value is an empty string rather than a null, so that
+a string may be set empty (APP_NAME= or --name=).
+
+The loaded Config records the source of each value for GetSource(), e.g. "defaults",
+"file:/etc/app.yaml", "env:APP_DB__HOST", or "flag:--db.host".
+
+TODO:
+ * Support a configurable file name search path (./, ~/.app/, /etc/app/) for optional files
+ * Support flag short names/aliases (-v for --verbose)
+
+*/
+
+import (
+	"os"
+	"fmt"
+	"strings"
+	"strconv"
+	"path/filepath"
+
+	"github.com/DigiStratum/GoLib/Data"
+	"github.com/DigiStratum/GoLib/Data/json"
+	"github.com/DigiStratum/GoLib/Data/yaml"
+)
+
+const (
+	CONFIG_SOURCE_DEFAULTS	= "defaults"
+	CONFIG_SOURCE_FILE	= "file"
+	CONFIG_SOURCE_ENV	= "env"
+	CONFIG_SOURCE_FLAG	= "flag"
+	CONFIG_SOURCE_LEGACY	= "legacy"	// See FromLegacyConfig()
+)
+
+type ConfigLoaderIfc interface {
+	WithDefaults(defaults *data.DataValue) *ConfigLoader
+	WithFile(path string) *ConfigLoader
+	WithOptionalFile(path string) *ConfigLoader
+	WithEnv(prefix string) *ConfigLoader
+	WithFlags(args []string) *ConfigLoader
+	Load() (*Config, error)
+}
+
+type ConfigLoader struct {
+	defaults	[]*data.DataValue
+	files		[]configLoaderFile
+	envPrefixes	[]string
+	flagArgs	[][]string
+}
+
+type configLoaderFile struct {
+	path		string
+	isOptional	bool
+}
+
+// -------------------------------------------------------------------------------------------------
+// Factory Functions
+// -------------------------------------------------------------------------------------------------
+
+func NewConfigLoader() *ConfigLoader {
+	return &ConfigLoader{
+		defaults:	make([]*data.DataValue, 0),
+		files:		make([]configLoaderFile, 0),
+		envPrefixes:	make([]string, 0),
+		flagArgs:	make([][]string, 0),
+	}
+}
+
+// -------------------------------------------------------------------------------------------------
+// ConfigLoaderIfc
+// -------------------------------------------------------------------------------------------------
+
+// Defaults must be an object; multiple defaults are merged in the order added
+func (r *ConfigLoader) WithDefaults(defaults *data.DataValue) *ConfigLoader {
+	r.defaults = append(r.defaults, defaults)
+	return r
+}
+
+// A JSON (.json) or YAML (.yaml, .yml) file which must exist
+func (r *ConfigLoader) WithFile(path string) *ConfigLoader {
+	r.files = append(r.files, configLoaderFile{ path: path })
+	return r
+}
+
+// A JSON (.json) or YAML (.yaml, .yml) file which is skipped if it does not exist
+func (r *ConfigLoader) WithOptionalFile(path string) *ConfigLoader {
+	r.files = append(r.files, configLoaderFile{ path: path, isOptional: true })
+	return r
+}
+
+// Environment variables named with this prefix (plus '_'); read at Load() time
+func (r *ConfigLoader) WithEnv(prefix string) *ConfigLoader {
+	r.envPrefixes = append(r.envPrefixes, strings.TrimSuffix(prefix, "_") + "_")
+	return r
+}
+
+// Command line flags, typically os.Args[1:]; positional arguments are ignored
+func (r *ConfigLoader) WithFlags(args []string) *ConfigLoader {
+	r.flagArgs = append(r.flagArgs, args)
+	return r
+}
+
+// Load and merge all of the sources into a new Config
+func (r *ConfigLoader) Load() (*Config, error) {
+	merged := data.NewObject()
+	sources := make(map[string]string)
+
+	for _, defaults := range r.defaults {
+		if (nil == defaults) || ! defaults.IsObject() {
+			return nil, fmt.Errorf("ConfigLoader.Load(): Defaults must be an object")
+		}
+		overlayConfigValue(merged, []string{}, defaults, CONFIG_SOURCE_DEFAULTS, sources)
+	}
+
+	for _, file := range r.files {
+		dataValue, err := r.loadFile(file)
+		if nil != err { return nil, err }
+		if nil == dataValue { continue }
+		source := fmt.Sprintf("%s:%s", CONFIG_SOURCE_FILE, file.path)
+		overlayConfigValue(merged, []string{}, dataValue, source, sources)
+	}
+
+	for _, prefix := range r.envPrefixes {
+		for _, env := range os.Environ() {
+			name, value, found := strings.Cut(env, "=")
+			if ! found || ! strings.HasPrefix(name, prefix) { continue }
+			path := envNameToPath(strings.TrimPrefix(name, prefix))
+			if nil == path { continue }
+			source := fmt.Sprintf("%s:%s", CONFIG_SOURCE_ENV, name)
+			overlayConfigValue(merged, path, inferConfigValue(value), source, sources)
+		}
+	}
+
+	for _, args := range r.flagArgs {
+		for _, flag := range parseConfigFlags(args) {
+			source := fmt.Sprintf("%s:--%s", CONFIG_SOURCE_FLAG, flag.name)
+			path := strings.Split(flag.name, ".")
+			overlayConfigValue(merged, path, inferConfigValue(flag.value), source, sources)
+		}
+	}
+
+	config := FromDataValue(merged)
+	config.sources = sources
+	return config, nil
+}
+
+// -------------------------------------------------------------------------------------------------
+// ConfigLoader Implementation
+// -------------------------------------------------------------------------------------------------
+
+// Load the file into a DataValue; nil without error for a missing optional file
+func (r *ConfigLoader) loadFile(file configLoaderFile) (*data.DataValue, error) {
+	if _, err := os.Stat(file.path); nil != err {
+		if file.isOptional && os.IsNotExist(err) { return nil, nil }
+		return nil, fmt.Errorf("ConfigLoader.Load(): Error reading config file: %s", err.Error())
+	}
+	var dataValue *data.DataValue
+	var err error
+	switch strings.ToLower(filepath.Ext(file.path)) {
+		case ".json": dataValue, err = json.NewJsonFromFile(file.path).ToDataValue()
+		case ".yaml", ".yml": dataValue, err = yaml.NewYamlFromFile(file.path).ToDataValue()
+		default:
+			return nil, fmt.Errorf("ConfigLoader.Load(): Unsupported config file type: '%s'", file.path)
+	}
+	if nil != err { return nil, fmt.Errorf("ConfigLoader.Load(): (file='%s'): %s", file.path, err.Error()) }
+	// An empty file has nothing to say
+	if dataValue.IsNull() { return nil, nil }
+	if ! dataValue.IsObject() {
+		return nil, fmt.Errorf("ConfigLoader.Load(): (file='%s'): Config must be an object", file.path)
+	}
+	return dataValue, nil
+}
+
+// Convert an environment or flag value to the DataType that it looks like; empty is an empty string
+func inferConfigValue(value string) *data.DataValue {
+	if 0 == len(value) { return data.NewString("") }
+	return data.InferDataValue(value)
+}
+
+// APP_DB__MAX_CONNS (less the prefix) => ["db", "max_conns"], or nil if the name has empty parts
+func envNameToPath(name string) []string {
+	path := strings.Split(strings.ToLower(name), "__")
+	for _, part := range path {
+		if 0 == len(part) { return nil }
+	}
+	return path
+}
+
+type configFlag struct {
+	name	string
+	value	string
+}
+
+// Pick out the --name=value and --name (=true) flags; single dash works too. Everything else is a
+// positional argument, including a negative number, such as "-5"
+func parseConfigFlags(args []string) []configFlag {
+	flags := make([]configFlag, 0)
+	for _, arg := range args {
+		if "--" == arg { break }
+		if ! strings.HasPrefix(arg, "-") || ("-" == arg) { continue }
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if (0 == len(name)) || isNumericFlagName(name) { continue }
+		if ! hasValue { value = "true" }
+		flags = append(flags, configFlag{ name: name, value: value })
+	}
+	return flags
+}
+
+// "-5" or "-2.5" is a number, not a flag
+func isNumericFlagName(name string) bool {
+	_, err := strconv.ParseFloat(name, 64)
+	return nil == err
+}
+
+// Set value at path within target, recording source for each value; objects are merged property
+// by property, anything else replaces whatever was at path before
+func overlayConfigValue(target *data.DataValue, path []string, value *data.DataValue, source string, sources map[string]string) {
+	if value.IsObject() && (len(value.GetObjectProperties()) > 0) {
+		for _, property := range value.GetObjectProperties() {
+			childPath := append(append([]string{}, path...), property)
+			overlayConfigValue(target, childPath, value.GetObjectProperty(property), source, sources) // <- BEWARE: recursion!
+		}
+		return
+	}
+	if 0 == len(path) { return }
+
+	// Walk down to the parent of the value, making objects where there are none
+	parent := target
+	for index, property := range path[:len(path) - 1] {
+		child := parent.GetObjectProperty(property)
+		if (nil == child) || ! child.IsObject() {
+			delete(sources, strings.Join(path[:index + 1], "."))
+			child = data.NewObject()
+			parent.SetObjectProperty(property, child)
+		}
+		parent = child
+	}
+
+	selector := strings.Join(path, ".")
+	for existing := range sources {
+		if strings.HasPrefix(existing, selector + ".") { delete(sources, existing) }
+	}
+	property := path[len(path) - 1]
+	// An empty object only fills a gap, it does not wipe out what is already there
+	if value.IsObject() && parent.HasObjectProperty(property) && parent.GetObjectProperty(property).IsObject() { return }
+	parent.SetObjectProperty(property, value.Clone())
+	sources[selector] = source
+}
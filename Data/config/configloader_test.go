@@ -0,0 +1,219 @@
+package config
+
+import(
+	"os"
+	"testing"
+	"path/filepath"
+
+	"github.com/DigiStratum/GoLib/Data"
+
+	. "github.com/DigiStratum/GoLib/Testing"
+)
+
+func writeConfigLoaderTestFile(name, content string, t *testing.T) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0600); nil != err { t.Fatal(err) }
+	return path
+}
+
+func TestThat_ConfigLoader_NewConfigLoader_ReturnsInstance(t *testing.T) {
+	// Setup
+	var sut ConfigLoaderIfc = NewConfigLoader() // <- ensures that we satisfy our interface
+
+	// Verify
+	ExpectNonNil(sut, t)
+}
+
+func TestThat_ConfigLoader_Load_ReturnsEmptyConfig_WithoutSources(t *testing.T) {
+	// Setup
+	sut := NewConfigLoader()
+
+	// Test
+	actual, err := sut.Load()
+
+	// Verify
+	if ! ExpectNoError(err, t) { return }
+	ExpectTrue(actual.IsObject(), t)
+	ExpectInt(0, len(actual.GetObjectProperties()), t)
+}
+
+func TestThat_ConfigLoader_Load_ReturnsError_ForBadFiles(t *testing.T) {
+	// Setup
+	paths := []string{
+		"/this/file/does/not/exist.json",
+		writeConfigLoaderTestFile("config.ini", "a=1", t),
+		writeConfigLoaderTestFile("config.json", "{ broken", t),
+		writeConfigLoaderTestFile("config.yaml", "- not\n- an object", t),
+	}
+
+	for _, path := range paths {
+		sut := NewConfigLoader().WithFile(path)
+
+		// Test
+		actual, err := sut.Load()
+
+		// Verify
+		ExpectNil(actual, t)
+		ExpectError(err, t)
+	}
+}
+
+func TestThat_ConfigLoader_Load_ReturnsError_ForNonObjectDefaults(t *testing.T) {
+	// Setup
+	sut := NewConfigLoader().WithDefaults(data.NewString("nope"))
+
+	// Test
+	actual, err := sut.Load()
+
+	// Verify
+	ExpectNil(actual, t)
+	ExpectError(err, t)
+}
+
+func TestThat_ConfigLoader_Load_SkipsMissingOptionalFile(t *testing.T) {
+	// Setup
+	sut := NewConfigLoader().
+		WithDefaults(data.NewObject().SetObjectProperty("a", data.NewInteger(1))).
+		WithOptionalFile("/this/file/does/not/exist.yaml")
+
+	// Test
+	actual, err := sut.Load()
+
+	// Verify
+	if ! ExpectNoError(err, t) { return }
+	ExpectInt(1, int(actual.Select("a").GetInteger()), t)
+}
+
+func TestThat_ConfigLoader_Load_StacksSourcesInPrecedenceOrder(t *testing.T) {
+	// Setup
+	jsonPath := writeConfigLoaderTestFile("base.json", `{"db":{"host":"json-host","user":"json-user"},"tags":["a","b"]}`, t)
+	yamlPath := writeConfigLoaderTestFile("override.yml", "db:\n  user: yaml-user\n  pass: yaml-pass\ntags: [c]\n", t)
+	t.Setenv("CONFIGLOADERTEST_DB__PASS", "env-pass")
+	t.Setenv("CONFIGLOADERTEST_DB__MAX_CONNS", "12")
+	t.Setenv("CONFIGLOADERTEST_", "ignored")
+	defaults := data.NewObject().
+		SetObjectProperty("db", data.NewObject().
+			SetObjectProperty("host", data.NewString("localhost")).
+			SetObjectProperty("port", data.NewInteger(3306))).
+		SetObjectProperty("verbose", data.NewBoolean(false))
+
+	// Order of the calls must not matter
+	sut := NewConfigLoader().
+		WithFlags([]string{ "serve", "--db.host=flag-host", "--verbose", "--timeout=2.5", "--", "--ignored" }).
+		WithEnv("CONFIGLOADERTEST").
+		WithFile(jsonPath).
+		WithFile(yamlPath).
+		WithDefaults(defaults)
+
+	// Test
+	actual, err := sut.Load()
+
+	// Verify
+	if ! ExpectNoError(err, t) { return }
+	ExpectString("flag-host", actual.Select("db.host").GetString(), t)
+	ExpectString("flag:--db.host", actual.GetSource("db.host"), t)
+	ExpectInt(3306, int(actual.Select("db.port").GetInteger()), t)
+	ExpectString(CONFIG_SOURCE_DEFAULTS, actual.GetSource("db.port"), t)
+	ExpectString("yaml-user", actual.Select("db.user").GetString(), t)
+	ExpectString("file:" + yamlPath, actual.GetSource("db.user"), t)
+	ExpectString("env-pass", actual.Select("db.pass").GetString(), t)
+	ExpectString("env:CONFIGLOADERTEST_DB__PASS", actual.GetSource("db.pass"), t)
+	ExpectInt(12, int(actual.Select("db.max_conns").GetInteger()), t)
+	ExpectString(`["c"]`, actual.Select("tags").ToJson(), t)
+	ExpectString("file:" + yamlPath, actual.GetSource("tags[0]"), t)
+	ExpectTrue(actual.Select("verbose").GetBoolean(), t)
+	ExpectTrue(2.5 == actual.Select("timeout").GetFloat(), t)
+	ExpectFalse(actual.HasObjectProperty("ignored"), t)
+	ExpectFalse(actual.HasObjectProperty(""), t)
+	ExpectString("", actual.GetSource("db"), t)
+}
+
+func TestThat_ConfigLoader_Load_IgnoresPositionalArgs_AfterBareFlag(t *testing.T) {
+	// Setup
+	sut := NewConfigLoader().WithFlags([]string{ "--verbose", "file.txt", "--offset", "-5", "--limit=-5" })
+
+	// Test
+	actual, err := sut.Load()
+
+	// Verify
+	if ! ExpectNoError(err, t) { return }
+	ExpectTrue(actual.Select("verbose").GetBoolean(), t)
+	ExpectTrue(actual.Select("offset").GetBoolean(), t)
+	ExpectInt(-5, int(actual.Select("limit").GetInteger()), t)
+	ExpectFalse(actual.HasObjectProperty("5"), t)
+	ExpectFalse(actual.HasObjectProperty("file.txt"), t)
+}
+
+func TestThat_ConfigLoader_Load_SetsEmptyStrings_ForEmptyEnvAndFlagValues(t *testing.T) {
+	// Setup
+	t.Setenv("CONFIGLOADEREMPTYTEST_NAME", "")
+	t.Setenv("CONFIGLOADEREMPTYTEST_NOTHING", "null")
+	defaults := data.NewObject().
+		SetObjectProperty("name", data.NewString("default-name")).
+		SetObjectProperty("label", data.NewString("default-label"))
+	sut := NewConfigLoader().
+		WithDefaults(defaults).
+		WithEnv("CONFIGLOADEREMPTYTEST_").
+		WithFlags([]string{ "--label=" })
+
+	// Test
+	actual, err := sut.Load()
+
+	// Verify
+	if ! ExpectNoError(err, t) { return }
+	ExpectTrue(actual.Select("name").IsString(), t)
+	ExpectString("", actual.Select("name").GetString(), t)
+	ExpectTrue(actual.Select("label").IsString(), t)
+	ExpectString("", actual.Select("label").GetString(), t)
+	ExpectTrue(actual.Select("nothing").IsNull(), t)
+}
+
+func TestThat_ConfigLoader_Load_LeafReplacesObject_AndForgetsItsSources(t *testing.T) {
+	// Setup
+	defaults := data.NewObject().SetObjectProperty("db", data.NewObject().
+		SetObjectProperty("host", data.NewString("localhost")))
+	sut := NewConfigLoader().
+		WithDefaults(defaults).
+		WithFlags([]string{ "--db=off" })
+
+	// Test
+	actual, err := sut.Load()
+
+	// Verify
+	if ! ExpectNoError(err, t) { return }
+	ExpectString("off", actual.Select("db").GetString(), t)
+	ExpectString("flag:--db", actual.GetSource("db"), t)
+	ExpectString("flag:--db", actual.GetSource("db.host"), t)
+}
+
+func TestThat_Config_CloneConfig_KeepsSources(t *testing.T) {
+	// Setup
+	loaded, err := NewConfigLoader().
+		WithDefaults(data.NewObject().SetObjectProperty("a", data.NewInteger(1))).
+		Load()
+	if ! ExpectNoError(err, t) { return }
+
+	// Test
+	actual := loaded.CloneConfig()
+
+	// Verify
+	ExpectString(CONFIG_SOURCE_DEFAULTS, actual.GetSource("a"), t)
+}
+
+func TestThat_Config_MergeConfig_TakesSourcesOfMergedProperties(t *testing.T) {
+	// Setup
+	sut, _ := NewConfigLoader().
+		WithDefaults(data.NewObject().
+			SetObjectProperty("a", data.NewObject().SetObjectProperty("x", data.NewInteger(1))).
+			SetObjectProperty("b", data.NewInteger(2))).
+		Load()
+	other, _ := NewConfigLoader().WithFlags([]string{ "--a=3" }).Load()
+
+	// Test
+	sut.MergeConfig(other)
+
+	// Verify
+	ExpectString("flag:--a", sut.GetSource("a"), t)
+	ExpectString("flag:--a", sut.GetSource("a.x"), t)
+	ExpectString(CONFIG_SOURCE_DEFAULTS, sut.GetSource("b"), t)
+}
@@ -0,0 +1,129 @@
+package config
+
+/*
+
+Log levels by hierarchical name (see Logger/loglevelregistry.go) from config, so that the verbosity of
+one subsystem may be changed without a code change; with ReloadableConfig, without a restart either:
+
+	log:
+	  levels:
+	    "": INFO
+	    db.mysql.pool: DEBUG
+	    cache: WARN
+
+Nested objects are joined with dots, so `db: { mysql: { pool: DEBUG } }` is "db.mysql.pool" as well,
+and a string in ParseLogLevels() form ("db.mysql.pool=DEBUG, cache=WARN") works too, e.g. from an
+environment variable. For a Configurable:
+
+	r.DeclareConfigItems(config.NewLogLevelsConfigItem("log.levels"))
+
+To keep the levels up to date as a ReloadableConfig reloads:
+
+	config.WatchLogLevels(reloadable, "log.levels", nil)
+
+Either way the levels replace ALL of those in the LogLevelRegistry (nil for the GetLogger()
+singleton's), so a name removed from config goes back to its ancestor's level.
+
+*/
+
+import (
+	"fmt"
+
+	"github.com/DigiStratum/GoLib/Data"
+	"github.com/DigiStratum/GoLib/Logger"
+)
+
+// -------------------------------------------------------------------------------------------------
+// Factory Functions
+// -------------------------------------------------------------------------------------------------
+
+// A ConfigItem at selector whose log levels are captured into the GetLogger() singleton's registry
+func NewLogLevelsConfigItem(selector string) *configItem {
+	example := data.NewObject().
+		SetObjectProperty("db.mysql.pool", data.NewString("DEBUG")).
+		SetObjectProperty("cache", data.NewString("WARN"))
+	return NewConfigItem(selector).
+		SetType(CONFIG_ITEM_TYPE_ANY).
+		SetDescription("Log levels by hierarchical logger name").
+		SetExample(example).
+		ValidateWith(ValidateLogLevels).
+		CaptureWith(CaptureLogLevels(nil))
+}
+
+// -------------------------------------------------------------------------------------------------
+// Log Level Funcs
+// -------------------------------------------------------------------------------------------------
+
+// Replace the levels in registry (nil for the GetLogger() singleton's) with those captured
+func CaptureLogLevels(registry *logger.LogLevelRegistry) CaptureFunc {
+	return func (dataValue data.DataValueIfc) error {
+		levels, err := CoerceLogLevels(dataValue)
+		if nil != err { return err }
+		if nil == registry { registry = logger.GetLogLevelRegistry() }
+		registry.ReplaceLogLevels(levels)
+		return nil
+	}
+}
+
+func ValidateLogLevels(dataValue data.DataValueIfc) error {
+	_, err := CoerceLogLevels(dataValue)
+	return err
+}
+
+// Objects of names to levels (nested objects joined with dots), ParseLogLevels() strings, or null
+func CoerceLogLevels(dataValue data.DataValueIfc) (map[string]logger.LogLevel, error) {
+	levels := make(map[string]logger.LogLevel)
+	if nil == dataValue { return levels, nil }
+	switch dataValue.GetType() {
+		case data.DATA_TYPE_NULL: return levels, nil
+		case data.DATA_TYPE_STRING: return logger.ParseLogLevels(dataValue.GetString())
+		case data.DATA_TYPE_OBJECT:
+			if err := coerceLogLevelsObject("", dataValue, levels); nil != err { return nil, err }
+			return levels, nil
+	}
+	return nil, unexpectedValueError("log levels", dataValue)
+}
+
+// Keep the levels in registry (nil for the GetLogger() singleton's) up to date with those at selector
+// in reloadable; returns an id for Unsubscribe()
+func WatchLogLevels(reloadable *ReloadableConfig, selector string, registry *logger.LogLevelRegistry) int {
+	if nil == registry { registry = logger.GetLogLevelRegistry() }
+	apply := func () {
+		config := reloadable.GetConfig()
+		if nil == config { return }
+		// No levels at selector (any more) is no levels at all
+		var dataValue data.DataValueIfc
+		if selected := config.Select(selector); nil != selected { dataValue = selected }
+		levels, err := CoerceLogLevels(dataValue)
+		if nil != err {
+			logger.GetLogger().Error("WatchLogLevels(): Ignoring log levels at '%s': %s", selector, err.Error())
+			return
+		}
+		registry.ReplaceLogLevels(levels)
+	}
+	subscriptionId := reloadable.Subscribe(selector, func (changes []ConfigChange) { apply() })
+	apply()
+	return subscriptionId
+}
+
+// -------------------------------------------------------------------------------------------------
+// Log Level Implementation
+// -------------------------------------------------------------------------------------------------
+
+func coerceLogLevelsObject(prefix string, dataValue data.DataValueIfc, levels map[string]logger.LogLevel) error {
+	for _, property := range dataValue.GetObjectProperties() {
+		name := property
+		if len(prefix) > 0 { name = prefix + "." + property }
+		value := dataValue.GetObjectProperty(property)
+		if value.IsObject() {
+			if err := coerceLogLevelsObject(name, value, levels); nil != err { return err }
+			continue
+		}
+		levelStr, err := CoerceString(value)
+		if nil != err { return fmt.Errorf("Log level for '%s': %s", name, err.Error()) }
+		level, err := logger.StringToLogLevel(levelStr)
+		if nil != err { return fmt.Errorf("Log level for '%s': %s", name, err.Error()) }
+		levels[logger.NormalizeLogName(name)] = *level
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package config
+
+import(
+	"testing"
+
+	"github.com/DigiStratum/GoLib/Data"
+	"github.com/DigiStratum/GoLib/Logger"
+
+	. "github.com/DigiStratum/GoLib/Testing"
+)
+
+func TestThat_CoerceLogLevels_FlattensNestedObjects(t *testing.T) {
+	// Setup
+	dataValue := data.NewObject().
+		SetObjectProperty("db", data.NewObject().
+			SetObjectProperty("mysql.pool", data.NewString("debug")),
+		).
+		SetObjectProperty("Cache", data.NewString("WARN"))
+
+	// Test
+	levels, err := CoerceLogLevels(dataValue)
+
+	// Verify
+	if ExpectNoError(err, t) && ExpectInt(2, len(levels), t) {
+		ExpectTrue(logger.DEBUG == levels["db.mysql.pool"], t)
+		ExpectTrue(logger.WARN == levels["cache"], t)
+	}
+}
+
+func TestThat_CoerceLogLevels_ParsesStrings_AndAcceptsNull(t *testing.T) {
+	// Test
+	levels, err := CoerceLogLevels(data.NewString("db=ERROR"))
+	nullLevels, nullErr := CoerceLogLevels(data.NewNull())
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectTrue(logger.ERROR == levels["db"], t)
+	ExpectNoError(nullErr, t)
+	ExpectInt(0, len(nullLevels), t)
+}
+
+func TestThat_ValidateLogLevels_ReturnsError_ForBadLevelsAndTypes(t *testing.T) {
+	// Test
+	levelErr := ValidateLogLevels(data.NewObject().SetObjectProperty("db", data.NewString("LOUD")))
+	typeErr := ValidateLogLevels(data.NewInteger(3))
+
+	// Verify
+	ExpectError(levelErr, t)
+	ExpectError(typeErr, t)
+}
+
+func TestThat_CaptureLogLevels_ReplacesRegistryLevels(t *testing.T) {
+	// Setup
+	registry := logger.NewLogLevelRegistry().SetLogLevel("old", logger.DEBUG)
+	sut := NewLogLevelsConfigItem("log.levels").CaptureWith(CaptureLogLevels(registry))
+
+	// Test
+	err := sut.Capture(data.NewString("db.mysql.pool=DEBUG"))
+
+	// Verify
+	ExpectNoError(err, t)
+	names := registry.GetNames()
+	if ExpectInt(1, len(names), t) { ExpectString("db.mysql.pool", names[0], t) }
+}
+
+func TestThat_WatchLogLevels_KeepsRegistryUpToDate(t *testing.T) {
+	// Setup
+	path := writeConfigLoaderTestFile("loglevels.yaml", "log:\n  levels:\n    db: DEBUG\n", t)
+	reloadable := NewReloadableConfig(NewConfigLoader().WithFile(path)).
+		DeclareConfigItems(NewLogLevelsConfigItem("log.levels"))
+	if ! ExpectNoError(reloadable.Reload(), t) { return }
+	registry := logger.NewLogLevelRegistry()
+
+	// Test
+	WatchLogLevels(reloadable, "log.levels", registry)
+	initial, _ := registry.GetLogLevel("db.mysql")
+	rewriteReloadableConfigTestFile(path, "log:\n  levels:\n    cache: WARN\n", 1, t)
+	_, err := reloadable.CheckForChanges()
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectTrue(logger.DEBUG == initial, t)
+	names := registry.GetNames()
+	if ExpectInt(1, len(names), t) { ExpectString("cache", names[0], t) }
+}
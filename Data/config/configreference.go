@@ -0,0 +1,267 @@
+package config
+
+/*
+
+ConfigReference collects the ConfigItems declared by a set of Configurables and renders them as
+reference documentation: Markdown for humans, and JSON Schema for machines, e.g. to validate config
+files before deployment.
+
+Each Configurable is added at the selector where its config lives within the whole structured config
+document, so its items appear there in the reference:
+
+	reference := config.NewConfigReference().
+		SetTitle("MyApp Configuration").
+		AddConfigurable("cache", cache.NewCache()).
+		AddConfigurable("http.client", http.NewHttpClient())
+	markdown := reference.ToMarkdown()
+	schema, err := reference.ToJsonSchema()
+
+Required items are required by the schema, along with every object on the way to them. Objects in
+the schema are left open (additional properties are allowed) since some Configurables accept more
+than they declare. The typed capture funcs also accept the string forms of their types for legacy
+config data; the schema does not, it describes the structured config document we want.
+
+TODO:
+ * Support array index selectors ("servers[0].host"); for now each dotted part is a property name
+ * Render examples/defaults of object and array values as YAML as well as JSON?
+
+*/
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	gojson "encoding/json"
+
+	"github.com/DigiStratum/GoLib/Data"
+)
+
+const JSON_SCHEMA_DIALECT = "https://json-schema.org/draft/2020-12/schema"
+const DEFAULT_CONFIG_REFERENCE_TITLE = "Configuration Reference"
+
+// Go time.ParseDuration() strings, or a number of seconds as a string
+const DURATION_PATTERN = `^(-?([0-9]+(\.[0-9]*)?|\.[0-9]+)(ns|us|µs|ms|s|m|h))+$|^-?[0-9]+$`
+
+type ConfigReferenceIfc interface {
+	SetTitle(title string) *ConfigReference
+	AddConfigurable(selector string, configurable ConfigurableIfc) *ConfigReference
+	AddConfigItems(selector string, configItems ...ConfigItemIfc) *ConfigReference
+	GetSelectors() []string
+	ToMarkdown() string
+	ToJsonSchema() (string, error)
+}
+
+type ConfigReference struct {
+	title		string
+	sections	[]configReferenceSection
+}
+
+// The ConfigItems of one Configurable and where they live in the config document
+type configReferenceSection struct {
+	selector	string
+	configItems	[]ConfigItemIfc
+}
+
+// -------------------------------------------------------------------------------------------------
+// Factory Functions
+// -------------------------------------------------------------------------------------------------
+
+func NewConfigReference() *ConfigReference {
+	return &ConfigReference{
+		title:		DEFAULT_CONFIG_REFERENCE_TITLE,
+		sections:	make([]configReferenceSection, 0),
+	}
+}
+
+// -------------------------------------------------------------------------------------------------
+// ConfigReferenceIfc
+// -------------------------------------------------------------------------------------------------
+
+func (r *ConfigReference) SetTitle(title string) *ConfigReference {
+	r.title = title
+	return r
+}
+
+// Add the declared ConfigItems of configurable, whose config lives at selector ("" for the top)
+func (r *ConfigReference) AddConfigurable(selector string, configurable ConfigurableIfc) *ConfigReference {
+	if nil == configurable { return r }
+	return r.AddConfigItems(selector, configurable.GetConfigItems()...)
+}
+
+// Add ConfigItems whose selectors are relative to selector ("" for the top)
+func (r *ConfigReference) AddConfigItems(selector string, configItems ...ConfigItemIfc) *ConfigReference {
+	sorted := append([]ConfigItemIfc{}, configItems...)
+	sort.SliceStable(sorted, func (i, j int) bool { return sorted[i].GetSelector() < sorted[j].GetSelector() })
+	r.sections = append(r.sections, configReferenceSection{ selector: selector, configItems: sorted })
+	return r
+}
+
+// Get the full selector of every ConfigItem, sorted, without duplicates
+func (r *ConfigReference) GetSelectors() []string {
+	unique := make(map[string]bool)
+	for _, section := range r.sections {
+		for _, configItem := range section.configItems { unique[section.getSelector(configItem)] = true }
+	}
+	selectors := make([]string, 0, len(unique))
+	for selector := range unique { selectors = append(selectors, selector) }
+	sort.Strings(selectors)
+	return selectors
+}
+
+// Render a Markdown document with a table of ConfigItems for each section
+func (r *ConfigReference) ToMarkdown() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("# %s\n", r.title))
+	for _, section := range r.sections {
+		heading := section.selector
+		if 0 == len(heading) { heading = "(top level)" }
+		sb.WriteString(fmt.Sprintf("\n## %s\n\n", escapeMarkdown(heading)))
+		if 0 == len(section.configItems) {
+			sb.WriteString("No configuration.\n")
+			continue
+		}
+		sb.WriteString("| Selector | Type | Required | Default | Example | Description |\n")
+		sb.WriteString("|---|---|---|---|---|---|\n")
+		for _, configItem := range section.configItems {
+			required := "no"
+			if configItem.IsRequired() { required = "yes" }
+			sb.WriteString(fmt.Sprintf(
+				"| `%s` | %s | %s | %s | %s | %s |\n",
+				section.getSelector(configItem),
+				configItem.GetType().ToString(),
+				required,
+				formatMarkdownValue(configItem.GetDefault()),
+				formatMarkdownValue(configItem.GetExample()),
+				escapeMarkdown(configItem.GetDescription()),
+			))
+		}
+	}
+	return sb.String()
+}
+
+// Render a JSON Schema (draft 2020-12) for a config document holding all of our ConfigItems
+func (r *ConfigReference) ToJsonSchema() (string, error) {
+	root := newSchemaObject()
+	root["$schema"] = JSON_SCHEMA_DIALECT
+	root["title"] = r.title
+	for _, section := range r.sections {
+		for _, configItem := range section.configItems {
+			if err := addSchemaItem(root, section.getSelector(configItem), configItem); nil != err {
+				return "", fmt.Errorf("ConfigReference.ToJsonSchema(): %s", err.Error())
+			}
+		}
+	}
+	schema, err := gojson.MarshalIndent(root, "", "\t")
+	if nil != err { return "", fmt.Errorf("ConfigReference.ToJsonSchema(): %s", err.Error()) }
+	return string(schema), nil
+}
+
+// -------------------------------------------------------------------------------------------------
+// ConfigReference Implementation
+// -------------------------------------------------------------------------------------------------
+
+func (r configReferenceSection) getSelector(configItem ConfigItemIfc) string {
+	if 0 == len(r.selector) { return configItem.GetSelector() }
+	if 0 == len(configItem.GetSelector()) { return r.selector }
+	return r.selector + "." + configItem.GetSelector()
+}
+
+func newSchemaObject() map[string]interface{} {
+	return map[string]interface{}{
+		"type":		"object",
+		"properties":	map[string]interface{}{},
+	}
+}
+
+// Add the schema for configItem at selector, adding (required) objects along the way as needed
+func addSchemaItem(root map[string]interface{}, selector string, configItem ConfigItemIfc) error {
+	if 0 == len(selector) { return fmt.Errorf("ConfigItem with empty selector") }
+	parts := strings.Split(selector, ".")
+	node := root
+	for index, part := range parts {
+		if 0 == len(part) { return fmt.Errorf("Unusable selector '%s'", selector) }
+		properties := node["properties"].(map[string]interface{})
+		child, ok := properties[part].(map[string]interface{})
+		if ! ok {
+			child = map[string]interface{}{}
+			properties[part] = child
+		}
+		if configItem.IsRequired() { addSchemaRequired(node, part) }
+		if index < len(parts) - 1 {
+			// Anything beneath child makes it an object, even if a scalar item was declared there
+			child["type"] = "object"
+			delete(child, "pattern")
+			if _, hasProperties := child["properties"]; ! hasProperties { child["properties"] = map[string]interface{}{} }
+		}
+		node = child
+	}
+	return describeSchemaItem(node, configItem)
+}
+
+func addSchemaRequired(node map[string]interface{}, property string) {
+	required, _ := node["required"].([]string)
+	for _, existing := range required {
+		if existing == property { return }
+	}
+	required = append(required, property)
+	sort.Strings(required)
+	node["required"] = required
+}
+
+func describeSchemaItem(node map[string]interface{}, configItem ConfigItemIfc) error {
+	itemType := configItem.GetType()
+	// Other items beneath this one make it an object, whatever it says
+	if _, hasProperties := node["properties"]; hasProperties { itemType = CONFIG_ITEM_TYPE_OBJECT }
+	switch itemType {
+		case CONFIG_ITEM_TYPE_STRING: node["type"] = "string"
+		case CONFIG_ITEM_TYPE_INTEGER: node["type"] = "integer"
+		case CONFIG_ITEM_TYPE_FLOAT: node["type"] = "number"
+		case CONFIG_ITEM_TYPE_BOOLEAN: node["type"] = "boolean"
+		case CONFIG_ITEM_TYPE_OBJECT:
+			node["type"] = "object"
+			if _, hasProperties := node["properties"]; ! hasProperties { node["properties"] = map[string]interface{}{} }
+		case CONFIG_ITEM_TYPE_ARRAY: node["type"] = "array"
+		case CONFIG_ITEM_TYPE_DURATION:
+			// A string for time.ParseDuration(), or a number of seconds
+			node["type"] = []string{ "string", "number" }
+			node["pattern"] = DURATION_PATTERN
+	}
+	if description := configItem.GetDescription(); len(description) > 0 { node["description"] = description }
+	if defaultValue := configItem.GetDefault(); nil != defaultValue {
+		value, err := toSchemaValue(defaultValue)
+		if nil != err { return fmt.Errorf("Default for '%s': %s", configItem.GetSelector(), err.Error()) }
+		node["default"] = value
+	}
+	if example := configItem.GetExample(); nil != example {
+		value, err := toSchemaValue(example)
+		if nil != err { return fmt.Errorf("Example for '%s': %s", configItem.GetSelector(), err.Error()) }
+		node["examples"] = []interface{}{ value }
+	}
+	return nil
+}
+
+// Convert a DataValue to plain Go values that encoding/json will render with sorted object keys
+func toSchemaValue(dataValue data.DataValueIfc) (interface{}, error) {
+	var value interface{}
+	if err := gojson.Unmarshal([]byte(dataValue.ToJson()), &value); nil != err { return nil, err }
+	return value, nil
+}
+
+func formatMarkdownValue(dataValue data.DataValueIfc) string {
+	if nil == dataValue { return "" }
+	value, err := toSchemaValue(dataValue)
+	if nil != err { return "" }
+	// Sorted keys, courtesy of encoding/json, and no HTML escaping of <, > or &
+	var sb strings.Builder
+	encoder := gojson.NewEncoder(&sb)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); nil != err { return "" }
+	formatted := strings.ReplaceAll(strings.TrimSpace(sb.String()), "`", "'")
+	return "`" + strings.ReplaceAll(formatted, "|", "\\|") + "`"
+}
+
+// Keep text from breaking out of its table cell
+func escapeMarkdown(text string) string {
+	text = strings.ReplaceAll(text, "|", "\\|")
+	return strings.Join(strings.Fields(text), " ")
+}
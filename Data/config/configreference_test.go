@@ -0,0 +1,148 @@
+package config
+
+import(
+	"strings"
+	"testing"
+	gojson "encoding/json"
+
+	"github.com/DigiStratum/GoLib/Data"
+
+	. "github.com/DigiStratum/GoLib/Testing"
+)
+
+func newConfigReferenceTestConfigurables() (*Configurable, *Configurable) {
+	db := NewConfigurable(
+		NewConfigItem("host").
+			SetRequired().
+			SetType(CONFIG_ITEM_TYPE_STRING).
+			SetDescription("Database host | port").
+			SetExample(data.NewString("db.example.com:3306")),
+		NewConfigItem("timeout").
+			SetType(CONFIG_ITEM_TYPE_DURATION).
+			SetDefault(data.NewString("5s")),
+		NewConfigItem("pool.max").
+			SetDefault(data.NewInteger(10)),
+	)
+	log := NewConfigurable(
+		NewConfigItem("level").
+			SetDescription("Minimum\nlog level").
+			SetDefault(data.NewString("INFO")),
+	)
+	return db, log
+}
+
+func TestThat_ConfigReference_GetSelectors_Returns_full_selectors(t *testing.T) {
+	// Setup
+	db, log := newConfigReferenceTestConfigurables()
+	var sut ConfigReferenceIfc = NewConfigReference() // <- ensures that we satisfy our interface
+
+	// Test
+	sut.AddConfigurable("app.db", db).AddConfigurable("", log).AddConfigurable("nil", nil)
+
+	// Verify
+	ExpectString("app.db.host,app.db.pool.max,app.db.timeout,level", strings.Join(sut.GetSelectors(), ","), t)
+}
+
+func TestThat_ConfigReference_ToMarkdown_Renders_a_table_per_configurable(t *testing.T) {
+	// Setup
+	db, log := newConfigReferenceTestConfigurables()
+	sut := NewConfigReference().
+		SetTitle("MyApp").
+		AddConfigurable("db", db).
+		AddConfigurable("", log).
+		AddConfigItems("empty")
+
+	// Test
+	actual := sut.ToMarkdown()
+
+	// Verify
+	expected := "# MyApp\n" +
+		"\n## db\n\n" +
+		"| Selector | Type | Required | Default | Example | Description |\n" +
+		"|---|---|---|---|---|---|\n" +
+		"| `db.host` | string | yes |  | `\"db.example.com:3306\"` | Database host \\| port |\n" +
+		"| `db.pool.max` | integer | no | `10` |  |  |\n" +
+		"| `db.timeout` | duration | no | `\"5s\"` |  |  |\n" +
+		"\n## (top level)\n\n" +
+		"| Selector | Type | Required | Default | Example | Description |\n" +
+		"|---|---|---|---|---|---|\n" +
+		"| `level` | string | no | `\"INFO\"` |  | Minimum log level |\n" +
+		"\n## empty\n\n" +
+		"No configuration.\n"
+	ExpectString(expected, actual, t)
+}
+
+func TestThat_ConfigReference_ToJsonSchema_Renders_nested_schema(t *testing.T) {
+	// Setup
+	db, log := newConfigReferenceTestConfigurables()
+	sut := NewConfigReference().
+		AddConfigurable("app.db", db).
+		AddConfigurable("", log)
+
+	// Test
+	actual, err := sut.ToJsonSchema()
+
+	// Verify
+	if ! ExpectNoError(err, t) { return }
+	var schema map[string]interface{}
+	if ! ExpectNoError(gojson.Unmarshal([]byte(actual), &schema), t) { return }
+	ExpectString(JSON_SCHEMA_DIALECT, schema["$schema"].(string), t)
+	ExpectString(DEFAULT_CONFIG_REFERENCE_TITLE, schema["title"].(string), t)
+	ExpectString("object", schema["type"].(string), t)
+
+	// Required all the way down to app.db.host
+	ExpectString(`["app"]`, jsonOf(schema["required"]), t)
+	app := schema["properties"].(map[string]interface{})["app"].(map[string]interface{})
+	ExpectString(`["db"]`, jsonOf(app["required"]), t)
+	dbSchema := app["properties"].(map[string]interface{})["db"].(map[string]interface{})
+	ExpectString(`["host"]`, jsonOf(dbSchema["required"]), t)
+
+	properties := dbSchema["properties"].(map[string]interface{})
+	ExpectString(
+		`{"description":"Database host | port","examples":["db.example.com:3306"],"type":"string"}`,
+		jsonOf(properties["host"]), t,
+	)
+	timeout := properties["timeout"].(map[string]interface{})
+	ExpectString(`["string","number"]`, jsonOf(timeout["type"]), t)
+	ExpectString(DURATION_PATTERN, timeout["pattern"].(string), t)
+	ExpectString(`"5s"`, jsonOf(timeout["default"]), t)
+	pool := properties["pool"].(map[string]interface{})
+	ExpectString(`{"default":10,"type":"integer"}`, jsonOf(pool["properties"].(map[string]interface{})["max"]), t)
+
+	level := schema["properties"].(map[string]interface{})["level"].(map[string]interface{})
+	ExpectString(`{"default":"INFO","description":"Minimum\nlog level","type":"string"}`, jsonOf(level), t)
+}
+
+func TestThat_ConfigReference_ToJsonSchema_Prefers_object_over_scalar_at_same_selector(t *testing.T) {
+	// Setup
+	sut := NewConfigReference().
+		AddConfigItems("", NewConfigItem("db").SetType(CONFIG_ITEM_TYPE_STRING)).
+		AddConfigItems("db", NewConfigItem("host").SetType(CONFIG_ITEM_TYPE_STRING))
+
+	// Test
+	actual, err := sut.ToJsonSchema()
+
+	// Verify
+	if ! ExpectNoError(err, t) { return }
+	var schema map[string]interface{}
+	if ! ExpectNoError(gojson.Unmarshal([]byte(actual), &schema), t) { return }
+	db := schema["properties"].(map[string]interface{})["db"].(map[string]interface{})
+	ExpectString("object", db["type"].(string), t)
+	ExpectNonNil(db["properties"].(map[string]interface{})["host"], t)
+}
+
+func TestThat_ConfigReference_ToJsonSchema_Returns_error_for_unusable_selector(t *testing.T) {
+	// Setup
+	sut := NewConfigReference().AddConfigItems("", NewConfigItem("db..host"))
+
+	// Test
+	_, err := sut.ToJsonSchema()
+
+	// Verify
+	ExpectError(err, t)
+}
+
+func jsonOf(value interface{}) string {
+	encoded, _ := gojson.Marshal(value)
+	return string(encoded)
+}
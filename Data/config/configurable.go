@@ -15,8 +15,10 @@ also have need of some or all of the config data).
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
+	"github.com/DigiStratum/GoLib/Data"
 	"github.com/DigiStratum/GoLib/Process/startable"
 )
 
@@ -27,6 +29,8 @@ type ConfigurableIfc interface {
 
 	// Our own interface
 	DeclareConfigItems(configItems ...ConfigItemIfc) *Configurable
+	GetConfigItems() []ConfigItemIfc
+	SetSecretProviderRegistry(registry SecretProviderRegistryIfc) *Configurable
 	Configure(config ConfigIfc) error
 	GetMissingConfigs() []string
 	HasMissingConfigs() bool
@@ -38,6 +42,7 @@ type Configurable struct {
 	*startable.Startable
 	config		ConfigIfc
 	declared	map[string]ConfigItemIfc	// Key is ConfigItem.name for fast lookups
+	secretProviders	SecretProviderRegistryIfc
 }
 
 // -------------------------------------------------------------------------------------------------
@@ -49,6 +54,7 @@ func NewConfigurable(configItems ...ConfigItemIfc) *Configurable {
 		Startable:	startable.NewStartable(),
 		declared:	make(map[string]ConfigItemIfc),
 		config:		NewConfig(),
+		secretProviders:	GetSecretProviderRegistry(),
 	}
 	return c.DeclareConfigItems(configItems...)
 }
@@ -62,14 +68,34 @@ func (r *Configurable) DeclareConfigItems(configItems ...ConfigItemIfc) *Configu
 	return r
 }
 
-// Just capture the provided configuration by default
+// Get our declared ConfigItems, sorted by selector
+func (r *Configurable) GetConfigItems() []ConfigItemIfc {
+	selectors := make([]string, 0, len(r.declared))
+	for selector := range r.declared { selectors = append(selectors, selector) }
+	sort.Strings(selectors)
+	configItems := make([]ConfigItemIfc, len(selectors))
+	for index, selector := range selectors { configItems[index] = r.declared[selector] }
+	return configItems
+}
+
+// Resolve %secret:provider/path% references with this registry instead of our singleton
+func (r *Configurable) SetSecretProviderRegistry(registry SecretProviderRegistryIfc) *Configurable {
+	r.secretProviders = registry
+	return r
+}
+
+// Just capture the provided configuration (a copy, with any secret references resolved) by default
 // Overrides should call this parent, and return error if this fails or for any validation problems
 func (r *Configurable) Configure(config ConfigIfc) error {
 	// Disallow Configure() after we've already Started
 	if r.Startable.IsStarted() { return fmt.Errorf("Already started; Config is immutable now") }
 	if nil == config {
 		r.config = NewConfig()
-	} else { r.config = config }
+		return nil
+	}
+	resolved, err := r.resolveSecrets(config)
+	if nil != err { return err }
+	r.config = resolved
 	return nil
 }
 
@@ -100,39 +126,91 @@ func (r *Configurable) GetConfig() ConfigIfc {
 
 func (r *Configurable) Start() error {
 	if r.Startable.IsStarted() { return nil }
-	// Make sure nothing required is missing
-	if missingConfigs := r.GetMissingConfigs(); len(missingConfigs) > 0 {
-		return fmt.Errorf(
-			"Missing required config(s): '%s'",
-			strings.Join(missingConfigs, "','"),
-		)
+	if err := r.captureConfigItems(); nil != err { return err }
+	return r.Startable.Start()
+}
+
+// -------------------------------------------------------------------------------------------------
+// Configurable Public Interface
+// -------------------------------------------------------------------------------------------------
+
+// Configure and capture config right away, for those which take their config at any time rather than
+// once before Start(); unlike Configure(), this may be called again (even once started) to change it.
+// Each value not in config is captured as its default, as for Start(), or else left as it was if it
+// has none. On error config is not kept; a validation failure captures nothing, but a capture failure
+// may follow others which succeeded
+func (r *Configurable) Reconfigure(config ConfigIfc) error {
+	if nil == config {
+		config = NewConfig()
+	} else {
+		resolved, err := r.resolveSecrets(config)
+		if nil != err { return err }
+		config = resolved
+	}
+	previous := r.config
+	r.config = config
+	if err := r.captureConfigItems(); nil != err {
+		r.config = previous
+		return err
 	}
+	return r.Startable.Start()
+}
 
-	// For all the declared Config Items...
-	for selector, configItem := range r.declared {
+// -------------------------------------------------------------------------------------------------
+// Configurable Implementation
+// -------------------------------------------------------------------------------------------------
 
-		// Get the value for this config selector
-		configDataValue := r.config.Select(selector)
-
-		// If this ConfigItem has a Validation Func...
-		if (nil != configDataValue) && configItem.CanValidate() {
-			if err := configItem.Validate(configDataValue); nil != err {
-				return fmt.Errorf(
-					"Config Item '%s' failed validation with value: (%s) '%s': %s",
-					selector,
-					configDataValue.GetType().ToString(),
-					configDataValue.ToString(),
-					err.Error(),
-				)
-			}
+// Make sure that config has a value for each of configItems that is required, and that each value it
+// has for them is valid (see ReloadableConfig, which checks a new Config this way too)
+func validateConfigItems(config ConfigIfc, configItems []ConfigItemIfc) error {
+	missing := make([]string, 0)
+	for _, configItem := range configItems {
+		if configItem.IsRequired() && (nil == config.Select(configItem.GetSelector())) {
+			missing = append(missing, configItem.GetSelector())
 		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("Missing required config(s): '%s'", strings.Join(missing, "','"))
+	}
 
-		// If this ConfigItem has a Capture Func...
-		if configItem.CanCapture() {
-			if err := configItem.Capture(configDataValue); nil != err { return err }
+	for _, configItem := range configItems {
+		if ! configItem.CanValidate() { continue }
+		configDataValue := config.Select(configItem.GetSelector())
+		if nil == configDataValue { continue }
+		if err := configItem.Validate(configDataValue); nil != err {
+			return fmt.Errorf(
+				"Config Item '%s' failed validation with value: (%s) '%s': %s",
+				configItem.GetSelector(),
+				configDataValue.GetType().ToString(),
+				configDataValue.ToString(),
+				err.Error(),
+			)
 		}
 	}
+	return nil
+}
 
-	return r.Startable.Start()
+// Resolve the secret references within a copy of config, leaving the caller's own config as it was
+// (it may be shared with others, or logged)
+func (r *Configurable) resolveSecrets(config ConfigIfc) (*Config, error) {
+	resolved := config.CloneConfig()
+	if err := resolved.ResolveSecrets(r.secretProviders); nil != err { return nil, err }
+	return resolved, nil
+}
+
+// Make sure nothing required is missing and that everything is valid, then capture it all
+func (r *Configurable) captureConfigItems() error {
+	// Validate all of the declared Config Items before capturing any, so that a bad one changes nothing
+	if err := validateConfigItems(r.config, r.GetConfigItems()); nil != err { return err }
+
+	for selector, configItem := range r.declared {
+		if ! configItem.CanCapture() { continue }
+		// A missing value must reach Capture() as a nil interface, not a nil *DataValue
+		var captureValue data.DataValueIfc
+		if configDataValue := r.config.Select(selector); nil != configDataValue { captureValue = configDataValue }
+		if err := configItem.Capture(captureValue); nil != err { return err }
+	}
+	return nil
 }
 
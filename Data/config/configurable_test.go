@@ -2,6 +2,7 @@ package config
 
 import(
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/DigiStratum/GoLib/Data"
@@ -200,6 +201,101 @@ func TestThat_Configurable_Start_returns_error_for_capture_errors(t *testing.T)
 
 
 
+// validateConfigItems
+
+func TestThat_validateConfigItems_reports_all_missing_before_invalid_values(t *testing.T) {
+	// Setup
+	configItems := []ConfigItemIfc{
+		NewConfigItem("c3").SetRequired(),
+		NewConfigItem("c2").ValidateWith(func (dv data.DataValueIfc) error { return fmt.Errorf("fail!") }),
+		NewConfigItem("c1").SetRequired(),
+	}
+	config := NewConfig()
+	config.PrepareObject().SetObjectProperty("c2", data.NewInteger(2))
+	config.SetObjectProperty("c1", data.NewInteger(1))
+	config.SetObjectProperty("c3", data.NewInteger(3))
+
+	// Test
+	err1 := validateConfigItems(NewConfig(), configItems)
+	err2 := validateConfigItems(config, configItems)
+
+	// Verify
+	if ExpectError(err1, t) { ExpectString("Missing required config(s): 'c1','c3'", err1.Error(), t) }
+	if ExpectError(err2, t) { ExpectTrue(strings.Contains(err2.Error(), "Config Item 'c2' failed validation"), t) }
+}
+
+// Reconfigure
+
+func TestThat_Configurable_Reconfigure_Captures_again_when_started(t *testing.T) {
+	// Setup
+	var captured int64
+	sut := NewConfigurable(NewConfigItem("c1").CaptureWith(CaptureInt64(&captured)))
+	config1 := NewConfig()
+	config1.PrepareObject().SetObjectProperty("c1", data.NewInteger(1))
+	config2 := NewConfig()
+	config2.PrepareObject().SetObjectProperty("c1", data.NewInteger(2))
+
+	// Test
+	err1 := sut.Reconfigure(config1)
+	started := sut.IsStarted()
+	err2 := sut.Reconfigure(config2)
+
+	// Verify
+	ExpectNoError(err1, t)
+	ExpectTrue(started, t)
+	ExpectNoError(err2, t)
+	ExpectInt(2, int(captured), t)
+	ExpectInt(2, int(sut.GetConfig().Select("c1").GetInteger()), t)
+}
+
+func TestThat_Configurable_Reconfigure_Keeps_previous_config_for_validation_errors(t *testing.T) {
+	// Setup
+	var captured int64
+	sut := NewConfigurable(
+		NewConfigItem("c1").CaptureWith(CaptureInt64(&captured)),
+		NewConfigItem("c2").ValidateWith(func (dv data.DataValueIfc) error { return fmt.Errorf("fail!") }),
+	)
+	config1 := NewConfig()
+	config1.PrepareObject().SetObjectProperty("c1", data.NewInteger(1))
+	config2 := NewConfig()
+	config2.PrepareObject().
+		SetObjectProperty("c1", data.NewInteger(2)).
+		SetObjectProperty("c2", data.NewInteger(2))
+	sut.Reconfigure(config1)
+
+	// Test
+	err := sut.Reconfigure(config2)
+
+	// Verify
+	ExpectError(err, t)
+	ExpectInt(1, int(captured), t)
+	ExpectFalse(sut.GetConfig().HasObjectProperty("c2"), t)
+}
+
+func TestThat_Configurable_Reconfigure_Captures_defaults_for_values_not_in_config(t *testing.T) {
+	// Setup
+	var captured1, captured2 int64
+	sut := NewConfigurable(
+		NewConfigItem("c1").SetDefault(data.NewInteger(10)).CaptureWith(CaptureInt64(&captured1)),
+		NewConfigItem("c2").CaptureWith(CaptureInt64(&captured2)),
+	)
+	config1 := NewConfig()
+	config1.PrepareObject().
+		SetObjectProperty("c1", data.NewInteger(1)).
+		SetObjectProperty("c2", data.NewInteger(2))
+	sut.Reconfigure(config1)
+
+	// Test
+	err := sut.Reconfigure(NewConfig())
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectInt(10, int(captured1), t)
+	ExpectInt(2, int(captured2), t)
+}
+
+
+
 // GetConfig
 
 func TestThat_Configurable_Returns_nil_when_not_started(t *testing.T) {
@@ -233,3 +329,23 @@ func TestThat_Configurable_Returns_config_when_started(t *testing.T) {
 }
 
 
+
+// GetConfigItems
+
+func TestThat_Configurable_GetConfigItems_Returns_declared_items_sorted_by_selector(t *testing.T) {
+	// Setup
+	sut := NewConfigurable(
+		NewConfigItem("c"),
+		NewConfigItem("a"),
+		NewConfigItem("b"),
+	)
+
+	// Test
+	actual := sut.GetConfigItems()
+
+	// Verify
+	if ! ExpectInt(3, len(actual), t) { return }
+	if ! ExpectString("a", actual[0].GetSelector(), t) { return }
+	if ! ExpectString("b", actual[1].GetSelector(), t) { return }
+	if ! ExpectString("c", actual[2].GetSelector(), t) { return }
+}
@@ -0,0 +1,102 @@
+package config
+
+/*
+
+Adapters between the DEPRECATED GoLib/Config name=value Config and our structured Config so that
+legacy configuration data may be used to configure our Configurables and vice versa.
+
+Legacy names are dotted paths into the structure: { "db.host": "localhost" } converts to
+{ "db": { "host": "localhost" } }, which is selected here as "db.host" just as before. Legacy values
+are all strings and they are kept as strings; the typed capture funcs (see configcapture.go) accept
+the string forms of the types they capture, so legacy data works with typed ConfigItems as well.
+
+Converting the other way flattens nested objects back into dotted names; scalars take their string
+form, and arrays, which legacy Config could never hold, are carried as JSON strings.
+
+*/
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/DigiStratum/GoLib/Data"
+	legacy "github.com/DigiStratum/GoLib/Config"
+)
+
+// -------------------------------------------------------------------------------------------------
+// Factory Functions
+// -------------------------------------------------------------------------------------------------
+
+// Make a new Config from legacy Config data; dotted names become nested objects
+func FromLegacyConfig(legacyConfig legacy.ConfigIfc) (*Config, error) {
+	r := NewConfig()
+	r.PrepareObject()
+	if nil == legacyConfig { return r, nil }
+
+	// Sorted so that any conflict is reported the same way every time
+	names := legacyConfig.GetKeys()
+	sort.Strings(names)
+	for _, name := range names {
+		value := legacyConfig.Get(name)
+		if nil == value { continue }
+		if err := setLegacyValue(r.DataValue, name, name, *value); nil != err { return nil, err }
+		r.setSource(name, CONFIG_SOURCE_LEGACY)
+	}
+	return r, nil
+}
+
+// Make a new legacy Config from our Config data; nested objects become dotted names
+func ToLegacyConfig(config ConfigIfc) *legacy.Config {
+	r := legacy.NewConfig()
+	if nil == config { return r }
+	addLegacyValues(r, "", config.Select(""))
+	return r
+}
+
+// -------------------------------------------------------------------------------------------------
+// Legacy Config Adapter Implementation
+// -------------------------------------------------------------------------------------------------
+
+// Set value at the dotted path within object, preparing objects along the way as needed
+func setLegacyValue(object *data.DataValue, name, path, value string) error {
+	property, remainder, nested := strings.Cut(path, ".")
+	if (0 == len(property)) || (nested && (0 == len(remainder))) {
+		return fmt.Errorf("FromLegacyConfig(): Unusable legacy config name '%s'", name)
+	}
+	existing := object.GetObjectProperty(property)
+	if ! nested {
+		if nil != existing {
+			return fmt.Errorf("FromLegacyConfig(): Legacy config name '%s' conflicts with another name", name)
+		}
+		object.SetObjectProperty(property, data.NewString(value))
+		return nil
+	}
+	if nil == existing {
+		existing = data.NewObject()
+		object.SetObjectProperty(property, existing)
+	} else if ! existing.IsObject() {
+		return fmt.Errorf("FromLegacyConfig(): Legacy config name '%s' conflicts with another name", name)
+	}
+	return setLegacyValue(existing, name, remainder, value) // <- BEWARE: recursion!
+}
+
+// Add the string form of value to legacyConfig under name, or its properties under dotted names
+func addLegacyValues(legacyConfig *legacy.Config, name string, value *data.DataValue) {
+	if nil == value { return }
+	if value.IsObject() {
+		for _, property := range value.GetObjectProperties() {
+			propertyName := property
+			if len(name) > 0 { propertyName = name + "." + property }
+			addLegacyValues(legacyConfig, propertyName, value.GetObjectProperty(property)) // <- BEWARE: recursion!
+		}
+		return
+	}
+	if 0 == len(name) { return }
+	switch value.GetType() {
+		case data.DATA_TYPE_STRING: legacyConfig.Set(name, value.GetString())
+		case data.DATA_TYPE_NULL: legacyConfig.Set(name, "")
+		case data.DATA_TYPE_ARRAY: legacyConfig.Set(name, value.ToJson())
+		default: legacyConfig.Set(name, value.ToString())
+	}
+}
@@ -0,0 +1,119 @@
+package config
+
+import(
+	"testing"
+
+	"github.com/DigiStratum/GoLib/Data"
+	legacy "github.com/DigiStratum/GoLib/Config"
+
+	. "github.com/DigiStratum/GoLib/Testing"
+)
+
+// FromLegacyConfig
+
+func TestThat_FromLegacyConfig_returns_empty_object_for_nil(t *testing.T) {
+	// Test
+	actual, err := FromLegacyConfig(nil)
+
+	// Verify
+	if ! ExpectNoError(err, t) { return }
+	if ! ExpectTrue(actual.IsObject(), t) { return }
+	ExpectInt(0, len(actual.GetObjectProperties()), t)
+}
+
+func TestThat_FromLegacyConfig_nests_dotted_names(t *testing.T) {
+	// Setup
+	legacyConfig := legacy.NewConfig()
+	legacyConfig.Set("name", "app")
+	legacyConfig.Set("db.host", "localhost")
+	legacyConfig.Set("db.port", "3306")
+	legacyConfig.Set("db.pool.max", "10")
+
+	// Test
+	actual, err := FromLegacyConfig(legacyConfig)
+
+	// Verify
+	if ! ExpectNoError(err, t) { return }
+	ExpectString("app", actual.Select("name").GetString(), t)
+	ExpectString("localhost", actual.Select("db.host").GetString(), t)
+	ExpectString("3306", actual.Select("db.port").GetString(), t) // <- Still a string!
+	ExpectString("10", actual.Select("db.pool.max").GetString(), t)
+	ExpectString(CONFIG_SOURCE_LEGACY, actual.GetSource("db.port"), t)
+}
+
+func TestThat_FromLegacyConfig_returns_error_for_conflicting_names(t *testing.T) {
+	// Setup
+	legacyConfig := legacy.NewConfig()
+	legacyConfig.Set("db", "mysql")
+	legacyConfig.Set("db.host", "localhost")
+
+	// Test
+	actual, err := FromLegacyConfig(legacyConfig)
+
+	// Verify
+	ExpectError(err, t)
+	ExpectTrue(nil == actual, t)
+}
+
+func TestThat_FromLegacyConfig_returns_error_for_unusable_names(t *testing.T) {
+	for _, name := range []string{ ".db", "db.", "db..host" } {
+		// Setup
+		legacyConfig := legacy.NewConfig()
+		legacyConfig.Set(name, "value")
+
+		// Test
+		_, err := FromLegacyConfig(legacyConfig)
+
+		// Verify
+		ExpectError(err, t)
+	}
+}
+
+// ToLegacyConfig
+
+func TestThat_ToLegacyConfig_flattens_structure_to_strings(t *testing.T) {
+	// Setup
+	config := NewConfig()
+	config.PrepareObject().
+		SetObjectProperty("name", data.NewString("app")).
+		SetObjectProperty("db", data.NewObject().
+			SetObjectProperty("port", data.NewInteger(3306)).
+			SetObjectProperty("readonly", data.NewBoolean(true)).
+			SetObjectProperty("password", data.NewNull()).
+			SetObjectProperty("hosts", data.NewArray().
+				AppendArrayValue(data.NewString("a")).
+				AppendArrayValue(data.NewString("b")),
+			),
+		)
+
+	// Test
+	actual := ToLegacyConfig(config)
+
+	// Verify
+	ExpectInt(5, actual.Size(), t)
+	ExpectString("app", *actual.Get("name"), t)
+	ExpectString("3306", *actual.Get("db.port"), t)
+	ExpectString("true", *actual.Get("db.readonly"), t)
+	ExpectString("", *actual.Get("db.password"), t)
+	ExpectString(`["a","b"]`, *actual.Get("db.hosts"), t)
+}
+
+func TestThat_ToLegacyConfig_round_trips_legacy_config(t *testing.T) {
+	// Setup
+	legacyConfig := legacy.NewConfig()
+	legacyConfig.Set("name", "app")
+	legacyConfig.Set("db.host", "localhost")
+	legacyConfig.Set("db.port", "3306")
+	config, err := FromLegacyConfig(legacyConfig)
+	if ! ExpectNoError(err, t) { return }
+
+	// Test
+	actual := ToLegacyConfig(config)
+
+	// Verify
+	ExpectInt(legacyConfig.Size(), actual.Size(), t)
+	for _, name := range legacyConfig.GetKeys() {
+		if ! ExpectTrue(actual.Has(name), t) { return }
+		ExpectString(*legacyConfig.Get(name), *actual.Get(name), t)
+	}
+}
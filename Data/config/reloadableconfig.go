@@ -0,0 +1,329 @@
+package config
+
+/*
+
+ReloadableConfig keeps a Config up to date with the files of its ConfigLoader so that settings such as
+a log level or a pool size can change without a redeploy.
+
+Files are watched by polling their modification time and size through FileIO. When any of them
+changes, every source is loaded again (so precedence is just as it was for the first load), the
+declared ConfigItems are validated against the result, and, if all is well, the new Config replaces
+the old one and the differences are published to subscribers. An invalid reload is rejected as a
+whole: the last good Config stays in place and the error is kept for GetLastError().
+
+Subscribers register for a selector and receive only the changes at, above or below it: a subscriber
+for "db" hears about "db.host", and a subscriber for "db.host" hears about "db" being replaced by a
+non-object. The empty selector subscribes to everything. Subscribers are called on the goroutine that
+did the reload, never while we hold our lock, so they may call back into us.
+
+Usage:
+
+	reloadable := config.NewReloadableConfig(config.NewConfigLoader().WithFile("app.yaml")).
+		DeclareConfigItems(config.NewConfigItem("log.level").ValidateWith(isLogLevel))
+	if err := reloadable.Reload(); nil != err { ... } // Initial load
+	reloadable.Subscribe("log.level", func (changes []config.ConfigChange) { ... })
+	reloadable.Run()
+	defer reloadable.Stop()
+
+TODO:
+ * Use inotify (or equivalent) where available instead of polling
+ * Support Capture() of changed ConfigItems as part of a reload
+
+*/
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+	"strings"
+
+	"github.com/DigiStratum/GoLib/Data"
+	fileio "github.com/DigiStratum/GoLib/FileIO"
+	"github.com/DigiStratum/GoLib/Process/runnable"
+)
+
+const DEFAULT_RELOAD_POLL_INTERVAL = 5 * time.Second
+
+// One difference between the old and new Config; OldValue is nil for additions, NewValue for removals
+type ConfigChange struct {
+	Selector	string
+	OldValue	*data.DataValue
+	NewValue	*data.DataValue
+}
+
+type ConfigSubscriberFunc func (changes []ConfigChange)
+
+type ReloadableConfigIfc interface {
+	runnable.RunnableIfc
+
+	DeclareConfigItems(configItems ...ConfigItemIfc) *ReloadableConfig
+	SetPollInterval(interval time.Duration) *ReloadableConfig
+	GetConfig() *Config
+	GetLastError() error
+	Subscribe(selector string, subscriber ConfigSubscriberFunc) int
+	Unsubscribe(subscriptionId int)
+	CheckForChanges() (bool, error)
+	Reload() error
+}
+
+type ReloadableConfig struct {
+	loader		*ConfigLoader
+	declared	map[string]ConfigItemIfc
+	pollInterval	time.Duration
+	config		*Config
+	lastError	error
+	fileStates	map[string]string
+	subscriptions	map[int]configSubscription
+	nextId		int
+	stop		chan bool
+	mutex		sync.Mutex
+	reloadMutex	sync.Mutex	// Serializes reloads without blocking readers
+}
+
+type configSubscription struct {
+	selector	string
+	subscriber	ConfigSubscriberFunc
+}
+
+// -------------------------------------------------------------------------------------------------
+// Factory Functions
+// -------------------------------------------------------------------------------------------------
+
+func NewReloadableConfig(loader *ConfigLoader) *ReloadableConfig {
+	return &ReloadableConfig{
+		loader:		loader,
+		declared:	make(map[string]ConfigItemIfc),
+		pollInterval:	DEFAULT_RELOAD_POLL_INTERVAL,
+		fileStates:	make(map[string]string),
+		subscriptions:	make(map[int]configSubscription),
+	}
+}
+
+// -------------------------------------------------------------------------------------------------
+// ReloadableConfigIfc
+// -------------------------------------------------------------------------------------------------
+
+// Declare ConfigItems to validate every (re)load against
+func (r *ReloadableConfig) DeclareConfigItems(configItems ...ConfigItemIfc) *ReloadableConfig {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, configItem := range configItems { r.declared[configItem.GetSelector()] = configItem }
+	return r
+}
+
+func (r *ReloadableConfig) SetPollInterval(interval time.Duration) *ReloadableConfig {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if interval > 0 { r.pollInterval = interval }
+	return r
+}
+
+// Get the last good Config, or nil if there has not been one yet; treat it as read-only
+func (r *ReloadableConfig) GetConfig() *Config {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.config
+}
+
+// Get the error from the most recent (re)load, or nil if it succeeded
+func (r *ReloadableConfig) GetLastError() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.lastError
+}
+
+// Subscribe to changes at selector; returns an id for Unsubscribe()
+func (r *ReloadableConfig) Subscribe(selector string, subscriber ConfigSubscriberFunc) int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.nextId++
+	r.subscriptions[r.nextId] = configSubscription{ selector: selector, subscriber: subscriber }
+	return r.nextId
+}
+
+func (r *ReloadableConfig) Unsubscribe(subscriptionId int) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	delete(r.subscriptions, subscriptionId)
+}
+
+// Poll our files once, reloading if any of them changed; returns true if we attempted a reload
+func (r *ReloadableConfig) CheckForChanges() (bool, error) {
+	changed := false
+	fileStates := r.getFileStates()
+	r.mutex.Lock()
+	for path, state := range fileStates {
+		if r.fileStates[path] != state { changed = true }
+	}
+	r.mutex.Unlock()
+	if ! changed { return false, nil }
+	return true, r.Reload()
+}
+
+// Load all sources again, validate, and swap in the result, publishing any changes to subscribers
+func (r *ReloadableConfig) Reload() error {
+	r.reloadMutex.Lock()
+	defer r.reloadMutex.Unlock()
+
+	// Take note of the files before loading so that a change during the load is not missed next time
+	fileStates := r.getFileStates()
+	config, err := r.loader.Load()
+	if nil == err { err = r.validate(config) }
+
+	r.mutex.Lock()
+	r.fileStates = fileStates
+	r.lastError = err
+	if nil != err {
+		r.mutex.Unlock()
+		return err
+	}
+	var changes []ConfigChange
+	if nil != r.config { changes = diffConfigValues("", r.config.DataValue, config.DataValue) }
+	r.config = config
+	notifications := r.getNotifications(changes)
+	r.mutex.Unlock()
+
+	for _, notification := range notifications { notification() }
+	return nil
+}
+
+// -------------------------------------------------------------------------------------------------
+// GoLib/Process/runnable/RunnableIfc
+// -------------------------------------------------------------------------------------------------
+
+// Start polling for changes in the background
+func (r *ReloadableConfig) Run() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if nil != r.stop { return }
+	r.stop = make(chan bool)
+	go r.runLoop(r.stop, r.pollInterval)
+}
+
+func (r *ReloadableConfig) IsRunning() bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return nil != r.stop
+}
+
+func (r *ReloadableConfig) Stop() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if nil == r.stop { return }
+	close(r.stop)
+	r.stop = nil
+}
+
+// -------------------------------------------------------------------------------------------------
+// ReloadableConfig Implementation
+// -------------------------------------------------------------------------------------------------
+
+func (r *ReloadableConfig) runLoop(stop chan bool, pollInterval time.Duration) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+			case <-stop: return
+			case <-ticker.C: r.CheckForChanges()
+		}
+	}
+}
+
+// Capture modification time and size of each file; missing files are recorded as such
+func (r *ReloadableConfig) getFileStates() map[string]string {
+	fileStates := make(map[string]string)
+	for _, file := range r.loader.files {
+		// A new File each time; File caches its FileInfo
+		f := fileio.File(file.path)
+		modTime, err := f.GetModTime()
+		if nil != err {
+			fileStates[file.path] = "missing"
+			continue
+		}
+		size, _ := f.GetSize()
+		fileStates[file.path] = fmt.Sprintf("%d:%d", modTime.UnixNano(), *size)
+	}
+	return fileStates
+}
+
+// Validate config against our declared ConfigItems the same way that Configurable.Start() does
+func (r *ReloadableConfig) validate(config *Config) error {
+	r.mutex.Lock()
+	declared := make([]ConfigItemIfc, 0, len(r.declared))
+	for _, configItem := range r.declared { declared = append(declared, configItem) }
+	r.mutex.Unlock()
+	return validateConfigItems(config, declared)
+}
+
+// Make a closure for each subscriber that has changes to hear about
+func (r *ReloadableConfig) getNotifications(changes []ConfigChange) []func () {
+	notifications := make([]func (), 0)
+	if 0 == len(changes) { return notifications }
+	for _, subscription := range r.subscriptions {
+		matching := make([]ConfigChange, 0)
+		for _, change := range changes {
+			if isRelatedSelector(subscription.selector, change.Selector) { matching = append(matching, change) }
+		}
+		if 0 == len(matching) { continue }
+		subscriber := subscription.subscriber
+		notifications = append(notifications, func () { subscriber(matching) })
+	}
+	return notifications
+}
+
+// Is either selector the same as, or an ancestor of, the other?
+func isRelatedSelector(a, b string) bool {
+	if (0 == len(a)) || (0 == len(b)) || (a == b) { return true }
+	return strings.HasPrefix(a, b + ".") || strings.HasPrefix(b, a + ".")
+}
+
+// Find the differences between two values; objects are compared property by property, anything
+// else as a whole
+func diffConfigValues(selector string, oldValue, newValue *data.DataValue) []ConfigChange {
+	changes := make([]ConfigChange, 0)
+	if (nil != oldValue) && (nil != newValue) && oldValue.IsObject() && newValue.IsObject() {
+		properties := make(map[string]bool)
+		for _, property := range oldValue.GetObjectProperties() { properties[property] = true }
+		for _, property := range newValue.GetObjectProperties() { properties[property] = true }
+		names := make([]string, 0, len(properties))
+		for property := range properties { names = append(names, property) }
+		sort.Strings(names)
+		for _, property := range names {
+			childSelector := property
+			if len(selector) > 0 { childSelector = selector + "." + property }
+			changes = append(changes, diffConfigValues( // <- BEWARE: recursion!
+				childSelector,
+				oldValue.GetObjectProperty(property),
+				newValue.GetObjectProperty(property),
+			)...)
+		}
+		return changes
+	}
+	if (nil == oldValue) && (nil == newValue) { return changes }
+	if (nil != oldValue) && (nil != newValue) && equalConfigValues(oldValue, newValue) { return changes }
+	return append(changes, ConfigChange{ Selector: selector, OldValue: oldValue, NewValue: newValue })
+}
+
+// Deep comparison that does not depend on the order of object properties
+func equalConfigValues(a, b *data.DataValue) bool {
+	if a.GetType() != b.GetType() { return false }
+	switch a.GetType() {
+		case data.DATA_TYPE_OBJECT:
+			properties := a.GetObjectProperties()
+			if len(properties) != len(b.GetObjectProperties()) { return false }
+			for _, property := range properties {
+				if ! b.HasObjectProperty(property) { return false }
+				if ! equalConfigValues(a.GetObjectProperty(property), b.GetObjectProperty(property)) { return false } // <- BEWARE: recursion!
+			}
+			return true
+
+		case data.DATA_TYPE_ARRAY:
+			if a.GetArraySize() != b.GetArraySize() { return false }
+			for index := 0; index < a.GetArraySize(); index++ {
+				if ! equalConfigValues(a.GetArrayValue(index), b.GetArrayValue(index)) { return false } // <- BEWARE: recursion!
+			}
+			return true
+	}
+	return a.ToJson() == b.ToJson()
+}
@@ -0,0 +1,149 @@
+package config
+
+import(
+	"os"
+	"fmt"
+	"time"
+	"testing"
+
+	"github.com/DigiStratum/GoLib/Data"
+
+	. "github.com/DigiStratum/GoLib/Testing"
+)
+
+// Rewrite the file with a modification time that is sure to differ from the last one
+func rewriteReloadableConfigTestFile(path, content string, generation int, t *testing.T) {
+	if err := os.WriteFile(path, []byte(content), 0600); nil != err { t.Fatal(err) }
+	modTime := time.Now().Add(time.Duration(generation) * time.Minute)
+	if err := os.Chtimes(path, modTime, modTime); nil != err { t.Fatal(err) }
+}
+
+func newReloadableConfigTestSut(content string, t *testing.T) (*ReloadableConfig, string) {
+	path := writeConfigLoaderTestFile("reload.yaml", content, t)
+	sut := NewReloadableConfig(NewConfigLoader().WithFile(path)).
+		DeclareConfigItems(
+			NewConfigItem("pool.size").SetRequired().ValidateWith(func (dataValue data.DataValueIfc) error {
+				if dataValue.GetInteger() < 1 { return fmt.Errorf("pool.size must be positive") }
+				return nil
+			}),
+		)
+	return sut, path
+}
+
+func TestThat_ReloadableConfig_NewReloadableConfig_ReturnsInstance(t *testing.T) {
+	// Setup
+	var sut ReloadableConfigIfc = NewReloadableConfig(NewConfigLoader()) // <- ensures that we satisfy our interface
+
+	// Verify
+	ExpectNonNil(sut, t)
+	ExpectNil(sut.GetConfig(), t)
+	ExpectFalse(sut.IsRunning(), t)
+}
+
+func TestThat_ReloadableConfig_Reload_ReturnsError_ForInvalidInitialConfig(t *testing.T) {
+	// Setup
+	sut, _ := newReloadableConfigTestSut("pool:\n  size: 0\n", t)
+
+	// Test
+	err := sut.Reload()
+
+	// Verify
+	ExpectError(err, t)
+	ExpectError(sut.GetLastError(), t)
+	ExpectNil(sut.GetConfig(), t)
+}
+
+func TestThat_ReloadableConfig_CheckForChanges_DoesNothing_WhenFilesUnchanged(t *testing.T) {
+	// Setup
+	sut, _ := newReloadableConfigTestSut("pool:\n  size: 4\n", t)
+	if ! ExpectNoError(sut.Reload(), t) { return }
+
+	// Test
+	reloaded, err := sut.CheckForChanges()
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectFalse(reloaded, t)
+}
+
+func TestThat_ReloadableConfig_CheckForChanges_PublishesDiffsToMatchingSubscribers(t *testing.T) {
+	// Setup
+	sut, path := newReloadableConfigTestSut("pool:\n  size: 4\n  name: main\nlog:\n  level: info\n", t)
+	if ! ExpectNoError(sut.Reload(), t) { return }
+	var poolChanges, logChanges, allChanges []ConfigChange
+	sut.Subscribe("pool", func (changes []ConfigChange) { poolChanges = changes })
+	sut.Subscribe("log.level", func (changes []ConfigChange) { logChanges = changes })
+	sut.Subscribe("", func (changes []ConfigChange) { allChanges = changes })
+	unsubscribed := false
+	sut.Unsubscribe(sut.Subscribe("pool.size", func (changes []ConfigChange) { unsubscribed = true }))
+	rewriteReloadableConfigTestFile(path, "pool:\n  size: 8\n  name: main\n  extra: x\nlog:\n  level: info\n", 1, t)
+
+	// Test
+	reloaded, err := sut.CheckForChanges()
+
+	// Verify
+	ExpectTrue(reloaded, t)
+	if ! ExpectNoError(err, t) { return }
+	ExpectInt(8, int(sut.GetConfig().Select("pool.size").GetInteger()), t)
+	if ! ExpectInt(2, len(poolChanges), t) { return }
+	ExpectString("pool.extra", poolChanges[0].Selector, t)
+	ExpectTrue(nil == poolChanges[0].OldValue, t)
+	ExpectString("x", poolChanges[0].NewValue.GetString(), t)
+	ExpectString("pool.size", poolChanges[1].Selector, t)
+	ExpectInt(4, int(poolChanges[1].OldValue.GetInteger()), t)
+	ExpectInt(8, int(poolChanges[1].NewValue.GetInteger()), t)
+	ExpectInt(2, len(allChanges), t)
+	ExpectTrue(nil == logChanges, t)
+	ExpectFalse(unsubscribed, t)
+}
+
+func TestThat_ReloadableConfig_CheckForChanges_KeepsLastGoodConfig_WhenReloadInvalid(t *testing.T) {
+	// Setup
+	sut, path := newReloadableConfigTestSut("pool:\n  size: 4\n", t)
+	if ! ExpectNoError(sut.Reload(), t) { return }
+	notified := false
+	sut.Subscribe("", func (changes []ConfigChange) { notified = true })
+	badContents := []string{ "pool:\n  size: -1\n", "pool:\n  name: no size\n", "pool: [broken\n" }
+
+	for generation, badContent := range badContents {
+		rewriteReloadableConfigTestFile(path, badContent, generation + 1, t)
+
+		// Test
+		reloaded, err := sut.CheckForChanges()
+
+		// Verify
+		ExpectTrue(reloaded, t)
+		ExpectError(err, t)
+		ExpectError(sut.GetLastError(), t)
+		ExpectInt(4, int(sut.GetConfig().Select("pool.size").GetInteger()), t)
+	}
+	ExpectFalse(notified, t)
+
+	// A bad file is not retried until it changes again
+	reloaded, _ := sut.CheckForChanges()
+	ExpectFalse(reloaded, t)
+}
+
+func TestThat_ReloadableConfig_Run_PollsInBackground_UntilStopped(t *testing.T) {
+	// Setup
+	sut, path := newReloadableConfigTestSut("pool:\n  size: 4\n", t)
+	if ! ExpectNoError(sut.Reload(), t) { return }
+	changed := make(chan []ConfigChange, 1)
+	sut.Subscribe("pool.size", func (changes []ConfigChange) { changed <- changes })
+
+	// Test
+	sut.SetPollInterval(10 * time.Millisecond).Run()
+	defer sut.Stop()
+	rewriteReloadableConfigTestFile(path, "pool:\n  size: 5\n", 1, t)
+
+	// Verify
+	ExpectTrue(sut.IsRunning(), t)
+	select {
+		case changes := <-changed:
+			if ExpectInt(1, len(changes), t) { ExpectInt(5, int(changes[0].NewValue.GetInteger()), t) }
+		case <-time.After(5 * time.Second):
+			t.Error("Timed out waiting for reload")
+	}
+	sut.Stop()
+	ExpectFalse(sut.IsRunning(), t)
+}
@@ -0,0 +1,119 @@
+package config
+
+/*
+
+Secret references keep plaintext secrets (DB passwords, API keys, etc.) out of config files. A string
+config value may contain references of the form %secret:provider/path% which are resolved through
+a SecretProviderRegistry when a Configurable is Configure()d:
+
+	db:
+	  password: "%secret:env/DB_PASSWORD%"
+	  dsn: "app:%secret:file/db-password%@tcp(db:3306)/app"
+
+The provider name selects a SecretProvider from the registry, and the remaining path is meaningful
+only to that provider. Our registry singleton comes with these providers:
+
+ * env  - the path is the name of an environment variable
+ * file - the path is a file name relative to DEFAULT_SECRET_FILE_DIR, the standard Docker secret
+   mount point; register another file provider for other mounts, e.g. Kubernetes secret volumes
+
+There is also an encrypted local keystore provider (see secretproviderkeystore.go), which must be
+registered explicitly since it needs a passphrase.
+
+Resolved secret values are added to the Redactor (see GoLib/Redactor) so that they are masked in log
+output, and Config masks them in ToJson()/ToString() output as well.
+
+*/
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"strings"
+)
+
+const SECRET_REFERENCE_PREFIX = "secret:"
+
+type SecretProviderIfc interface {
+	GetSecret(path string) (string, error)
+}
+
+type SecretProviderRegistryIfc interface {
+	Register(name string, provider SecretProviderIfc) *secretProviderRegistry
+	Get(name string) SecretProviderIfc
+	GetNames() []string
+	GetSecret(reference string) (string, error)
+}
+
+type secretProviderRegistry struct {
+	mutex		sync.RWMutex
+	providers	map[string]SecretProviderIfc
+}
+
+var secretProviderRegistryInstance *secretProviderRegistry
+
+// Automagically set up our default singleton
+func init() {
+	secretProviderRegistryInstance = NewSecretProviderRegistry().
+		Register("env", NewSecretProviderEnv()).
+		Register("file", NewSecretProviderFile(DEFAULT_SECRET_FILE_DIR))
+}
+
+// Get our singleton
+func GetSecretProviderRegistry() *secretProviderRegistry {
+	return secretProviderRegistryInstance
+}
+
+// -------------------------------------------------------------------------------------------------
+// Factory Functions
+// -------------------------------------------------------------------------------------------------
+
+func NewSecretProviderRegistry() *secretProviderRegistry {
+	return &secretProviderRegistry{
+		providers:	make(map[string]SecretProviderIfc),
+	}
+}
+
+// -------------------------------------------------------------------------------------------------
+// SecretProviderRegistryIfc
+// -------------------------------------------------------------------------------------------------
+
+// Register the provider under name; replaces any existing registration
+func (r *secretProviderRegistry) Register(name string, provider SecretProviderIfc) *secretProviderRegistry {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if nil == provider {
+		delete(r.providers, name)
+	} else { r.providers[name] = provider }
+	return r
+}
+
+// Get the provider registered under name, or nil if there is none
+func (r *secretProviderRegistry) Get(name string) SecretProviderIfc {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	provider, _ := r.providers[name]
+	return provider
+}
+
+func (r *secretProviderRegistry) GetNames() []string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	names := make([]string, 0, len(r.providers))
+	for name := range r.providers { names = append(names, name) }
+	sort.Strings(names)
+	return names
+}
+
+// Get the secret for a reference of the form provider/path (with or without the "secret:" prefix)
+func (r *secretProviderRegistry) GetSecret(reference string) (string, error) {
+	name, path, found := strings.Cut(strings.TrimPrefix(reference, SECRET_REFERENCE_PREFIX), "/")
+	if ! found || (0 == len(name)) || (0 == len(path)) {
+		return "", fmt.Errorf("Malformed secret reference '%s'; expected provider/path", reference)
+	}
+	provider := r.Get(name)
+	if nil == provider { return "", fmt.Errorf("No secret provider registered for '%s'", name) }
+	secret, err := provider.GetSecret(path)
+	if nil != err { return "", fmt.Errorf("Secret provider '%s' failed for '%s': %s", name, path, err.Error()) }
+	return secret, nil
+}
@@ -0,0 +1,190 @@
+package config
+
+import(
+	"os"
+	"strings"
+	"testing"
+	"path/filepath"
+
+	"github.com/DigiStratum/GoLib/Data"
+	"github.com/DigiStratum/GoLib/Redactor"
+
+	. "github.com/DigiStratum/GoLib/Testing"
+)
+
+type secretProviderMock struct { secrets map[string]string }
+
+func (r *secretProviderMock) GetSecret(path string) (string, error) {
+	secret, ok := r.secrets[path]
+	if ! ok { return "", os.ErrNotExist }
+	return secret, nil
+}
+
+// Registry
+
+func TestThat_SecretProviderRegistry_GetSecretProviderRegistry_has_our_providers(t *testing.T) {
+	// Setup
+	var sut SecretProviderRegistryIfc = GetSecretProviderRegistry() // <- ensures that we satisfy our interface
+
+	// Verify
+	ExpectString("env,file", strings.Join(sut.GetNames(), ","), t)
+}
+
+func TestThat_SecretProviderRegistry_GetSecret_returns_errors_for_bad_references(t *testing.T) {
+	// Setup
+	sut := NewSecretProviderRegistry().Register("mock", &secretProviderMock{})
+
+	for _, reference := range []string{ "mock", "secret:/x", "secret:mock/", "secret:nope/x", "secret:mock/missing" } {
+		// Test
+		_, err := sut.GetSecret(reference)
+
+		// Verify
+		ExpectError(err, t)
+	}
+}
+
+func TestThat_SecretProviderRegistry_Register_nil_removes_provider(t *testing.T) {
+	// Setup
+	sut := NewSecretProviderRegistry().Register("mock", &secretProviderMock{})
+
+	// Test
+	sut.Register("mock", nil)
+
+	// Verify
+	ExpectTrue(nil == sut.Get("mock"), t)
+}
+
+// Providers
+
+func TestThat_SecretProviderEnv_GetSecret_reads_environment(t *testing.T) {
+	// Setup
+	sut := NewSecretProviderEnv()
+	t.Setenv("SECRETPROVIDERTEST_PASSWORD", "pa55word")
+
+	// Test
+	actual, err := sut.GetSecret("SECRETPROVIDERTEST_PASSWORD")
+	_, missingErr := sut.GetSecret("SECRETPROVIDERTEST_MISSING")
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectString("pa55word", actual, t)
+	ExpectError(missingErr, t)
+}
+
+func TestThat_SecretProviderFile_GetSecret_reads_files_within_base_dir(t *testing.T) {
+	// Setup
+	baseDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(baseDir, "db-password"), []byte("pa55word\n"), 0600); nil != err { t.Fatal(err) }
+	sut := NewSecretProviderFile(baseDir)
+
+	// Test
+	actual, err := sut.GetSecret("db-password")
+	_, escapeErr := sut.GetSecret("../../../etc/hostname")
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectString("pa55word", actual, t)
+	ExpectError(escapeErr, t)
+}
+
+func TestThat_SecretProviderKeystore_GetSecret_reads_encrypted_keystore(t *testing.T) {
+	// Setup
+	path := filepath.Join(t.TempDir(), "secrets.keystore")
+	err := WriteSecretKeystore(path, "correct horse", map[string]string{ "db/password": "pa55word" })
+	if ! ExpectNoError(err, t) { return }
+	content, _ := os.ReadFile(path)
+	sut := NewSecretProviderKeystore(path, "correct horse")
+
+	// Test
+	actual, err := sut.GetSecret("db/password")
+	_, missingErr := sut.GetSecret("db/missing")
+	_, wrongErr := NewSecretProviderKeystore(path, "battery staple").GetSecret("db/password")
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectString("pa55word", actual, t)
+	ExpectError(missingErr, t)
+	ExpectError(wrongErr, t)
+	ExpectFalse(strings.Contains(string(content), "pa55word"), t)
+}
+
+// Config
+
+func newSecretTestRegistry() *secretProviderRegistry {
+	return NewSecretProviderRegistry().Register("mock", &secretProviderMock{ secrets: map[string]string{
+		"db/password": "pa55word-for-config-test",
+		"api/key": "k3y-for-config-test",
+	} })
+}
+
+func TestThat_Config_ResolveSecrets_replaces_nested_references_and_redacts_output(t *testing.T) {
+	// Setup
+	sut := NewConfig()
+	sut.PrepareObject().
+		SetObjectProperty("db", data.NewObject().
+			SetObjectProperty("password", data.NewString("%secret:mock/db/password%")).
+			SetObjectProperty("dsn", data.NewString("app:%secret:mock/db/password%@tcp(db)/app"))).
+		SetObjectProperty("keys", data.NewArray().AppendArrayValue(data.NewString("%secret:mock/api/key%"))).
+		SetObjectProperty("name", data.NewString("%not-a-secret%"))
+
+	// Test
+	err := sut.ResolveSecrets(newSecretTestRegistry())
+
+	// Verify
+	if ! ExpectNoError(err, t) { return }
+	ExpectString("pa55word-for-config-test", sut.Select("db.password").GetString(), t)
+	ExpectString("app:pa55word-for-config-test@tcp(db)/app", sut.Select("db.dsn").GetString(), t)
+	ExpectString("k3y-for-config-test", sut.Select("keys[0]").GetString(), t)
+	ExpectString("%not-a-secret%", sut.Select("name").GetString(), t)
+	for _, output := range []string{ sut.ToJson(), sut.ToString(), sut.CloneConfig().ToJson() } {
+		ExpectFalse(strings.Contains(output, "pa55word"), t)
+		ExpectFalse(strings.Contains(output, "k3y"), t)
+		ExpectTrue(strings.Contains(output, "app:" + redactor.REDACTED + "@tcp(db)/app"), t)
+	}
+	ExpectString("x " + redactor.REDACTED, redactor.GetRedactor().Redact("x pa55word-for-config-test"), t)
+}
+
+func TestThat_Config_ResolveSecrets_returns_error_naming_selector(t *testing.T) {
+	// Setup
+	sut := NewConfig()
+	sut.PrepareObject().SetObjectProperty("db", data.NewObject().
+		SetObjectProperty("password", data.NewString("%secret:mock/db/nope%")))
+
+	// Test
+	err := sut.ResolveSecrets(newSecretTestRegistry())
+
+	// Verify
+	if ! ExpectError(err, t) { return }
+	ExpectTrue(strings.Contains(err.Error(), "'db.password'"), t)
+}
+
+// Configurable
+
+func TestThat_Configurable_Configure_resolves_secrets(t *testing.T) {
+	// Setup
+	sut := NewConfigurable().SetSecretProviderRegistry(newSecretTestRegistry())
+	config := NewConfig()
+	config.PrepareObject().SetObjectProperty("password", data.NewString("%secret:mock/db/password%"))
+
+	// Test
+	err := sut.Configure(config)
+
+	// Verify
+	if ! ExpectNoError(err, t) { return }
+	if ! ExpectNoError(sut.Start(), t) { return }
+	ExpectString("pa55word-for-config-test", sut.GetConfig().Select("password").GetString(), t)
+	ExpectString("%secret:mock/db/password%", config.Select("password").GetString(), t)
+}
+
+func TestThat_Configurable_Configure_returns_error_for_unresolvable_secret(t *testing.T) {
+	// Setup
+	sut := NewConfigurable().SetSecretProviderRegistry(NewSecretProviderRegistry())
+	config := NewConfig()
+	config.PrepareObject().SetObjectProperty("password", data.NewString("%secret:mock/db/password%"))
+
+	// Test
+	err := sut.Configure(config)
+
+	// Verify
+	ExpectError(err, t)
+}
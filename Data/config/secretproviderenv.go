@@ -0,0 +1,30 @@
+package config
+
+/*
+A SecretProvider for environment variables: %secret:env/DB_PASSWORD%
+*/
+
+import (
+	"os"
+	"fmt"
+)
+
+type secretProviderEnv struct { }
+
+// -------------------------------------------------------------------------------------------------
+// Factory Functions
+// -------------------------------------------------------------------------------------------------
+
+func NewSecretProviderEnv() *secretProviderEnv {
+	return &secretProviderEnv{}
+}
+
+// -------------------------------------------------------------------------------------------------
+// SecretProviderIfc
+// -------------------------------------------------------------------------------------------------
+
+func (r *secretProviderEnv) GetSecret(path string) (string, error) {
+	secret, ok := os.LookupEnv(path)
+	if ! ok { return "", fmt.Errorf("Environment variable '%s' is not set", path) }
+	return secret, nil
+}
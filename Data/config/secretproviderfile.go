@@ -0,0 +1,41 @@
+package config
+
+/*
+A SecretProvider for files under a base directory, such as Docker (/run/secrets) or Kubernetes
+secret volume mounts: %secret:file/db-password%
+
+The path may not escape the base directory. A single trailing line break is dropped since secret
+files are so often written with one.
+*/
+
+import (
+	"strings"
+	"path/filepath"
+
+	fileio "github.com/DigiStratum/GoLib/FileIO"
+)
+
+const DEFAULT_SECRET_FILE_DIR = "/run/secrets"
+
+type secretProviderFile struct {
+	baseDir		string
+}
+
+// -------------------------------------------------------------------------------------------------
+// Factory Functions
+// -------------------------------------------------------------------------------------------------
+
+func NewSecretProviderFile(baseDir string) *secretProviderFile {
+	return &secretProviderFile{ baseDir: baseDir }
+}
+
+// -------------------------------------------------------------------------------------------------
+// SecretProviderIfc
+// -------------------------------------------------------------------------------------------------
+
+func (r *secretProviderFile) GetSecret(path string) (string, error) {
+	// Cleaning the path as if it were absolute does away with any ../ that would take us outside
+	secret, err := fileio.File(filepath.Join(r.baseDir, filepath.Clean("/" + path))).ReadString()
+	if nil != err { return "", err }
+	return strings.TrimSuffix(strings.TrimSuffix(*secret, "\n"), "\r"), nil
+}
@@ -0,0 +1,134 @@
+package config
+
+/*
+A SecretProvider for an encrypted local keystore file: %secret:keystore/db/password%
+
+The keystore holds a set of named secrets, encrypted as a whole with AES-256-GCM under a key that is
+derived from a passphrase with PBKDF2-SHA256. WriteSecretKeystore() makes one; the file is JSON:
+
+	{ "version": 1, "iterations": 600000, "salt": "...", "nonce": "...", "ciphertext": "..." }
+
+The keystore is decrypted on the first GetSecret() and kept in memory thereafter. Register it with
+whatever name suits, e.g.:
+
+	config.GetSecretProviderRegistry().Register(
+		"keystore", config.NewSecretProviderKeystore("/etc/app/secrets.keystore", passphrase),
+	)
+
+TODO:
+ * Support rotating the passphrase without rewriting every secret by hand
+*/
+
+import (
+	"os"
+	"fmt"
+	"sync"
+	"crypto/aes"
+	"crypto/rand"
+	"crypto/cipher"
+	"crypto/sha256"
+	"crypto/pbkdf2"
+	gojson "encoding/json"
+)
+
+const SECRET_KEYSTORE_VERSION = 1
+const SECRET_KEYSTORE_ITERATIONS = 600000
+
+type secretProviderKeystore struct {
+	path		string
+	passphrase	string
+	secrets		map[string]string
+	mutex		sync.Mutex
+}
+
+// The keystore file format
+type secretKeystoreFile struct {
+	Version		int	`json:"version"`
+	Iterations	int	`json:"iterations"`
+	Salt		[]byte	`json:"salt"`
+	Nonce		[]byte	`json:"nonce"`
+	Ciphertext	[]byte	`json:"ciphertext"`
+}
+
+// -------------------------------------------------------------------------------------------------
+// Factory Functions
+// -------------------------------------------------------------------------------------------------
+
+func NewSecretProviderKeystore(path, passphrase string) *secretProviderKeystore {
+	return &secretProviderKeystore{ path: path, passphrase: passphrase }
+}
+
+// Encrypt the secrets into a new keystore file at path, replacing any existing one
+func WriteSecretKeystore(path, passphrase string, secrets map[string]string) error {
+	plaintext, err := gojson.Marshal(secrets)
+	if nil != err { return fmt.Errorf("WriteSecretKeystore(): %s", err.Error()) }
+	keystore := secretKeystoreFile{
+		Version:	SECRET_KEYSTORE_VERSION,
+		Iterations:	SECRET_KEYSTORE_ITERATIONS,
+		Salt:		make([]byte, 16),
+	}
+	if _, err := rand.Read(keystore.Salt); nil != err { return fmt.Errorf("WriteSecretKeystore(): %s", err.Error()) }
+	aead, err := newSecretKeystoreCipher(passphrase, keystore.Salt, keystore.Iterations)
+	if nil != err { return fmt.Errorf("WriteSecretKeystore(): %s", err.Error()) }
+	keystore.Nonce = make([]byte, aead.NonceSize())
+	if _, err := rand.Read(keystore.Nonce); nil != err { return fmt.Errorf("WriteSecretKeystore(): %s", err.Error()) }
+	keystore.Ciphertext = aead.Seal(nil, keystore.Nonce, plaintext, nil)
+	content, err := gojson.Marshal(keystore)
+	if nil != err { return fmt.Errorf("WriteSecretKeystore(): %s", err.Error()) }
+	if err := os.WriteFile(path, content, 0600); nil != err { return fmt.Errorf("WriteSecretKeystore(): %s", err.Error()) }
+	return nil
+}
+
+// -------------------------------------------------------------------------------------------------
+// SecretProviderIfc
+// -------------------------------------------------------------------------------------------------
+
+func (r *secretProviderKeystore) GetSecret(path string) (string, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if nil == r.secrets {
+		secrets, err := r.open()
+		if nil != err { return "", err }
+		r.secrets = secrets
+	}
+	secret, ok := r.secrets[path]
+	if ! ok { return "", fmt.Errorf("No secret named '%s' in keystore '%s'", path, r.path) }
+	return secret, nil
+}
+
+// -------------------------------------------------------------------------------------------------
+// secretProviderKeystore
+// -------------------------------------------------------------------------------------------------
+
+func (r *secretProviderKeystore) open() (map[string]string, error) {
+	content, err := os.ReadFile(r.path)
+	if nil != err { return nil, fmt.Errorf("Error reading keystore: %s", err.Error()) }
+	var keystore secretKeystoreFile
+	if err := gojson.Unmarshal(content, &keystore); nil != err {
+		return nil, fmt.Errorf("Malformed keystore '%s': %s", r.path, err.Error())
+	}
+	if SECRET_KEYSTORE_VERSION != keystore.Version {
+		return nil, fmt.Errorf("Unsupported keystore version %d in '%s'", keystore.Version, r.path)
+	}
+	aead, err := newSecretKeystoreCipher(r.passphrase, keystore.Salt, keystore.Iterations)
+	if nil != err { return nil, err }
+	if len(keystore.Nonce) != aead.NonceSize() { return nil, fmt.Errorf("Malformed keystore '%s': bad nonce", r.path) }
+	plaintext, err := aead.Open(nil, keystore.Nonce, keystore.Ciphertext, nil)
+	if nil != err {
+		return nil, fmt.Errorf("Cannot decrypt keystore '%s'; wrong passphrase or corrupt file", r.path)
+	}
+	secrets := make(map[string]string)
+	if err := gojson.Unmarshal(plaintext, &secrets); nil != err {
+		return nil, fmt.Errorf("Malformed keystore '%s': %s", r.path, err.Error())
+	}
+	return secrets, nil
+}
+
+func newSecretKeystoreCipher(passphrase string, salt []byte, iterations int) (cipher.AEAD, error) {
+	if iterations < 1 { return nil, fmt.Errorf("Bad keystore iteration count %d", iterations) }
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, iterations, 32)
+	if nil != err { return nil, err }
+	block, err := aes.NewCipher(key)
+	if nil != err { return nil, err }
+	return cipher.NewGCM(block)
+}
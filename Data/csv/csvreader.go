@@ -0,0 +1,146 @@
+// DigiStratum GoLib - CSV
+package csv
+
+/*
+
+Read delimited text (RFC 4180 CSV, or TSV) into a DataValue array of objects; one object per record
+with one property per column.
+
+Column names come from the first (header) record by default. If the source has no header record,
+or if the header names are undesirable, explicit column names may be supplied instead; when both a
+header record and explicit column names are present, the header record is skipped and the explicit
+names win.
+
+All values are strings by default. With type inference enabled, each value is examined and converted
+to the first DataType that it matches (see Data/inferdatavalue.go); an empty value becomes a null.
+
+Ref: https://www.rfc-editor.org/rfc/rfc4180
+
+TODO:
+ * Add support for chunked/streaming reads that hand off one record at a time instead of loading
+   the entire document into a single DataValue
+
+*/
+
+import (
+	"io"
+	"fmt"
+	gocsv "encoding/csv"
+
+	"github.com/DigiStratum/GoLib/Data"
+)
+
+type CsvReaderIfc interface {
+	SetDelimiter(delimiter rune) *csvReader
+	SetHasHeader(hasHeader bool) *csvReader
+	SetColumns(columns ...string) *csvReader
+	SetInferTypes(inferTypes bool) *csvReader
+	ToDataValue() (*data.DataValue, error)
+}
+
+type csvReader struct {
+	reader		io.Reader
+	delimiter	rune
+	hasHeader	bool
+	columns		[]string
+	inferTypes	bool
+}
+
+// -------------------------------------------------------------------------------------------------
+// Factory Functions
+// -------------------------------------------------------------------------------------------------
+
+// Make a new one of these for comma separated values with a header record
+func NewCsvReader(reader io.Reader) *csvReader {
+	return &csvReader{
+		reader:		reader,
+		delimiter:	',',
+		hasHeader:	true,
+	}
+}
+
+// Make a new one of these for tab separated values with a header record
+func NewTsvReader(reader io.Reader) *csvReader {
+	return NewCsvReader(reader).SetDelimiter('\t')
+}
+
+// -------------------------------------------------------------------------------------------------
+// CsvReaderIfc
+// -------------------------------------------------------------------------------------------------
+
+func (r *csvReader) SetDelimiter(delimiter rune) *csvReader {
+	r.delimiter = delimiter
+	return r
+}
+
+func (r *csvReader) SetHasHeader(hasHeader bool) *csvReader {
+	r.hasHeader = hasHeader
+	return r
+}
+
+func (r *csvReader) SetColumns(columns ...string) *csvReader {
+	r.columns = columns
+	return r
+}
+
+func (r *csvReader) SetInferTypes(inferTypes bool) *csvReader {
+	r.inferTypes = inferTypes
+	return r
+}
+
+// Read all the records into a DataValue array of objects
+func (r *csvReader) ToDataValue() (*data.DataValue, error) {
+	if nil == r.reader { return nil, fmt.Errorf("csvReader.ToDataValue(): nil reader") }
+	if (! r.hasHeader) && (0 == len(r.columns)) {
+		return nil, fmt.Errorf("csvReader.ToDataValue(): no header record and no column names supplied")
+	}
+
+	reader := gocsv.NewReader(r.reader)
+	reader.Comma = r.delimiter
+	// The header record may be any width when it is skipped in favour of explicit column names
+	reader.FieldsPerRecord = -1
+
+	columns := r.columns
+	if r.hasHeader {
+		header, err := reader.Read()
+		if io.EOF == err { return data.NewArray(), nil }
+		if nil != err { return nil, fmt.Errorf("csvReader.ToDataValue(): error reading header: %s", err.Error()) }
+		if 0 == len(columns) { columns = header }
+	}
+	if err := r.validateColumns(columns); nil != err { return nil, err }
+	reader.FieldsPerRecord = len(columns)
+
+	result := data.NewArray()
+	for {
+		record, err := reader.Read()
+		if io.EOF == err { break }
+		if nil != err { return nil, fmt.Errorf("csvReader.ToDataValue(): error reading record: %s", err.Error()) }
+		object := data.NewObject()
+		for index, column := range columns {
+			object.SetObjectProperty(column, r.toDataValue(record[index]))
+		}
+		result.AppendArrayValue(object)
+	}
+	return result, nil
+}
+
+// -------------------------------------------------------------------------------------------------
+// csvReader
+// -------------------------------------------------------------------------------------------------
+
+func (r *csvReader) validateColumns(columns []string) error {
+	seen := make(map[string]bool)
+	for _, column := range columns {
+		if 0 == len(column) { return fmt.Errorf("csvReader.ToDataValue(): empty column name") }
+		if _, ok := seen[column]; ok {
+			return fmt.Errorf("csvReader.ToDataValue(): duplicate column name '%s'", column)
+		}
+		seen[column] = true
+	}
+	return nil
+}
+
+func (r *csvReader) toDataValue(value string) *data.DataValue {
+	if ! r.inferTypes { return data.NewString(value) }
+	return data.InferDataValue(value)
+}
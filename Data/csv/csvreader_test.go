@@ -0,0 +1,175 @@
+package csv
+
+/*
+
+Unit Tests for csvReader
+
+*/
+
+import(
+	"strings"
+	"testing"
+
+	. "github.com/DigiStratum/GoLib/Testing"
+)
+
+func TestThat_CsvReader_NewCsvReader_ReturnsInstance(t *testing.T) {
+	// Setup
+	var sut CsvReaderIfc = NewCsvReader(strings.NewReader("")) // <- ensures that we satisfy our interface
+
+	// Verify
+	ExpectNonNil(sut, t)
+}
+
+func TestThat_CsvReader_ToDataValue_ReturnsError_ForNilReader(t *testing.T) {
+	// Setup
+	sut := NewCsvReader(nil)
+
+	// Test
+	actual, err := sut.ToDataValue()
+
+	// Verify
+	ExpectNil(actual, t)
+	ExpectError(err, t)
+}
+
+func TestThat_CsvReader_ToDataValue_ReturnsError_WithoutHeaderOrColumns(t *testing.T) {
+	// Setup
+	sut := NewCsvReader(strings.NewReader("a,b\n")).SetHasHeader(false)
+
+	// Test
+	actual, err := sut.ToDataValue()
+
+	// Verify
+	ExpectNil(actual, t)
+	ExpectError(err, t)
+}
+
+func TestThat_CsvReader_ToDataValue_ReturnsEmptyArray_ForEmptySource(t *testing.T) {
+	// Setup
+	sut := NewCsvReader(strings.NewReader(""))
+
+	// Test
+	actual, err := sut.ToDataValue()
+
+	// Verify
+	ExpectNoError(err, t)
+	if ! ExpectNonNil(actual, t) { return }
+	ExpectTrue(actual.IsArray(), t)
+	ExpectInt(0, actual.GetArraySize(), t)
+}
+
+func TestThat_CsvReader_ToDataValue_ReadsObjects_UsingHeaderRecord(t *testing.T) {
+	// Setup
+	sut := NewCsvReader(strings.NewReader("id,name\n1,\"Smith, John\"\n2,\"say \"\"hi\"\"\"\n"))
+
+	// Test
+	actual, err := sut.ToDataValue()
+
+	// Verify
+	ExpectNoError(err, t)
+	if ! ExpectNonNil(actual, t) { return }
+	ExpectInt(2, actual.GetArraySize(), t)
+	ExpectString("1", actual.Select("[0].id").GetString(), t)
+	ExpectString("Smith, John", actual.Select("[0].name").GetString(), t)
+	ExpectString("say \"hi\"", actual.Select("[1].name").GetString(), t)
+}
+
+func TestThat_CsvReader_ToDataValue_ReadsObjects_UsingExplicitColumns(t *testing.T) {
+	// Setup
+	sut := NewCsvReader(strings.NewReader("1,one\n2,two\n")).
+		SetHasHeader(false).
+		SetColumns("num", "word")
+
+	// Test
+	actual, err := sut.ToDataValue()
+
+	// Verify
+	ExpectNoError(err, t)
+	if ! ExpectNonNil(actual, t) { return }
+	ExpectInt(2, actual.GetArraySize(), t)
+	ExpectString("two", actual.Select("[1].word").GetString(), t)
+}
+
+func TestThat_CsvReader_ToDataValue_SkipsHeader_WhenExplicitColumnsSupplied(t *testing.T) {
+	// Setup
+	sut := NewCsvReader(strings.NewReader("a,b\n1,2\n")).SetColumns("x", "y")
+
+	// Test
+	actual, err := sut.ToDataValue()
+
+	// Verify
+	ExpectNoError(err, t)
+	if ! ExpectNonNil(actual, t) { return }
+	ExpectInt(1, actual.GetArraySize(), t)
+	ExpectString("2", actual.Select("[0].y").GetString(), t)
+	ExpectFalse(actual.GetArrayValue(0).HasObjectProperty("a"), t)
+}
+
+func TestThat_CsvReader_ToDataValue_SkipsHeader_WhenExplicitColumnCountDiffers(t *testing.T) {
+	// Setup
+	sut := NewCsvReader(strings.NewReader("a,b,c\n1,2\n")).SetColumns("x", "y")
+
+	// Test
+	actual, err := sut.ToDataValue()
+
+	// Verify
+	ExpectNoError(err, t)
+	if ! ExpectNonNil(actual, t) { return }
+	ExpectInt(1, actual.GetArraySize(), t)
+	ExpectString("2", actual.Select("[0].y").GetString(), t)
+}
+
+func TestThat_CsvReader_ToDataValue_ReturnsError_WhenRecordWidthDiffersFromColumns(t *testing.T) {
+	// Setup
+	sut := NewCsvReader(strings.NewReader("a,b\n1,2\n3,4,5\n"))
+
+	// Test
+	_, err := sut.ToDataValue()
+
+	// Verify
+	ExpectError(err, t)
+}
+
+func TestThat_CsvReader_ToDataValue_ReturnsError_ForDuplicateColumns(t *testing.T) {
+	// Setup
+	sut := NewCsvReader(strings.NewReader("a,a\n1,2\n"))
+
+	// Test
+	actual, err := sut.ToDataValue()
+
+	// Verify
+	ExpectNil(actual, t)
+	ExpectError(err, t)
+}
+
+func TestThat_CsvReader_ToDataValue_ReturnsError_ForWrongFieldCount(t *testing.T) {
+	// Setup
+	sut := NewCsvReader(strings.NewReader("a,b\n1,2,3\n"))
+
+	// Test
+	actual, err := sut.ToDataValue()
+
+	// Verify
+	ExpectNil(actual, t)
+	ExpectError(err, t)
+}
+
+func TestThat_CsvReader_ToDataValue_InfersTypes_WhenEnabled(t *testing.T) {
+	// Setup
+	sut := NewTsvReader(strings.NewReader("i\tf\tb\tn\ts\tz\n-42\t3.5\tTRUE\t\thello\t007\n")).
+		SetInferTypes(true)
+
+	// Test
+	actual, err := sut.ToDataValue()
+
+	// Verify
+	ExpectNoError(err, t)
+	if ! ExpectNonNil(actual, t) { return }
+	ExpectInt64(-42, actual.Select("[0].i").GetInteger(), t)
+	ExpectFloat64(3.5, actual.Select("[0].f").GetFloat(), t)
+	ExpectTrue(actual.Select("[0].b").GetBoolean(), t)
+	ExpectTrue(actual.Select("[0].n").IsNull(), t)
+	ExpectString("hello", actual.Select("[0].s").GetString(), t)
+	ExpectString("007", actual.Select("[0].z").GetString(), t)
+}
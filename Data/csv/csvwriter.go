@@ -0,0 +1,142 @@
+package csv
+
+/*
+
+Write a DataValue array of objects out as delimited text (RFC 4180 CSV, or TSV); one record per
+object with one field per column.
+
+Columns are written in the order supplied with SetColumns(); properties not named there are left
+out. Without explicit columns, the union of all property names across all objects is written in
+sorted order so that the output is stable from one run to the next.
+
+Values are written as follows:
+
+  NULL, missing property: '' (empty)
+  STRING:                 as-is (quoted by the writer only if needed)
+  BOOLEAN|INTEGER|FLOAT:  as with DataValue.ToString()
+  OBJECT|ARRAY:           as JSON, since there is no flat representation
+
+*/
+
+import (
+	"io"
+	"fmt"
+	"sort"
+	gocsv "encoding/csv"
+
+	"github.com/DigiStratum/GoLib/Data"
+)
+
+type CsvWriterIfc interface {
+	SetDelimiter(delimiter rune) *csvWriter
+	SetHasHeader(hasHeader bool) *csvWriter
+	SetColumns(columns ...string) *csvWriter
+	Write(dataValue *data.DataValue) error
+}
+
+type csvWriter struct {
+	writer		io.Writer
+	delimiter	rune
+	hasHeader	bool
+	columns		[]string
+}
+
+// -------------------------------------------------------------------------------------------------
+// Factory Functions
+// -------------------------------------------------------------------------------------------------
+
+// Make a new one of these for comma separated values with a header record
+func NewCsvWriter(writer io.Writer) *csvWriter {
+	return &csvWriter{
+		writer:		writer,
+		delimiter:	',',
+		hasHeader:	true,
+	}
+}
+
+// Make a new one of these for tab separated values with a header record
+func NewTsvWriter(writer io.Writer) *csvWriter {
+	return NewCsvWriter(writer).SetDelimiter('\t')
+}
+
+// -------------------------------------------------------------------------------------------------
+// CsvWriterIfc
+// -------------------------------------------------------------------------------------------------
+
+func (r *csvWriter) SetDelimiter(delimiter rune) *csvWriter {
+	r.delimiter = delimiter
+	return r
+}
+
+func (r *csvWriter) SetHasHeader(hasHeader bool) *csvWriter {
+	r.hasHeader = hasHeader
+	return r
+}
+
+func (r *csvWriter) SetColumns(columns ...string) *csvWriter {
+	r.columns = columns
+	return r
+}
+
+// Write the supplied DataValue array of objects
+func (r *csvWriter) Write(dataValue *data.DataValue) error {
+	if nil == r.writer { return fmt.Errorf("csvWriter.Write(): nil writer") }
+	if (nil == dataValue) || ! dataValue.IsArray() {
+		return fmt.Errorf("csvWriter.Write(): expected an array of objects")
+	}
+	for index := 0; index < dataValue.GetArraySize(); index++ {
+		if ! dataValue.GetArrayValue(index).IsObject() {
+			return fmt.Errorf("csvWriter.Write(): array element %d is not an object", index)
+		}
+	}
+
+	columns := r.columns
+	if 0 == len(columns) { columns = r.collectColumns(dataValue) }
+
+	writer := gocsv.NewWriter(r.writer)
+	writer.Comma = r.delimiter
+	if r.hasHeader {
+		if err := writer.Write(columns); nil != err {
+			return fmt.Errorf("csvWriter.Write(): error writing header: %s", err.Error())
+		}
+	}
+	record := make([]string, len(columns))
+	for index := 0; index < dataValue.GetArraySize(); index++ {
+		object := dataValue.GetArrayValue(index)
+		for columnIndex, column := range columns {
+			record[columnIndex] = r.toField(object.GetObjectProperty(column))
+		}
+		if err := writer.Write(record); nil != err {
+			return fmt.Errorf("csvWriter.Write(): error writing record %d: %s", index, err.Error())
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// -------------------------------------------------------------------------------------------------
+// csvWriter
+// -------------------------------------------------------------------------------------------------
+
+func (r *csvWriter) collectColumns(dataValue *data.DataValue) []string {
+	seen := make(map[string]bool)
+	columns := make([]string, 0)
+	for index := 0; index < dataValue.GetArraySize(); index++ {
+		for _, name := range dataValue.GetArrayValue(index).GetObjectProperties() {
+			if _, ok := seen[name]; ok { continue }
+			seen[name] = true
+			columns = append(columns, name)
+		}
+	}
+	sort.Strings(columns)
+	return columns
+}
+
+func (r *csvWriter) toField(value *data.DataValue) string {
+	if nil == value { return "" }
+	switch value.GetType() {
+		case data.DATA_TYPE_NULL, data.DATA_TYPE_INVALID: return ""
+		case data.DATA_TYPE_OBJECT, data.DATA_TYPE_ARRAY: return value.ToJson()
+	}
+	return value.ToString()
+}
@@ -0,0 +1,100 @@
+package csv
+
+/*
+
+Unit Tests for csvWriter
+
+*/
+
+import(
+	"strings"
+	"testing"
+
+	"github.com/DigiStratum/GoLib/Data"
+	. "github.com/DigiStratum/GoLib/Testing"
+)
+
+func TestThat_CsvWriter_NewCsvWriter_ReturnsInstance(t *testing.T) {
+	// Setup
+	var sut CsvWriterIfc = NewCsvWriter(&strings.Builder{}) // <- ensures that we satisfy our interface
+
+	// Verify
+	ExpectNonNil(sut, t)
+}
+
+func TestThat_CsvWriter_Write_ReturnsError_ForNonArray(t *testing.T) {
+	// Setup
+	sut := NewCsvWriter(&strings.Builder{})
+
+	// Test
+	err := sut.Write(data.NewObject())
+
+	// Verify
+	ExpectError(err, t)
+}
+
+func TestThat_CsvWriter_Write_ReturnsError_ForNonObjectElement(t *testing.T) {
+	// Setup
+	sut := NewCsvWriter(&strings.Builder{})
+	dataValue := data.NewArray().AppendArrayValue(data.NewString("nope"))
+
+	// Test
+	err := sut.Write(dataValue)
+
+	// Verify
+	ExpectError(err, t)
+}
+
+func TestThat_CsvWriter_Write_WritesSortedColumns_WhenNoneSupplied(t *testing.T) {
+	// Setup
+	var sb strings.Builder
+	sut := NewCsvWriter(&sb)
+	dataValue := data.NewArray().
+		AppendArrayValue(data.NewObject().
+			SetObjectProperty("b", data.NewInteger(1)).
+			SetObjectProperty("a", data.NewString("x, y"))).
+		AppendArrayValue(data.NewObject().
+			SetObjectProperty("c", data.NewBoolean(true)).
+			SetObjectProperty("a", data.NewNull()))
+
+	// Test
+	err := sut.Write(dataValue)
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectString("a,b,c\n\"x, y\",1,\n,,true\n", sb.String(), t)
+}
+
+func TestThat_CsvWriter_Write_WritesSuppliedColumnsInOrder(t *testing.T) {
+	// Setup
+	var sb strings.Builder
+	sut := NewTsvWriter(&sb).SetColumns("b", "a").SetHasHeader(false)
+	dataValue := data.NewArray().
+		AppendArrayValue(data.NewObject().
+			SetObjectProperty("a", data.NewFloat(1.5)).
+			SetObjectProperty("b", data.NewArray().AppendArrayValue(data.NewInteger(2))).
+			SetObjectProperty("c", data.NewString("dropped")))
+
+	// Test
+	err := sut.Write(dataValue)
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectString("[2]\t1.5\n", sb.String(), t)
+}
+
+func TestThat_CsvWriter_Write_RoundTripsWithCsvReader(t *testing.T) {
+	// Setup
+	source := "id,name,ok\n1,\"Smith, John\",true\n2,\"line\nbreak\",false\n"
+	dataValue, err := NewCsvReader(strings.NewReader(source)).SetInferTypes(true).ToDataValue()
+	if ! ExpectNoError(err, t) { return }
+	var sb strings.Builder
+	sut := NewCsvWriter(&sb).SetColumns("id", "name", "ok")
+
+	// Test
+	err = sut.Write(dataValue)
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectString(source, sb.String(), t)
+}
@@ -11,6 +11,7 @@ const (
 	DATA_TYPE_STRING
 	DATA_TYPE_OBJECT
 	DATA_TYPE_ARRAY
+	DATA_TYPE_BINARY
 )
 
 func (r DataType) ToString() string {
@@ -23,6 +24,7 @@ func (r DataType) ToString() string {
 		case DATA_TYPE_STRING: return "string"
 		case DATA_TYPE_OBJECT: return "object"
 		case DATA_TYPE_ARRAY: return "array"
+		case DATA_TYPE_BINARY: return "binary"
 	}
 	return ""
 }
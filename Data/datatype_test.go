@@ -16,7 +16,8 @@ func TestThat_DataType_ToString_Returns_expected_type_descriptors(t *testing.T)
 	if ! ExpectString("string", DATA_TYPE_STRING.ToString(), t) { return }
 	if ! ExpectString("object", DATA_TYPE_OBJECT.ToString(), t) { return }
 	if ! ExpectString("array", DATA_TYPE_ARRAY.ToString(), t) { return }
-	dt := DATA_TYPE_ARRAY + 1
+	if ! ExpectString("binary", DATA_TYPE_BINARY.ToString(), t) { return }
+	dt := DATA_TYPE_BINARY + 1
 	if ! ExpectString("", dt.ToString(), t) { return }
 }
 
@@ -39,19 +39,19 @@ TODO:
    document into memory before lexing into structured data)
  * Add YAML loader/lexer like json
  * Add INI loader/lexer like json
- * Add XML loader/lexer like json
- * Add CSV loader/lexer like json
- * Add loader/lexers for Google Protocol Buffers (AKA protobuf), MessagePack, BSON (Binary JSON),
+ * Add loader/lexers for Google Protocol Buffers (AKA protobuf), BSON (Binary JSON),
    and Avro (from Apache Hadoop) for faster/tighter data handling, application-to-application data
    exchange where human readability is less important
- * Add support for binary (bytearray) data type
  * Consider Iterating tree recursively for all data types, not just Object|Array; maybe some new type
    of iterator with an onMutation circuit breaker and callable (i.e. Iterator calls callable
 */
 
 import (
 	"fmt"
+	"bytes"
 	"strings"
+	"encoding/gob"
+	"encoding/base64"
 	"strconv"
 	"unicode"
 
@@ -112,6 +112,11 @@ type DataValueIfc interface {
 	GetInteger() int64
 	SetInteger(value int64) *DataValue
 
+	// Binaries
+	IsBinary() bool
+	GetBinary() []byte
+	SetBinary(value []byte) *DataValue
+
 	// Modern amenities ;^)
 	Select(selector string) *DataValue
 	HasAll(selectors ...string) bool
@@ -130,6 +135,7 @@ type DataValue struct {
 	valueInteger		int64
 	valueFloat		float64
 	valueString		string
+	valueBinary		[]byte
 	valueArray		[]*DataValue
 	valueObject		map[string]*DataValue
 }
@@ -159,6 +165,8 @@ func NewFloat(value float64) *DataValue { return NewDataValue().SetFloat(value)
 
 func NewInteger(value int64) *DataValue { return NewDataValue().SetInteger(value) }
 
+func NewBinary(value []byte) *DataValue { return NewDataValue().SetBinary(value) }
+
 // -------------------------------------------------------------------------------------------------
 // DataValueIfc
 // -------------------------------------------------------------------------------------------------
@@ -509,6 +517,31 @@ func (r *DataValue) SetInteger(value int64) *DataValue {
 	return r
 }
 
+// -----------------------------------------------
+// Binaries
+
+func (r *DataValue) IsBinary() bool {
+	r.err = nil
+	return r.dataType == DATA_TYPE_BINARY
+}
+
+func (r *DataValue) GetBinary() []byte {
+	r.err = nil
+	if ! r.IsBinary() { return nil }
+	return r.valueBinary
+}
+
+func (r *DataValue) SetBinary(value []byte) *DataValue {
+	if r.isImmutable {
+		r.err = fmt.Errorf("Data is immutable, cannot modify!")
+		return r
+	}
+	r.err = nil
+	r.dataType = DATA_TYPE_BINARY
+	r.valueBinary = value
+	return r
+}
+
 // -----------------------------------------------
 // Conveniences
 
@@ -625,6 +658,10 @@ func (r *DataValue) Clone() *DataValue {
 		valueString:		r.valueString,
 	}
 	switch r.dataType {
+		case DATA_TYPE_BINARY:
+			dv.valueBinary = make([]byte, len(r.valueBinary))
+			copy(dv.valueBinary, r.valueBinary)
+
 		case DATA_TYPE_ARRAY:
 			dv.valueArray = make([]*DataValue, 0)
 			for _, arrayValue := range r.valueArray {
@@ -691,6 +728,83 @@ func (r *DataValue) GetIterator() func () interface{} {
 	return nil
 }
 
+// -------------------------------------------------------------------------------------------------
+// encoding/gob.GobEncoder, GobDecoder
+// -------------------------------------------------------------------------------------------------
+
+// Exported mirror of DataValue's state so that gob can see it; gob handles the recursion for us
+type gobDataValue struct {
+	Type		DataType
+	Boolean		bool
+	Integer		int64
+	Float		float64
+	String		string
+	Binary		[]byte
+	Array		[]*gobDataValue
+	Object		map[string]*gobDataValue
+}
+
+func (r *DataValue) GobEncode() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(r.toGob()); nil != err { return nil, err }
+	return buf.Bytes(), nil
+}
+
+func (r *DataValue) GobDecode(encoded []byte) error {
+	if r.isImmutable { return fmt.Errorf("Data is immutable, cannot modify!") }
+	var gdv gobDataValue
+	if err := gob.NewDecoder(bytes.NewReader(encoded)).Decode(&gdv); nil != err { return err }
+	*r = *fromGob(&gdv)
+	return nil
+}
+
+func (r *DataValue) toGob() *gobDataValue {
+	gdv := gobDataValue{
+		Type:		r.dataType,
+		Boolean:	r.valueBoolean,
+		Integer:	r.valueInteger,
+		Float:		r.valueFloat,
+		String:		r.valueString,
+		Binary:		r.valueBinary,
+	}
+	switch r.dataType {
+		case DATA_TYPE_ARRAY:
+			gdv.Array = make([]*gobDataValue, len(r.valueArray))
+			for index, value := range r.valueArray { gdv.Array[index] = value.toGob() } // <- BEWARE: recursion!
+		case DATA_TYPE_OBJECT:
+			gdv.Object = make(map[string]*gobDataValue)
+			for key, value := range r.valueObject { gdv.Object[key] = value.toGob() } // <- BEWARE: recursion!
+	}
+	return &gdv
+}
+
+func fromGob(gdv *gobDataValue) *DataValue {
+	dv := DataValue{
+		dataType:	gdv.Type,
+		valueBoolean:	gdv.Boolean,
+		valueInteger:	gdv.Integer,
+		valueFloat:	gdv.Float,
+		valueString:	gdv.String,
+		valueBinary:	gdv.Binary,
+	}
+	switch gdv.Type {
+		case DATA_TYPE_ARRAY:
+			dv.valueArray = make([]*DataValue, 0, len(gdv.Array))
+			for _, value := range gdv.Array {
+				// gob sends nil pointers as zero values, so guard anyway
+				if nil == value { value = &gobDataValue{ Type: DATA_TYPE_NULL } }
+				dv.valueArray = append(dv.valueArray, fromGob(value)) // <- BEWARE: recursion!
+			}
+		case DATA_TYPE_OBJECT:
+			dv.valueObject = make(map[string]*DataValue)
+			for key, value := range gdv.Object {
+				if nil == value { value = &gobDataValue{ Type: DATA_TYPE_NULL } }
+				dv.valueObject[key] = fromGob(value) // <- BEWARE: recursion!
+			}
+	}
+	return &dv
+}
+
 // -----------------------------------------------
 // Internal implementation
 
@@ -814,6 +928,12 @@ func (r *DataValue) stringify(quoteStrings bool) string {
 
 		case DATA_TYPE_FLOAT: return fmt.Sprint(r.valueFloat)
 
+		// There is no JSON binary type, so we use base64 (RFC 4648) as the string representation
+		case DATA_TYPE_BINARY:
+			encoded := base64.StdEncoding.EncodeToString(r.valueBinary)
+			if quoteStrings { return strconv.Quote(encoded) }
+			return encoded
+
 		case DATA_TYPE_ARRAY:
 			var sb strings.Builder
 			sb.WriteString("[")
@@ -1,6 +1,8 @@
 package data
 
 import(
+	"bytes"
+	"encoding/gob"
 	"fmt"
 	"strings"
 	"testing"
@@ -860,6 +862,51 @@ func TestThat_DataValue_GetInteger_returns_0value_for_non_integers(t *testing.T)
 	if ! ExpectInt64(0, sut.GetInteger(), t) { return }
 }
 
+// Binaries
+
+func TestThat_NewBinary_clears_error_sets_type_and_returns_value(t *testing.T) {
+	// Setup
+	expectedBinary := []byte{ 0x00, 0xff, 0x10 }
+
+	// Test
+	actual := NewBinary(expectedBinary)
+
+	// Verify
+	if ! ExpectNonNil(actual, t) { return }
+	if ! ExpectTrue(actual.IsBinary(), t) { return }
+	if ! ExpectNoError(actual.GetError(), t) { return }
+	if ! ExpectString(string(expectedBinary), string(actual.GetBinary()), t) { return }
+}
+
+func TestThat_DataValue_SetBinary_sets_error_when_immutable(t *testing.T) {
+	// Setup
+	sut := NewArray().SetImmutable()
+
+	// Test
+	sut.SetBinary([]byte{ 0x01 })
+
+	// Verify
+	if ! ExpectError(sut.GetError(), t) { return }
+	if ! ExpectTrue(sut.IsArray(), t) { return }
+}
+
+func TestThat_DataValue_GetBinary_returns_nil_for_non_binaries(t *testing.T) {
+	// Setup
+	sut := NewString("abc")
+
+	// Verify
+	if ! ExpectTrue(nil == sut.GetBinary(), t) { return }
+}
+
+func TestThat_DataValue_ToJson_Returns_base64_for_binaries(t *testing.T) {
+	// Setup
+	sut := NewBinary([]byte("hello"))
+
+	// Verify
+	if ! ExpectString("\"aGVsbG8=\"", sut.ToJson(), t) { return }
+	if ! ExpectString("aGVsbG8=", sut.ToString(), t) { return }
+}
+
 // Conveniences
 
 func makeBigDataValue() *DataValue {
@@ -1314,3 +1361,52 @@ func TestThat_DataValue_Clone_Returns_deep_copy(t *testing.T) {
 	if ! ExpectTrue(actual.GetArrayValue(2).GetObjectProperty("null").IsNull(), t) { return }
 }
 
+func TestThat_DataValue_Clone_Returns_independent_binary_copy(t *testing.T) {
+	// Setup
+	source := []byte{ 0x01, 0x02 }
+	sut := NewBinary(source)
+
+	// Test
+	actual := sut.Clone()
+	source[0] = 0x09
+
+	// Verify
+	if ! ExpectTrue(actual.IsBinary(), t) { return }
+	if ! ExpectInt(0x01, int(actual.GetBinary()[0]), t) { return }
+}
+
+
+func TestThat_DataValue_GobEncode_RoundTrips_with_GobDecode(t *testing.T) {
+	// Setup
+	sut := NewObject().
+		SetObjectProperty("s", NewString("x")).
+		SetObjectProperty("b", NewBinary([]byte{ 0x00, 0xff })).
+		SetObjectProperty("a", NewArray().AppendArrayValue(NewFloat(2.5)).AppendArrayValue(NewNull()))
+	var buf bytes.Buffer
+
+	// Test
+	err := gob.NewEncoder(&buf).Encode(sut)
+	if ! ExpectNoError(err, t) { return }
+	actual := NewDataValue()
+	err = gob.NewDecoder(&buf).Decode(actual)
+
+	// Verify
+	if ! ExpectNoError(err, t) { return }
+	ExpectString("x", actual.GetObjectProperty("s").GetString(), t)
+	ExpectTrue(actual.GetObjectProperty("b").IsBinary(), t)
+	ExpectString(sut.GetObjectProperty("b").ToJson(), actual.GetObjectProperty("b").ToJson(), t)
+	ExpectString("[2.5,null]", actual.GetObjectProperty("a").ToJson(), t)
+}
+
+func TestThat_DataValue_GobDecode_Returns_error_when_immutable(t *testing.T) {
+	// Setup
+	encoded, err := NewString("x").GobEncode()
+	if ! ExpectNoError(err, t) { return }
+	sut := NewDataValue().SetImmutable()
+
+	// Test
+	err = sut.GobDecode(encoded)
+
+	// Verify
+	ExpectError(err, t)
+}
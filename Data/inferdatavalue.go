@@ -0,0 +1,42 @@
+package data
+
+/*
+
+Infer a DataValue from text which carries no type of its own (a CSV field, an environment variable,
+a command line flag...), converting it to the first DataType that it matches:
+
+  NULL:    '' (empty) or 'null' (any case)
+  BOOLEAN: 'true'|'false' (any case)
+  INTEGER: '-12', '345' (but not '007' - leading zeros suggest an identifier, so it stays a string)
+  FLOAT:   '3.14', '-2.79e-4'
+  STRING:  anything else
+
+*/
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Convert a string value to the first DataType that it matches (see above)
+func InferDataValue(value string) *DataValue {
+	lower := strings.ToLower(value)
+	switch lower {
+		case "", "null": return NewNull()
+		case "true": return NewBoolean(true)
+		case "false": return NewBoolean(false)
+	}
+
+	// Leading zeros (other than a lone "0" or "0.x") suggest an identifier; leave it as a string
+	digits := strings.TrimPrefix(value, "-")
+	if (len(digits) > 1) && ('0' == digits[0]) && ('.' != digits[1]) { return NewString(value) }
+
+	if i, err := strconv.ParseInt(value, 10, 64); nil == err { return NewInteger(i) }
+
+	// ParseFloat will happily accept "Inf" and "NaN"; we only want regular numbers
+	if f, err := strconv.ParseFloat(value, 64); (nil == err) && ! (math.IsInf(f, 0) || math.IsNaN(f)) {
+		return NewFloat(f)
+	}
+	return NewString(value)
+}
@@ -0,0 +1,29 @@
+package data
+
+import(
+	"testing"
+
+	. "github.com/DigiStratum/GoLib/Testing"
+)
+
+func TestThat_InferDataValue_ReturnsExpectedTypes(t *testing.T) {
+	// Setup
+	cases := map[string]DataType{
+		"":		DATA_TYPE_NULL,
+		"NULL":		DATA_TYPE_NULL,
+		"false":	DATA_TYPE_BOOLEAN,
+		"0":		DATA_TYPE_INTEGER,
+		"-17":		DATA_TYPE_INTEGER,
+		"0.25":		DATA_TYPE_FLOAT,
+		"-2.79e-4":	DATA_TYPE_FLOAT,
+		"NaN":		DATA_TYPE_STRING,
+		"Inf":		DATA_TYPE_STRING,
+		"0123":		DATA_TYPE_STRING,
+		"abc":		DATA_TYPE_STRING,
+	}
+
+	// Test / Verify
+	for value, expected := range cases {
+		if ! ExpectEqual(expected, InferDataValue(value).GetType(), t) { t.Logf("value: '%s'", value) }
+	}
+}
@@ -0,0 +1,312 @@
+// DigiStratum GoLib - MessagePack
+package msgpack
+
+/*
+
+Encode a DataValue to MessagePack, and decode MessagePack back into a DataValue.
+
+DataType mapping:
+
+  NULL    <=> nil
+  BOOLEAN <=> false|true
+  INTEGER <=> positive/negative fixint, uint 8/16/32/64, int 8/16/32/64 (smallest that fits)
+  FLOAT   <=> float 64 (float 32 is accepted when decoding)
+  STRING  <=> fixstr, str 8/16/32
+  BINARY  <=> bin 8/16/32
+  ARRAY   <=> fixarray, array 16/32
+  OBJECT  <=> fixmap, map 16/32 (keys written sorted, so equal values encode identically)
+
+Decoding fails for map keys that are not strings, for uint 64 values too large for an int64, and
+for extension types, none of which have a DataValue equivalent.
+
+Ref: https://github.com/msgpack/msgpack/blob/master/spec.md
+
+*/
+
+import(
+	"fmt"
+	"math"
+	"sort"
+	"encoding/binary"
+
+	"github.com/DigiStratum/GoLib/Data"
+)
+
+// Nesting limit for decoding so that hostile input cannot exhaust the stack
+const MSGPACK_MAX_DEPTH = 512
+
+type MsgPackIfc interface {
+	Encode(dataValue *data.DataValue) ([]byte, error)
+	Decode(encoded []byte) (*data.DataValue, error)
+}
+
+type msgPack struct { }
+
+// Decoding state for a single Decode() call
+type msgPackDecoder struct {
+	buf	[]byte
+	pos	int
+}
+
+// -------------------------------------------------------------------------------------------------
+// Factory Functions
+// -------------------------------------------------------------------------------------------------
+
+func NewMsgPack() *msgPack {
+	return &msgPack{}
+}
+
+// -------------------------------------------------------------------------------------------------
+// MsgPackIfc
+// -------------------------------------------------------------------------------------------------
+
+func (r *msgPack) Encode(dataValue *data.DataValue) ([]byte, error) {
+	if nil == dataValue { return nil, fmt.Errorf("msgPack.Encode(): nil DataValue") }
+	return r.encode(make([]byte, 0, 64), dataValue)
+}
+
+func (r *msgPack) Decode(encoded []byte) (*data.DataValue, error) {
+	if 0 == len(encoded) { return nil, fmt.Errorf("msgPack.Decode(): nothing to decode") }
+	decoder := msgPackDecoder{ buf: encoded }
+	dataValue, err := decoder.decode(0)
+	if nil != err { return nil, fmt.Errorf("msgPack.Decode(): %s", err.Error()) }
+	if decoder.pos != len(encoded) {
+		return nil, fmt.Errorf("msgPack.Decode(): %d unexpected trailing bytes", len(encoded) - decoder.pos)
+	}
+	return dataValue, nil
+}
+
+// -------------------------------------------------------------------------------------------------
+// msgPack encoding
+// -------------------------------------------------------------------------------------------------
+
+func (r *msgPack) encode(buf []byte, dataValue *data.DataValue) ([]byte, error) {
+	switch dataValue.GetType() {
+		case data.DATA_TYPE_NULL:
+			return append(buf, 0xc0), nil
+
+		case data.DATA_TYPE_BOOLEAN:
+			if dataValue.GetBoolean() { return append(buf, 0xc3), nil }
+			return append(buf, 0xc2), nil
+
+		case data.DATA_TYPE_INTEGER:
+			return r.encodeInteger(buf, dataValue.GetInteger()), nil
+
+		case data.DATA_TYPE_FLOAT:
+			buf = append(buf, 0xcb)
+			return binary.BigEndian.AppendUint64(buf, math.Float64bits(dataValue.GetFloat())), nil
+
+		case data.DATA_TYPE_STRING:
+			value := dataValue.GetString()
+			size := len(value)
+			switch {
+				case size < 32: buf = append(buf, 0xa0 | byte(size))
+				case size <= math.MaxUint8: buf = append(buf, 0xd9, byte(size))
+				case size <= math.MaxUint16: buf = binary.BigEndian.AppendUint16(append(buf, 0xda), uint16(size))
+				case size <= math.MaxUint32: buf = binary.BigEndian.AppendUint32(append(buf, 0xdb), uint32(size))
+				default: return nil, fmt.Errorf("msgPack.Encode(): string too long (%d bytes)", size)
+			}
+			return append(buf, value...), nil
+
+		case data.DATA_TYPE_BINARY:
+			value := dataValue.GetBinary()
+			size := len(value)
+			switch {
+				case size <= math.MaxUint8: buf = append(buf, 0xc4, byte(size))
+				case size <= math.MaxUint16: buf = binary.BigEndian.AppendUint16(append(buf, 0xc5), uint16(size))
+				case size <= math.MaxUint32: buf = binary.BigEndian.AppendUint32(append(buf, 0xc6), uint32(size))
+				default: return nil, fmt.Errorf("msgPack.Encode(): binary too long (%d bytes)", size)
+			}
+			return append(buf, value...), nil
+
+		case data.DATA_TYPE_ARRAY:
+			size := dataValue.GetArraySize()
+			buf = r.encodeContainerHeader(buf, size, 0x90, 0xdc, 0xdd)
+			var err error
+			for index := 0; index < size; index++ {
+				buf, err = r.encode(buf, dataValue.GetArrayValue(index)) // <- BEWARE: recursion!
+				if nil != err { return nil, err }
+			}
+			return buf, nil
+
+		case data.DATA_TYPE_OBJECT:
+			names := dataValue.GetObjectProperties()
+			sort.Strings(names)
+			buf = r.encodeContainerHeader(buf, len(names), 0x80, 0xde, 0xdf)
+			var err error
+			for _, name := range names {
+				buf, err = r.encode(buf, data.NewString(name))
+				if nil != err { return nil, err }
+				buf, err = r.encode(buf, dataValue.GetObjectProperty(name)) // <- BEWARE: recursion!
+				if nil != err { return nil, err }
+			}
+			return buf, nil
+	}
+	return nil, fmt.Errorf("msgPack.Encode(): unsupported DataType '%s'", dataValue.GetType().ToString())
+}
+
+func (r *msgPack) encodeInteger(buf []byte, value int64) []byte {
+	if value >= 0 {
+		switch {
+			case value <= 0x7f: return append(buf, byte(value))
+			case value <= math.MaxUint8: return append(buf, 0xcc, byte(value))
+			case value <= math.MaxUint16: return binary.BigEndian.AppendUint16(append(buf, 0xcd), uint16(value))
+			case value <= math.MaxUint32: return binary.BigEndian.AppendUint32(append(buf, 0xce), uint32(value))
+		}
+		return binary.BigEndian.AppendUint64(append(buf, 0xcf), uint64(value))
+	}
+	switch {
+		case value >= -32: return append(buf, byte(value))
+		case value >= math.MinInt8: return append(buf, 0xd0, byte(value))
+		case value >= math.MinInt16: return binary.BigEndian.AppendUint16(append(buf, 0xd1), uint16(value))
+		case value >= math.MinInt32: return binary.BigEndian.AppendUint32(append(buf, 0xd2), uint32(value))
+	}
+	return binary.BigEndian.AppendUint64(append(buf, 0xd3), uint64(value))
+}
+
+func (r *msgPack) encodeContainerHeader(buf []byte, size int, fix, type16, type32 byte) []byte {
+	switch {
+		case size < 16: return append(buf, fix | byte(size))
+		case size <= math.MaxUint16: return binary.BigEndian.AppendUint16(append(buf, type16), uint16(size))
+	}
+	return binary.BigEndian.AppendUint32(append(buf, type32), uint32(size))
+}
+
+// -------------------------------------------------------------------------------------------------
+// msgPackDecoder
+// -------------------------------------------------------------------------------------------------
+
+func (r *msgPackDecoder) decode(depth int) (*data.DataValue, error) {
+	if depth > MSGPACK_MAX_DEPTH { return nil, fmt.Errorf("nesting exceeds %d levels", MSGPACK_MAX_DEPTH) }
+	format, err := r.readByte()
+	if nil != err { return nil, err }
+
+	switch {
+		case format <= 0x7f: return data.NewInteger(int64(format)), nil
+		case format >= 0xe0: return data.NewInteger(int64(int8(format))), nil
+		case (format & 0xe0) == 0xa0: return r.decodeString(int(format & 0x1f))
+		case (format & 0xf0) == 0x90: return r.decodeArray(int(format & 0x0f), depth)
+		case (format & 0xf0) == 0x80: return r.decodeMap(int(format & 0x0f), depth)
+	}
+
+	switch format {
+		case 0xc0: return data.NewNull(), nil
+		case 0xc2: return data.NewBoolean(false), nil
+		case 0xc3: return data.NewBoolean(true), nil
+
+		case 0xc4, 0xc5, 0xc6:
+			size, err := r.readSize(format - 0xc4)
+			if nil != err { return nil, err }
+			value, err := r.readBytes(size)
+			if nil != err { return nil, err }
+			// Copy so that the DataValue does not pin (or get changed along with) the caller's buffer
+			return data.NewBinary(append([]byte{}, value...)), nil
+
+		case 0xca:
+			bits, err := r.readUint(4)
+			if nil != err { return nil, err }
+			return data.NewFloat(float64(math.Float32frombits(uint32(bits)))), nil
+
+		case 0xcb:
+			bits, err := r.readUint(8)
+			if nil != err { return nil, err }
+			return data.NewFloat(math.Float64frombits(bits)), nil
+
+		case 0xcc, 0xcd, 0xce, 0xcf:
+			value, err := r.readUint(1 << (format - 0xcc))
+			if nil != err { return nil, err }
+			if value > math.MaxInt64 { return nil, fmt.Errorf("uint 64 value %d overflows int64", value) }
+			return data.NewInteger(int64(value)), nil
+
+		case 0xd0, 0xd1, 0xd2, 0xd3:
+			width := 1 << (format - 0xd0)
+			value, err := r.readUint(width)
+			if nil != err { return nil, err }
+			// Sign-extend from the encoded width
+			shift := uint(64 - (8 * width))
+			return data.NewInteger(int64(value << shift) >> shift), nil
+
+		case 0xd9, 0xda, 0xdb:
+			size, err := r.readSize(format - 0xd9)
+			if nil != err { return nil, err }
+			return r.decodeString(size)
+
+		case 0xdc, 0xdd:
+			size, err := r.readSize(format - 0xdc + 1)
+			if nil != err { return nil, err }
+			return r.decodeArray(size, depth)
+
+		case 0xde, 0xdf:
+			size, err := r.readSize(format - 0xde + 1)
+			if nil != err { return nil, err }
+			return r.decodeMap(size, depth)
+
+		case 0xc7, 0xc8, 0xc9, 0xd4, 0xd5, 0xd6, 0xd7, 0xd8:
+			return nil, fmt.Errorf("extension types are not supported (format 0x%02x)", format)
+	}
+	return nil, fmt.Errorf("invalid format byte 0x%02x at offset %d", format, r.pos - 1)
+}
+
+func (r *msgPackDecoder) decodeString(size int) (*data.DataValue, error) {
+	value, err := r.readBytes(size)
+	if nil != err { return nil, err }
+	return data.NewString(string(value)), nil
+}
+
+func (r *msgPackDecoder) decodeArray(size int, depth int) (*data.DataValue, error) {
+	// Every element takes at least one byte, so a bigger size than that is a lie; don't allocate for it
+	if size > len(r.buf) - r.pos { return nil, fmt.Errorf("array size %d exceeds remaining input", size) }
+	dataValue := data.NewArray()
+	for index := 0; index < size; index++ {
+		value, err := r.decode(depth + 1) // <- BEWARE: recursion!
+		if nil != err { return nil, err }
+		dataValue.AppendArrayValue(value)
+	}
+	return dataValue, nil
+}
+
+func (r *msgPackDecoder) decodeMap(size int, depth int) (*data.DataValue, error) {
+	if size > len(r.buf) - r.pos { return nil, fmt.Errorf("map size %d exceeds remaining input", size) }
+	dataValue := data.NewObject()
+	for index := 0; index < size; index++ {
+		key, err := r.decode(depth + 1) // <- BEWARE: recursion!
+		if nil != err { return nil, err }
+		if ! key.IsString() { return nil, fmt.Errorf("map key is %s; only strings are supported", key.GetType().ToString()) }
+		value, err := r.decode(depth + 1) // <- BEWARE: recursion!
+		if nil != err { return nil, err }
+		dataValue.SetObjectProperty(key.GetString(), value)
+	}
+	return dataValue, nil
+}
+
+func (r *msgPackDecoder) readByte() (byte, error) {
+	if r.pos >= len(r.buf) { return 0, fmt.Errorf("unexpected end of input") }
+	value := r.buf[r.pos]
+	r.pos++
+	return value, nil
+}
+
+func (r *msgPackDecoder) readBytes(size int) ([]byte, error) {
+	if (size < 0) || (size > len(r.buf) - r.pos) {
+		return nil, fmt.Errorf("need %d bytes at offset %d; only %d remain", size, r.pos, len(r.buf) - r.pos)
+	}
+	value := r.buf[r.pos:r.pos + size]
+	r.pos += size
+	return value, nil
+}
+
+// Read a big-endian unsigned integer of width bytes (1, 2, 4 or 8)
+func (r *msgPackDecoder) readUint(width int) (uint64, error) {
+	bytes, err := r.readBytes(width)
+	if nil != err { return 0, err }
+	var value uint64
+	for _, b := range bytes { value = (value << 8) | uint64(b) }
+	return value, nil
+}
+
+// Read a length; widthCode 0, 1, 2 means 8, 16, 32 bits
+func (r *msgPackDecoder) readSize(widthCode byte) (int, error) {
+	value, err := r.readUint(1 << widthCode)
+	if nil != err { return 0, err }
+	return int(value), nil
+}
@@ -0,0 +1,155 @@
+package msgpack
+
+/*
+
+Unit Tests for msgPack
+
+*/
+
+import(
+	"math"
+	"bytes"
+	"testing"
+
+	"github.com/DigiStratum/GoLib/Data"
+	. "github.com/DigiStratum/GoLib/Testing"
+)
+
+func TestThat_MsgPack_NewMsgPack_ReturnsInstance(t *testing.T) {
+	// Setup
+	var sut MsgPackIfc = NewMsgPack() // <- ensures that we satisfy our interface
+
+	// Verify
+	ExpectNonNil(sut, t)
+}
+
+func TestThat_MsgPack_Encode_ReturnsError_ForNilAndInvalidValues(t *testing.T) {
+	// Setup
+	sut := NewMsgPack()
+
+	// Test
+	actual1, err1 := sut.Encode(nil)
+	actual2, err2 := sut.Encode(data.NewDataValue())
+
+	// Verify
+	ExpectTrue(nil == actual1, t)
+	ExpectError(err1, t)
+	ExpectTrue(nil == actual2, t)
+	ExpectError(err2, t)
+}
+
+func TestThat_MsgPack_Encode_ProducesSpecFormats(t *testing.T) {
+	// Setup
+	sut := NewMsgPack()
+	cases := []struct {
+		value		*data.DataValue
+		expected	[]byte
+	}{
+		{ data.NewNull(), []byte{ 0xc0 } },
+		{ data.NewBoolean(true), []byte{ 0xc3 } },
+		{ data.NewBoolean(false), []byte{ 0xc2 } },
+		{ data.NewInteger(5), []byte{ 0x05 } },
+		{ data.NewInteger(-1), []byte{ 0xff } },
+		{ data.NewInteger(200), []byte{ 0xcc, 0xc8 } },
+		{ data.NewInteger(-200), []byte{ 0xd1, 0xff, 0x38 } },
+		{ data.NewInteger(70000), []byte{ 0xce, 0x00, 0x01, 0x11, 0x70 } },
+		{ data.NewFloat(1.5), []byte{ 0xcb, 0x3f, 0xf8, 0, 0, 0, 0, 0, 0 } },
+		{ data.NewString("abc"), []byte{ 0xa3, 'a', 'b', 'c' } },
+		{ data.NewBinary([]byte{ 0x01, 0x02 }), []byte{ 0xc4, 0x02, 0x01, 0x02 } },
+		{ data.NewArray().AppendArrayValue(data.NewInteger(1)), []byte{ 0x91, 0x01 } },
+		{
+			data.NewObject().SetObjectProperty("b", data.NewNull()).SetObjectProperty("a", data.NewInteger(1)),
+			[]byte{ 0x82, 0xa1, 'a', 0x01, 0xa1, 'b', 0xc0 },
+		},
+	}
+
+	// Test / Verify
+	for _, c := range cases {
+		actual, err := sut.Encode(c.value)
+		ExpectNoError(err, t)
+		if ! ExpectTrue(bytes.Equal(c.expected, actual), t) { t.Logf("%s: % x", c.value.ToJson(), actual) }
+	}
+}
+
+func TestThat_MsgPack_Decode_RoundTripsEveryDataType(t *testing.T) {
+	// Setup
+	sut := NewMsgPack()
+	long := string(bytes.Repeat([]byte{ 'x' }, 70000))
+	source := data.NewObject().
+		SetObjectProperty("null", data.NewNull()).
+		SetObjectProperty("bool", data.NewBoolean(true)).
+		SetObjectProperty("min", data.NewInteger(math.MinInt64)).
+		SetObjectProperty("max", data.NewInteger(math.MaxInt64)).
+		SetObjectProperty("int16", data.NewInteger(-30000)).
+		SetObjectProperty("float", data.NewFloat(-2.79e-4)).
+		SetObjectProperty("long", data.NewString(long)).
+		SetObjectProperty("bin", data.NewBinary(bytes.Repeat([]byte{ 0xfe }, 300))).
+		SetObjectProperty("array", data.NewArray().
+			AppendArrayValue(data.NewString("é")).
+			AppendArrayValue(data.NewObject()))
+
+	// Test
+	encoded, err := sut.Encode(source)
+	if ! ExpectNoError(err, t) { return }
+	actual, err := sut.Decode(encoded)
+
+	// Verify
+	if ! ExpectNoError(err, t) { return }
+	ExpectTrue(actual.Select("null").IsNull(), t)
+	ExpectTrue(actual.Select("bool").GetBoolean(), t)
+	ExpectInt64(math.MinInt64, actual.Select("min").GetInteger(), t)
+	ExpectInt64(math.MaxInt64, actual.Select("max").GetInteger(), t)
+	ExpectInt64(-30000, actual.Select("int16").GetInteger(), t)
+	ExpectFloat64(-2.79e-4, actual.Select("float").GetFloat(), t)
+	ExpectString(long, actual.Select("long").GetString(), t)
+	ExpectTrue(bytes.Equal(source.Select("bin").GetBinary(), actual.Select("bin").GetBinary()), t)
+	ExpectString("é", actual.Select("array[0]").GetString(), t)
+	ExpectTrue(actual.Select("array[1]").IsObject(), t)
+}
+
+func TestThat_MsgPack_Decode_AcceptsFloat32(t *testing.T) {
+	// Setup
+	sut := NewMsgPack()
+
+	// Test
+	actual, err := sut.Decode([]byte{ 0xca, 0x3f, 0xc0, 0x00, 0x00 })
+
+	// Verify
+	if ! ExpectNoError(err, t) { return }
+	ExpectFloat64(1.5, actual.GetFloat(), t)
+}
+
+func TestThat_MsgPack_Decode_ReturnsError_ForBadInput(t *testing.T) {
+	// Setup
+	sut := NewMsgPack()
+	cases := [][]byte{
+		{ },						// nothing
+		{ 0xc1 },					// never used
+		{ 0xa3, 'a' },					// truncated string
+		{ 0x81, 0x01, 0x01 },				// non-string map key
+		{ 0xcf, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff },	// uint64 overflow
+		{ 0xd4, 0x01, 0x00 },				// extension
+		{ 0xc0, 0xc0 },					// trailing bytes
+		{ 0xdd, 0xff, 0xff, 0xff, 0xff },		// array size beyond input
+	}
+
+	// Test / Verify
+	for _, c := range cases {
+		actual, err := sut.Decode(c)
+		if ! ExpectError(err, t) { t.Logf("% x", c) }
+		ExpectNil(actual, t)
+	}
+}
+
+func TestThat_MsgPack_Decode_ReturnsError_ForExcessiveNesting(t *testing.T) {
+	// Setup
+	sut := NewMsgPack()
+	encoded := append(bytes.Repeat([]byte{ 0x91 }, MSGPACK_MAX_DEPTH + 1), 0xc0)
+
+	// Test
+	actual, err := sut.Decode(encoded)
+
+	// Verify
+	ExpectError(err, t)
+	ExpectNil(actual, t)
+}
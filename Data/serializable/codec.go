@@ -0,0 +1,55 @@
+package serializable
+
+/*
+A Codec converts values to and from one particular serialized format, identified by MIME type.
+
+Unlike SerializableIfc, which captures state as a string, a Codec works with []byte and with
+io.Reader|Writer so that binary formats are first-class citizens. Codecs are collected in a
+CodecRegistry (see codecregistry.go) so that consumers can pick a format by MIME type, e.g. from a
+Content-Type or Accept header, without knowing anything about the format itself.
+
+Every Codec we supply accepts *data.DataValue in addition to whatever native Go values its format
+supports; formats that can only represent a DataValue (MessagePack, CBOR) convert other values by
+way of JSON (see codecdatavalue.go).
+*/
+
+import (
+	"io"
+	"fmt"
+)
+
+const (
+	MIME_TYPE_JSON		= "application/json"
+	MIME_TYPE_GOB		= "application/x-gob"
+	MIME_TYPE_MSGPACK	= "application/msgpack"
+	MIME_TYPE_CBOR		= "application/cbor"
+)
+
+type CodecIfc interface {
+	GetMimeType() string
+	Marshal(value interface{}) ([]byte, error)
+	Unmarshal(encoded []byte, target interface{}) error
+	Encode(writer io.Writer, value interface{}) error
+	Decode(reader io.Reader, target interface{}) error
+}
+
+// -------------------------------------------------------------------------------------------------
+// Codec support shared by our implementations
+// -------------------------------------------------------------------------------------------------
+
+// Marshal with the codec, then write the whole result
+func encodeWith(codec CodecIfc, writer io.Writer, value interface{}) error {
+	if nil == writer { return fmt.Errorf("%s codec: nil writer", codec.GetMimeType()) }
+	encoded, err := codec.Marshal(value)
+	if nil != err { return err }
+	_, err = writer.Write(encoded)
+	return err
+}
+
+// Read everything, then unmarshal with the codec
+func decodeWith(codec CodecIfc, reader io.Reader, target interface{}) error {
+	if nil == reader { return fmt.Errorf("%s codec: nil reader", codec.GetMimeType()) }
+	encoded, err := io.ReadAll(reader)
+	if nil != err { return err }
+	return codec.Unmarshal(encoded, target)
+}
@@ -0,0 +1,125 @@
+package serializable
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/DigiStratum/GoLib/Data"
+	. "github.com/DigiStratum/GoLib/Testing"
+)
+
+type codecTestThing struct {
+	Name	string		`json:"name"`
+	Count	int		`json:"count"`
+	Tags	[]string	`json:"tags"`
+}
+
+func allCodecs() []CodecIfc {
+	return []CodecIfc{ NewCodecJson(), NewCodecGob(), NewCodecMsgPack(), NewCodecCbor() }
+}
+
+func TestThat_Codec_Factories_ReturnInstances(t *testing.T) {
+	// Setup
+	var sut CodecIfc // <- ensures that we satisfy our interface
+	for _, sut = range allCodecs() {
+		// Verify
+		ExpectNonNil(sut, t)
+	}
+}
+
+func TestThat_Codec_MarshalUnmarshal_RoundTripsDataValue(t *testing.T) {
+	// Setup
+	expected := data.NewObject().
+		SetObjectProperty("s", data.NewString("hello")).
+		SetObjectProperty("i", data.NewInteger(-42)).
+		SetObjectProperty("f", data.NewFloat(1.5)).
+		SetObjectProperty("b", data.NewBoolean(true)).
+		SetObjectProperty("n", data.NewNull()).
+		SetObjectProperty("a", data.NewArray().AppendArrayValue(data.NewInteger(1)))
+	for _, sut := range allCodecs() {
+		// Test
+		encoded, err := sut.Marshal(expected)
+		if ! ExpectNoError(err, t) { return }
+		actual := data.NewDataValue()
+		err = sut.Unmarshal(encoded, actual)
+
+		// Verify
+		if ! ExpectNoError(err, t) { return }
+		for _, name := range expected.GetObjectProperties() {
+			ExpectString(expected.GetObjectProperty(name).ToJson(), actual.GetObjectProperty(name).ToJson(), t)
+		}
+	}
+}
+
+func TestThat_Codec_MarshalUnmarshal_RoundTripsStructs(t *testing.T) {
+	// Setup
+	expected := codecTestThing{ Name: "widget", Count: 3, Tags: []string{ "a", "b" } }
+	for _, sut := range allCodecs() {
+		// Test
+		encoded, err := sut.Marshal(&expected)
+		if ! ExpectNoError(err, t) { return }
+		var actual codecTestThing
+		err = sut.Unmarshal(encoded, &actual)
+
+		// Verify
+		if ! ExpectNoError(err, t) { return }
+		ExpectString(expected.Name, actual.Name, t)
+		ExpectInt(expected.Count, actual.Count, t)
+		ExpectInt(2, len(actual.Tags), t)
+	}
+}
+
+func TestThat_Codec_EncodeDecode_RoundTripsThroughStreams(t *testing.T) {
+	// Setup
+	expected := data.NewArray().AppendArrayValue(data.NewString("x")).AppendArrayValue(data.NewNull())
+	for _, sut := range allCodecs() {
+		var buf bytes.Buffer
+
+		// Test
+		err := sut.Encode(&buf, expected)
+		if ! ExpectNoError(err, t) { return }
+		actual := data.NewDataValue()
+		err = sut.Decode(&buf, actual)
+
+		// Verify
+		if ! ExpectNoError(err, t) { return }
+		ExpectString(expected.ToJson(), actual.ToJson(), t)
+	}
+}
+
+func TestThat_Codec_Unmarshal_ReturnsError_ForGarbage(t *testing.T) {
+	// Setup
+	garbage := []byte{ 0xc1, 0xff, 0x00 }
+	for _, sut := range allCodecs() {
+		// Test
+		err := sut.Unmarshal(garbage, data.NewDataValue())
+
+		// Verify
+		ExpectError(err, t)
+	}
+}
+
+func TestThat_SerializeAs_ReturnsError_ForUnknownMimeType(t *testing.T) {
+	// Test
+	actual, err := SerializeAs("x", "text/html")
+
+	// Verify
+	ExpectError(err, t)
+	ExpectTrue(nil == actual, t)
+	ExpectError(DeserializeAs("text/html", []byte("x"), data.NewDataValue()), t)
+}
+
+func TestThat_SerializeAs_DeserializeAs_RoundTripsWithRegisteredCodec(t *testing.T) {
+	// Setup
+	expected := map[string]interface{}{ "k": "v" }
+
+	// Test
+	encoded, err := SerializeAs(expected, MIME_TYPE_CBOR)
+	if ! ExpectNoError(err, t) { return }
+	actual := map[string]interface{}{}
+	err = DeserializeAs(MIME_TYPE_CBOR, encoded, &actual)
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectString("v", actual["k"].(string), t)
+}
@@ -0,0 +1,53 @@
+package serializable
+
+/*
+Codec for CBOR (RFC 8949); values are converted to|from DataValue (see codecdatavalue.go)
+*/
+
+import (
+	"io"
+	"fmt"
+
+	"github.com/DigiStratum/GoLib/Data/cbor"
+)
+
+type codecCbor struct { }
+
+// -------------------------------------------------------------------------------------------------
+// Factory Functions
+// -------------------------------------------------------------------------------------------------
+
+func NewCodecCbor() *codecCbor {
+	return &codecCbor{}
+}
+
+// -------------------------------------------------------------------------------------------------
+// CodecIfc
+// -------------------------------------------------------------------------------------------------
+
+func (r *codecCbor) GetMimeType() string {
+	return MIME_TYPE_CBOR
+}
+
+func (r *codecCbor) Marshal(value interface{}) ([]byte, error) {
+	dataValue, err := toDataValue(value)
+	if nil != err { return nil, fmt.Errorf("codecCbor.Marshal(): %s", err.Error()) }
+	return cbor.NewCbor().Encode(dataValue)
+}
+
+func (r *codecCbor) Unmarshal(encoded []byte, target interface{}) error {
+	dataValue, err := cbor.NewCbor().Decode(encoded)
+	if nil != err { return err }
+	if err = fromDataValue(dataValue, target); nil != err {
+		return fmt.Errorf("codecCbor.Unmarshal(): %s", err.Error())
+	}
+	return nil
+}
+
+func (r *codecCbor) Encode(writer io.Writer, value interface{}) error {
+	return encodeWith(r, writer, value)
+}
+
+func (r *codecCbor) Decode(reader io.Reader, target interface{}) error {
+	return decodeWith(r, reader, target)
+}
@@ -0,0 +1,67 @@
+package serializable
+
+/*
+Conversions between arbitrary values and DataValue for Codecs whose format can only represent a
+DataValue. Anything that is not already a DataValue goes by way of JSON, so it must be something
+that either we (JsonSerializableIfc, JsonDeserializableIfc) or encoding/json know how to handle.
+*/
+
+import (
+	"fmt"
+	gojson "encoding/json"
+
+	"github.com/DigiStratum/GoLib/Data"
+	"github.com/DigiStratum/GoLib/Data/json"
+)
+
+// Anything that can represent itself as a DataValue, e.g. a DB/MySQL result set
+type DataValueableIfc interface {
+	ToDataValue() *data.DataValue
+}
+
+func toDataValue(value interface{}) (*data.DataValue, error) {
+	switch v := value.(type) {
+		case nil: return data.NewNull(), nil
+		case *data.DataValue:
+			if nil == v { return data.NewNull(), nil }
+			return v, nil
+		case DataValueableIfc: return v.ToDataValue(), nil
+	}
+	jsonString, err := toJson(value)
+	if nil != err { return nil, err }
+	return json.NewJson(jsonString).ToDataValue()
+}
+
+func fromDataValue(dataValue *data.DataValue, target interface{}) error {
+	if dv, ok := target.(*data.DataValue); ok {
+		if nil == dv { return fmt.Errorf("cannot decode into nil *DataValue") }
+		*dv = *dataValue
+		return nil
+	}
+	jsonString := dataValue.ToJson()
+	return fromJson(&jsonString, target)
+}
+
+func toJson(value interface{}) (*string, error) {
+	switch v := value.(type) {
+		case *data.DataValue:
+			jsonString := v.ToJson()
+			return &jsonString, nil
+		case json.JsonSerializableIfc: return v.ToJson()
+	}
+	jsonBytes, err := gojson.Marshal(value)
+	if nil != err { return nil, err }
+	jsonString := string(jsonBytes)
+	return &jsonString, nil
+}
+
+func fromJson(jsonString *string, target interface{}) error {
+	switch t := target.(type) {
+		case *data.DataValue:
+			dataValue, err := json.NewJson(jsonString).ToDataValue()
+			if nil != err { return err }
+			return fromDataValue(dataValue, t)
+		case json.JsonDeserializableIfc: return t.FromJson(jsonString)
+	}
+	return gojson.Unmarshal([]byte(*jsonString), target)
+}
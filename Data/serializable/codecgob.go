@@ -0,0 +1,54 @@
+package serializable
+
+/*
+Codec for encoding/gob; Go-to-Go only, but compact and fast for anything gob can handle, including
+DataValue (which implements GobEncoder|GobDecoder).
+*/
+
+import (
+	"io"
+	"fmt"
+	"bytes"
+	"encoding/gob"
+)
+
+type codecGob struct { }
+
+// -------------------------------------------------------------------------------------------------
+// Factory Functions
+// -------------------------------------------------------------------------------------------------
+
+func NewCodecGob() *codecGob {
+	return &codecGob{}
+}
+
+// -------------------------------------------------------------------------------------------------
+// CodecIfc
+// -------------------------------------------------------------------------------------------------
+
+func (r *codecGob) GetMimeType() string {
+	return MIME_TYPE_GOB
+}
+
+func (r *codecGob) Marshal(value interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(value); nil != err {
+		return nil, fmt.Errorf("codecGob.Marshal(): %s", err.Error())
+	}
+	return buf.Bytes(), nil
+}
+
+func (r *codecGob) Unmarshal(encoded []byte, target interface{}) error {
+	if err := gob.NewDecoder(bytes.NewReader(encoded)).Decode(target); nil != err {
+		return fmt.Errorf("codecGob.Unmarshal(): %s", err.Error())
+	}
+	return nil
+}
+
+func (r *codecGob) Encode(writer io.Writer, value interface{}) error {
+	return encodeWith(r, writer, value)
+}
+
+func (r *codecGob) Decode(reader io.Reader, target interface{}) error {
+	return decodeWith(r, reader, target)
+}
@@ -0,0 +1,51 @@
+package serializable
+
+/*
+Codec for JSON (RFC 8259); DataValue and our own Json(De)SerializableIfc are supported along with
+anything that encoding/json can handle.
+*/
+
+import (
+	"io"
+	"fmt"
+)
+
+type codecJson struct { }
+
+// -------------------------------------------------------------------------------------------------
+// Factory Functions
+// -------------------------------------------------------------------------------------------------
+
+func NewCodecJson() *codecJson {
+	return &codecJson{}
+}
+
+// -------------------------------------------------------------------------------------------------
+// CodecIfc
+// -------------------------------------------------------------------------------------------------
+
+func (r *codecJson) GetMimeType() string {
+	return MIME_TYPE_JSON
+}
+
+func (r *codecJson) Marshal(value interface{}) ([]byte, error) {
+	jsonString, err := toJson(value)
+	if nil != err { return nil, fmt.Errorf("codecJson.Marshal(): %s", err.Error()) }
+	return []byte(*jsonString), nil
+}
+
+func (r *codecJson) Unmarshal(encoded []byte, target interface{}) error {
+	jsonString := string(encoded)
+	if err := fromJson(&jsonString, target); nil != err {
+		return fmt.Errorf("codecJson.Unmarshal(): %s", err.Error())
+	}
+	return nil
+}
+
+func (r *codecJson) Encode(writer io.Writer, value interface{}) error {
+	return encodeWith(r, writer, value)
+}
+
+func (r *codecJson) Decode(reader io.Reader, target interface{}) error {
+	return decodeWith(r, reader, target)
+}
@@ -0,0 +1,53 @@
+package serializable
+
+/*
+Codec for MessagePack; values are converted to|from DataValue (see codecdatavalue.go)
+*/
+
+import (
+	"io"
+	"fmt"
+
+	"github.com/DigiStratum/GoLib/Data/msgpack"
+)
+
+type codecMsgPack struct { }
+
+// -------------------------------------------------------------------------------------------------
+// Factory Functions
+// -------------------------------------------------------------------------------------------------
+
+func NewCodecMsgPack() *codecMsgPack {
+	return &codecMsgPack{}
+}
+
+// -------------------------------------------------------------------------------------------------
+// CodecIfc
+// -------------------------------------------------------------------------------------------------
+
+func (r *codecMsgPack) GetMimeType() string {
+	return MIME_TYPE_MSGPACK
+}
+
+func (r *codecMsgPack) Marshal(value interface{}) ([]byte, error) {
+	dataValue, err := toDataValue(value)
+	if nil != err { return nil, fmt.Errorf("codecMsgPack.Marshal(): %s", err.Error()) }
+	return msgpack.NewMsgPack().Encode(dataValue)
+}
+
+func (r *codecMsgPack) Unmarshal(encoded []byte, target interface{}) error {
+	dataValue, err := msgpack.NewMsgPack().Decode(encoded)
+	if nil != err { return err }
+	if err = fromDataValue(dataValue, target); nil != err {
+		return fmt.Errorf("codecMsgPack.Unmarshal(): %s", err.Error())
+	}
+	return nil
+}
+
+func (r *codecMsgPack) Encode(writer io.Writer, value interface{}) error {
+	return encodeWith(r, writer, value)
+}
+
+func (r *codecMsgPack) Decode(reader io.Reader, target interface{}) error {
+	return decodeWith(r, reader, target)
+}
@@ -0,0 +1,160 @@
+package serializable
+
+/*
+A registry of Codecs keyed by MIME type.
+
+MIME types are matched case-insensitively with any parameters (e.g. "; charset=utf-8") ignored, so
+a Content-Type header value may be used directly for lookups. A Codec may be registered under alias
+MIME types in addition to its own (e.g. "application/x-msgpack"). Negotiate() picks the best Codec
+for an HTTP Accept header value, honoring quality values and wildcards.
+
+GetCodecRegistry() returns a shared singleton with all of our own Codecs registered; consumers may
+register more on it, or make their own registry with NewCodecRegistry().
+
+ref: https://www.rfc-editor.org/rfc/rfc9110#section-12.5.1
+*/
+
+import (
+	"sort"
+	"sync"
+	"strconv"
+	"strings"
+)
+
+type CodecRegistryIfc interface {
+	Register(codec CodecIfc, aliases ...string)
+	Get(mimeType string) CodecIfc
+	Has(mimeType string) bool
+	GetMimeTypes() []string
+	Negotiate(accept string) CodecIfc
+}
+
+type codecRegistry struct {
+	mutex		sync.RWMutex
+	codecs		map[string]CodecIfc
+	mimeTypes	[]string	// registration order; the first is the default for "*/*"
+}
+
+var codecRegistryInstance *codecRegistry
+
+// Automagically set up our default singleton
+func init() {
+	codecRegistryInstance = NewCodecRegistry()
+	codecRegistryInstance.Register(NewCodecJson(), "text/json")
+	codecRegistryInstance.Register(NewCodecGob())
+	codecRegistryInstance.Register(NewCodecMsgPack(), "application/x-msgpack", "application/vnd.msgpack")
+	codecRegistryInstance.Register(NewCodecCbor())
+}
+
+// Get our singleton
+func GetCodecRegistry() *codecRegistry {
+	return codecRegistryInstance
+}
+
+// -------------------------------------------------------------------------------------------------
+// Factory Functions
+// -------------------------------------------------------------------------------------------------
+
+func NewCodecRegistry() *codecRegistry {
+	return &codecRegistry{
+		codecs:		make(map[string]CodecIfc),
+		mimeTypes:	make([]string, 0),
+	}
+}
+
+// -------------------------------------------------------------------------------------------------
+// CodecRegistryIfc
+// -------------------------------------------------------------------------------------------------
+
+// Register the codec under its own MIME type and any aliases; replaces any existing registration
+func (r *codecRegistry) Register(codec CodecIfc, aliases ...string) {
+	if nil == codec { return }
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, mimeType := range append([]string{ codec.GetMimeType() }, aliases...) {
+		mimeType = normalizeMimeType(mimeType)
+		if _, ok := r.codecs[mimeType]; ! ok { r.mimeTypes = append(r.mimeTypes, mimeType) }
+		r.codecs[mimeType] = codec
+	}
+}
+
+// Get the codec for the MIME type, or nil if there is none
+func (r *codecRegistry) Get(mimeType string) CodecIfc {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	codec, _ := r.codecs[normalizeMimeType(mimeType)]
+	return codec
+}
+
+func (r *codecRegistry) Has(mimeType string) bool {
+	return nil != r.Get(mimeType)
+}
+
+// Get all registered MIME types (including aliases) in registration order
+func (r *codecRegistry) GetMimeTypes() []string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return append([]string{}, r.mimeTypes...)
+}
+
+// Pick the best codec for the Accept header value, or nil if nothing acceptable is registered
+func (r *codecRegistry) Negotiate(accept string) CodecIfc {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	if 0 == len(r.mimeTypes) { return nil }
+
+	// No Accept header means the client accepts anything
+	if 0 == len(strings.TrimSpace(accept)) { return r.codecs[r.mimeTypes[0]] }
+
+	for _, mediaRange := range parseAccept(accept) {
+		switch {
+			case "*/*" == mediaRange:
+				return r.codecs[r.mimeTypes[0]]
+
+			case strings.HasSuffix(mediaRange, "/*"):
+				prefix := strings.TrimSuffix(mediaRange, "*")
+				for _, mimeType := range r.mimeTypes {
+					if strings.HasPrefix(mimeType, prefix) { return r.codecs[mimeType] }
+				}
+
+			default:
+				if codec, ok := r.codecs[mediaRange]; ok { return codec }
+		}
+	}
+	return nil
+}
+
+// -------------------------------------------------------------------------------------------------
+// codecRegistry
+// -------------------------------------------------------------------------------------------------
+
+func normalizeMimeType(mimeType string) string {
+	if index := strings.Index(mimeType, ";"); index >= 0 { mimeType = mimeType[:index] }
+	return strings.ToLower(strings.TrimSpace(mimeType))
+}
+
+// Parse the Accept header into media ranges ordered by descending quality; q=0 ranges are dropped
+func parseAccept(accept string) []string {
+	type weighted struct {
+		mediaRange	string
+		quality		float64
+	}
+	ranges := make([]weighted, 0)
+	for _, part := range strings.Split(accept, ",") {
+		quality := 1.0
+		params := strings.Split(part, ";")
+		for _, param := range params[1:] {
+			name, value, found := strings.Cut(strings.TrimSpace(param), "=")
+			if ! found || ("q" != strings.ToLower(strings.TrimSpace(name))) { continue }
+			if q, err := strconv.ParseFloat(strings.TrimSpace(value), 64); nil == err { quality = q }
+		}
+		mediaRange := normalizeMimeType(params[0])
+		if (0 == len(mediaRange)) || (quality <= 0) { continue }
+		ranges = append(ranges, weighted{ mediaRange: mediaRange, quality: quality })
+	}
+	// Stable so that equally weighted ranges keep the client's order
+	sort.SliceStable(ranges, func(i, j int) bool { return ranges[i].quality > ranges[j].quality })
+	mediaRanges := make([]string, len(ranges))
+	for index, w := range ranges { mediaRanges[index] = w.mediaRange }
+	return mediaRanges
+}
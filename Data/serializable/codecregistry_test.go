@@ -0,0 +1,101 @@
+package serializable
+
+import (
+	"testing"
+
+	. "github.com/DigiStratum/GoLib/Testing"
+)
+
+func TestThat_CodecRegistry_NewCodecRegistry_ReturnsEmptyInstance(t *testing.T) {
+	// Setup
+	var sut CodecRegistryIfc = NewCodecRegistry() // <- ensures that we satisfy our interface
+
+	// Verify
+	ExpectNonNil(sut, t)
+	ExpectInt(0, len(sut.GetMimeTypes()), t)
+	ExpectTrue(nil == sut.Negotiate("*/*"), t)
+}
+
+func TestThat_CodecRegistry_GetCodecRegistry_HasOurCodecs(t *testing.T) {
+	// Setup
+	sut := GetCodecRegistry()
+
+	// Verify
+	ExpectTrue(sut.Has(MIME_TYPE_JSON), t)
+	ExpectTrue(sut.Has(MIME_TYPE_GOB), t)
+	ExpectTrue(sut.Has(MIME_TYPE_MSGPACK), t)
+	ExpectTrue(sut.Has(MIME_TYPE_CBOR), t)
+	ExpectString(MIME_TYPE_MSGPACK, sut.Get("application/x-msgpack").GetMimeType(), t)
+}
+
+func TestThat_CodecRegistry_Get_IgnoresCaseAndParameters(t *testing.T) {
+	// Setup
+	sut := NewCodecRegistry()
+	sut.Register(NewCodecJson())
+
+	// Test
+	actual := sut.Get("Application/JSON; charset=utf-8")
+
+	// Verify
+	if ! ExpectNonNil(actual, t) { return }
+	ExpectString(MIME_TYPE_JSON, actual.GetMimeType(), t)
+	ExpectFalse(sut.Has("application/cbor"), t)
+}
+
+func TestThat_CodecRegistry_Register_ReplacesExistingRegistration(t *testing.T) {
+	// Setup
+	sut := NewCodecRegistry()
+	sut.Register(NewCodecJson(), "application/x-thing")
+
+	// Test
+	sut.Register(NewCodecCbor(), "application/x-thing")
+
+	// Verify
+	ExpectString(MIME_TYPE_CBOR, sut.Get("application/x-thing").GetMimeType(), t)
+	ExpectInt(3, len(sut.GetMimeTypes()), t)
+}
+
+func TestThat_CodecRegistry_Negotiate_PicksDefault_ForEmptyOrWildcardAccept(t *testing.T) {
+	// Setup
+	sut := NewCodecRegistry()
+	sut.Register(NewCodecCbor())
+	sut.Register(NewCodecJson())
+
+	// Verify
+	ExpectString(MIME_TYPE_CBOR, sut.Negotiate("").GetMimeType(), t)
+	ExpectString(MIME_TYPE_CBOR, sut.Negotiate("text/html, */*;q=0.1").GetMimeType(), t)
+}
+
+func TestThat_CodecRegistry_Negotiate_HonorsQualityValues(t *testing.T) {
+	// Setup
+	sut := GetCodecRegistry()
+
+	// Test
+	actual := sut.Negotiate("application/json;q=0.5, application/cbor, application/msgpack;q=0.9")
+
+	// Verify
+	if ! ExpectNonNil(actual, t) { return }
+	ExpectString(MIME_TYPE_CBOR, actual.GetMimeType(), t)
+}
+
+func TestThat_CodecRegistry_Negotiate_MatchesSubtypeWildcard(t *testing.T) {
+	// Setup
+	sut := NewCodecRegistry()
+	sut.Register(NewCodecJson(), "text/json")
+
+	// Test
+	actual := sut.Negotiate("text/*")
+
+	// Verify
+	if ! ExpectNonNil(actual, t) { return }
+	ExpectString(MIME_TYPE_JSON, actual.GetMimeType(), t)
+}
+
+func TestThat_CodecRegistry_Negotiate_ReturnsNil_WhenNothingAcceptable(t *testing.T) {
+	// Setup
+	sut := GetCodecRegistry()
+
+	// Verify
+	ExpectTrue(nil == sut.Negotiate("text/html"), t)
+	ExpectTrue(nil == sut.Negotiate("application/json;q=0"), t)
+}
@@ -4,6 +4,10 @@ package serializable
 General support for de|serialization
 */
 
+import (
+	"fmt"
+)
+
 // Capture the state of an instance variable/struct as a string that can be stored
 type SerializableIfc interface {
 	Serialize() (*string, error)
@@ -14,3 +18,29 @@ type DeserializableIfc interface {
 	Deserialize(data *string) error
 }
 
+
+// Capture the state of an instance as bytes in the format of the Codec registered for the MIME type
+type CodecSerializableIfc interface {
+	SerializeAs(mimeType string) ([]byte, error)
+}
+
+// Restore the state of an instance from bytes in the format of the Codec registered for the MIME type
+type CodecDeserializableIfc interface {
+	DeserializeAs(mimeType string, data []byte) error
+}
+
+// Serialize the value with the Codec that our CodecRegistry singleton has for the MIME type
+func SerializeAs(value interface{}, mimeType string) ([]byte, error) {
+	if codecSerializable, ok := value.(CodecSerializableIfc); ok { return codecSerializable.SerializeAs(mimeType) }
+	codec := GetCodecRegistry().Get(mimeType)
+	if nil == codec { return nil, fmt.Errorf("No Codec registered for MIME type '%s'", mimeType) }
+	return codec.Marshal(value)
+}
+
+// Deserialize into the target with the Codec that our CodecRegistry singleton has for the MIME type
+func DeserializeAs(mimeType string, data []byte, target interface{}) error {
+	if codecDeserializable, ok := target.(CodecDeserializableIfc); ok { return codecDeserializable.DeserializeAs(mimeType, data) }
+	codec := GetCodecRegistry().Get(mimeType)
+	if nil == codec { return fmt.Errorf("No Codec registered for MIME type '%s'", mimeType) }
+	return codec.Unmarshal(data, target)
+}
@@ -12,6 +12,7 @@ TODO:
 import (
 	"fmt"
 	"regexp"
+	"encoding/base64"
 
 	xc "github.com/DigiStratum/GoLib/Data/transcoder"
 )
@@ -32,9 +33,18 @@ func NewSerializer(transcoder xc.TranscoderIfc) *Serializer {
 }
 
 func (r Serializer) Serialize(data *string, typeName string) (*string, error) {
+	if nil == r.transcoder {
+		return nil, fmt.Errorf("Serialization requires Transcoder with EncodingSchemeBase64 Encoder")
+	}
+
+	// Binary encodings get wrapped in base64 to keep the serialized result text-safe
+	switch r.transcoder.GetEncoderSchemeName() {
+		case "msgpack": return r.serializeBinary(data, typeName, "m64")
+		case "cbor": return r.serializeBinary(data, typeName, "c64")
+	}
 
 	// Encoding base64, JSON data
-	if (nil == r.transcoder) || ("base64" != r.transcoder.GetEncoderSchemeName()) {
+	if "base64" != r.transcoder.GetEncoderSchemeName() {
 		return nil, fmt.Errorf("Serialization requires Transcoder with EncodingSchemeBase64 Encoder")
 	}
 
@@ -96,8 +106,37 @@ func (r Serializer) Deserialize(data *string) (*string, error) {
 			}
 
 			return r.transcoder.Decode(&edata)
+
+		case "m64", "c64":
+			// Encoding base64, MessagePack|CBOR data
+			schemeName := map[string]string{ "m64": "msgpack", "c64": "cbor" }[method]
+			if (nil == r.transcoder) || (schemeName != r.transcoder.GetDecoderSchemeName()) {
+				return nil, fmt.Errorf("Deserialization requires Transcoder with %s Decoder", schemeName)
+			}
+
+			utype, err := base64.StdEncoding.DecodeString(etype)
+			if (nil != err) || ("Object" != string(utype)) {
+				return nil, fmt.Errorf("Error decoding serialized data type")
+			}
+
+			bdata, err := base64.StdEncoding.DecodeString(edata)
+			if nil != err { return nil, fmt.Errorf("Error decoding serialized data") }
+			sdata := string(bdata)
+			return r.transcoder.Decode(&sdata)
 	}
 
 	return nil, fmt.Errorf("Unsupported serialization method '%s'", method)
 }
 
+
+// Overall format: "ser[{Method}:{Type}:{Data}]" with base64 type and base64-wrapped binary data
+func (r Serializer) serializeBinary(data *string, typeName string, method string) (*string, error) {
+	bdata, err := r.transcoder.Encode(data)
+	if (nil != err) || (nil == bdata) {
+		return nil, fmt.Errorf("Error encoding serialized data")
+	}
+	edata := base64.StdEncoding.EncodeToString([]byte(*bdata))
+	etype := base64.StdEncoding.EncodeToString([]byte(typeName))
+	serialized := fmt.Sprintf("ser[%s:%s:%s]", method, etype, edata)
+	return &serialized, nil
+}
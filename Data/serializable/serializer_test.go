@@ -396,3 +396,47 @@ func TestThat_Serializer_Serialize_ProducesExpectedFormat(t *testing.T) {
 		t.Error("Expected method 'j64:' after prefix")
 	}
 }
+
+func TestThat_Serializer_RoundTrip_PreservesJson_WithBinaryEncodingSchemes(t *testing.T) {
+	// Setup
+	schemes := map[string]func () enc.EncodingSchemeIfc{
+		"m64": func () enc.EncodingSchemeIfc { return enc.NewEncodingSchemeMsgPack() },
+		"c64": func () enc.EncodingSchemeIfc { return enc.NewEncodingSchemeCBOR() },
+	}
+	data := `{"count":123}`
+
+	for method, scheme := range schemes {
+		transcoder := xc.NewTranscoder()
+		transcoder.SetEncoderScheme(scheme())
+		transcoder.SetDecoderScheme(scheme())
+		sut := NewSerializer(transcoder)
+
+		// Test
+		serialized, err := sut.Serialize(&data, "Object")
+		if ! ExpectNoError(err, t) { return }
+		deserialized, err := sut.Deserialize(serialized)
+
+		// Verify
+		ExpectMatch("^ser\\[" + method + ":", *serialized, t)
+		if ! ExpectNoError(err, t) { return }
+		ExpectString(data, *deserialized, t)
+	}
+}
+
+func TestThat_Serializer_Deserialize_ReturnsError_WithMismatchedBinaryDecoderScheme(t *testing.T) {
+	// Setup
+	transcoder := xc.NewTranscoder()
+	transcoder.SetEncoderScheme(enc.NewEncodingSchemeMsgPack())
+	transcoder.SetDecoderScheme(enc.NewEncodingSchemeCBOR())
+	sut := NewSerializer(transcoder)
+	data := `{"count":123}`
+	serialized, err := sut.Serialize(&data, "Object")
+	if ! ExpectNoError(err, t) { return }
+
+	// Test
+	deserialized, err := sut.Deserialize(serialized)
+
+	// Verify
+	ExpectError(err, t)
+	ExpectNil(deserialized, t)
+}
@@ -0,0 +1,81 @@
+package encodingscheme
+
+import(
+	"fmt"
+
+	"github.com/DigiStratum/GoLib/Data/json"
+	"github.com/DigiStratum/GoLib/Data/cbor"
+)
+
+/*
+Implement EncodingSchemeIfc for CBOR (RFC 8949)
+
+The decoded value is JSON text and the encoded value is the equivalent CBOR, carried as the
+raw bytes in a string; it is binary, so it is not safe to embed in text without a further encoding
+such as base64. Conversion goes by way of DataValue, so only what a DataValue can represent survives.
+
+We capture two values, encoded and raw so that we can cache the encode/decode results for this value
+to avoid re-running expensive en|decode operations on repeat requests. When we set either value,
+encoded or deccoded, we wipe out the cached value of the other to prevent returning stale results
+and cause the conversion to run again and re-cache.
+
+ref: https://www.rfc-editor.org/rfc/rfc8949
+*/
+
+type EncodingSchemeCBOR struct {
+	contentEncoded		*string
+	contentRaw		*string
+}
+
+// -------------------------------------------------------------------------------------------------
+// Factory Functions
+// -------------------------------------------------------------------------------------------------
+func NewEncodingSchemeCBOR() *EncodingSchemeCBOR {
+	return &EncodingSchemeCBOR{}
+}
+
+// -------------------------------------------------------------------------------------------------
+// EncodingSchemeIfc Public Interface
+// -------------------------------------------------------------------------------------------------
+
+func (r *EncodingSchemeCBOR) GetName() string {
+	return "cbor"
+}
+
+func (r *EncodingSchemeCBOR) SetEncodedValue(source *string) error {
+	if nil == source { return fmt.Errorf("Cannot set: source is nil") }
+	r.contentEncoded = source
+	r.contentRaw = nil
+	return nil
+}
+
+func (r *EncodingSchemeCBOR) GetEncodedValue() (*string, error) {
+	if nil == r.contentEncoded {
+		if nil == r.contentRaw { return nil, fmt.Errorf("Cannot encode: raw content is nil") }
+		dataValue, err := json.NewJson(r.contentRaw).ToDataValue()
+		if nil != err { return nil, err }
+		encodedBytes, err := cbor.NewCbor().Encode(dataValue)
+		if nil != err { return nil, err }
+		encoded := string(encodedBytes)
+		r.contentEncoded = &encoded
+	}
+	return r.contentEncoded, nil
+}
+
+func (r *EncodingSchemeCBOR) SetDecodedValue(source *string) error {
+	if nil == source { return fmt.Errorf("Cannot set: source is nil") }
+	r.contentRaw = source
+	r.contentEncoded = nil
+	return nil
+}
+
+func (r *EncodingSchemeCBOR) GetDecodedValue() (*string, error) {
+	if nil == r.contentRaw {
+		if nil == r.contentEncoded { return nil, fmt.Errorf("Cannot decode: encoded content is nil") }
+		dataValue, err := cbor.NewCbor().Decode([]byte(*r.contentEncoded))
+		if nil != err { return nil, err }
+		decoded := dataValue.ToJson()
+		r.contentRaw = &decoded
+	}
+	return r.contentRaw, nil
+}
@@ -0,0 +1,72 @@
+package encodingscheme
+
+import(
+	"testing"
+
+	. "github.com/DigiStratum/GoLib/Testing"
+)
+
+func TestThat_EncodingSchemeCBOR_NewEncodingSchemeCBOR_ReturnsEncodingScheme(t *testing.T) {
+	// Test
+	var sut EncodingSchemeIfc = NewEncodingSchemeCBOR() // <- ensures that we satisfy our interface
+
+	// Verify
+	ExpectNonNil(sut, t)
+	ExpectString("cbor", sut.GetName(), t)
+}
+
+func TestThat_EncodingSchemeCBOR_GetEncodedValue_ReturnsError_WhenSourceIsNil(t *testing.T) {
+	// Setup
+	sut := NewEncodingSchemeCBOR()
+
+	// Test
+	actual, err := sut.GetEncodedValue()
+
+	// Verify
+	ExpectError(err, t)
+	ExpectNil(actual, t)
+}
+
+func TestThat_EncodingSchemeCBOR_GetEncodedValue_ReturnsError_ForInvalidJson(t *testing.T) {
+	// Setup
+	sut := NewEncodingSchemeCBOR()
+	source := "{not json"
+	sut.SetDecodedValue(&source)
+
+	// Test
+	actual, err := sut.GetEncodedValue()
+
+	// Verify
+	ExpectError(err, t)
+	ExpectNil(actual, t)
+}
+
+func TestThat_EncodingSchemeCBOR_GetEncodedValue_ReturnsCBOR_ForJson(t *testing.T) {
+	// Setup
+	sut := NewEncodingSchemeCBOR()
+	source := "{\"a\":[1,true,null]}"
+	sut.SetDecodedValue(&source)
+
+	// Test
+	actual, err := sut.GetEncodedValue()
+
+	// Verify
+	ExpectNoError(err, t)
+	if ! ExpectNonNil(actual, t) { return }
+	ExpectString("\xa1\x61a\x83\x01\xf5\xf6", *actual, t)
+}
+
+func TestThat_EncodingSchemeCBOR_GetDecodedValue_ReturnsJson_ForCBOR(t *testing.T) {
+	// Setup
+	sut := NewEncodingSchemeCBOR()
+	source := "\xa1\x61a\x83\x01\xf5\xf6"
+	sut.SetEncodedValue(&source)
+
+	// Test
+	actual, err := sut.GetDecodedValue()
+
+	// Verify
+	ExpectNoError(err, t)
+	if ! ExpectNonNil(actual, t) { return }
+	ExpectString("{\"a\":[1,true,null]}", *actual, t)
+}
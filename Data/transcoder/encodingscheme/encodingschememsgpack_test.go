@@ -0,0 +1,72 @@
+package encodingscheme
+
+import(
+	"testing"
+
+	. "github.com/DigiStratum/GoLib/Testing"
+)
+
+func TestThat_EncodingSchemeMsgPack_NewEncodingSchemeMsgPack_ReturnsEncodingScheme(t *testing.T) {
+	// Test
+	var sut EncodingSchemeIfc = NewEncodingSchemeMsgPack() // <- ensures that we satisfy our interface
+
+	// Verify
+	ExpectNonNil(sut, t)
+	ExpectString("msgpack", sut.GetName(), t)
+}
+
+func TestThat_EncodingSchemeMsgPack_GetEncodedValue_ReturnsError_WhenSourceIsNil(t *testing.T) {
+	// Setup
+	sut := NewEncodingSchemeMsgPack()
+
+	// Test
+	actual, err := sut.GetEncodedValue()
+
+	// Verify
+	ExpectError(err, t)
+	ExpectNil(actual, t)
+}
+
+func TestThat_EncodingSchemeMsgPack_GetEncodedValue_ReturnsError_ForInvalidJson(t *testing.T) {
+	// Setup
+	sut := NewEncodingSchemeMsgPack()
+	source := "{not json"
+	sut.SetDecodedValue(&source)
+
+	// Test
+	actual, err := sut.GetEncodedValue()
+
+	// Verify
+	ExpectError(err, t)
+	ExpectNil(actual, t)
+}
+
+func TestThat_EncodingSchemeMsgPack_GetEncodedValue_ReturnsMsgPack_ForJson(t *testing.T) {
+	// Setup
+	sut := NewEncodingSchemeMsgPack()
+	source := "{\"a\":[1,true,null]}"
+	sut.SetDecodedValue(&source)
+
+	// Test
+	actual, err := sut.GetEncodedValue()
+
+	// Verify
+	ExpectNoError(err, t)
+	if ! ExpectNonNil(actual, t) { return }
+	ExpectString("\x81\xa1a\x93\x01\xc3\xc0", *actual, t)
+}
+
+func TestThat_EncodingSchemeMsgPack_GetDecodedValue_ReturnsJson_ForMsgPack(t *testing.T) {
+	// Setup
+	sut := NewEncodingSchemeMsgPack()
+	source := "\x81\xa1a\x93\x01\xc3\xc0"
+	sut.SetEncodedValue(&source)
+
+	// Test
+	actual, err := sut.GetDecodedValue()
+
+	// Verify
+	ExpectNoError(err, t)
+	if ! ExpectNonNil(actual, t) { return }
+	ExpectString("{\"a\":[1,true,null]}", *actual, t)
+}
@@ -0,0 +1,191 @@
+// DigiStratum GoLib - XML
+package xml
+
+/*
+
+Map XML documents onto a DataValue object tree (and back again with xmlWriter) using the following
+convention:
+
+ * The document becomes an object with a single property named for the root element
+ * An element with neither attributes nor child elements becomes a string of its text content, or
+   null if it has no text content at all: <a>x</a> => {"a": "x"}, <a/> => {"a": null}
+ * Any other element becomes an object:
+   * Each attribute is a string property named '@' + attribute name: <a id="1"/> => {"a": {"@id": "1"}}
+   * Text content, if any, is a string property named '#text': <a id="1">x</a> => {"a": {"@id": "1", "#text": "x"}}
+   * Each child element is a property named for the child element
+   * Repeated child elements with the same name are collected into an array, in document order:
+     <a><b>1</b><b>2</b></a> => {"a": {"b": ["1", "2"]}}
+ * Text content is trimmed of leading/trailing white space; white-space-only text is discarded
+ * Comments, processing instructions and directives are discarded
+ * Namespaces are discarded; elements and attributes are known only by their local names and
+   namespace declarations (xmlns, xmlns:*) are not retained as attributes
+
+All values are strings (or null); XML carries no type information for us to go on.
+
+TODO:
+ * Support namespace-qualified names (perhaps as 'prefix:name' properties) for documents that
+   depend on them, e.g. SOAP envelopes with same-named elements in different namespaces
+ * Support non-UTF-8 character encodings declared in the XML prolog (decoder.CharsetReader)
+
+*/
+
+import(
+	"io"
+	"os"
+	"fmt"
+	"strings"
+	goxml "encoding/xml"
+
+	"github.com/DigiStratum/GoLib/Data"
+)
+
+const (
+	XML_ATTRIBUTE_PREFIX = "@"
+	XML_TEXT_PROPERTY = "#text"
+)
+
+type XmlIfc interface {
+	ToDataValue() (*data.DataValue, error)
+}
+
+type Xml struct {
+	source	string
+	path	string
+	xml	*string
+	reader	io.Reader
+}
+
+// State for one element while we are between its start and end tags
+type xmlElement struct {
+	name		string
+	value		*data.DataValue
+	text		strings.Builder
+	isComplex	bool
+}
+
+// -------------------------------------------------------------------------------------------------
+// Factory Functions
+// -------------------------------------------------------------------------------------------------
+
+func NewXml(xmlString *string) *Xml {
+	return &Xml{ xml: xmlString, source: "string" }
+}
+
+// Make a new one of these (from file)!
+func NewXmlFromFile(path string) *Xml {
+	return &Xml{ path: path, source: "file" }
+}
+
+// Make a new one of these (from reader)!
+func NewXmlFromReader(reader io.Reader) *Xml {
+	return &Xml{ reader: reader, source: "reader" }
+}
+
+// -------------------------------------------------------------------------------------------------
+// XmlIfc Public Interface
+// -------------------------------------------------------------------------------------------------
+
+// Convert the Xml source to a dynamic DataValue
+func (r *Xml) ToDataValue() (*data.DataValue, error) {
+	switch (r.source) {
+		case "string":
+			if (nil == r.xml) || ("" == *r.xml) {
+				return nil, fmt.Errorf(
+					"Xml.ToDataValue(): We were given nil or empty string for the XML",
+				)
+			}
+			return r.decode(strings.NewReader(*r.xml))
+		case "file":
+			file, err := os.Open(r.path)
+			if nil != err {
+				return nil, fmt.Errorf(
+					"Xml.ToDataValue(): Error reading XML file: %s", err.Error(),
+				)
+			}
+			defer file.Close()
+			return r.decode(file)
+		case "reader":
+			if nil == r.reader {
+				return nil, fmt.Errorf("Xml.ToDataValue(): We were given a nil reader for the XML")
+			}
+			return r.decode(r.reader)
+	}
+	return nil, fmt.Errorf("Xml.ToDataValue(): Unsupported xml source: '%s'", r.source)
+}
+
+// -------------------------------------------------------------------------------------------------
+// Xml Private Implementation
+// -------------------------------------------------------------------------------------------------
+
+func (r *Xml) decode(reader io.Reader) (*data.DataValue, error) {
+	decoder := goxml.NewDecoder(reader)
+	var result *data.DataValue
+	stack := make([]*xmlElement, 0)
+	for {
+		token, err := decoder.Token()
+		if io.EOF == err { break }
+		if nil != err { return nil, fmt.Errorf("Xml.ToDataValue(): %s", err.Error()) }
+
+		switch t := token.(type) {
+			case goxml.StartElement:
+				if (0 == len(stack)) && (nil != result) {
+					return nil, fmt.Errorf("Xml.ToDataValue(): Multiple root elements; found '%s'", t.Name.Local)
+				}
+				stack = append(stack, newXmlElement(t))
+
+			case goxml.CharData:
+				if len(stack) > 0 { stack[len(stack) - 1].text.Write(t) }
+
+			case goxml.EndElement:
+				element := stack[len(stack) - 1]
+				stack = stack[:len(stack) - 1]
+				value := element.finalize()
+				if 0 == len(stack) {
+					result = data.NewObject().SetObjectProperty(element.name, value)
+					continue
+				}
+				stack[len(stack) - 1].addChild(element.name, value)
+		}
+	}
+	if nil == result { return nil, fmt.Errorf("Xml.ToDataValue(): No root element found") }
+	return result, nil
+}
+
+func newXmlElement(start goxml.StartElement) *xmlElement {
+	element := xmlElement{
+		name:	start.Name.Local,
+		value:	data.NewObject(),
+	}
+	for _, attr := range start.Attr {
+		// Namespace declarations are discarded along with the namespaces themselves
+		if ("xmlns" == attr.Name.Space) || (("" == attr.Name.Space) && ("xmlns" == attr.Name.Local)) { continue }
+		element.value.SetObjectProperty(XML_ATTRIBUTE_PREFIX + attr.Name.Local, data.NewString(attr.Value))
+		element.isComplex = true
+	}
+	return &element
+}
+
+func (r *xmlElement) addChild(name string, value *data.DataValue) {
+	r.isComplex = true
+	existing := r.value.GetObjectProperty(name)
+	if nil == existing {
+		r.value.SetObjectProperty(name, value)
+		return
+	}
+	// Parsing never produces an array value for an element, so an array here means repetition
+	if ! existing.IsArray() {
+		existing = data.NewArray().AppendArrayValue(existing)
+		r.value.SetObjectProperty(name, existing)
+	}
+	existing.AppendArrayValue(value)
+}
+
+func (r *xmlElement) finalize() *data.DataValue {
+	text := strings.TrimSpace(r.text.String())
+	if ! r.isComplex {
+		if 0 == len(text) { return data.NewNull() }
+		return data.NewString(text)
+	}
+	if len(text) > 0 { r.value.SetObjectProperty(XML_TEXT_PROPERTY, data.NewString(text)) }
+	return r.value
+}
@@ -0,0 +1,114 @@
+package xml
+
+/*
+
+Unit Tests for Xml
+
+*/
+
+import(
+	"strings"
+	"testing"
+
+	. "github.com/DigiStratum/GoLib/Testing"
+)
+
+func TestThat_Xml_NewXml_ReturnsInstance(t *testing.T) {
+	// Setup
+	var sut XmlIfc = NewXml(nil) // <- ensures that we satisfy our interface
+
+	// Verify
+	ExpectNonNil(sut, t)
+}
+
+func TestThat_Xml_ToDataValue_ReturnsError_ForNilXmlString(t *testing.T) {
+	// Setup
+	sut := NewXml(nil)
+
+	// Test
+	actual, err := sut.ToDataValue()
+
+	// Verify
+	ExpectNil(actual, t)
+	ExpectError(err, t)
+}
+
+func TestThat_Xml_ToDataValue_ReturnsError_ForMissingFile(t *testing.T) {
+	// Setup
+	sut := NewXmlFromFile("/this/file/does/not/exist.xml")
+
+	// Test
+	actual, err := sut.ToDataValue()
+
+	// Verify
+	ExpectNil(actual, t)
+	ExpectError(err, t)
+}
+
+func TestThat_Xml_ToDataValue_ReturnsError_ForMalformedXml(t *testing.T) {
+	// Setup
+	xmlString := "<a><b></a>"
+	sut := NewXml(&xmlString)
+
+	// Test
+	actual, err := sut.ToDataValue()
+
+	// Verify
+	ExpectNil(actual, t)
+	ExpectError(err, t)
+}
+
+func TestThat_Xml_ToDataValue_ReturnsError_ForMultipleRootElements(t *testing.T) {
+	// Setup
+	sut := NewXmlFromReader(strings.NewReader("<a/><b/>"))
+
+	// Test
+	actual, err := sut.ToDataValue()
+
+	// Verify
+	ExpectNil(actual, t)
+	ExpectError(err, t)
+}
+
+func TestThat_Xml_ToDataValue_MapsSimpleElements(t *testing.T) {
+	// Setup
+	xmlString := "<?xml version=\"1.0\"?><!-- hi --><root><name> Bob </name><empty/></root>"
+	sut := NewXml(&xmlString)
+
+	// Test
+	actual, err := sut.ToDataValue()
+
+	// Verify
+	ExpectNoError(err, t)
+	if ! ExpectNonNil(actual, t) { return }
+	ExpectString("Bob", actual.Select("root.name").GetString(), t)
+	ExpectTrue(actual.Select("root.empty").IsNull(), t)
+}
+
+func TestThat_Xml_ToDataValue_MapsAttributesTextAndRepeatedElements(t *testing.T) {
+	// Setup
+	xmlString := `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">
+		<soap:Body>
+			<item id="1">first</item>
+			<item id="2"><sub>x</sub></item>
+			<single>only</single>
+		</soap:Body>
+	</soap:Envelope>`
+	sut := NewXml(&xmlString)
+
+	// Test
+	actual, err := sut.ToDataValue()
+
+	// Verify
+	ExpectNoError(err, t)
+	if ! ExpectNonNil(actual, t) { return }
+	ExpectFalse(actual.Select("Envelope").HasObjectProperty("@soap"), t)
+	items := actual.Select("Envelope.Body.item")
+	if ! ExpectNonNil(items, t) { return }
+	ExpectTrue(items.IsArray(), t)
+	ExpectInt(2, items.GetArraySize(), t)
+	ExpectString("1", items.GetArrayValue(0).GetObjectProperty("@id").GetString(), t)
+	ExpectString("first", items.GetArrayValue(0).GetObjectProperty("#text").GetString(), t)
+	ExpectString("x", items.Select("[1].sub").GetString(), t)
+	ExpectString("only", actual.Select("Envelope.Body.single").GetString(), t)
+}
@@ -0,0 +1,157 @@
+package xml
+
+/*
+
+Write a DataValue object tree out as XML following the same convention that Xml.ToDataValue() uses
+to read it in (see xml.go); the DataValue must be an object with a single property for the root
+element. Otherwise:
+
+ * null values become empty elements
+ * boolean, integer, float and string values become text content
+ * arrays become repeated elements with the same name; an array may not directly contain another
+   array, since there would be no element name for the inner one
+ * object properties named '@...' become attributes, '#text' becomes text content, and all others
+   become child elements
+
+Object properties have no order, so attributes and child elements are written sorted by name to keep
+the output stable; documents whose meaning depends on the interleaving of differently named child
+elements will not survive a round trip.
+
+*/
+
+import(
+	"io"
+	"fmt"
+	"sort"
+	"strings"
+	goxml "encoding/xml"
+
+	"github.com/DigiStratum/GoLib/Data"
+)
+
+type XmlWriterIfc interface {
+	SetHasDeclaration(hasDeclaration bool) *xmlWriter
+	SetIndent(prefix, indent string) *xmlWriter
+	Write(dataValue *data.DataValue) error
+}
+
+type xmlWriter struct {
+	writer		io.Writer
+	hasDeclaration	bool
+	prefix		string
+	indent		string
+}
+
+// -------------------------------------------------------------------------------------------------
+// Factory Functions
+// -------------------------------------------------------------------------------------------------
+
+// Make a new one of these with an XML declaration and no indentation
+func NewXmlWriter(writer io.Writer) *xmlWriter {
+	return &xmlWriter{
+		writer:		writer,
+		hasDeclaration:	true,
+	}
+}
+
+// -------------------------------------------------------------------------------------------------
+// XmlWriterIfc
+// -------------------------------------------------------------------------------------------------
+
+func (r *xmlWriter) SetHasDeclaration(hasDeclaration bool) *xmlWriter {
+	r.hasDeclaration = hasDeclaration
+	return r
+}
+
+func (r *xmlWriter) SetIndent(prefix, indent string) *xmlWriter {
+	r.prefix = prefix
+	r.indent = indent
+	return r
+}
+
+func (r *xmlWriter) Write(dataValue *data.DataValue) error {
+	if nil == r.writer { return fmt.Errorf("xmlWriter.Write(): nil writer") }
+	if (nil == dataValue) || ! dataValue.IsObject() || (1 != len(dataValue.GetObjectProperties())) {
+		return fmt.Errorf("xmlWriter.Write(): expected an object with a single (root element) property")
+	}
+	rootName := dataValue.GetObjectProperties()[0]
+	root := dataValue.GetObjectProperty(rootName)
+	if root.IsArray() { return fmt.Errorf("xmlWriter.Write(): root element '%s' cannot be an array", rootName) }
+
+	if r.hasDeclaration {
+		if _, err := io.WriteString(r.writer, goxml.Header); nil != err {
+			return fmt.Errorf("xmlWriter.Write(): %s", err.Error())
+		}
+	}
+	encoder := goxml.NewEncoder(r.writer)
+	encoder.Indent(r.prefix, r.indent)
+	if err := r.writeElement(encoder, rootName, root); nil != err { return err }
+	if err := encoder.Flush(); nil != err { return fmt.Errorf("xmlWriter.Write(): %s", err.Error()) }
+	return nil
+}
+
+// -------------------------------------------------------------------------------------------------
+// xmlWriter
+// -------------------------------------------------------------------------------------------------
+
+func (r *xmlWriter) writeElement(encoder *goxml.Encoder, name string, value *data.DataValue) error {
+	if strings.HasPrefix(name, XML_ATTRIBUTE_PREFIX) || (XML_TEXT_PROPERTY == name) || (0 == len(name)) {
+		return fmt.Errorf("xmlWriter.Write(): '%s' is not usable as an element name", name)
+	}
+
+	// Arrays are repeated elements
+	if value.IsArray() {
+		for index := 0; index < value.GetArraySize(); index++ {
+			item := value.GetArrayValue(index)
+			if item.IsArray() {
+				return fmt.Errorf("xmlWriter.Write(): element '%s' has an array directly within an array", name)
+			}
+			if err := r.writeElement(encoder, name, item); nil != err { return err } // <- BEWARE: recursion!
+		}
+		return nil
+	}
+
+	start := goxml.StartElement{ Name: goxml.Name{ Local: name } }
+	text := ""
+	children := make([]string, 0)
+	if value.IsObject() {
+		properties := value.GetObjectProperties()
+		sort.Strings(properties)
+		for _, property := range properties {
+			propertyValue := value.GetObjectProperty(property)
+			switch {
+				case strings.HasPrefix(property, XML_ATTRIBUTE_PREFIX):
+					start.Attr = append(start.Attr, goxml.Attr{
+						Name:	goxml.Name{ Local: property[len(XML_ATTRIBUTE_PREFIX):] },
+						Value:	r.toText(propertyValue),
+					})
+				case XML_TEXT_PROPERTY == property:
+					text = r.toText(propertyValue)
+				default:
+					children = append(children, property)
+			}
+		}
+	} else {
+		text = r.toText(value)
+	}
+
+	if err := encoder.EncodeToken(start); nil != err { return fmt.Errorf("xmlWriter.Write(): %s", err.Error()) }
+	if len(text) > 0 {
+		if err := encoder.EncodeToken(goxml.CharData(text)); nil != err {
+			return fmt.Errorf("xmlWriter.Write(): %s", err.Error())
+		}
+	}
+	for _, child := range children {
+		if err := r.writeElement(encoder, child, value.GetObjectProperty(child)); nil != err { return err } // <- BEWARE: recursion!
+	}
+	if err := encoder.EncodeToken(start.End()); nil != err { return fmt.Errorf("xmlWriter.Write(): %s", err.Error()) }
+	return nil
+}
+
+func (r *xmlWriter) toText(value *data.DataValue) string {
+	switch value.GetType() {
+		case data.DATA_TYPE_NULL, data.DATA_TYPE_INVALID: return ""
+		case data.DATA_TYPE_OBJECT, data.DATA_TYPE_ARRAY: return value.ToJson()
+	}
+	return value.ToString()
+}
@@ -0,0 +1,86 @@
+package xml
+
+/*
+
+Unit Tests for xmlWriter
+
+*/
+
+import(
+	"strings"
+	"testing"
+
+	"github.com/DigiStratum/GoLib/Data"
+	. "github.com/DigiStratum/GoLib/Testing"
+)
+
+func TestThat_XmlWriter_NewXmlWriter_ReturnsInstance(t *testing.T) {
+	// Setup
+	var sut XmlWriterIfc = NewXmlWriter(&strings.Builder{}) // <- ensures that we satisfy our interface
+
+	// Verify
+	ExpectNonNil(sut, t)
+}
+
+func TestThat_XmlWriter_Write_ReturnsError_WithoutSingleRootProperty(t *testing.T) {
+	// Setup
+	sut := NewXmlWriter(&strings.Builder{})
+	dataValue := data.NewObject().
+		SetObjectProperty("a", data.NewNull()).
+		SetObjectProperty("b", data.NewNull())
+
+	// Test
+	err := sut.Write(dataValue)
+
+	// Verify
+	ExpectError(err, t)
+}
+
+func TestThat_XmlWriter_Write_ReturnsError_ForNestedArrays(t *testing.T) {
+	// Setup
+	sut := NewXmlWriter(&strings.Builder{})
+	dataValue := data.NewObject().SetObjectProperty("root", data.NewObject().
+		SetObjectProperty("a", data.NewArray().AppendArrayValue(data.NewArray())))
+
+	// Test
+	err := sut.Write(dataValue)
+
+	// Verify
+	ExpectError(err, t)
+}
+
+func TestThat_XmlWriter_Write_WritesConvention(t *testing.T) {
+	// Setup
+	var sb strings.Builder
+	sut := NewXmlWriter(&sb).SetHasDeclaration(false)
+	dataValue := data.NewObject().SetObjectProperty("root", data.NewObject().
+		SetObjectProperty("@id", data.NewInteger(7)).
+		SetObjectProperty("#text", data.NewString("a < b")).
+		SetObjectProperty("item", data.NewArray().
+			AppendArrayValue(data.NewString("one")).
+			AppendArrayValue(data.NewBoolean(true))).
+		SetObjectProperty("empty", data.NewNull()))
+
+	// Test
+	err := sut.Write(dataValue)
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectString("<root id=\"7\">a &lt; b<empty></empty><item>one</item><item>true</item></root>", sb.String(), t)
+}
+
+func TestThat_XmlWriter_Write_RoundTripsWithXml(t *testing.T) {
+	// Setup
+	source := "<feed><entry lang=\"en\">hello</entry><entry><title>x</title></entry></feed>"
+	dataValue, err := NewXml(&source).ToDataValue()
+	if ! ExpectNoError(err, t) { return }
+	var sb strings.Builder
+	sut := NewXmlWriter(&sb)
+
+	// Test
+	err = sut.Write(dataValue)
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectString("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n" + source, sb.String(), t)
+}
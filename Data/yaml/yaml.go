@@ -0,0 +1,617 @@
+// DigiStratum GoLib - YAML
+package yaml
+
+/*
+
+Load YAML documents onto a DataValue object tree, much as Data/json and Data/xml do for theirs.
+
+We support the subset of YAML 1.2 that turns up in configuration files:
+
+ * Block mappings and sequences, including sequences at the same indentation as their parent
+   mapping key, and compact nested collections ("- name: x")
+ * Flow mappings and sequences ({a: 1, b: [x, y]}), which may span multiple lines
+ * Plain, single-quoted and double-quoted scalars; plain scalars may continue on more-indented lines
+ * Literal (|) and folded (>) block scalars with chomping (-, +) and indentation indicators
+ * Comments, a leading %YAML directive, and '---' document start/'...' document end markers
+ * Core schema resolution of plain scalars to null, boolean, integer (decimal, 0x hex, 0o octal) and
+   float; anything else is a string. Quoted scalars are always strings
+
+TODO:
+ * Support anchors (&), aliases (*) and tags (!); these are rejected with an error for now
+ * Support multiple documents in one stream
+ * Support complex ("? ") mapping keys
+ * Support .inf/.nan once DataValue has some way to carry them through JSON
+
+*/
+
+import(
+	"io"
+	"os"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/DigiStratum/GoLib/Data"
+)
+
+type YamlIfc interface {
+	ToDataValue() (*data.DataValue, error)
+}
+
+type Yaml struct {
+	source	string
+	path	string
+	yaml	*string
+	reader	io.Reader
+}
+
+// State for one pass over the lines of a document
+type yamlParser struct {
+	lines	[]string
+	pos	int
+}
+
+var (
+	yamlIntDecimal	= regexp.MustCompile(`^[-+]?[0-9]+$`)
+	yamlIntOctal	= regexp.MustCompile(`^0o[0-7]+$`)
+	yamlIntHex	= regexp.MustCompile(`^0x[0-9a-fA-F]+$`)
+	yamlFloat	= regexp.MustCompile(`^[-+]?(\.[0-9]+|[0-9]+(\.[0-9]*)?)([eE][-+]?[0-9]+)?$`)
+	yamlBlockHeader	= regexp.MustCompile(`^[|>]([-+]?[1-9]?|[1-9][-+])$`)
+)
+
+// -------------------------------------------------------------------------------------------------
+// Factory Functions
+// -------------------------------------------------------------------------------------------------
+
+func NewYaml(yamlString *string) *Yaml {
+	return &Yaml{ yaml: yamlString, source: "string" }
+}
+
+// Make a new one of these (from file)!
+func NewYamlFromFile(path string) *Yaml {
+	return &Yaml{ path: path, source: "file" }
+}
+
+// Make a new one of these (from reader)!
+func NewYamlFromReader(reader io.Reader) *Yaml {
+	return &Yaml{ reader: reader, source: "reader" }
+}
+
+// -------------------------------------------------------------------------------------------------
+// YamlIfc Public Interface
+// -------------------------------------------------------------------------------------------------
+
+// Convert the Yaml source to a dynamic DataValue; an empty document is null
+func (r *Yaml) ToDataValue() (*data.DataValue, error) {
+	switch (r.source) {
+		case "string":
+			if nil == r.yaml {
+				return nil, fmt.Errorf("Yaml.ToDataValue(): We were given nil string for the YAML")
+			}
+			return r.decode(*r.yaml)
+		case "file":
+			content, err := os.ReadFile(r.path)
+			if nil != err {
+				return nil, fmt.Errorf(
+					"Yaml.ToDataValue(): Error reading YAML file: %s", err.Error(),
+				)
+			}
+			return r.decode(string(content))
+		case "reader":
+			if nil == r.reader {
+				return nil, fmt.Errorf("Yaml.ToDataValue(): We were given a nil reader for the YAML")
+			}
+			content, err := io.ReadAll(r.reader)
+			if nil != err {
+				return nil, fmt.Errorf("Yaml.ToDataValue(): Error reading YAML: %s", err.Error())
+			}
+			return r.decode(string(content))
+	}
+	return nil, fmt.Errorf("Yaml.ToDataValue(): Unsupported yaml source: '%s'", r.source)
+}
+
+// -------------------------------------------------------------------------------------------------
+// Yaml Private Implementation
+// -------------------------------------------------------------------------------------------------
+
+func (r *Yaml) decode(content string) (*data.DataValue, error) {
+	if ! utf8.ValidString(content) { return nil, fmt.Errorf("Yaml.ToDataValue(): YAML is not valid UTF-8") }
+	content = strings.TrimPrefix(content, "\ufeff")
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	lines, err := documentLines(lines)
+	if nil != err { return nil, fmt.Errorf("Yaml.ToDataValue(): %s", err.Error()) }
+	parser := yamlParser{ lines: lines }
+	result, err := parser.parseBlock(0)
+	if nil == err {
+		if index := parser.skipBlank(); index < len(parser.lines) {
+			err = fmt.Errorf("line %d: unexpected content '%s'", index + 1, strings.TrimSpace(parser.lines[index]))
+		}
+	}
+	if nil != err { return nil, fmt.Errorf("Yaml.ToDataValue(): %s", err.Error()) }
+	return result, nil
+}
+
+// Pick out the lines of the one document in the stream, blanking out directives and markers so that
+// line numbers in error messages still match the source
+func documentLines(lines []string) ([]string, error) {
+	started := false
+	hasContent := false
+	for index, line := range lines {
+		switch {
+			case ! started && ! hasContent && strings.HasPrefix(line, "%"):
+				lines[index] = ""
+
+			case ("---" == line) || strings.HasPrefix(line, "--- "):
+				if hasContent || started {
+					return nil, fmt.Errorf("line %d: multiple documents are not supported", index + 1)
+				}
+				started = true
+				lines[index] = strings.TrimPrefix(strings.TrimPrefix(line, "---"), " ")
+
+			case ("..." == line) || strings.HasPrefix(line, "... "):
+				return lines[:index], nil
+
+			default:
+				if ! isBlank(line) { hasContent = true }
+		}
+	}
+	return lines, nil
+}
+
+// -------------------------------------------------------------------------------------------------
+// yamlParser Block Structure
+// -------------------------------------------------------------------------------------------------
+
+// Parse whatever block node starts at the next content line, provided it is indented at least minIndent
+func (r *yamlParser) parseBlock(minIndent int) (*data.DataValue, error) {
+	index := r.skipBlank()
+	if index >= len(r.lines) { return data.NewNull(), nil }
+	indent, err := r.indentOf(index)
+	if nil != err { return nil, err }
+	if indent < minIndent { return data.NewNull(), nil }
+	r.pos = index
+	content := stripComment(r.lines[index][indent:])
+	if isSequenceEntry(content) { return r.parseSequence(indent) }
+	if findMappingColon(content) >= 0 { return r.parseMapping(indent) }
+	r.pos++
+	return r.parseValue(content, minIndent - 1, index)
+}
+
+func (r *yamlParser) parseSequence(indent int) (*data.DataValue, error) {
+	result := data.NewArray()
+	for {
+		index := r.skipBlank()
+		if index >= len(r.lines) { break }
+		lineIndent, err := r.indentOf(index)
+		if nil != err { return nil, err }
+		if lineIndent < indent { break }
+		content := stripComment(r.lines[index][lineIndent:])
+		if (lineIndent > indent) || ! isSequenceEntry(content) {
+			if lineIndent == indent { break }
+			return nil, fmt.Errorf("line %d: bad indentation of a sequence entry", index + 1)
+		}
+		// Blank out the dash so that whatever follows it parses as a block node at its own column
+		r.lines[index] = r.lines[index][:lineIndent] + " " + r.lines[index][lineIndent + 1:]
+		r.pos = index
+		if 0 == len(strings.TrimSpace(content[1:])) { r.pos++ }
+		value, err := r.parseBlock(indent + 1)
+		if nil != err { return nil, err }
+		result.AppendArrayValue(value)
+	}
+	return result, nil
+}
+
+func (r *yamlParser) parseMapping(indent int) (*data.DataValue, error) {
+	result := data.NewObject()
+	for {
+		index := r.skipBlank()
+		if index >= len(r.lines) { break }
+		lineIndent, err := r.indentOf(index)
+		if nil != err { return nil, err }
+		if lineIndent < indent { break }
+		content := stripComment(r.lines[index][lineIndent:])
+		if (lineIndent == indent) && isSequenceEntry(content) { break }
+		colon := findMappingColon(content)
+		if (lineIndent > indent) || (colon < 0) {
+			return nil, fmt.Errorf("line %d: expected a mapping key, found '%s'", index + 1, content)
+		}
+		key, err := parseKey(strings.TrimSpace(content[:colon]))
+		if nil != err { return nil, fmt.Errorf("line %d: %s", index + 1, err.Error()) }
+		if result.HasObjectProperty(key) {
+			return nil, fmt.Errorf("line %d: duplicate mapping key '%s'", index + 1, key)
+		}
+		rest := strings.TrimSpace(content[colon + 1:])
+		r.pos = index + 1
+		var value *data.DataValue
+		if 0 == len(rest) {
+			// The value is a nested block, which may be a sequence at our own indentation
+			if r.hasSequenceAt(indent) {
+				value, err = r.parseSequence(indent)
+			} else { value, err = r.parseBlock(indent + 1) }
+		} else { value, err = r.parseValue(rest, indent, index) }
+		if nil != err { return nil, err }
+		result.SetObjectProperty(key, value)
+	}
+	return result, nil
+}
+
+// Parse the value that starts inline on line index; parentIndent bounds any continuation lines
+func (r *yamlParser) parseValue(content string, parentIndent int, index int) (*data.DataValue, error) {
+	if err := checkUnsupported(content); nil != err { return nil, fmt.Errorf("line %d: %s", index + 1, err.Error()) }
+	switch content[0] {
+		case '|', '>':
+			return r.parseBlockScalar(content, parentIndent, index)
+
+		case '[', '{':
+			flow := content
+			for ! isFlowClosed(flow) {
+				if r.pos >= len(r.lines) {
+					return nil, fmt.Errorf("line %d: unterminated flow collection", index + 1)
+				}
+				flow += " " + stripComment(strings.TrimSpace(r.lines[r.pos]))
+				r.pos++
+			}
+			value, err := parseFlow(flow)
+			if nil != err { return nil, fmt.Errorf("line %d: %s", index + 1, err.Error()) }
+			return value, nil
+
+		case '"', '\'':
+			value, rest, err := parseQuoted(content)
+			if (nil == err) && (len(strings.TrimSpace(rest)) > 0) {
+				err = fmt.Errorf("unexpected content after quoted scalar: '%s'", rest)
+			}
+			if nil != err { return nil, fmt.Errorf("line %d: %s", index + 1, err.Error()) }
+			return data.NewString(value), nil
+	}
+
+	// Plain scalars fold more-indented continuation lines into a single line
+	for r.pos < len(r.lines) {
+		if isBlank(r.lines[r.pos]) { break }
+		lineIndent, err := r.indentOf(r.pos)
+		if nil != err { return nil, err }
+		if lineIndent <= parentIndent { break }
+		content += " " + stripComment(strings.TrimSpace(r.lines[r.pos]))
+		r.pos++
+	}
+	return resolvePlain(content), nil
+}
+
+func (r *yamlParser) parseBlockScalar(header string, parentIndent int, index int) (*data.DataValue, error) {
+	if ! yamlBlockHeader.MatchString(header) {
+		return nil, fmt.Errorf("line %d: bad block scalar header '%s'", index + 1, header)
+	}
+	folded := '>' == header[0]
+	chomping := byte(0)
+	blockIndent := 0
+	for _, ch := range []byte(header[1:]) {
+		if ('-' == ch) || ('+' == ch) { chomping = ch } else { blockIndent = parentIndent + 1 + int(ch - '1') }
+	}
+	if blockIndent < 0 { blockIndent = 0 }
+
+	// Collect the content lines, which are blank or indented beyond our parent; a '#' is content
+	// here, not a comment, so only white space makes a line blank
+	content := make([]string, 0)
+	for ; r.pos < len(r.lines); r.pos++ {
+		line := r.lines[r.pos]
+		if 0 == len(strings.TrimSpace(line)) {
+			content = append(content, "")
+			continue
+		}
+		lineIndent := len(line) - len(strings.TrimLeft(line, " "))
+		if lineIndent <= parentIndent { break }
+		if 0 == blockIndent { blockIndent = lineIndent }
+		if lineIndent < blockIndent {
+			return nil, fmt.Errorf("line %d: bad indentation in block scalar", r.pos + 1)
+		}
+		content = append(content, line[blockIndent:])
+	}
+
+	// Trailing blank lines belong to chomping, not to the content
+	trailing := 0
+	for (len(content) > 0) && ("" == content[len(content) - 1]) {
+		content = content[:len(content) - 1]
+		trailing++
+	}
+	var sb strings.Builder
+	for i, line := range content {
+		if i > 0 {
+			// Folding joins adjacent lines with a space; blank and more-indented lines keep line breaks
+			previous := content[i - 1]
+			switch {
+				case ! folded || ("" == line): sb.WriteString("\n")
+				case "" == previous:
+				case (' ' == previous[0]) || (' ' == line[0]): sb.WriteString("\n")
+				default: sb.WriteString(" ")
+			}
+		}
+		sb.WriteString(line)
+	}
+	if len(content) > 0 {
+		switch chomping {
+			case 0: sb.WriteString("\n")
+			case '+': sb.WriteString(strings.Repeat("\n", trailing + 1))
+		}
+	}
+	return data.NewString(sb.String()), nil
+}
+
+// Is the next content line a sequence entry at exactly this indentation?
+func (r *yamlParser) hasSequenceAt(indent int) bool {
+	index := r.skipBlank()
+	if index >= len(r.lines) { return false }
+	line := r.lines[index]
+	lineIndent := len(line) - len(strings.TrimLeft(line, " "))
+	return (lineIndent == indent) && isSequenceEntry(stripComment(line[lineIndent:]))
+}
+
+// Index of the next line with any content, skipping blank and comment-only lines
+func (r *yamlParser) skipBlank() int {
+	index := r.pos
+	for (index < len(r.lines)) && isBlank(r.lines[index]) { index++ }
+	return index
+}
+
+func (r *yamlParser) indentOf(index int) (int, error) {
+	line := r.lines[index]
+	indent := len(line) - len(strings.TrimLeft(line, " "))
+	if (indent < len(line)) && ('\t' == line[indent]) {
+		return 0, fmt.Errorf("line %d: tabs are not allowed for indentation", index + 1)
+	}
+	return indent, nil
+}
+
+// -------------------------------------------------------------------------------------------------
+// Flow Collections
+// -------------------------------------------------------------------------------------------------
+
+func parseFlow(content string) (*data.DataValue, error) {
+	value, rest, err := parseFlowNode(content)
+	if nil != err { return nil, err }
+	if len(strings.TrimSpace(rest)) > 0 {
+		return nil, fmt.Errorf("unexpected content after flow collection: '%s'", rest)
+	}
+	return value, nil
+}
+
+// Parse one flow node from the start of content, returning what is left over after it
+func parseFlowNode(content string) (*data.DataValue, string, error) {
+	content = strings.TrimLeft(content, " ")
+	if 0 == len(content) { return nil, "", fmt.Errorf("unexpected end of flow collection") }
+	switch content[0] {
+		case '[':
+			result := data.NewArray()
+			rest := strings.TrimLeft(content[1:], " ")
+			for {
+				if strings.HasPrefix(rest, "]") { return result, rest[1:], nil }
+				value, after, err := parseFlowNode(rest)
+				if nil != err { return nil, "", err }
+				result.AppendArrayValue(value)
+				if rest, err = flowSeparator(after, ']'); nil != err { return nil, "", err }
+			}
+
+		case '{':
+			result := data.NewObject()
+			rest := strings.TrimLeft(content[1:], " ")
+			for {
+				if strings.HasPrefix(rest, "}") { return result, rest[1:], nil }
+				keyValue, after, err := parseFlowScalar(rest, true)
+				if nil != err { return nil, "", err }
+				key := keyValue.ToString()
+				if result.HasObjectProperty(key) { return nil, "", fmt.Errorf("duplicate mapping key '%s'", key) }
+				after = strings.TrimLeft(after, " ")
+				value := data.NewNull()
+				if strings.HasPrefix(after, ":") {
+					if value, after, err = parseFlowNode(after[1:]); nil != err { return nil, "", err }
+				}
+				result.SetObjectProperty(key, value)
+				if rest, err = flowSeparator(after, '}'); nil != err { return nil, "", err }
+			}
+	}
+	return parseFlowScalar(content, false)
+}
+
+// Consume the comma between flow entries; the closer stays for our caller to find
+func flowSeparator(content string, closer byte) (string, error) {
+	content = strings.TrimLeft(content, " ")
+	if strings.HasPrefix(content, ",") { return strings.TrimLeft(content[1:], " "), nil }
+	if (len(content) > 0) && (closer == content[0]) { return content, nil }
+	return "", fmt.Errorf("expected ',' or '%c' in flow collection", closer)
+}
+
+func parseFlowScalar(content string, isKey bool) (*data.DataValue, string, error) {
+	content = strings.TrimLeft(content, " ")
+	if 0 == len(content) { return nil, "", fmt.Errorf("unexpected end of flow collection") }
+	if ('"' == content[0]) || ('\'' == content[0]) {
+		value, rest, err := parseQuoted(content)
+		if nil != err { return nil, "", err }
+		return data.NewString(value), rest, nil
+	}
+	if err := checkUnsupported(content); nil != err { return nil, "", err }
+	if ('[' == content[0]) || ('{' == content[0]) { return nil, "", fmt.Errorf("collections cannot be mapping keys") }
+	end := 0
+	for ; end < len(content); end++ {
+		ch := content[end]
+		if (',' == ch) || (']' == ch) || ('}' == ch) { break }
+		if (':' == ch) && ((end + 1 == len(content)) || strings.ContainsRune(" ,]}", rune(content[end + 1])) || isKey) { break }
+	}
+	plain := strings.TrimSpace(content[:end])
+	if isKey { return data.NewString(plain), content[end:], nil }
+	return resolvePlain(plain), content[end:], nil
+}
+
+func isFlowClosed(content string) bool {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(content); i++ {
+		ch := content[i]
+		switch {
+			case 0 != quote:
+				i, quote = skipQuoted(content, i, quote)
+			case ('"' == ch) || ('\'' == ch): quote = ch
+			case ('[' == ch) || ('{' == ch): depth++
+			case (']' == ch) || ('}' == ch): depth--
+		}
+	}
+	return depth <= 0
+}
+
+// -------------------------------------------------------------------------------------------------
+// Scalars
+// -------------------------------------------------------------------------------------------------
+
+func parseKey(key string) (string, error) {
+	if 0 == len(key) { return "", fmt.Errorf("empty mapping key") }
+	if ('"' == key[0]) || ('\'' == key[0]) {
+		value, rest, err := parseQuoted(key)
+		if (nil == err) && (len(strings.TrimSpace(rest)) > 0) { err = fmt.Errorf("bad mapping key '%s'", key) }
+		return value, err
+	}
+	if err := checkUnsupported(key); nil != err { return "", err }
+	if ('[' == key[0]) || ('{' == key[0]) { return "", fmt.Errorf("collections cannot be mapping keys") }
+	return key, nil
+}
+
+// Parse the quoted scalar at the start of content, returning what is left over after it
+func parseQuoted(content string) (string, string, error) {
+	quote := content[0]
+	var sb strings.Builder
+	for i := 1; i < len(content); i++ {
+		ch := content[i]
+		if '\'' == quote {
+			if '\'' != ch {
+				sb.WriteByte(ch)
+				continue
+			}
+			if (i + 1 < len(content)) && ('\'' == content[i + 1]) {
+				sb.WriteByte('\'')
+				i++
+				continue
+			}
+			return sb.String(), content[i + 1:], nil
+		}
+		if '"' == ch { return sb.String(), content[i + 1:], nil }
+		if '\\' != ch {
+			sb.WriteByte(ch)
+			continue
+		}
+		i++
+		if i >= len(content) { break }
+		switch content[i] {
+			case 'n': sb.WriteByte('\n')
+			case 't': sb.WriteByte('\t')
+			case 'r': sb.WriteByte('\r')
+			case '0': sb.WriteByte(0)
+			case 'a': sb.WriteByte('\a')
+			case 'b': sb.WriteByte('\b')
+			case 'e': sb.WriteByte(0x1b)
+			case 'f': sb.WriteByte('\f')
+			case 'v': sb.WriteByte('\v')
+			case ' ', '"', '/', '\\': sb.WriteByte(content[i])
+			case 'x', 'u', 'U':
+				size := map[byte]int{ 'x': 2, 'u': 4, 'U': 8 }[content[i]]
+				if i + size >= len(content) { return "", "", fmt.Errorf("truncated escape in quoted scalar") }
+				code, err := strconv.ParseUint(content[i + 1:i + 1 + size], 16, 32)
+				if nil != err { return "", "", fmt.Errorf("bad escape in quoted scalar: %s", err.Error()) }
+				sb.WriteRune(rune(code))
+				i += size
+			default:
+				return "", "", fmt.Errorf("unknown escape '\\%c' in quoted scalar", content[i])
+		}
+	}
+	return "", "", fmt.Errorf("unterminated quoted scalar")
+}
+
+// Resolve a plain scalar by the YAML 1.2 core schema
+func resolvePlain(plain string) *data.DataValue {
+	switch plain {
+		case "", "~", "null", "Null", "NULL": return data.NewNull()
+		case "true", "True", "TRUE": return data.NewBoolean(true)
+		case "false", "False", "FALSE": return data.NewBoolean(false)
+	}
+	if yamlIntDecimal.MatchString(plain) {
+		if i, err := strconv.ParseInt(plain, 10, 64); nil == err { return data.NewInteger(i) }
+	}
+	if yamlIntOctal.MatchString(plain) {
+		if i, err := strconv.ParseInt(plain[2:], 8, 64); nil == err { return data.NewInteger(i) }
+	}
+	if yamlIntHex.MatchString(plain) {
+		if i, err := strconv.ParseInt(plain[2:], 16, 64); nil == err { return data.NewInteger(i) }
+	}
+	if yamlFloat.MatchString(plain) {
+		if f, err := strconv.ParseFloat(plain, 64); nil == err { return data.NewFloat(f) }
+	}
+	return data.NewString(plain)
+}
+
+func checkUnsupported(content string) error {
+	switch content[0] {
+		case '&': return fmt.Errorf("anchors are not supported")
+		case '*': return fmt.Errorf("aliases are not supported")
+		case '!': return fmt.Errorf("tags are not supported")
+		case '?': return fmt.Errorf("complex mapping keys are not supported")
+		case '@', '`': return fmt.Errorf("reserved indicator '%c' cannot start a plain scalar", content[0])
+	}
+	return nil
+}
+
+// -------------------------------------------------------------------------------------------------
+// Line Helpers
+// -------------------------------------------------------------------------------------------------
+
+// Step over the quoted character at index i, returning the index to carry on from and the quote we
+// are still in, if any
+func skipQuoted(content string, i int, quote byte) (int, byte) {
+	ch := content[i]
+	if ('\\' == ch) && ('"' == quote) { return i + 1, quote }
+	if ch != quote { return i, quote }
+	// Single quotes are escaped by doubling them up
+	if ('\'' == quote) && (i + 1 < len(content)) && ('\'' == content[i + 1]) { return i + 1, quote }
+	return i, 0
+}
+
+func isBlank(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return (0 == len(trimmed)) || ('#' == trimmed[0])
+}
+
+func isSequenceEntry(content string) bool {
+	return ("-" == content) || strings.HasPrefix(content, "- ")
+}
+
+// Drop any trailing comment: a '#' at the start or after white space, outside of quotes
+func stripComment(content string) string {
+	var quote byte
+	for i := 0; i < len(content); i++ {
+		ch := content[i]
+		switch {
+			case 0 != quote:
+				i, quote = skipQuoted(content, i, quote)
+			case (('"' == ch) || ('\'' == ch)) && ((0 == i) || strings.ContainsRune(" [{,:", rune(content[i - 1]))):
+				quote = ch
+			case ('#' == ch) && ((0 == i) || (' ' == content[i - 1]) || ('\t' == content[i - 1])):
+				return strings.TrimRight(content[:i], " \t")
+		}
+	}
+	return strings.TrimRight(content, " \t")
+}
+
+// Index of the ':' that separates a block mapping key from its value, or -1 if there is none
+func findMappingColon(content string) int {
+	var quote byte
+	depth := 0
+	for i := 0; i < len(content); i++ {
+		ch := content[i]
+		switch {
+			case 0 != quote:
+				i, quote = skipQuoted(content, i, quote)
+			case (('"' == ch) || ('\'' == ch)) && (0 == i): quote = ch
+			case ('[' == ch) || ('{' == ch): depth++
+			case (']' == ch) || ('}' == ch): depth--
+			case (':' == ch) && (0 == depth) && ((i + 1 == len(content)) || (' ' == content[i + 1])):
+				return i
+		}
+	}
+	return -1
+}
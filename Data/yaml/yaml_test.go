@@ -0,0 +1,244 @@
+package yaml
+
+/*
+
+Unit Tests for Yaml
+
+*/
+
+import(
+	"strings"
+	"testing"
+
+	. "github.com/DigiStratum/GoLib/Testing"
+)
+
+func TestThat_Yaml_NewYaml_ReturnsInstance(t *testing.T) {
+	// Setup
+	var sut YamlIfc = NewYaml(nil) // <- ensures that we satisfy our interface
+
+	// Verify
+	ExpectNonNil(sut, t)
+}
+
+func TestThat_Yaml_ToDataValue_ReturnsError_ForNilYamlString(t *testing.T) {
+	// Setup
+	sut := NewYaml(nil)
+
+	// Test
+	actual, err := sut.ToDataValue()
+
+	// Verify
+	ExpectNil(actual, t)
+	ExpectError(err, t)
+}
+
+func TestThat_Yaml_ToDataValue_ReturnsError_ForMissingFile(t *testing.T) {
+	// Setup
+	sut := NewYamlFromFile("/this/file/does/not/exist.yaml")
+
+	// Test
+	actual, err := sut.ToDataValue()
+
+	// Verify
+	ExpectNil(actual, t)
+	ExpectError(err, t)
+}
+
+func TestThat_Yaml_ToDataValue_ReturnsNull_ForEmptyDocument(t *testing.T) {
+	// Setup
+	yamlString := "# nothing to see here\n---\n"
+	sut := NewYaml(&yamlString)
+
+	// Test
+	actual, err := sut.ToDataValue()
+
+	// Verify
+	ExpectNoError(err, t)
+	if ! ExpectNonNil(actual, t) { return }
+	ExpectTrue(actual.IsNull(), t)
+}
+
+func TestThat_Yaml_ToDataValue_ReturnsError_ForBadDocuments(t *testing.T) {
+	// Setup
+	badDocuments := []string{
+		"a: 1\n---\nb: 2",
+		"a: &anchor 1",
+		"a: *alias",
+		"a: !!str 1",
+		"a: 1\na: 2",
+		"a:\n\t- 1",
+		"a: \"unterminated",
+		"a: [1, 2",
+		"a: {b: 1 c: 2}",
+		"a:\n  b: 1\n c: 2",
+		"- a\nb: 1",
+	}
+
+	for _, badDocument := range badDocuments {
+		sut := NewYaml(&badDocument)
+
+		// Test
+		actual, err := sut.ToDataValue()
+
+		// Verify
+		ExpectNil(actual, t)
+		if ! ExpectError(err, t) { t.Logf("for: %q", badDocument) }
+	}
+}
+
+func TestThat_Yaml_ToDataValue_ResolvesPlainScalars(t *testing.T) {
+	// Setup
+	yamlString := `
+nulls: [~, null, ]
+bools: [true, False]
+ints: [42, -7, 0x1F, 0o17, 010]
+floats: [1.5, -.5, 1e3]
+strings: [hello world, 1.2.3, "42", 'true', .inf]
+`
+	sut := NewYaml(&yamlString)
+
+	// Test
+	actual, err := sut.ToDataValue()
+
+	// Verify
+	if ! ExpectNoError(err, t) { return }
+	ExpectString("[null,null]", actual.Select("nulls").ToJson(), t)
+	ExpectString("[true,false]", actual.Select("bools").ToJson(), t)
+	ExpectString("[42,-7,31,15,10]", actual.Select("ints").ToJson(), t)
+	ExpectString("[1.5,-0.5,1000]", actual.Select("floats").ToJson(), t)
+	ExpectString(`["hello world","1.2.3","42","true",".inf"]`, actual.Select("strings").ToJson(), t)
+}
+
+func TestThat_Yaml_ToDataValue_MapsBlockCollections(t *testing.T) {
+	// Setup
+	yamlString := `%YAML 1.2
+---
+# Service settings
+db:
+  host: localhost   # trailing comment
+  port: 3306
+  url: "mysql://user@host:3306/db#x"
+servers:
+- name: alpha
+  tags:
+    - a
+    - b
+- name: beta
+  tags: [c, d]
+-
+  name: gamma
+matrix:
+  - - 1
+    - 2
+  - - 3
+empty:
+...
+ignored: true
+`
+	sut := NewYamlFromReader(strings.NewReader(yamlString))
+
+	// Test
+	actual, err := sut.ToDataValue()
+
+	// Verify
+	if ! ExpectNoError(err, t) { return }
+	ExpectString("localhost", actual.Select("db.host").GetString(), t)
+	ExpectInt(3306, int(actual.Select("db.port").GetInteger()), t)
+	ExpectString("mysql://user@host:3306/db#x", actual.Select("db.url").GetString(), t)
+	ExpectInt(3, actual.Select("servers").GetArraySize(), t)
+	ExpectString(`["a","b"]`, actual.Select("servers[0].tags").ToJson(), t)
+	ExpectString(`["c","d"]`, actual.Select("servers[1].tags").ToJson(), t)
+	ExpectString("gamma", actual.Select("servers[2].name").GetString(), t)
+	ExpectString("[[1,2],[3]]", actual.Select("matrix").ToJson(), t)
+	ExpectTrue(actual.Select("empty").IsNull(), t)
+	ExpectFalse(actual.HasObjectProperty("ignored"), t)
+}
+
+func TestThat_Yaml_ToDataValue_MapsFlowCollectionsAcrossLines(t *testing.T) {
+	// Setup
+	yamlString := "a: {b: [1, {c: x}],\n    'd e': \"q, r\"}\n"
+	sut := NewYaml(&yamlString)
+
+	// Test
+	actual, err := sut.ToDataValue()
+
+	// Verify
+	if ! ExpectNoError(err, t) { return }
+	ExpectString("x", actual.Select("a.b[1].c").GetString(), t)
+	ExpectString("q, r", actual.Select("a").GetObjectProperty("d e").GetString(), t)
+}
+
+func TestThat_Yaml_ToDataValue_MapsQuotedAndMultiLineScalars(t *testing.T) {
+	// Setup
+	yamlString := `single: 'it''s # not a comment'
+double: "tab\there \u00e9"
+plain: this plain
+  scalar folds
+literal: |
+  line one
+   indented
+
+  line three
+folded: >-
+  folded
+  text
+
+  para
+keep: |+
+  kept
+
+last: x
+`
+	sut := NewYaml(&yamlString)
+
+	// Test
+	actual, err := sut.ToDataValue()
+
+	// Verify
+	if ! ExpectNoError(err, t) { return }
+	ExpectString("it's # not a comment", actual.Select("single").GetString(), t)
+	ExpectString("tab\there é", actual.Select("double").GetString(), t)
+	ExpectString("this plain scalar folds", actual.Select("plain").GetString(), t)
+	ExpectString("line one\n indented\n\nline three\n", actual.Select("literal").GetString(), t)
+	ExpectString("folded text\npara", actual.Select("folded").GetString(), t)
+	ExpectString("kept\n\n", actual.Select("keep").GetString(), t)
+	ExpectString("x", actual.Select("last").GetString(), t)
+}
+
+func TestThat_Yaml_ToDataValue_KeepsHashLines_InBlockScalars(t *testing.T) {
+	// Setup
+	yamlString := `script: |
+  #!/bin/sh
+  # not a comment
+  echo hi
+folded: >
+  # still
+  content
+# a real comment
+last: x
+`
+	sut := NewYaml(&yamlString)
+
+	// Test
+	actual, err := sut.ToDataValue()
+
+	// Verify
+	if ! ExpectNoError(err, t) { return }
+	ExpectString("#!/bin/sh\n# not a comment\necho hi\n", actual.Select("script").GetString(), t)
+	ExpectString("# still content\n", actual.Select("folded").GetString(), t)
+	ExpectString("x", actual.Select("last").GetString(), t)
+}
+
+func TestThat_Yaml_ToDataValue_MapsTopLevelSequence(t *testing.T) {
+	// Setup
+	yamlString := "- 1\n- two\n- - 3\n"
+	sut := NewYaml(&yamlString)
+
+	// Test
+	actual, err := sut.ToDataValue()
+
+	// Verify
+	if ! ExpectNoError(err, t) { return }
+	ExpectString(`[1,"two",[3]]`, actual.ToJson(), t)
+}
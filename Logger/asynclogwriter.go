@@ -0,0 +1,246 @@
+package logger
+
+/*
+
+An AsyncLogWriter takes log output off of the caller's goroutine: each line or LogRecord goes into a
+bounded ring buffer, and a background goroutine writes them to the underlying LogWriter, so that a
+slow LogWriter (a busy StdOut, a network sink...) doesn't hold up request handlers:
+
+	asyncLogWriter := logger.NewAsyncLogWriter(lw.NewStdOutLogWriter(), 10000, logger.LOG_OVERFLOW_DROP_OLDEST)
+	defer asyncLogWriter.Close(5 * time.Second)
+	logger.GetLogger().SetLogWriter(asyncLogWriter)
+
+When the buffer is full, the overflow policy decides what gives:
+
+	LOG_OVERFLOW_BLOCK		the caller waits for space, as if writing synchronously
+	LOG_OVERFLOW_DROP_OLDEST	the oldest buffered entry is dropped to make room
+	LOG_OVERFLOW_DROP_NEWEST	the new entry is dropped
+
+Every dropped entry is counted; see GetDroppedCount(). Close() stops accepting entries and waits, up to
+a deadline, for those already buffered to be written; anything left after that is dropped too.
+
+Any LogWriter will do underneath. LogRecords are handed on as they are to one that is a
+LogRecordWriterIfc (such as a FanOutLogWriter); for any other, they are formatted with our own
+LogFormatter (a TextLogFormatter unless set otherwise) since the Logger hands LogRecords to us rather
+than formatting them itself.
+
+*/
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	lw "github.com/DigiStratum/GoLib/Logger/logwriter"
+)
+
+type LogOverflowPolicy int
+
+const (
+	LOG_OVERFLOW_BLOCK LogOverflowPolicy = iota
+	LOG_OVERFLOW_DROP_OLDEST
+	LOG_OVERFLOW_DROP_NEWEST
+)
+
+const ASYNC_LOG_WRITER_DEFAULT_CAPACITY = 1024
+
+type AsyncLogWriterIfc interface {
+	SetLogFormatter(logFormatter LogFormatterIfc) *AsyncLogWriter
+	GetDroppedCount() uint64
+	GetBufferedCount() int
+	Log(format string, a ...interface{})
+	LogRecord(record *LogRecord)
+	Close(timeout time.Duration) error
+}
+
+type AsyncLogWriter struct {
+	mutex		sync.Mutex
+	notEmpty	*sync.Cond
+	notFull		*sync.Cond
+	logWriter	lw.LogWriterIfc
+	logFormatter	LogFormatterIfc
+	overflowPolicy	LogOverflowPolicy
+	buffer		[]asyncLogEntry		// Ring buffer...
+	head		int			// ...with its oldest entry here...
+	count		int			// ...and this many entries
+	dropped		uint64
+	closing		bool			// No more entries accepted; drain what we have
+	abandoned	bool			// Close() gave up waiting; stop writing
+	done		chan bool		// Closed when the flusher has finished
+}
+
+// One line, or one LogRecord
+type asyncLogEntry struct {
+	line		string
+	record		*LogRecord
+}
+
+// -------------------------------------------------------------------------------------------------
+// Factory Functions
+// -------------------------------------------------------------------------------------------------
+
+// Make a new AsyncLogWriter buffering up to capacity entries for logWriter, and start writing them
+func NewAsyncLogWriter(logWriter lw.LogWriterIfc, capacity int, overflowPolicy LogOverflowPolicy) *AsyncLogWriter {
+	if nil == logWriter { logWriter = lw.NewStdOutLogWriter() }
+	if capacity < 1 { capacity = ASYNC_LOG_WRITER_DEFAULT_CAPACITY }
+	r := AsyncLogWriter{
+		logWriter:	logWriter,
+		logFormatter:	NewTextLogFormatter(),
+		overflowPolicy:	overflowPolicy,
+		buffer:		make([]asyncLogEntry, capacity),
+		done:		make(chan bool),
+	}
+	r.notEmpty = sync.NewCond(&r.mutex)
+	r.notFull = sync.NewCond(&r.mutex)
+	go r.flusher()
+	return &r
+}
+
+// -------------------------------------------------------------------------------------------------
+// AsyncLogWriterIfc Public Interface
+// -------------------------------------------------------------------------------------------------
+
+// Set the LogFormatter for LogRecords going to a LogWriter that isn't a LogRecordWriterIfc
+func (r *AsyncLogWriter) SetLogFormatter(logFormatter LogFormatterIfc) *AsyncLogWriter {
+	if nil == logFormatter { logFormatter = NewTextLogFormatter() }
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.logFormatter = logFormatter
+	return r
+}
+
+// How many entries have been dropped, whether to overflow or to Close()
+func (r *AsyncLogWriter) GetDroppedCount() uint64 {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.dropped
+}
+
+// How many entries are waiting to be written
+func (r *AsyncLogWriter) GetBufferedCount() int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.count
+}
+
+// Stop accepting entries and wait up to timeout for those buffered to be written; error if they weren't
+func (r *AsyncLogWriter) Close(timeout time.Duration) error {
+	r.mutex.Lock()
+	r.closing = true
+	r.notEmpty.Broadcast()
+	r.notFull.Broadcast()
+	r.mutex.Unlock()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+		case <-r.done: return nil
+		case <-timer.C:
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.abandoned = true
+	undelivered := r.count
+	r.dropped += uint64(r.count)
+	r.head = 0
+	r.count = 0
+	return fmt.Errorf("AsyncLogWriter.Close(): Deadline passed with %d entries not written", undelivered)
+}
+
+// -------------------------------------------------------------------------------------------------
+// LogWriterIfc Public Interface
+// -------------------------------------------------------------------------------------------------
+
+func (r *AsyncLogWriter) Log(format string, a ...interface{}) {
+	r.add(asyncLogEntry{ line: fmt.Sprintf(format, a...) })
+}
+
+// -------------------------------------------------------------------------------------------------
+// LogRecordWriterIfc Public Interface
+// -------------------------------------------------------------------------------------------------
+
+func (r *AsyncLogWriter) LogRecord(record *LogRecord) {
+	if nil == record { return }
+	// The caller may reuse their LogRecord once we return, so we keep our own copy
+	copied := *record
+	copied.Fields = append([]LogField{}, record.Fields...)
+	r.add(asyncLogEntry{ record: &copied })
+}
+
+// -------------------------------------------------------------------------------------------------
+// AsyncLogWriter Implementation
+// -------------------------------------------------------------------------------------------------
+
+// Add entry to the buffer, applying our overflow policy if it is full
+func (r *AsyncLogWriter) add(entry asyncLogEntry) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	capacity := len(r.buffer)
+	if r.overflowPolicy == LOG_OVERFLOW_BLOCK {
+		for (r.count == capacity) && ! r.closing { r.notFull.Wait() }
+	}
+	if r.closing {
+		r.dropped++
+		return
+	}
+	if r.count == capacity {
+		r.dropped++
+		if r.overflowPolicy == LOG_OVERFLOW_DROP_NEWEST { return }
+		// Drop the oldest
+		r.buffer[r.head] = asyncLogEntry{}
+		r.head = (r.head + 1) % capacity
+		r.count--
+	}
+	r.buffer[(r.head + r.count) % capacity] = entry
+	r.count++
+	r.notEmpty.Signal()
+}
+
+// Take everything buffered, waiting for something if there's nothing; nil once closed and drained
+func (r *AsyncLogWriter) take() ([]asyncLogEntry, LogFormatterIfc) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for (0 == r.count) && ! r.closing { r.notEmpty.Wait() }
+	if (0 == r.count) || r.abandoned { return nil, nil }
+	capacity := len(r.buffer)
+	entries := make([]asyncLogEntry, 0, r.count)
+	for ; r.count > 0; r.count-- {
+		entries = append(entries, r.buffer[r.head])
+		r.buffer[r.head] = asyncLogEntry{}
+		r.head = (r.head + 1) % capacity
+	}
+	r.head = 0
+	r.notFull.Broadcast()
+	return entries, r.logFormatter
+}
+
+func (r *AsyncLogWriter) isAbandoned() bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.abandoned
+}
+
+func (r *AsyncLogWriter) flusher() {
+	defer close(r.done)
+	recordWriter, isRecordWriter := r.logWriter.(LogRecordWriterIfc)
+	for {
+		entries, logFormatter := r.take()
+		if nil == entries { return }
+		for index, entry := range entries {
+			if r.isAbandoned() {
+				r.mutex.Lock()
+				r.dropped += uint64(len(entries) - index)
+				r.mutex.Unlock()
+				return
+			}
+			if nil == entry.record {
+				r.logWriter.Log("%s", entry.line)
+			} else if isRecordWriter {
+				recordWriter.LogRecord(entry.record)
+			} else {
+				r.logWriter.Log("%s", logFormatter.Format(entry.record))
+			}
+		}
+	}
+}
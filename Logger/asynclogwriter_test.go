@@ -0,0 +1,177 @@
+package logger
+
+import(
+	"fmt"
+	"time"
+	"strings"
+	"testing"
+
+	lw "github.com/DigiStratum/GoLib/Logger/logwriter"
+
+	. "github.com/DigiStratum/GoLib/Testing"
+)
+
+// A LogWriter that holds up each write until its gate opens, announcing each write as it starts
+type gatedLogWriter struct {
+	collectingLogWriter
+	entered		chan string
+	gate		chan bool
+}
+
+func newGatedLogWriter() *gatedLogWriter {
+	return &gatedLogWriter{ entered: make(chan string, 100), gate: make(chan bool) }
+}
+
+func (r *gatedLogWriter) Log(format string, a ...interface{}) {
+	line := fmt.Sprintf(format, a...)
+	r.entered <- line
+	<-r.gate
+	r.collectingLogWriter.Log("%s", line)
+}
+
+// Log "1" and wait for it to be taken out of the buffer and held up at the gate
+func holdAsyncLogWriterForTest(sut *AsyncLogWriter, gated *gatedLogWriter, t *testing.T) {
+	sut.Log("1")
+	select {
+		case <-gated.entered:
+		case <-time.After(2 * time.Second): t.Fatal("Timed out waiting for the flusher")
+	}
+}
+
+func TestThat_AsyncLogWriter_NewAsyncLogWriter_ReturnsSomething(t *testing.T) {
+	// Test
+	var sut AsyncLogWriterIfc = NewAsyncLogWriter(nil, 0, LOG_OVERFLOW_BLOCK) // <- ensures that we satisfy our interface
+	var logWriter lw.LogWriterIfc = sut.(*AsyncLogWriter)
+	var recordWriter LogRecordWriterIfc = sut.(*AsyncLogWriter)
+
+	// Verify
+	ExpectNonNil(logWriter, t)
+	ExpectNonNil(recordWriter, t)
+	ExpectNoError(sut.Close(time.Second), t)
+}
+
+func TestThat_AsyncLogWriter_Writes_lines_and_records_in_order(t *testing.T) {
+	// Setup
+	collecting := &collectingLogWriter{}
+	sut := NewAsyncLogWriter(collecting, 10, LOG_OVERFLOW_BLOCK).SetLogFormatter(NewJsonLogFormatter())
+	logger := NewLogger("1").SetLogWriter(sut).LogTimestamp(false)
+
+	// Test
+	sut.Log("%s", "plain")
+	logger.With("k", 1).Info("structured")
+	err := sut.Close(time.Second)
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectString(
+		`plain|{"level":"INFO","streamId":"1","message":"structured","fields":{"k":1}}`,
+		strings.Join(collecting.getLines(), "|"), t,
+	)
+	ExpectTrue(0 == sut.GetDroppedCount(), t)
+}
+
+func TestThat_AsyncLogWriter_Hands_records_to_record_writers(t *testing.T) {
+	// Setup
+	recordWriter := &collectingLogRecordWriter{}
+	sut := NewAsyncLogWriter(recordWriter, 10, LOG_OVERFLOW_BLOCK)
+	record := LogRecord{ Level: WARN, Message: "original", Fields: NewLogFields("k", 1) }
+
+	// Test
+	sut.LogRecord(&record)
+	record.Message = "changed"
+	record.Fields[0].Value = 2
+	sut.Close(time.Second)
+
+	// Verify
+	if ExpectInt(1, len(recordWriter.records), t) {
+		ExpectString("original", recordWriter.records[0].Message, t)
+		ExpectTrue(1 == recordWriter.records[0].Fields[0].Value, t)
+	}
+}
+
+func TestThat_AsyncLogWriter_DropOldest_Drops_oldest_buffered_entry(t *testing.T) {
+	// Setup
+	gated := newGatedLogWriter()
+	sut := NewAsyncLogWriter(gated, 2, LOG_OVERFLOW_DROP_OLDEST)
+	holdAsyncLogWriterForTest(sut, gated, t)
+
+	// Test
+	sut.Log("2")
+	sut.Log("3")
+	sut.Log("4")
+	close(gated.gate)
+	err := sut.Close(time.Second)
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectString("1,3,4", strings.Join(gated.getLines(), ","), t)
+	ExpectTrue(1 == sut.GetDroppedCount(), t)
+}
+
+func TestThat_AsyncLogWriter_DropNewest_Drops_new_entry(t *testing.T) {
+	// Setup
+	gated := newGatedLogWriter()
+	sut := NewAsyncLogWriter(gated, 2, LOG_OVERFLOW_DROP_NEWEST)
+	holdAsyncLogWriterForTest(sut, gated, t)
+
+	// Test
+	sut.Log("2")
+	sut.Log("3")
+	sut.Log("4")
+	sut.Log("5")
+	close(gated.gate)
+	err := sut.Close(time.Second)
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectString("1,2,3", strings.Join(gated.getLines(), ","), t)
+	ExpectTrue(2 == sut.GetDroppedCount(), t)
+}
+
+func TestThat_AsyncLogWriter_Block_Waits_for_space(t *testing.T) {
+	// Setup
+	gated := newGatedLogWriter()
+	sut := NewAsyncLogWriter(gated, 1, LOG_OVERFLOW_BLOCK)
+	holdAsyncLogWriterForTest(sut, gated, t)
+	sut.Log("2")
+	returned := make(chan bool)
+
+	// Test
+	go func () {
+		sut.Log("3")
+		close(returned)
+	}()
+	var blocked bool
+	select {
+		case <-returned: blocked = false
+		case <-time.After(50 * time.Millisecond): blocked = true
+	}
+	close(gated.gate)
+	<-returned
+	err := sut.Close(time.Second)
+
+	// Verify
+	ExpectTrue(blocked, t)
+	ExpectNoError(err, t)
+	ExpectString("1,2,3", strings.Join(gated.getLines(), ","), t)
+	ExpectTrue(0 == sut.GetDroppedCount(), t)
+}
+
+func TestThat_AsyncLogWriter_Close_Gives_up_at_deadline(t *testing.T) {
+	// Setup
+	gated := newGatedLogWriter()
+	defer close(gated.gate)
+	sut := NewAsyncLogWriter(gated, 10, LOG_OVERFLOW_BLOCK)
+	holdAsyncLogWriterForTest(sut, gated, t)
+	sut.Log("2")
+	sut.Log("3")
+
+	// Test
+	err := sut.Close(20 * time.Millisecond)
+	sut.Log("4")
+
+	// Verify
+	ExpectError(err, t)
+	ExpectInt(0, sut.GetBufferedCount(), t)
+	ExpectTrue(3 == sut.GetDroppedCount(), t) // <- "2" and "3" undelivered, "4" after closing
+}
@@ -0,0 +1,157 @@
+package logger
+
+/*
+
+A FanOutLogWriter sends each LogRecord to any number of named LogWriters, each with its own minimum
+LogLevel and LogFormatter, so that one Logger can log to several places at once:
+
+	fanOut := logger.NewFanOutLogWriter().
+		AddLogWriter("stdout", lw.NewStdOutLogWriter(), logger.INFO, nil).
+		AddLogWriter("file", fileLogWriter, logger.DEBUG, logger.NewJsonLogFormatter()).
+		AddLogWriter("alerts", alertLogWriter, logger.ERROR, nil)
+	logger.GetLogger().SetMinLogLevel(logger.DEBUG).SetLogWriter(fanOut)
+
+The Logger's own minimum LogLevel still applies first, so it must be at least as low as the lowest of
+the LogWriters' for them to see anything at that level.
+
+LogWriters may be added, replaced (by adding another with the same name) and removed at any time, from
+any goroutine, including while logging is under way. A nil LogFormatter means the TextLogFormatter; a
+LogWriter which is itself a LogRecordWriterIfc is handed the LogRecord instead, to format as it sees
+fit. Plain lines logged with Log() by anything that doesn't know about LogRecords have no LogLevel,
+so they go to every LogWriter as they are.
+
+*/
+
+import (
+	"sync"
+
+	lw "github.com/DigiStratum/GoLib/Logger/logwriter"
+)
+
+type FanOutLogWriterIfc interface {
+	AddLogWriter(name string, logWriter lw.LogWriterIfc, minLogLevel LogLevel, logFormatter LogFormatterIfc) *FanOutLogWriter
+	RemoveLogWriter(name string) lw.LogWriterIfc
+	HasLogWriter(name string) bool
+	GetLogWriterNames() []string
+	Log(format string, a ...interface{})
+	LogRecord(record *LogRecord)
+}
+
+type FanOutLogWriter struct {
+	mutex		sync.RWMutex
+	targets		[]fanOutTarget	// Replaced, never modified, so that loggers may use it unlocked
+}
+
+type fanOutTarget struct {
+	name		string
+	logWriter	lw.LogWriterIfc
+	minLogLevel	LogLevel
+	logFormatter	LogFormatterIfc
+}
+
+// -------------------------------------------------------------------------------------------------
+// Factory Functions
+// -------------------------------------------------------------------------------------------------
+
+func NewFanOutLogWriter() *FanOutLogWriter {
+	return &FanOutLogWriter{
+		targets:	make([]fanOutTarget, 0),
+	}
+}
+
+// -------------------------------------------------------------------------------------------------
+// FanOutLogWriterIfc Public Interface
+// -------------------------------------------------------------------------------------------------
+
+// Add logWriter for LogRecords at minLogLevel or above, replacing any existing one with the same name
+func (r *FanOutLogWriter) AddLogWriter(name string, logWriter lw.LogWriterIfc, minLogLevel LogLevel, logFormatter LogFormatterIfc) *FanOutLogWriter {
+	if nil == logWriter { return r }
+	if nil == logFormatter { logFormatter = NewTextLogFormatter() }
+	target := fanOutTarget{
+		name:		name,
+		logWriter:	logWriter,
+		minLogLevel:	minLogLevel,
+		logFormatter:	logFormatter,
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	targets := make([]fanOutTarget, 0, len(r.targets) + 1)
+	replaced := false
+	for _, existing := range r.targets {
+		if existing.name == name {
+			targets = append(targets, target)
+			replaced = true
+			continue
+		}
+		targets = append(targets, existing)
+	}
+	if ! replaced { targets = append(targets, target) }
+	r.targets = targets
+	return r
+}
+
+// Remove the named LogWriter, returning it (so that the caller may close it, etc) or nil if there isn't one
+func (r *FanOutLogWriter) RemoveLogWriter(name string) lw.LogWriterIfc {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	var removed lw.LogWriterIfc
+	targets := make([]fanOutTarget, 0, len(r.targets))
+	for _, existing := range r.targets {
+		if existing.name == name {
+			removed = existing.logWriter
+			continue
+		}
+		targets = append(targets, existing)
+	}
+	r.targets = targets
+	return removed
+}
+
+func (r *FanOutLogWriter) HasLogWriter(name string) bool {
+	for _, target := range r.getTargets() {
+		if target.name == name { return true }
+	}
+	return false
+}
+
+// Get the names of our LogWriters in the order they were added
+func (r *FanOutLogWriter) GetLogWriterNames() []string {
+	targets := r.getTargets()
+	names := make([]string, 0, len(targets))
+	for _, target := range targets { names = append(names, target.name) }
+	return names
+}
+
+// -------------------------------------------------------------------------------------------------
+// LogWriterIfc Public Interface
+// -------------------------------------------------------------------------------------------------
+
+func (r *FanOutLogWriter) Log(format string, a ...interface{}) {
+	for _, target := range r.getTargets() { target.logWriter.Log(format, a...) }
+}
+
+// -------------------------------------------------------------------------------------------------
+// LogRecordWriterIfc Public Interface
+// -------------------------------------------------------------------------------------------------
+
+func (r *FanOutLogWriter) LogRecord(record *LogRecord) {
+	if nil == record { return }
+	for _, target := range r.getTargets() {
+		if record.Level < target.minLogLevel { continue }
+		if recordWriter, ok := target.logWriter.(LogRecordWriterIfc); ok {
+			recordWriter.LogRecord(record)
+			continue
+		}
+		target.logWriter.Log("%s", target.logFormatter.Format(record))
+	}
+}
+
+// -------------------------------------------------------------------------------------------------
+// FanOutLogWriter Implementation
+// -------------------------------------------------------------------------------------------------
+
+func (r *FanOutLogWriter) getTargets() []fanOutTarget {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.targets
+}
@@ -0,0 +1,170 @@
+package logger
+
+import(
+	"fmt"
+	"sync"
+	"testing"
+
+	lw "github.com/DigiStratum/GoLib/Logger/logwriter"
+
+	. "github.com/DigiStratum/GoLib/Testing"
+)
+
+type collectingLogWriter struct {
+	mutex		sync.Mutex
+	lines		[]string
+}
+
+func (r *collectingLogWriter) Log(format string, a ...interface{}) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.lines = append(r.lines, fmt.Sprintf(format, a...))
+}
+
+func (r *collectingLogWriter) getLines() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]string{}, r.lines...)
+}
+
+type collectingLogRecordWriter struct {
+	collectingLogWriter
+	records		[]*LogRecord
+}
+
+func (r *collectingLogRecordWriter) LogRecord(record *LogRecord) {
+	r.records = append(r.records, record)
+}
+
+func TestThat_FanOutLogWriter_NewFanOutLogWriter_ReturnsSomething(t *testing.T) {
+	// Test
+	var sut FanOutLogWriterIfc = NewFanOutLogWriter() // <- ensures that we satisfy our interface
+	var logWriter lw.LogWriterIfc = NewFanOutLogWriter()
+	var recordWriter LogRecordWriterIfc = NewFanOutLogWriter()
+
+	// Verify
+	ExpectNonNil(sut, t)
+	ExpectNonNil(logWriter, t)
+	ExpectNonNil(recordWriter, t)
+}
+
+func TestThat_FanOutLogWriter_LogRecord_Filters_by_level_and_formats_per_writer(t *testing.T) {
+	// Setup
+	stdout := &collectingLogWriter{}
+	file := &collectingLogWriter{}
+	alerts := &collectingLogWriter{}
+	sut := NewFanOutLogWriter().
+		AddLogWriter("stdout", stdout, INFO, nil).
+		AddLogWriter("file", file, DEBUG, NewJsonLogFormatter()).
+		AddLogWriter("alerts", alerts, ERROR, nil)
+	logger := NewLogger("1").SetMinLogLevel(DEBUG).SetLogWriter(sut).LogTimestamp(false)
+
+	// Test
+	logger.Debug("d")
+	logger.Info("i")
+	logger.With("k", 1).Error("e")
+
+	// Verify
+	ExpectString("thread:1  INFO i|thread:1 ERROR e k=1", fmt.Sprintf("%s|%s", stdout.getLines()[0], stdout.getLines()[1]), t)
+	if ExpectInt(3, len(file.getLines()), t) {
+		ExpectString(`{"level":"DEBUG","streamId":"1","message":"d"}`, file.getLines()[0], t)
+	}
+	if ExpectInt(1, len(alerts.getLines()), t) {
+		ExpectString("thread:1 ERROR e k=1", alerts.getLines()[0], t)
+	}
+}
+
+func TestThat_FanOutLogWriter_LogRecord_Hands_records_to_record_writers(t *testing.T) {
+	// Setup
+	recordWriter := &collectingLogRecordWriter{}
+	sut := NewFanOutLogWriter().AddLogWriter("records", recordWriter, WARN, nil)
+
+	// Test
+	sut.LogRecord(&LogRecord{ Level: INFO, Message: "below" })
+	sut.LogRecord(&LogRecord{ Level: WARN, Message: "at" })
+
+	// Verify
+	if ExpectInt(1, len(recordWriter.records), t) { ExpectString("at", recordWriter.records[0].Message, t) }
+	ExpectInt(0, len(recordWriter.getLines()), t)
+}
+
+func TestThat_FanOutLogWriter_Log_Sends_plain_lines_to_every_writer(t *testing.T) {
+	// Setup
+	one := &collectingLogWriter{}
+	two := &collectingLogWriter{}
+	sut := NewFanOutLogWriter().AddLogWriter("one", one, FATAL, nil).AddLogWriter("two", two, CRAZY, nil)
+
+	// Test
+	sut.Log("%s %d", "plain", 1)
+
+	// Verify
+	ExpectString("plain 1", one.getLines()[0], t)
+	ExpectString("plain 1", two.getLines()[0], t)
+}
+
+func TestThat_FanOutLogWriter_AddLogWriter_Replaces_by_name(t *testing.T) {
+	// Setup
+	first := &collectingLogWriter{}
+	second := &collectingLogWriter{}
+	sut := NewFanOutLogWriter().
+		AddLogWriter("a", first, INFO, nil).
+		AddLogWriter("b", &collectingLogWriter{}, INFO, nil).
+		AddLogWriter("a", second, INFO, nil).
+		AddLogWriter("nil", nil, INFO, nil)
+
+	// Test
+	sut.Log("x")
+
+	// Verify
+	ExpectString("a,b", fmt.Sprintf("%s,%s", sut.GetLogWriterNames()[0], sut.GetLogWriterNames()[1]), t)
+	ExpectInt(2, len(sut.GetLogWriterNames()), t)
+	ExpectInt(0, len(first.getLines()), t)
+	ExpectInt(1, len(second.getLines()), t)
+}
+
+func TestThat_FanOutLogWriter_RemoveLogWriter_Returns_removed_writer(t *testing.T) {
+	// Setup
+	removable := &collectingLogWriter{}
+	sut := NewFanOutLogWriter().AddLogWriter("a", removable, INFO, nil)
+
+	// Test
+	actual := sut.RemoveLogWriter("a")
+	missing := sut.RemoveLogWriter("a")
+	sut.Log("x")
+
+	// Verify
+	ExpectTrue(actual == removable, t)
+	ExpectTrue(nil == missing, t)
+	ExpectFalse(sut.HasLogWriter("a"), t)
+	ExpectInt(0, len(removable.getLines()), t)
+}
+
+func TestThat_FanOutLogWriter_Supports_concurrent_changes_while_logging(t *testing.T) {
+	// Setup
+	sink := &collectingLogWriter{}
+	sut := NewFanOutLogWriter().AddLogWriter("sink", sink, CRAZY, nil)
+	var wg sync.WaitGroup
+
+	// Test
+	for worker := 0; worker < 4; worker++ {
+		wg.Add(2)
+		go func(worker int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ { sut.LogRecord(&LogRecord{ Level: INFO, Message: "m" }) }
+		}(worker)
+		go func(worker int) {
+			defer wg.Done()
+			name := fmt.Sprintf("w%d", worker)
+			for i := 0; i < 100; i++ {
+				sut.AddLogWriter(name, &collectingLogWriter{}, INFO, nil)
+				sut.RemoveLogWriter(name)
+			}
+		}(worker)
+	}
+	wg.Wait()
+
+	// Verify
+	ExpectInt(400, len(sink.getLines()), t)
+	ExpectString("sink", sut.GetLogWriterNames()[0], t)
+	ExpectInt(1, len(sut.GetLogWriterNames()), t)
+}
@@ -0,0 +1,174 @@
+package logger
+
+/*
+
+Request, trace and span IDs travel with a context.Context so that every layer handling a request (HTTP,
+Cache, DB/MySQL...) can log with them, and the logs for one request can be correlated:
+
+	// Where the request comes in
+	ctx = logger.ContextWithRequestId(ctx, requestId)
+	ctx = logger.ContextWithTraceParent(ctx, traceparentHeader)
+
+	// Anywhere below, given ctx
+	logger.GetLogger().WithContext(ctx).Info("Fetched user", "user_id", userId)
+	// -> ... INFO Fetched user request_id=... trace_id=... span_id=... user_id=42
+
+WithContext() adds the IDs found in ctx as fields (LOG_FIELD_REQUEST_ID, LOG_FIELD_TRACE_ID and
+LOG_FIELD_SPAN_ID) on every record that the resulting StructuredLogger logs. A StructuredLogger may
+also be stored in the context itself with ContextWithLogger(), e.g. with fields for the whole
+request; FromContext() gets that one back (with the IDs) or, if there isn't one, falls back to
+WithContext() on the Logger it was called on.
+
+*/
+
+import (
+	"context"
+	"strings"
+	"encoding/hex"
+)
+
+const LOG_FIELD_REQUEST_ID = "request_id"
+const LOG_FIELD_TRACE_ID = "trace_id"
+const LOG_FIELD_SPAN_ID = "span_id"
+
+// Private so that nobody else's context values collide with ours
+type logContextKey int
+
+const (
+	logContextKeyRequestId logContextKey = iota
+	logContextKeyTraceId
+	logContextKeySpanId
+	logContextKeyLogger
+)
+
+// -------------------------------------------------------------------------------------------------
+// Context Functions
+// -------------------------------------------------------------------------------------------------
+
+func ContextWithRequestId(ctx context.Context, requestId string) context.Context {
+	return contextWithValue(ctx, logContextKeyRequestId, requestId)
+}
+
+func ContextWithTraceId(ctx context.Context, traceId string) context.Context {
+	return contextWithValue(ctx, logContextKeyTraceId, traceId)
+}
+
+func ContextWithSpanId(ctx context.Context, spanId string) context.Context {
+	return contextWithValue(ctx, logContextKeySpanId, spanId)
+}
+
+// Add the trace and span IDs from a W3C Trace Context traceparent header value, if it is valid:
+// "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+func ContextWithTraceParent(ctx context.Context, traceParent string) context.Context {
+	traceId, spanId, ok := ParseTraceParent(traceParent)
+	if ! ok {
+		if nil == ctx { ctx = context.Background() }
+		return ctx
+	}
+	return ContextWithSpanId(ContextWithTraceId(ctx, traceId), spanId)
+}
+
+// Store structuredLogger in the context for FromContext() to get back later
+func ContextWithLogger(ctx context.Context, structuredLogger *StructuredLogger) context.Context {
+	if nil == ctx { ctx = context.Background() }
+	if nil == structuredLogger { return ctx }
+	return context.WithValue(ctx, logContextKeyLogger, structuredLogger)
+}
+
+func GetRequestId(ctx context.Context) string {
+	return getContextString(ctx, logContextKeyRequestId)
+}
+
+func GetTraceId(ctx context.Context) string {
+	return getContextString(ctx, logContextKeyTraceId)
+}
+
+func GetSpanId(ctx context.Context) string {
+	return getContextString(ctx, logContextKeySpanId)
+}
+
+// Get the trace and span IDs from a W3C Trace Context traceparent header value; ok=false if invalid
+func ParseTraceParent(traceParent string) (traceId, spanId string, ok bool) {
+	parts := strings.Split(strings.TrimSpace(traceParent), "-")
+	if (len(parts) < 4) || (2 != len(parts[0])) || ("ff" == parts[0]) { return "", "", false }
+	// Version 00 has exactly four parts; later versions may add more
+	if ("00" == parts[0]) && (4 != len(parts)) { return "", "", false }
+	if ! isHexId(parts[0], 2) || ! isHexId(parts[1], 32) || ! isHexId(parts[2], 16) || ! isHexId(parts[3], 2) {
+		return "", "", false
+	}
+	return parts[1], parts[2], true
+}
+
+// The Logger's StructuredLogger for ctx (see package doc above)
+func FromContext(ctx context.Context) *StructuredLogger {
+	return GetLogger().FromContext(ctx)
+}
+
+// -------------------------------------------------------------------------------------------------
+// Logger Context Support
+// -------------------------------------------------------------------------------------------------
+
+// Get a StructuredLogger which logs through us with the request, trace and span IDs from ctx
+func (r *Logger) WithContext(ctx context.Context) *StructuredLogger {
+	return NewStructuredLogger(r).WithContext(ctx)
+}
+
+// Get the StructuredLogger stored in ctx, if any, else one of our own; either way with the IDs from ctx
+func (r *Logger) FromContext(ctx context.Context) *StructuredLogger {
+	if nil != ctx {
+		if structuredLogger, ok := ctx.Value(logContextKeyLogger).(*StructuredLogger); ok {
+			return structuredLogger.WithContext(ctx)
+		}
+	}
+	return r.WithContext(ctx)
+}
+
+// Get a new StructuredLogger with our fields and the request, trace and span IDs from ctx
+func (r *StructuredLogger) WithContext(ctx context.Context) *StructuredLogger {
+	keysAndValues := make([]interface{}, 0, 6)
+	for _, field := range []struct{ key string; id string }{
+		{ LOG_FIELD_REQUEST_ID, GetRequestId(ctx) },
+		{ LOG_FIELD_TRACE_ID, GetTraceId(ctx) },
+		{ LOG_FIELD_SPAN_ID, GetSpanId(ctx) },
+	} {
+		if (0 == len(field.id)) || r.hasField(field.key, field.id) { continue }
+		keysAndValues = append(keysAndValues, field.key, field.id)
+	}
+	return r.With(keysAndValues...)
+}
+
+// -------------------------------------------------------------------------------------------------
+// Context Implementation
+// -------------------------------------------------------------------------------------------------
+
+func contextWithValue(ctx context.Context, key logContextKey, value string) context.Context {
+	if nil == ctx { ctx = context.Background() }
+	if 0 == len(value) { return ctx }
+	return context.WithValue(ctx, key, value)
+}
+
+func getContextString(ctx context.Context, key logContextKey) string {
+	if nil == ctx { return "" }
+	value, _ := ctx.Value(key).(string)
+	return value
+}
+
+// Lower case hex of the given length, and not all zeroes (which W3C Trace Context calls invalid)
+func isHexId(id string, length int) bool {
+	if (len(id) != length) || (strings.ToLower(id) != id) { return false }
+	decoded, err := hex.DecodeString(id)
+	if nil != err { return false }
+	for _, b := range decoded {
+		if 0 != b { return true }
+	}
+	// All zeroes is only valid for the version and flags
+	return length == 2
+}
+
+// Do we already have this field with this value? (no sense in repeating it)
+func (r *StructuredLogger) hasField(key string, value interface{}) bool {
+	for _, field := range r.fields {
+		if (field.Key == key) && (field.Value == value) { return true }
+	}
+	return false
+}
@@ -0,0 +1,124 @@
+package logger
+
+import(
+	"context"
+	"testing"
+
+	. "github.com/DigiStratum/GoLib/Testing"
+)
+
+func newLogContextTestContext() context.Context {
+	ctx := ContextWithRequestId(context.Background(), "req-1")
+	return ContextWithTraceParent(ctx, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+}
+
+func TestThat_ContextWithRequestId_Stores_ids_for_getters(t *testing.T) {
+	// Setup
+	ctx := ContextWithSpanId(ContextWithTraceId(ContextWithRequestId(nil, "r"), "t"), "s")
+
+	// Verify
+	ExpectString("r", GetRequestId(ctx), t)
+	ExpectString("t", GetTraceId(ctx), t)
+	ExpectString("s", GetSpanId(ctx), t)
+	ExpectEmptyString(GetRequestId(context.Background()), t)
+	ExpectEmptyString(GetRequestId(nil), t)
+}
+
+func TestThat_ParseTraceParent_Accepts_valid_and_rejects_invalid_values(t *testing.T) {
+	// Test
+	traceId, spanId, ok := ParseTraceParent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	// Verify
+	ExpectTrue(ok, t)
+	ExpectString("4bf92f3577b34da6a3ce929d0e0e4736", traceId, t)
+	ExpectString("00f067aa0ba902b7", spanId, t)
+	for _, invalid := range []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",			// Missing flags
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",	// Version 00 has 4 parts
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",		// Forbidden version
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",		// All zero trace ID
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",		// All zero span ID
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",		// Upper case
+		"00-4bf92f3577b34da6a3ce929d0e0e473-00f067aa0ba902b7-01",		// Short trace ID
+	} {
+		_, _, ok := ParseTraceParent(invalid)
+		if ! ExpectFalse(ok, t) { t.Logf("Accepted '%s'", invalid) }
+	}
+	// Later versions may add more parts
+	_, _, ok = ParseTraceParent("01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra")
+	ExpectTrue(ok, t)
+}
+
+func TestThat_ContextWithTraceParent_Ignores_invalid_value(t *testing.T) {
+	// Test
+	ctx := ContextWithTraceParent(nil, "garbage")
+
+	// Verify
+	ExpectTrue(nil != ctx, t)
+	ExpectEmptyString(GetTraceId(ctx), t)
+}
+
+func TestThat_Logger_WithContext_Adds_ids_to_every_record(t *testing.T) {
+	// Setup
+	logger := newStructuredLoggerTestLogger()
+	var loggerIfc LoggerIfc = logger // <- ensures that we still satisfy our interface
+
+	// Test
+	sut := loggerIfc.WithContext(newLogContextTestContext())
+	sut.Info("one", "k", 1)
+	first := LastMessage
+	sut.Warn("two")
+
+	// Verify
+	ExpectString("thread:7  INFO one request_id=req-1 trace_id=4bf92f3577b34da6a3ce929d0e0e4736 span_id=00f067aa0ba902b7 k=1", first, t)
+	ExpectString("thread:7  WARN two request_id=req-1 trace_id=4bf92f3577b34da6a3ce929d0e0e4736 span_id=00f067aa0ba902b7", LastMessage, t)
+}
+
+func TestThat_Logger_WithContext_Adds_nothing_for_empty_context(t *testing.T) {
+	// Setup
+	logger := newStructuredLoggerTestLogger()
+
+	// Test
+	sut := logger.WithContext(context.Background())
+	sutNil := logger.WithContext(nil)
+
+	// Verify
+	ExpectInt(0, len(sut.GetFields()), t)
+	ExpectInt(0, len(sutNil.GetFields()), t)
+}
+
+func TestThat_Logger_FromContext_Prefers_StructuredLogger_in_context(t *testing.T) {
+	// Setup
+	logger := newStructuredLoggerTestLogger()
+	ctx := ContextWithLogger(ContextWithRequestId(nil, "req-2"), logger.With("user", "bob"))
+
+	// Test
+	sut := NewLogger("other").FromContext(ctx)
+	sut.Info("hello")
+
+	// Verify
+	ExpectString("thread:7  INFO hello user=bob request_id=req-2", LastMessage, t)
+}
+
+func TestThat_Logger_FromContext_Does_not_repeat_ids(t *testing.T) {
+	// Setup
+	logger := newStructuredLoggerTestLogger()
+	ctx := ContextWithRequestId(nil, "req-3")
+	ctx = ContextWithLogger(ctx, logger.WithContext(ctx))
+
+	// Test
+	sut := logger.FromContext(ctx)
+
+	// Verify
+	ExpectInt(1, len(sut.GetFields()), t)
+}
+
+func TestThat_FromContext_Falls_back_to_singleton(t *testing.T) {
+	// Test
+	sut := FromContext(ContextWithRequestId(nil, "req-4"))
+
+	// Verify
+	ExpectTrue(GetLogger() == sut.logger, t)
+	ExpectInt(1, len(sut.GetFields()), t)
+}
@@ -0,0 +1,136 @@
+package logger
+
+/*
+
+A LogFormatter turns a LogRecord into a single line of log output (without the line ending, which is
+up to the LogWriter). Two are provided:
+
+TextLogFormatter: the classic human readable Logger output, with any fields appended as key=value:
+
+	2022-05-19T08:03:24-07:00 thread:1652972604971037495  INFO [db] Connected host=db1 ms=12
+
+JsonLogFormatter: one JSON object per line (JSON Lines) for log pipelines to ingest without parsing:
+
+	{"timestamp":"2022-05-19T08:03:24.123-07:00","level":"INFO","streamId":"1652972604971037495",
+	"prefix":"[db] ","message":"Connected","fields":{"host":"db1","ms":12}}
+
+Properties of the JSON object are always in the order above, and fields in the order they were
+logged; timestamp, prefix and fields are omitted when empty. If a field key is logged more than once,
+the last value wins.
+
+*/
+
+import (
+	"fmt"
+	"time"
+	"strings"
+	"strconv"
+	gojson "encoding/json"
+)
+
+type LogFormatterIfc interface {
+	Format(record *LogRecord) string
+}
+
+type TextLogFormatter struct {}
+
+type JsonLogFormatter struct {}
+
+// -------------------------------------------------------------------------------------------------
+// Factory Functions
+// -------------------------------------------------------------------------------------------------
+
+func NewTextLogFormatter() *TextLogFormatter {
+	return &TextLogFormatter{}
+}
+
+func NewJsonLogFormatter() *JsonLogFormatter {
+	return &JsonLogFormatter{}
+}
+
+// -------------------------------------------------------------------------------------------------
+// LogFormatterIfc Public Interface
+// -------------------------------------------------------------------------------------------------
+
+func (r TextLogFormatter) Format(record *LogRecord) string {
+	if nil == record { return "" }
+	var sb strings.Builder
+	if ! record.Timestamp.IsZero() {
+		sb.WriteString(record.Timestamp.Format(time.RFC3339))
+		sb.WriteString(" ")
+	}
+	sb.WriteString(fmt.Sprintf("thread:%s %5s %s%s", record.StreamId, record.Level.ToString(), record.Prefix, record.Message))
+	sb.WriteString(formatTextLogFields(record.Fields))
+	return sb.String()
+}
+
+func (r JsonLogFormatter) Format(record *LogRecord) string {
+	if nil == record { return "" }
+	var sb strings.Builder
+	sb.WriteString("{")
+	if ! record.Timestamp.IsZero() {
+		sb.WriteString(fmt.Sprintf("\"timestamp\":%s,", formatJsonLogValue(record.Timestamp.Format(time.RFC3339Nano))))
+	}
+	sb.WriteString(fmt.Sprintf("\"level\":%s", formatJsonLogValue(record.Level.ToString())))
+	sb.WriteString(fmt.Sprintf(",\"streamId\":%s", formatJsonLogValue(record.StreamId)))
+	if len(record.Prefix) > 0 {
+		sb.WriteString(fmt.Sprintf(",\"prefix\":%s", formatJsonLogValue(record.Prefix)))
+	}
+	sb.WriteString(fmt.Sprintf(",\"message\":%s", formatJsonLogValue(record.Message)))
+	if fields := uniqueLogFields(record.Fields); len(fields) > 0 {
+		sb.WriteString(",\"fields\":{")
+		for index, field := range fields {
+			if index > 0 { sb.WriteString(",") }
+			sb.WriteString(fmt.Sprintf("%s:%s", formatJsonLogValue(field.Key), formatJsonLogValue(field.Value)))
+		}
+		sb.WriteString("}")
+	}
+	sb.WriteString("}")
+	return sb.String()
+}
+
+// -------------------------------------------------------------------------------------------------
+// LogFormatter Implementation
+// -------------------------------------------------------------------------------------------------
+
+// Fields as " k1=v1 k2=v2"; values are quoted when they would otherwise be ambiguous
+func formatTextLogFields(fields []LogField) string {
+	var sb strings.Builder
+	for _, field := range fields {
+		sb.WriteString(" ")
+		sb.WriteString(formatTextLogValue(field.Key))
+		sb.WriteString("=")
+		sb.WriteString(formatTextLogValue(field.Value))
+	}
+	return sb.String()
+}
+
+func formatTextLogValue(value interface{}) string {
+	if nil == value { return "null" }
+	str := fmt.Sprintf("%v", value)
+	if (0 == len(str)) || strings.ContainsAny(str, " =\"\t\r\n") { return strconv.Quote(str) }
+	return str
+}
+
+func formatJsonLogValue(value interface{}) string {
+	// Normalized field values always encode; anything else that doesn't is logged as its string form
+	encoded, err := gojson.Marshal(value)
+	if nil != err { encoded, _ = gojson.Marshal(fmt.Sprintf("%v", value)) }
+	return string(encoded)
+}
+
+// The last value for each key, in the order that each key first appeared
+func uniqueLogFields(fields []LogField) []LogField {
+	if len(fields) < 2 { return fields }
+	positions := make(map[string]int)
+	unique := make([]LogField, 0, len(fields))
+	for _, field := range fields {
+		if position, ok := positions[field.Key]; ok {
+			unique[position] = field
+			continue
+		}
+		positions[field.Key] = len(unique)
+		unique = append(unique, field)
+	}
+	return unique
+}
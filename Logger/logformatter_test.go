@@ -0,0 +1,76 @@
+package logger
+
+import(
+	"time"
+	"math"
+	"testing"
+	gojson "encoding/json"
+
+	. "github.com/DigiStratum/GoLib/Testing"
+)
+
+func newLogFormatterTestRecord() *LogRecord {
+	return &LogRecord{
+		Timestamp:	time.Date(2024, 1, 2, 3, 4, 5, 123000000, time.UTC),
+		Level:		INFO,
+		StreamId:	"42",
+		Prefix:		"[db] ",
+		Message:	"Connected \"now\"",
+		Fields:		NewLogFields("host", "db1", "ms", 12, "note", "two words", "empty", ""),
+	}
+}
+
+func TestThat_TextLogFormatter_Format_Renders_classic_output_with_fields(t *testing.T) {
+	// Setup
+	var sut LogFormatterIfc = NewTextLogFormatter() // <- ensures that we satisfy our interface
+
+	// Test
+	actual := sut.Format(newLogFormatterTestRecord())
+
+	// Verify
+	ExpectString("2024-01-02T03:04:05Z thread:42  INFO [db] Connected \"now\" host=db1 ms=12 note=\"two words\" empty=\"\"", actual, t)
+}
+
+func TestThat_TextLogFormatter_Format_Omits_zero_timestamp(t *testing.T) {
+	// Setup
+	sut := NewTextLogFormatter()
+	record := LogRecord{ Level: ERROR, StreamId: "1", Message: "oops" }
+
+	// Test
+	actual := sut.Format(&record)
+
+	// Verify
+	ExpectString("thread:1 ERROR oops", actual, t)
+}
+
+func TestThat_JsonLogFormatter_Format_Renders_json_line_in_property_order(t *testing.T) {
+	// Setup
+	var sut LogFormatterIfc = NewJsonLogFormatter() // <- ensures that we satisfy our interface
+
+	// Test
+	actual := sut.Format(newLogFormatterTestRecord())
+
+	// Verify
+	expected := `{"timestamp":"2024-01-02T03:04:05.123Z","level":"INFO","streamId":"42","prefix":"[db] ",` +
+		`"message":"Connected \"now\"","fields":{"host":"db1","ms":12,"note":"two words","empty":""}}`
+	ExpectString(expected, actual, t)
+	var decoded map[string]interface{}
+	ExpectNoError(gojson.Unmarshal([]byte(actual), &decoded), t)
+}
+
+func TestThat_JsonLogFormatter_Format_Omits_empty_properties_and_keeps_last_duplicate(t *testing.T) {
+	// Setup
+	sut := NewJsonLogFormatter()
+	record := LogRecord{
+		Level:		WARN,
+		StreamId:	"1",
+		Message:	"line1\nline2",
+		Fields:		NewLogFields("a", 1, "b", math.NaN(), "a", 2),
+	}
+
+	// Test
+	actual := sut.Format(&record)
+
+	// Verify
+	ExpectString(`{"level":"WARN","streamId":"1","message":"line1\nline2","fields":{"a":2,"b":"NaN"}}`, actual, t)
+}
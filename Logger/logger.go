@@ -5,17 +5,31 @@ package logger
 
 This simple logging class provides a standardized interface to produce log output using log levels
 as a means of filtering what types of log output get produced. The log level may be changed at any
-time and is soft-configurable. Currently the output is limited to StdOut, however the intent is to
-provide a connector interface to redirect output to one or more other locations.
+time and is soft-configurable. Output goes to StdOut by default, or to any other LogWriter, such as
+a (rotating) FileLogWriter, a SyslogLogWriter or NetworkLogWriter, an AsyncLogWriter to take the
+writing off of the caller's goroutine, or a FanOutLogWriter to send it to several places at once.
 
 There are two ways to get a Logger instance, one returns our own singleton, the other returns a new
 instance. This way you may use the singleton throughout your application without reinitializing or
 passing it around all over the place, or create a new Logger with a separate configuration and do
 just that, as needed.
 
+Each message becomes a LogRecord which our LogFormatter turns into a line for our LogWriter; the
+default TextLogFormatter produces the classic output, and a JsonLogFormatter produces JSON Lines. For
+structured key/value fields rather than printf style messages, use With() to get a StructuredLogger:
+
+	logger.GetLogger().With("request_id", id).Info("msg", "k", v)
+
+To correlate everything logged for one request, carry its request, trace and span IDs in a
+context.Context and log with WithContext(ctx); see logcontext.go. To keep a flood of repetitive
+records from drowning out everything else, sample them with a LogSampler; see logsampler.go.
+
+Loggers for subsystems may be named hierarchically with GetNamedLogger() ("db", "db.mysql.pool"...)
+so that their levels can be changed at runtime, all at once, through a LogLevelRegistry; see
+loglevelregistry.go.
+
 TODO:
- * Add support to connect log output to a file, database, or API (event stream), etc
- * Add support for multiple LogWriter's so that we can send logs to more than one place
+ * Add support to connect log output to a database
 
  */
 
@@ -23,14 +37,27 @@ import (
 	"fmt"
 	"time"
 	"errors"
+	"context"
+
 	lw "github.com/DigiStratum/GoLib/Logger/logwriter"
 )
 
 type LoggerIfc interface {
 	GetNewPrefixedLogger(prefix string) *Logger
+	GetNamedLogger(name string) *Logger
+	GetName() string
 	SetMinLogLevel(minLogLevel LogLevel) *Logger
+	GetMinLogLevel() LogLevel
+	SetLogLevelRegistry(levelRegistry *LogLevelRegistry) *Logger
 	SetLogWriter(logWriter lw.LogWriterIfc) *Logger
+	SwapLogWriter(logWriter lw.LogWriterIfc) func ()
 	LogTimestamp(logTimestamp bool) *Logger
+	SetLogFormatter(logFormatter LogFormatterIfc) *Logger
+	SetLogSampler(logSampler *LogSampler) *Logger
+	FlushLogSampler() *Logger
+	With(keysAndValues ...interface{}) *StructuredLogger
+	WithContext(ctx context.Context) *StructuredLogger
+	FromContext(ctx context.Context) *StructuredLogger
 	Any(level LogLevel, format string, a ...interface{}) error
 	Crazy(format string, a ...interface{}) error
 	Trace(format string, a ...interface{}) error
@@ -45,8 +72,12 @@ type Logger struct {
 	streamId	string			// Quasi-distinct streamId to filter log output by thread
 	minLogLevel	LogLevel		// The minimum logging level
 	logWriter	lw.LogWriterIfc		// The LogWriter we are going to use
+	logFormatter	LogFormatterIfc		// The LogFormatter that makes our LogRecords into lines of output
+	logSampler	*LogSampler		// Optional LogSampler to thin out repetitive records
 	logTimestamp	bool			// Add timestamps on the log output (default=true)
 	prefix		string			// Some prefix to contextualize these log messages
+	name		string			// Hierarchical name to look up our level in levelRegistry
+	levelRegistry	*LogLevelRegistry	// Optional LogLevelRegistry to override minLogLevel
 }
 
 // -------------------------------------------------------------------------------------------------
@@ -60,6 +91,7 @@ func init() {
 	// Default log streamId is our instantiation timestamp
 	streamId := fmt.Sprintf("%d", time.Now().UTC().UnixNano())
 	loggerInstance = *NewLogger(streamId)
+	loggerInstance.levelRegistry = GetLogLevelRegistry()
 }
 
 // Get our singleton instance
@@ -77,6 +109,7 @@ func NewLogger(streamId string) *Logger {
 		streamId:	streamId,
 		minLogLevel:	INFO,
 		logWriter:	lw.NewStdOutLogWriter(),
+		logFormatter:	NewTextLogFormatter(),
 		logTimestamp:	true,
 	}
 	return &newLogger
@@ -94,50 +127,100 @@ func (r *Logger) GetNewPrefixedLogger(prefix string) *Logger {
 		r.logWriter,
 	).LogTimestamp(
 		r.logTimestamp,
+	).SetLogFormatter(
+		r.logFormatter,
+	).SetLogSampler(
+		r.logSampler,
+	).SetLogLevelRegistry(
+		r.levelRegistry,
 	)
 	prefixedLogger.prefix = prefix
+	prefixedLogger.name = r.name
 	return prefixedLogger
 }
 
-// Set the minimum log level
+// Get a new Logger like us (with our prefix) named name below ours, e.g. "db" + "mysql" = "db.mysql"
+func (r *Logger) GetNamedLogger(name string) *Logger {
+	namedLogger := r.GetNewPrefixedLogger(r.prefix)
+	namedLogger.name = NormalizeLogName(r.name + "." + name)
+	return namedLogger
+}
+
+// Get our hierarchical name ("" if we have none)
+func (r *Logger) GetName() string {
+	return r.name
+}
+
+// Set the minimum log level (unless our LogLevelRegistry has one for our name)
 func (r *Logger) SetMinLogLevel(minLogLevel LogLevel) *Logger {
 	r.minLogLevel = minLogLevel
 	return r
 }
 
+// Get the minimum log level in effect right now: from our LogLevelRegistry, else our own
+func (r Logger) GetMinLogLevel() LogLevel {
+	if nil != r.levelRegistry {
+		if level, ok := r.levelRegistry.getLogLevel(r.name); ok { return level }
+	}
+	return r.minLogLevel
+}
+
+// Look up our level in levelRegistry (shared with any Loggers we make) on every record; nil to stop
+func (r *Logger) SetLogLevelRegistry(levelRegistry *LogLevelRegistry) *Logger {
+	r.levelRegistry = levelRegistry
+	return r
+}
+
 // Replace the current LogWriter with something more to our liking
 func (r *Logger) SetLogWriter(logWriter lw.LogWriterIfc) *Logger {
 	r.logWriter = logWriter
 	return r
 }
 
+// Replace the current LogWriter for now; call the func returned to put the original back
+func (r *Logger) SwapLogWriter(logWriter lw.LogWriterIfc) func () {
+	original := r.logWriter
+	r.SetLogWriter(logWriter)
+	return func () { r.SetLogWriter(original) }
+}
+
 // Set the logTimestamp state (defaults to true to enable timestamps in logger output)
 func (r *Logger) LogTimestamp(logTimestamp bool) *Logger {
 	r.logTimestamp = logTimestamp
 	return r
 }
 
+// Replace the current LogFormatter, e.g. with a JsonLogFormatter for log pipelines
+func (r *Logger) SetLogFormatter(logFormatter LogFormatterIfc) *Logger {
+	if nil == logFormatter { logFormatter = NewTextLogFormatter() }
+	r.logFormatter = logFormatter
+	return r
+}
+
+// Thin out repetitive records with logSampler (shared with any prefixed Loggers we make); nil to stop
+func (r *Logger) SetLogSampler(logSampler *LogSampler) *Logger {
+	r.logSampler = logSampler
+	return r
+}
+
+// Log summaries of any records that our LogSampler has suppressed since their last summary
+func (r *Logger) FlushLogSampler() *Logger {
+	if nil == r.logSampler { return r }
+	minLogLevel := r.GetMinLogLevel()
+	for _, summary := range r.logSampler.takeSummaries() {
+		if summary.level >= minLogLevel { r.logSummary(summary) }
+	}
+	return r
+}
+
+// Get a StructuredLogger which logs through us with fields from keysAndValues
+func (r *Logger) With(keysAndValues ...interface{}) *StructuredLogger {
+	return NewStructuredLogger(r, keysAndValues...)
+}
+
 // Log some output; return a matching error for WARN|ERROR|FATAL, else nil
 func (r Logger) Any(level LogLevel, format string, a ...interface{}) error {
-	msg := fmt.Sprintf(format, a...)
-	logMsg := fmt.Sprintf("%5s %s%s", level.ToString(), r.prefix, msg)
-	if level >= r.minLogLevel {
-		// Send the log message to our LogWriter
-		timestamp := ""
-		if r.logTimestamp {
-			timestamp = fmt.Sprintf("%s ", time.Now().Format(time.RFC3339))
-		}
-		r.logWriter.Log(fmt.Sprintf(
-			"%sthread:%s %s",
-			timestamp,
-			r.streamId,
-			logMsg,
-		))
-	}
-	// Wrap level (WARN|ERROR|FATAL)+message in an error as a code
-	// reduction convenience to any caller wanting to return it
-	if level >= WARN { return errors.New(logMsg) }
-	return nil
+	return r.log(level, format, fmt.Sprintf(format, a...), nil)
 }
 
 // Log CRAZY output
@@ -175,3 +258,59 @@ func (r Logger) Fatal(format string, a ...interface{}) error {
 	return r.Any(FATAL, format, a...)
 }
 
+// -------------------------------------------------------------------------------------------------
+// Logger Implementation
+// -------------------------------------------------------------------------------------------------
+
+// Log message (made from template) with fields; return a matching error for WARN|ERROR|FATAL, else nil
+func (r Logger) log(level LogLevel, template string, message string, fields []LogField) error {
+	record := LogRecord{
+		Level:		level,
+		StreamId:	r.streamId,
+		Prefix:		r.prefix,
+		Message:	message,
+		Fields:		fields,
+	}
+	if level >= r.GetMinLogLevel() {
+		allowed := true
+		if nil != r.logSampler {
+			var suppressed uint64
+			allowed, suppressed = r.logSampler.sample(level, template)
+			if suppressed > 0 { r.logSummary(logSampleSummary{ level: level, template: template, suppressed: suppressed }) }
+		}
+		if allowed { r.write(&record) }
+	}
+	// Wrap level (WARN|ERROR|FATAL)+message in an error as a code
+	// reduction convenience to any caller wanting to return it
+	if level >= WARN {
+		record.redact()
+		return errors.New(fmt.Sprintf("%5s %s%s%s", level.ToString(), r.prefix, record.Message, formatTextLogFields(record.Fields)))
+	}
+	return nil
+}
+
+// Send record, redacted, to our LogWriter
+func (r Logger) write(record *LogRecord) {
+	record.redact()
+	if r.logTimestamp { record.Timestamp = time.Now() }
+	if recordWriter, ok := r.logWriter.(LogRecordWriterIfc); ok {
+		recordWriter.LogRecord(record)
+		return
+	}
+	r.logWriter.Log("%s", r.logFormatter.Format(record))
+}
+
+// Log a record summarizing records suppressed by our LogSampler
+func (r Logger) logSummary(summary logSampleSummary) {
+	r.write(&LogRecord{
+		Level:		summary.level,
+		StreamId:	r.streamId,
+		Prefix:		r.prefix,
+		Message:	fmt.Sprintf(
+			"message repeated %s times: %s",
+			formatLogCount(summary.suppressed),
+			summary.template,
+		),
+		Fields:		[]LogField{ NewLogField(LOG_FIELD_SUPPRESSED, summary.suppressed) },
+	})
+}
@@ -153,6 +153,17 @@ func TestThat_Logger_ErrorsReturnedOverWarnLevel(t *testing.T) {
 	ExpectNoError(err, t)
 }
 
+func TestThat_Logger_Any_Logs_messages_containing_format_verbs_verbatim(t *testing.T) {
+	// Setup
+	sut := NewLogger("7").SetLogWriter(mockLogWriter{}).LogTimestamp(false)
+
+	// Test
+	sut.Info("%s", "100% done")
+
+	// Verify
+	ExpectString("thread:7  INFO 100% done", LastMessage, t)
+}
+
 func getMockedLogger() *Logger {
 	var sut *Logger = GetLogger()
 	sut.SetLogWriter(mockLogWriter{})
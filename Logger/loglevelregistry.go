@@ -0,0 +1,181 @@
+package logger
+
+/*
+
+A LogLevelRegistry holds log levels by hierarchical name so that the verbosity of one subsystem may be
+raised (or lowered) at runtime, in production, without a restart:
+
+	dbLogger := logger.GetLogger().GetNamedLogger("db")		// named "db"
+	poolLogger := dbLogger.GetNamedLogger("mysql.pool")		// named "db.mysql.pool"
+
+	logger.GetLogLevelRegistry().SetLogLevel("db.mysql.pool", logger.DEBUG)
+
+Every Logger derived from one with a registry (with GetNamedLogger() or GetNewPrefixedLogger()) shares
+that registry and consults it on every record it logs, so a change applies everywhere at once, unlike
+SetMinLogLevel() which only applies to the one Logger. The level for a name is that of its closest
+registered ancestor: with "db=WARN" and "db.mysql=DEBUG" registered, "db.mysql.pool" logs DEBUG,
+"db.cache" logs WARN, and "cache" logs whatever its Logger's own minimum level is. The empty name is
+the root, the ancestor of all names. Names are case insensitive.
+
+The GetLogger() singleton uses the registry from GetLogLevelRegistry(); Loggers from NewLogger() have
+none until given one with SetLogLevelRegistry().
+
+Levels may also be given as a spec, e.g. from an environment variable or a command line flag:
+
+	levels, err := logger.ParseLogLevels("db.mysql.pool=DEBUG, cache=WARN")
+
+See Data/config for getting levels from config, and Net/http for an admin endpoint to change them.
+
+*/
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"strings"
+)
+
+type LogLevelRegistryIfc interface {
+	SetLogLevel(name string, level LogLevel) *LogLevelRegistry
+	ClearLogLevel(name string) *LogLevelRegistry
+	SetLogLevels(levels map[string]LogLevel) *LogLevelRegistry
+	ReplaceLogLevels(levels map[string]LogLevel) *LogLevelRegistry
+	GetLogLevels() map[string]LogLevel
+	GetNames() []string
+	GetLogLevel(name string) (LogLevel, bool)
+}
+
+type LogLevelRegistry struct {
+	mutex		sync.RWMutex
+	levels		map[string]LogLevel
+}
+
+var logLevelRegistryInstance = NewLogLevelRegistry()
+
+// Get the registry used by the GetLogger() singleton and every Logger derived from it
+func GetLogLevelRegistry() *LogLevelRegistry {
+	return logLevelRegistryInstance
+}
+
+// -------------------------------------------------------------------------------------------------
+// Factory Functions
+// -------------------------------------------------------------------------------------------------
+
+func NewLogLevelRegistry() *LogLevelRegistry {
+	return &LogLevelRegistry{
+		levels:		make(map[string]LogLevel),
+	}
+}
+
+// Parse a spec of name=LEVEL pairs separated by commas, semicolons or new lines; "=LEVEL" is the root
+func ParseLogLevels(spec string) (map[string]LogLevel, error) {
+	levels := make(map[string]LogLevel)
+	pairs := strings.FieldsFunc(spec, func (c rune) bool { return (',' == c) || (';' == c) || ('\n' == c) })
+	for _, pair := range pairs {
+		if 0 == len(strings.TrimSpace(pair)) { continue }
+		name, levelStr, ok := strings.Cut(pair, "=")
+		if ! ok { return nil, fmt.Errorf("ParseLogLevels(): Expected name=LEVEL, got [%s]", strings.TrimSpace(pair)) }
+		level, err := StringToLogLevel(strings.TrimSpace(levelStr))
+		if nil != err { return nil, fmt.Errorf("ParseLogLevels(): %s", err.Error()) }
+		levels[NormalizeLogName(name)] = *level
+	}
+	return levels, nil
+}
+
+// Trimmed, lower case, without empty segments: " DB..MySQL. " -> "db.mysql"
+func NormalizeLogName(name string) string {
+	segments := make([]string, 0)
+	for _, segment := range strings.Split(strings.ToLower(name), ".") {
+		segment = strings.TrimSpace(segment)
+		if len(segment) > 0 { segments = append(segments, segment) }
+	}
+	return strings.Join(segments, ".")
+}
+
+// -------------------------------------------------------------------------------------------------
+// LogLevelRegistryIfc Public Interface
+// -------------------------------------------------------------------------------------------------
+
+// Set the level for name and its descendants (those without a level of their own)
+func (r *LogLevelRegistry) SetLogLevel(name string, level LogLevel) *LogLevelRegistry {
+	if level >= logLevelEnd { return r }
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.levels[NormalizeLogName(name)] = level
+	return r
+}
+
+// Forget the level for name, leaving it to its closest registered ancestor
+func (r *LogLevelRegistry) ClearLogLevel(name string) *LogLevelRegistry {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	delete(r.levels, NormalizeLogName(name))
+	return r
+}
+
+// Set the levels for all of the names in levels, leaving any others as they are
+func (r *LogLevelRegistry) SetLogLevels(levels map[string]LogLevel) *LogLevelRegistry {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for name, level := range levels {
+		if level < logLevelEnd { r.levels[NormalizeLogName(name)] = level }
+	}
+	return r
+}
+
+// Replace all of our levels with levels, e.g. when the config they came from is reloaded
+func (r *LogLevelRegistry) ReplaceLogLevels(levels map[string]LogLevel) *LogLevelRegistry {
+	replacement := make(map[string]LogLevel)
+	for name, level := range levels {
+		if level < logLevelEnd { replacement[NormalizeLogName(name)] = level }
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.levels = replacement
+	return r
+}
+
+// Get a copy of the registered levels
+func (r *LogLevelRegistry) GetLogLevels() map[string]LogLevel {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	levels := make(map[string]LogLevel, len(r.levels))
+	for name, level := range r.levels { levels[name] = level }
+	return levels
+}
+
+// Get the registered names, sorted
+func (r *LogLevelRegistry) GetNames() []string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	names := make([]string, 0, len(r.levels))
+	for name := range r.levels { names = append(names, name) }
+	sort.Strings(names)
+	return names
+}
+
+// Get the level for name from it or its closest registered ancestor; ok=false if there is none
+func (r *LogLevelRegistry) GetLogLevel(name string) (LogLevel, bool) {
+	return r.getLogLevel(NormalizeLogName(name))
+}
+
+// -------------------------------------------------------------------------------------------------
+// LogLevelRegistry Implementation
+// -------------------------------------------------------------------------------------------------
+
+// GetLogLevel() for a name that is already normalized
+func (r *LogLevelRegistry) getLogLevel(name string) (LogLevel, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	if 0 == len(r.levels) { return 0, false }
+	for {
+		if level, ok := r.levels[name]; ok { return level, true }
+		if 0 == len(name) { return 0, false }
+		index := strings.LastIndex(name, ".")
+		if index < 0 {
+			name = ""
+			continue
+		}
+		name = name[:index]
+	}
+}
@@ -0,0 +1,125 @@
+package logger
+
+import(
+	"testing"
+
+	. "github.com/DigiStratum/GoLib/Testing"
+)
+
+func TestThat_LogLevelRegistry_NewLogLevelRegistry_ReturnsSomething(t *testing.T) {
+	// Test
+	var sut LogLevelRegistryIfc = NewLogLevelRegistry() // <- ensures that we satisfy our interface
+
+	// Verify
+	ExpectNonNil(sut, t)
+	ExpectInt(0, len(sut.GetNames()), t)
+}
+
+func TestThat_LogLevelRegistry_GetLogLevel_UsesClosestRegisteredAncestor(t *testing.T) {
+	// Setup
+	sut := NewLogLevelRegistry().
+		SetLogLevel("db", WARN).
+		SetLogLevel("DB.MySQL", DEBUG)
+
+	// Test
+	poolLevel, poolOk := sut.GetLogLevel("db.mysql.pool")
+	cacheLevel, cacheOk := sut.GetLogLevel("db.cache")
+	_, otherOk := sut.GetLogLevel("cache")
+	sut.SetLogLevel("", ERROR)
+	rootLevel, rootOk := sut.GetLogLevel("cache")
+
+	// Verify
+	ExpectTrue(poolOk && (DEBUG == poolLevel), t)
+	ExpectTrue(cacheOk && (WARN == cacheLevel), t)
+	ExpectFalse(otherOk, t)
+	ExpectTrue(rootOk && (ERROR == rootLevel), t)
+}
+
+func TestThat_LogLevelRegistry_ClearLogLevel_FallsBackToAncestor(t *testing.T) {
+	// Setup
+	sut := NewLogLevelRegistry().SetLogLevel("db", WARN).SetLogLevel("db.mysql", DEBUG)
+
+	// Test
+	sut.ClearLogLevel("db.mysql")
+	level, _ := sut.GetLogLevel("db.mysql.pool")
+
+	// Verify
+	ExpectTrue(WARN == level, t)
+	ExpectInt(1, len(sut.GetLogLevels()), t)
+}
+
+func TestThat_LogLevelRegistry_ReplaceLogLevels_ForgetsOthers(t *testing.T) {
+	// Setup
+	sut := NewLogLevelRegistry().SetLogLevel("db", WARN)
+
+	// Test
+	sut.ReplaceLogLevels(map[string]LogLevel{ "cache": DEBUG, "bogus": logLevelEnd })
+
+	// Verify
+	names := sut.GetNames()
+	if ExpectInt(1, len(names), t) { ExpectString("cache", names[0], t) }
+}
+
+func TestThat_ParseLogLevels_ParsesSpec(t *testing.T) {
+	// Test
+	levels, err := ParseLogLevels("db.mysql.pool=debug, cache = WARN;\n=ERROR")
+
+	// Verify
+	if ExpectNoError(err, t) && ExpectInt(3, len(levels), t) {
+		ExpectTrue(DEBUG == levels["db.mysql.pool"], t)
+		ExpectTrue(WARN == levels["cache"], t)
+		ExpectTrue(ERROR == levels[""], t)
+	}
+}
+
+func TestThat_ParseLogLevels_ReturnsError_ForBadSpec(t *testing.T) {
+	// Test
+	_, missingErr := ParseLogLevels("db")
+	_, levelErr := ParseLogLevels("db=LOUD")
+
+	// Verify
+	ExpectError(missingErr, t)
+	ExpectError(levelErr, t)
+}
+
+func TestThat_NormalizeLogName_TrimsAndLowers(t *testing.T) {
+	// Test
+	name := NormalizeLogName(" DB..MySQL. ")
+
+	// Verify
+	ExpectString("db.mysql", name, t)
+}
+
+func TestThat_Logger_GetNamedLogger_ConsultsRegistryLive(t *testing.T) {
+	// Setup
+	registry := NewLogLevelRegistry()
+	memoryLogWriter := NewMemoryLogWriter()
+	logger := NewLogger("1").SetLogWriter(memoryLogWriter).SetLogLevelRegistry(registry)
+	sut := logger.GetNamedLogger("db").GetNamedLogger("mysql.pool")
+	prefixed := sut.GetNewPrefixedLogger("[pool] ")
+
+	// Test
+	sut.Debug("before")
+	registry.SetLogLevel("db.mysql", DEBUG)
+	sut.Debug("after")
+	prefixed.Debug("prefixed")
+	logger.Debug("unnamed")
+
+	// Verify
+	ExpectString("db.mysql.pool", sut.GetName(), t)
+	ExpectString("db.mysql.pool", prefixed.GetName(), t)
+	ExpectTrue(DEBUG == sut.GetMinLogLevel(), t)
+	ExpectTrue(INFO == logger.GetMinLogLevel(), t)
+	ExpectNotLogged(memoryLogWriter, DEBUG, "before|unnamed", t)
+	ExpectLogged(memoryLogWriter, DEBUG, "^after$", t)
+	ExpectLogged(memoryLogWriter, DEBUG, `^\[pool\] prefixed$`, t)
+}
+
+func TestThat_Logger_GetLogger_UsesGetLogLevelRegistry(t *testing.T) {
+	// Test
+	sut := GetLogger().GetNamedLogger("x")
+
+	// Verify
+	ExpectTrue(GetLogLevelRegistry() == sut.levelRegistry, t)
+	ExpectTrue(nil == NewLogger("1").levelRegistry, t)
+}
@@ -0,0 +1,109 @@
+package logger
+
+/*
+
+A LogRecord is one log message along with everything known about it: when, how severe, which stream
+and prefix it came from, and any structured key/value fields that go with it. Loggers build these and
+LogFormatters turn them into lines of output for our LogWriters.
+
+Field values are normalized when the record is built so that every formatter sees the same thing:
+nil, booleans, integers and floats are kept as they are, times become RFC 3339 strings, and anything
+else (strings, errors, Stringers, structs...) becomes its string form. Loggers redact the message and
+string field values once they know the record will be written (or returned as an error), so that
+secrets stay out of structured output just as they do out of messages, without the cost of redacting
+records that are filtered out.
+
+A LogWriter that wants whole LogRecords rather than formatted lines (to filter by level, format for
+itself, map levels to syslog severities, etc) implements LogRecordWriterIfc as well as LogWriterIfc;
+Loggers hand it each LogRecord instead of formatting it.
+
+*/
+
+import (
+	"fmt"
+	"time"
+)
+
+const LOG_FIELD_BAD_KEY = "!BADKEY"
+
+type LogRecordWriterIfc interface {
+	LogRecord(record *LogRecord)
+}
+
+type LogField struct {
+	Key		string
+	Value		interface{}
+}
+
+type LogRecord struct {
+	Timestamp	time.Time	// Zero if timestamps are not being logged
+	Level		LogLevel
+	StreamId	string
+	Prefix		string
+	Message		string
+	Fields		[]LogField
+	redacted	bool		// Have secrets been masked in our Message and Fields yet?
+}
+
+// -------------------------------------------------------------------------------------------------
+// Factory Functions
+// -------------------------------------------------------------------------------------------------
+
+// Make LogFields from alternating keys and values: "k1", v1, "k2", v2...
+// A key that is not a string is logged as a value with key LOG_FIELD_BAD_KEY; a trailing key with no
+// value is logged with a nil value.
+func NewLogFields(keysAndValues ...interface{}) []LogField {
+	fields := make([]LogField, 0, (len(keysAndValues) + 1) / 2)
+	for index := 0; index < len(keysAndValues); index++ {
+		key, ok := keysAndValues[index].(string)
+		if ! ok {
+			fields = append(fields, NewLogField(LOG_FIELD_BAD_KEY, keysAndValues[index]))
+			continue
+		}
+		var value interface{}
+		if index + 1 < len(keysAndValues) {
+			index++
+			value = keysAndValues[index]
+		}
+		fields = append(fields, NewLogField(key, value))
+	}
+	return fields
+}
+
+func NewLogField(key string, value interface{}) LogField {
+	return LogField{ Key: key, Value: normalizeLogFieldValue(value) }
+}
+
+// -------------------------------------------------------------------------------------------------
+// LogRecord Implementation
+// -------------------------------------------------------------------------------------------------
+
+func normalizeLogFieldValue(value interface{}) interface{} {
+	switch v := value.(type) {
+		case nil, bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+			return v
+		case time.Time:
+			return v.Format(time.RFC3339Nano)
+		case string:
+			return v
+		case error:
+			return v.Error()
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// Mask secrets (see GoLib/Redactor) in our Message and string Field values, unless we already have;
+// Fields may be shared with other records (such as a StructuredLogger's), so we redact a copy
+func (r *LogRecord) redact() {
+	if r.redacted { return }
+	r.redacted = true
+	redactor := GetRedactor()
+	r.Message = redactor.Redact(r.Message)
+	if 0 == len(r.Fields) { return }
+	fields := make([]LogField, len(r.Fields))
+	for index, field := range r.Fields {
+		if str, ok := field.Value.(string); ok { field.Value = redactor.Redact(str) }
+		fields[index] = field
+	}
+	r.Fields = fields
+}
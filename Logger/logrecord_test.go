@@ -0,0 +1,76 @@
+package logger
+
+import(
+	"fmt"
+	"time"
+	"errors"
+	"testing"
+
+	. "github.com/DigiStratum/GoLib/Testing"
+)
+
+func TestThat_NewLogFields_Returns_fields_in_order(t *testing.T) {
+	// Test
+	actual := NewLogFields("a", 1, "b", true, "c", nil, "d", 1.5)
+
+	// Verify
+	if ! ExpectInt(4, len(actual), t) { return }
+	ExpectString("a", actual[0].Key, t)
+	ExpectTrue(1 == actual[0].Value, t)
+	ExpectTrue(true == actual[1].Value, t)
+	ExpectTrue(nil == actual[2].Value, t)
+	ExpectTrue(1.5 == actual[3].Value, t)
+}
+
+func TestThat_NewLogFields_Handles_bad_keys_and_missing_values(t *testing.T) {
+	// Test
+	actual := NewLogFields(42, "a", "b")
+
+	// Verify
+	if ! ExpectInt(2, len(actual), t) { return }
+	ExpectString(LOG_FIELD_BAD_KEY, actual[0].Key, t)
+	ExpectTrue(42 == actual[0].Value, t)
+	ExpectString("a", actual[1].Key, t)
+	ExpectTrue("b" == actual[1].Value, t)
+
+	// And a trailing key with no value
+	actual = NewLogFields("a")
+	if ! ExpectInt(1, len(actual), t) { return }
+	ExpectTrue(nil == actual[0].Value, t)
+}
+
+func TestThat_NewLogField_Normalizes_values_to_strings(t *testing.T) {
+	// Setup
+	when := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	// Test
+	actualString := NewLogField("k", "pw=x").Value
+	actualError := NewLogField("k", errors.New("bad x")).Value
+	actualTime := NewLogField("k", when).Value
+	actualDuration := NewLogField("k", 3 * time.Second).Value
+	actualStruct := NewLogField("k", struct{ A int }{ 7 }).Value
+
+	// Verify
+	ExpectTrue("pw=x" == actualString, t)
+	ExpectTrue("bad x" == actualError, t)
+	ExpectString("2024-01-02T03:04:05Z", fmt.Sprintf("%v", actualTime), t)
+	ExpectTrue("3s" == actualDuration, t)
+	ExpectTrue("{7}" == actualStruct, t)
+}
+
+func TestThat_LogRecord_redact_MasksSecrets_InMessageAndFieldCopies(t *testing.T) {
+	// Setup
+	GetRedactor().AddSecret("logrecord-secret")
+	fields := NewLogFields("pw", "pw=logrecord-secret", "err", errors.New("bad logrecord-secret"), "n", 1)
+	sut := LogRecord{ Level: INFO, Message: "is logrecord-secret", Fields: fields }
+
+	// Test
+	sut.redact()
+
+	// Verify
+	ExpectString("is " + REDACTED, sut.Message, t)
+	ExpectTrue(("pw=" + REDACTED) == sut.Fields[0].Value, t)
+	ExpectTrue(("bad " + REDACTED) == sut.Fields[1].Value, t)
+	ExpectTrue(1 == sut.Fields[2].Value, t)
+	ExpectTrue("pw=logrecord-secret" == fields[0].Value, t)
+}
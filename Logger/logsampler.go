@@ -0,0 +1,234 @@
+package logger
+
+/*
+
+A LogSampler keeps a flood of repetitive log records (e.g. thousands of identical WARNs a second from
+a failing dependency) from drowning out everything else. Records are grouped by call site (where in
+the code they were logged from) or by message template (the format string, or the message for a
+StructuredLogger), and for each group, per interval, the first N records are logged, then 1 in M:
+
+	sampler := logger.NewLogSampler(logger.LOG_SAMPLE_BY_CALL_SITE).
+		SetPolicy(logger.WARN, logger.LogSamplingPolicy{ First: 10, Interval: time.Second, Thereafter: 100 }).
+		SetPolicy(logger.INFO, logger.LogSamplingPolicy{ First: 100, Interval: time.Second })
+	logger.GetLogger().SetLogSampler(sampler)
+
+Policies are per LogLevel; a level with no policy is not sampled at all. Thereafter=0 suppresses
+everything beyond the first N until the next interval.
+
+Suppressed records are not lost without a trace: the next record logged for the same group is
+preceded by a summary record at the same level, e.g.
+
+	message repeated 4,211 times: Connection to %s refused
+
+with the count in a "suppressed" field. Logger.FlushLogSampler() logs summaries for every group with
+suppressed records outstanding, e.g. at shutdown or on a timer.
+
+Suppressed WARN|ERROR|FATAL records still return their errors to the caller; only logging is skipped.
+
+*/
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+	"runtime"
+	"strings"
+	"reflect"
+)
+
+type LogSampleKeyMode int
+
+const (
+	LOG_SAMPLE_BY_CALL_SITE LogSampleKeyMode = iota
+	LOG_SAMPLE_BY_TEMPLATE
+)
+
+const LOG_FIELD_SUPPRESSED = "suppressed"
+
+// Groups with nothing outstanding are forgotten once we are tracking this many
+const LOG_SAMPLER_MAX_GROUPS = 10000
+
+type LogSamplingPolicy struct {
+	First		int		// Log the first First records per Interval...
+	Interval	time.Duration
+	Thereafter	int		// ...then 1 in every Thereafter (0 = none)
+}
+
+type LogSamplerIfc interface {
+	SetPolicy(level LogLevel, policy LogSamplingPolicy) *LogSampler
+	ClearPolicy(level LogLevel) *LogSampler
+	GetKeyMode() LogSampleKeyMode
+}
+
+type LogSampler struct {
+	mutex		sync.Mutex
+	keyMode		LogSampleKeyMode
+	policies	map[LogLevel]LogSamplingPolicy
+	groups		map[string]*logSampleGroup
+	now		func () time.Time
+}
+
+// The state of one group of records at one level
+type logSampleGroup struct {
+	level		LogLevel
+	template	string		// The most recent, for the summary
+	windowStart	time.Time
+	count		int		// Records in the current interval
+	suppressed	uint64		// Records suppressed since the last summary
+}
+
+// Suppressed records for one group, to be summarized
+type logSampleSummary struct {
+	level		LogLevel
+	template	string
+	suppressed	uint64
+}
+
+// Function name prefixes of our own methods, to skip over when looking for the call site
+var logSamplerSkipPrefixes []string
+
+func init() {
+	pkgPath := reflect.TypeOf(Logger{}).PkgPath()
+	logSamplerSkipPrefixes = []string{
+		pkgPath + ".Logger.",
+		pkgPath + ".(*Logger).",
+		pkgPath + ".(*StructuredLogger).",
+	}
+}
+
+// -------------------------------------------------------------------------------------------------
+// Factory Functions
+// -------------------------------------------------------------------------------------------------
+
+func NewLogSampler(keyMode LogSampleKeyMode) *LogSampler {
+	return &LogSampler{
+		keyMode:	keyMode,
+		policies:	make(map[LogLevel]LogSamplingPolicy),
+		groups:		make(map[string]*logSampleGroup),
+		now:		time.Now,
+	}
+}
+
+// -------------------------------------------------------------------------------------------------
+// LogSamplerIfc Public Interface
+// -------------------------------------------------------------------------------------------------
+
+// Sample records at level according to policy
+func (r *LogSampler) SetPolicy(level LogLevel, policy LogSamplingPolicy) *LogSampler {
+	if policy.First < 0 { policy.First = 0 }
+	if policy.Thereafter < 0 { policy.Thereafter = 0 }
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.policies[level] = policy
+	return r
+}
+
+// Stop sampling records at level
+func (r *LogSampler) ClearPolicy(level LogLevel) *LogSampler {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	delete(r.policies, level)
+	return r
+}
+
+func (r *LogSampler) GetKeyMode() LogSampleKeyMode {
+	return r.keyMode
+}
+
+// -------------------------------------------------------------------------------------------------
+// LogSampler Implementation
+// -------------------------------------------------------------------------------------------------
+
+// Should a record at level with template be logged? If so, how many in its group were suppressed first?
+func (r *LogSampler) sample(level LogLevel, template string) (bool, uint64) {
+	r.mutex.Lock()
+	policy, ok := r.policies[level]
+	r.mutex.Unlock()
+	if ! ok { return true, 0 }
+
+	// Finding the call site is relatively costly, so we do it outside of the lock
+	key := fmt.Sprintf("%d|%s", level, template)
+	if LOG_SAMPLE_BY_CALL_SITE == r.keyMode { key = fmt.Sprintf("%d|%s", level, getLogCallSite()) }
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	now := r.now()
+	group, ok := r.groups[key]
+	if ! ok {
+		if len(r.groups) >= LOG_SAMPLER_MAX_GROUPS { r.forgetIdleGroups(now) }
+		group = &logSampleGroup{ level: level, windowStart: now }
+		r.groups[key] = group
+	}
+	group.template = template
+	if (policy.Interval > 0) && (now.Sub(group.windowStart) >= policy.Interval) {
+		group.windowStart = now
+		group.count = 0
+	}
+	group.count++
+	allowed := group.count <= policy.First
+	if ! allowed && (policy.Thereafter > 0) { allowed = 0 == (group.count - policy.First) % policy.Thereafter }
+	if ! allowed {
+		group.suppressed++
+		return false, 0
+	}
+	suppressed := group.suppressed
+	group.suppressed = 0
+	return true, suppressed
+}
+
+// Take summaries of every group with suppressed records, in a predictable order
+func (r *LogSampler) takeSummaries() []logSampleSummary {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	keys := make([]string, 0)
+	for key, group := range r.groups {
+		if group.suppressed > 0 { keys = append(keys, key) }
+	}
+	sort.Strings(keys)
+	summaries := make([]logSampleSummary, 0, len(keys))
+	for _, key := range keys {
+		group := r.groups[key]
+		summaries = append(summaries, logSampleSummary{ level: group.level, template: group.template, suppressed: group.suppressed })
+		group.suppressed = 0
+	}
+	return summaries
+}
+
+// Forget groups whose interval is over with nothing suppressed; caller must hold the mutex
+func (r *LogSampler) forgetIdleGroups(now time.Time) {
+	for key, group := range r.groups {
+		if group.suppressed > 0 { continue }
+		if now.Sub(group.windowStart) < r.policies[group.level].Interval { continue }
+		delete(r.groups, key)
+	}
+}
+
+// The file:line of the first caller outside of our Logger methods
+func getLogCallSite() string {
+	pcs := make([]uintptr, 16)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+	for {
+		frame, more := frames.Next()
+		skip := false
+		for _, prefix := range logSamplerSkipPrefixes {
+			if strings.HasPrefix(frame.Function, prefix) {
+				skip = true
+				break
+			}
+		}
+		if ! skip { return fmt.Sprintf("%s:%d", frame.File, frame.Line) }
+		if ! more { return "" }
+	}
+}
+
+// 4211 -> "4,211"
+func formatLogCount(count uint64) string {
+	digits := fmt.Sprintf("%d", count)
+	var sb strings.Builder
+	for index, digit := range digits {
+		if (index > 0) && (0 == (len(digits) - index) % 3) { sb.WriteString(",") }
+		sb.WriteRune(digit)
+	}
+	return sb.String()
+}
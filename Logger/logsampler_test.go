@@ -0,0 +1,154 @@
+package logger
+
+import(
+	"time"
+	"strings"
+	"testing"
+
+	. "github.com/DigiStratum/GoLib/Testing"
+)
+
+func newLogSamplerTestLogger(sampler *LogSampler) (*Logger, *collectingLogWriter) {
+	writer := &collectingLogWriter{}
+	return NewLogger("7").SetLogWriter(writer).LogTimestamp(false).SetLogSampler(sampler), writer
+}
+
+func newLogSamplerForTest(keyMode LogSampleKeyMode, now *time.Time) *LogSampler {
+	sampler := NewLogSampler(keyMode)
+	sampler.now = func () time.Time { return *now }
+	return sampler
+}
+
+func TestThat_LogSampler_NewLogSampler_ReturnsSomething(t *testing.T) {
+	// Test
+	var sut LogSamplerIfc = NewLogSampler(LOG_SAMPLE_BY_TEMPLATE) // <- ensures that we satisfy our interface
+
+	// Verify
+	ExpectNonNil(sut, t)
+	ExpectTrue(LOG_SAMPLE_BY_TEMPLATE == sut.GetKeyMode(), t)
+}
+
+func TestThat_formatLogCount_Groups_thousands(t *testing.T) {
+	// Verify
+	ExpectString("0", formatLogCount(0), t)
+	ExpectString("999", formatLogCount(999), t)
+	ExpectString("4,211", formatLogCount(4211), t)
+	ExpectString("1,234,567", formatLogCount(1234567), t)
+}
+
+func TestThat_LogSampler_Logs_first_N_then_1_in_M_with_summaries(t *testing.T) {
+	// Setup
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	sampler := newLogSamplerForTest(LOG_SAMPLE_BY_TEMPLATE, &now).
+		SetPolicy(WARN, LogSamplingPolicy{ First: 2, Interval: time.Second, Thereafter: 3 })
+	sut, writer := newLogSamplerTestLogger(sampler)
+
+	// Test
+	var errCount int
+	for i := 1; i <= 8; i++ {
+		if nil != sut.Warn("Connection to %s refused (%d)", "db", i) { errCount++ }
+	}
+
+	// Verify
+	ExpectInt(8, errCount, t)
+	ExpectString(strings.Join([]string{
+		"thread:7  WARN Connection to db refused (1)",
+		"thread:7  WARN Connection to db refused (2)",
+		"thread:7  WARN message repeated 2 times: Connection to %s refused (%d) suppressed=2",
+		"thread:7  WARN Connection to db refused (5)",
+		"thread:7  WARN message repeated 2 times: Connection to %s refused (%d) suppressed=2",
+		"thread:7  WARN Connection to db refused (8)",
+	}, "\n"), strings.Join(writer.getLines(), "\n"), t)
+}
+
+func TestThat_LogSampler_Starts_over_each_interval(t *testing.T) {
+	// Setup
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	sampler := newLogSamplerForTest(LOG_SAMPLE_BY_TEMPLATE, &now).
+		SetPolicy(ERROR, LogSamplingPolicy{ First: 1, Interval: time.Second })
+	logger, writer := newLogSamplerTestLogger(sampler)
+	sut := logger.With("k", 1)
+
+	// Test
+	for i := 0; i < 5; i++ { sut.Error("down") }
+	now = now.Add(time.Second)
+	sut.Error("down")
+	sut.Error("down")
+
+	// Verify
+	ExpectString(strings.Join([]string{
+		"thread:7 ERROR down k=1",
+		"thread:7 ERROR message repeated 4 times: down suppressed=4",
+		"thread:7 ERROR down k=1",
+	}, "\n"), strings.Join(writer.getLines(), "\n"), t)
+}
+
+func TestThat_LogSampler_Leaves_levels_without_policy_alone(t *testing.T) {
+	// Setup
+	sampler := NewLogSampler(LOG_SAMPLE_BY_TEMPLATE).SetPolicy(WARN, LogSamplingPolicy{ First: 1, Interval: time.Hour })
+	sut, writer := newLogSamplerTestLogger(sampler)
+
+	// Test
+	for i := 0; i < 3; i++ { sut.Info("same") }
+	sampler.SetPolicy(INFO, LogSamplingPolicy{ First: 1, Interval: time.Hour }).ClearPolicy(INFO)
+	sut.Info("same")
+
+	// Verify
+	ExpectInt(4, len(writer.getLines()), t)
+}
+
+func TestThat_LogSampler_By_call_site_groups_by_where_records_are_logged(t *testing.T) {
+	// Setup
+	sampler := NewLogSampler(LOG_SAMPLE_BY_CALL_SITE).SetPolicy(INFO, LogSamplingPolicy{ First: 1, Interval: time.Hour })
+	sut, writer := newLogSamplerTestLogger(sampler)
+
+	// Test
+	for i := 0; i < 3; i++ {
+		sut.Info("loop %d", i)				// One call site, whatever the template
+		sut.With("i", i).Info("structured")		// Another
+	}
+	sut.Info("loop %d", 99)					// Yet another, though the template is the same
+
+	// Verify
+	ExpectString(strings.Join([]string{
+		"thread:7  INFO loop 0",
+		"thread:7  INFO structured i=0",
+		"thread:7  INFO loop 99",
+	}, "\n"), strings.Join(writer.getLines(), "\n"), t)
+}
+
+func TestThat_Logger_FlushLogSampler_Logs_outstanding_summaries(t *testing.T) {
+	// Setup
+	sampler := NewLogSampler(LOG_SAMPLE_BY_TEMPLATE).
+		SetPolicy(WARN, LogSamplingPolicy{ First: 1, Interval: time.Hour }).
+		SetPolicy(ERROR, LogSamplingPolicy{ First: 1, Interval: time.Hour })
+	sut, writer := newLogSamplerTestLogger(sampler)
+	for i := 0; i < 3; i++ { sut.Warn("w") }
+	for i := 0; i < 2; i++ { sut.Error("e") }
+
+	// Test
+	sut.FlushLogSampler()
+	sut.FlushLogSampler()
+
+	// Verify
+	ExpectString(strings.Join([]string{
+		"thread:7  WARN w",
+		"thread:7 ERROR e",
+		"thread:7  WARN message repeated 2 times: w suppressed=2",
+		"thread:7 ERROR message repeated 1 times: e suppressed=1",
+	}, "\n"), strings.Join(writer.getLines(), "\n"), t)
+}
+
+func TestThat_Logger_GetNewPrefixedLogger_Shares_LogSampler(t *testing.T) {
+	// Setup
+	sampler := NewLogSampler(LOG_SAMPLE_BY_TEMPLATE).SetPolicy(INFO, LogSamplingPolicy{ First: 1, Interval: time.Hour })
+	logger, writer := newLogSamplerTestLogger(sampler)
+	sut := logger.GetNewPrefixedLogger("[p] ")
+
+	// Test
+	logger.Info("same")
+	sut.Info("same")
+
+	// Verify
+	ExpectInt(1, len(writer.getLines()), t)
+}
@@ -0,0 +1,485 @@
+package logwriter
+
+/*
+
+File LogWriter
+
+Appends log lines to a file, rotating it when it grows too large or when a time boundary passes, and
+optionally compressing and expiring the rotated files:
+
+	fileLogWriter, err := logwriter.NewFileLogWriter("/var/log/myapp/myapp.log")
+	if nil != err { ... }
+	defer fileLogWriter.Close()
+	fileLogWriter.
+		SetMaxSize(100 * 1024 * 1024).		// Rotate at 100MB...
+		SetRotateInterval(24 * time.Hour).	// ...and at (UTC) midnight
+		SetCompress(true).			// gzip rotated files
+		SetMaxFiles(14).			// Keep at most 14 rotated files...
+		SetMaxAge(30 * 24 * time.Hour).		// ...none more than 30 days old
+		SetFlushInterval(time.Second).		// Flush buffered lines every second
+		ReopenOnSIGHUP()			// Let logrotate, etc, move the file out from under us
+
+Rotated files are renamed alongside the log file with the time of rotation appended, e.g.
+myapp.log.2024-01-02T00-00-00.000 (with .gz appended once compressed). Compression and retention
+are taken care of in the background so that logging is not held up; Close() waits for them.
+
+Time boundaries are multiples of the rotate interval since the zero time, so they fall on UTC
+hours/days. A log file that is left over from an earlier boundary (e.g. yesterday's, when the process
+restarts today) is rotated on the first write.
+
+Writes are buffered: nothing is guaranteed to reach the file until Flush() or Close(), or until the
+flush interval (if any) passes. Write errors are reported on StdErr, since we can hardly log them.
+If the file can't be opened again (after rotating, say, with its directory briefly unwritable), lines
+are dropped until it can; Log() tries again, backing off from FILE_LOG_WRITER_MIN_REOPEN_BACKOFF up to
+FILE_LOG_WRITER_MAX_REOPEN_BACKOFF between tries so that a lasting problem doesn't slow every line.
+
+*/
+
+import (
+	"io"
+	"os"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+	"bufio"
+	"strings"
+	"syscall"
+	"os/signal"
+	"path/filepath"
+	"compress/gzip"
+)
+
+const FILE_LOG_WRITER_DEFAULT_BUFFER_SIZE = 64 * 1024
+const FILE_LOG_WRITER_TIMESTAMP_LAYOUT = "2006-01-02T15-04-05.000"
+const FILE_LOG_WRITER_COMPRESSED_EXTENSION = ".gz"
+const FILE_LOG_WRITER_MIN_REOPEN_BACKOFF = 100 * time.Millisecond
+const FILE_LOG_WRITER_MAX_REOPEN_BACKOFF = time.Minute
+
+type FileLogWriterIfc interface {
+	SetMaxSize(maxSize int64) *FileLogWriter
+	SetRotateInterval(rotateInterval time.Duration) *FileLogWriter
+	SetCompress(compress bool) *FileLogWriter
+	SetMaxFiles(maxFiles int) *FileLogWriter
+	SetMaxAge(maxAge time.Duration) *FileLogWriter
+	SetBufferSize(bufferSize int) *FileLogWriter
+	SetFlushInterval(flushInterval time.Duration) *FileLogWriter
+	ReopenOnSIGHUP() *FileLogWriter
+	GetPath() string
+	Log(format string, a ...interface{})
+	Flush() error
+	Rotate() error
+	Reopen() error
+	Close() error
+}
+
+type FileLogWriter struct {
+	mutex			sync.Mutex
+	path			string
+	file			*os.File
+	writer			*bufio.Writer
+	size			int64			// Bytes in the current file, buffered or not
+	rotateAt		time.Time		// Next time boundary, if rotating on an interval
+	leftoverModTime		time.Time		// Last modified time of a non-empty file we opened
+	reopenAt		time.Time		// Don't try to open the file again before this, after failing
+	reopenBackoff		time.Duration		// Wait this long after the next failure to open the file
+	maxSize			int64			// Rotate before the file would exceed this many bytes (0=no limit)
+	rotateInterval		time.Duration		// Rotate at multiples of this interval (0=never)
+	compress		bool			// gzip rotated files?
+	maxFiles		int			// Keep at most this many rotated files (0=no limit)
+	maxAge			time.Duration		// Remove rotated files older than this (0=no limit)
+	bufferSize		int
+	flushInterval		time.Duration
+	flushStop		chan bool		// Stops the flusher, if running
+	signals			chan os.Signal		// SIGHUP arrives here, if we're listening
+	signalStop		chan bool		// Stops the SIGHUP listener, if running
+	housekeeping		sync.WaitGroup		// Compression and retention in progress
+	housekeepingMutex	sync.Mutex		// One housekeeping pass at a time
+	closed			bool
+	now			func () time.Time
+}
+
+// -------------------------------------------------------------------------------------------------
+// Factory Functions
+// -------------------------------------------------------------------------------------------------
+
+// Make a new LogWriter appending to the file at path, creating the file (but not its directory) if needed
+func NewFileLogWriter(path string) (*FileLogWriter, error) {
+	r := FileLogWriter{
+		path:		path,
+		bufferSize:	FILE_LOG_WRITER_DEFAULT_BUFFER_SIZE,
+		now:		time.Now,
+	}
+	if err := r.open(); nil != err { return nil, fmt.Errorf("NewFileLogWriter(): %s", err.Error()) }
+	return &r, nil
+}
+
+// -------------------------------------------------------------------------------------------------
+// FileLogWriterIfc Public Interface
+// -------------------------------------------------------------------------------------------------
+
+// Rotate before the file would grow beyond maxSize bytes; 0 (default) for no limit
+func (r *FileLogWriter) SetMaxSize(maxSize int64) *FileLogWriter {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.maxSize = maxSize
+	return r
+}
+
+// Rotate at each multiple of rotateInterval, e.g. time.Hour or 24 * time.Hour; 0 (default) for never
+func (r *FileLogWriter) SetRotateInterval(rotateInterval time.Duration) *FileLogWriter {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.rotateInterval = rotateInterval
+	r.setRotateAt()
+	return r
+}
+
+// gzip files once they are rotated (default false)
+func (r *FileLogWriter) SetCompress(compress bool) *FileLogWriter {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.compress = compress
+	return r
+}
+
+// Keep at most maxFiles rotated files, removing the oldest; 0 (default) for no limit
+func (r *FileLogWriter) SetMaxFiles(maxFiles int) *FileLogWriter {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.maxFiles = maxFiles
+	return r
+}
+
+// Remove rotated files once they are older than maxAge; 0 (default) for no limit
+func (r *FileLogWriter) SetMaxAge(maxAge time.Duration) *FileLogWriter {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.maxAge = maxAge
+	return r
+}
+
+// Buffer up to bufferSize bytes before writing to the file; 0 to write every line immediately
+func (r *FileLogWriter) SetBufferSize(bufferSize int) *FileLogWriter {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if bufferSize < 0 { bufferSize = 0 }
+	r.bufferSize = bufferSize
+	if nil != r.writer {
+		r.reportError(r.writer.Flush())
+		r.writer = r.newWriter()
+	}
+	return r
+}
+
+// Flush buffered lines every flushInterval in the background; 0 (default) to only flush explicitly
+func (r *FileLogWriter) SetFlushInterval(flushInterval time.Duration) *FileLogWriter {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if nil != r.flushStop {
+		close(r.flushStop)
+		r.flushStop = nil
+	}
+	r.flushInterval = flushInterval
+	if (flushInterval > 0) && ! r.closed {
+		r.flushStop = make(chan bool)
+		go r.flusher(flushInterval, r.flushStop)
+	}
+	return r
+}
+
+// Reopen our file whenever the process receives SIGHUP, as logrotate expects
+func (r *FileLogWriter) ReopenOnSIGHUP() *FileLogWriter {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if (nil != r.signals) || r.closed { return r }
+	r.signals = make(chan os.Signal, 1)
+	r.signalStop = make(chan bool)
+	signal.Notify(r.signals, syscall.SIGHUP)
+	go r.signalListener(r.signals, r.signalStop)
+	return r
+}
+
+func (r *FileLogWriter) GetPath() string {
+	return r.path
+}
+
+// Write any buffered lines to the file
+func (r *FileLogWriter) Flush() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if nil == r.writer { return nil }
+	if err := r.writer.Flush(); nil != err { return fmt.Errorf("FileLogWriter.Flush(): %s", err.Error()) }
+	return nil
+}
+
+// Rotate the file now, whatever its size or age
+func (r *FileLogWriter) Rotate() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.closed { return fmt.Errorf("FileLogWriter.Rotate(): Already closed") }
+	if err := r.rotate(); nil != err { return fmt.Errorf("FileLogWriter.Rotate(): %s", err.Error()) }
+	return nil
+}
+
+// Close and reopen the file at our path, e.g. after something else has moved it away
+func (r *FileLogWriter) Reopen() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.closed { return fmt.Errorf("FileLogWriter.Reopen(): Already closed") }
+	if err := r.closeFile(); nil != err { return fmt.Errorf("FileLogWriter.Reopen(): %s", err.Error()) }
+	if err := r.reopen(); nil != err { return fmt.Errorf("FileLogWriter.Reopen(): %s", err.Error()) }
+	return nil
+}
+
+// Flush and close the file, stop any background work and wait for housekeeping to finish
+func (r *FileLogWriter) Close() error {
+	r.mutex.Lock()
+	if r.closed {
+		r.mutex.Unlock()
+		return nil
+	}
+	r.closed = true
+	if nil != r.flushStop {
+		close(r.flushStop)
+		r.flushStop = nil
+	}
+	if nil != r.signals {
+		signal.Stop(r.signals)
+		close(r.signalStop)
+		r.signals = nil
+	}
+	err := r.closeFile()
+	r.mutex.Unlock()
+	r.housekeeping.Wait()
+	if nil != err { return fmt.Errorf("FileLogWriter.Close(): %s", err.Error()) }
+	return nil
+}
+
+// -------------------------------------------------------------------------------------------------
+// LogWriterIfc Public Interface
+// -------------------------------------------------------------------------------------------------
+
+func (r *FileLogWriter) Log(format string, a ...interface{}) {
+	line := fmt.Sprintf(format, a...) + "\n"
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.closed { return }
+	if nil == r.writer { r.reportError(r.retryOpen()) }
+	if nil == r.writer { return }
+	if r.needsRotation(int64(len(line))) { r.reportError(r.rotate()) }
+	if nil == r.writer { return }
+	written, err := r.writer.WriteString(line)
+	r.size += int64(written)
+	if (nil == err) && (0 == r.bufferSize) { err = r.writer.Flush() }
+	r.reportError(err)
+}
+
+// -------------------------------------------------------------------------------------------------
+// FileLogWriter Implementation
+// -------------------------------------------------------------------------------------------------
+
+// Open our file for appending; caller must hold the mutex
+func (r *FileLogWriter) open() error {
+	file, err := os.OpenFile(r.path, os.O_WRONLY | os.O_APPEND | os.O_CREATE, 0644)
+	if nil != err { return err }
+	info, err := file.Stat()
+	if nil != err {
+		file.Close()
+		return err
+	}
+	r.file = file
+	r.writer = r.newWriter()
+	r.size = info.Size()
+	r.leftoverModTime = time.Time{}
+	if r.size > 0 { r.leftoverModTime = info.ModTime() }
+	r.setRotateAt()
+	r.reopenAt = time.Time{}
+	r.reopenBackoff = 0
+	return nil
+}
+
+// Try to open our file again, once the backoff since the last try has passed; caller must hold the mutex
+func (r *FileLogWriter) retryOpen() error {
+	if r.now().Before(r.reopenAt) { return nil }
+	return r.reopen()
+}
+
+// Open our file, holding off the next retry (for longer each time) if we can't; caller must hold the mutex
+func (r *FileLogWriter) reopen() error {
+	err := r.open()
+	if nil == err { return nil }
+	r.reopenBackoff *= 2
+	if r.reopenBackoff < FILE_LOG_WRITER_MIN_REOPEN_BACKOFF { r.reopenBackoff = FILE_LOG_WRITER_MIN_REOPEN_BACKOFF }
+	if r.reopenBackoff > FILE_LOG_WRITER_MAX_REOPEN_BACKOFF { r.reopenBackoff = FILE_LOG_WRITER_MAX_REOPEN_BACKOFF }
+	r.reopenAt = r.now().Add(r.reopenBackoff)
+	return err
+}
+
+func (r *FileLogWriter) newWriter() *bufio.Writer {
+	if nil == r.file { return nil }
+	// With no buffer size we still use a (default sized) buffer, but flush every line
+	if 0 == r.bufferSize { return bufio.NewWriter(r.file) }
+	return bufio.NewWriterSize(r.file, r.bufferSize)
+}
+
+// Flush and close our file, if open; caller must hold the mutex
+func (r *FileLogWriter) closeFile() error {
+	if nil == r.file { return nil }
+	flushErr := r.writer.Flush()
+	closeErr := r.file.Close()
+	r.file = nil
+	r.writer = nil
+	if nil != flushErr { return flushErr }
+	return closeErr
+}
+
+func (r *FileLogWriter) setRotateAt() {
+	if r.rotateInterval <= 0 {
+		r.rotateAt = time.Time{}
+		return
+	}
+	r.rotateAt = r.now().Truncate(r.rotateInterval).Add(r.rotateInterval)
+	// A non-empty file left over from an earlier time boundary belongs to that one
+	if ! r.leftoverModTime.IsZero() && r.leftoverModTime.Before(r.rotateAt.Add(-r.rotateInterval)) {
+		r.rotateAt = r.leftoverModTime
+	}
+}
+
+// Should we rotate before writing length more bytes? Caller must hold the mutex
+func (r *FileLogWriter) needsRotation(length int64) bool {
+	if r.size <= 0 { return false }
+	if (r.maxSize > 0) && (r.size + length > r.maxSize) { return true }
+	return (r.rotateInterval > 0) && ! r.now().Before(r.rotateAt)
+}
+
+// Move our file aside, open a new one, and start housekeeping; caller must hold the mutex
+func (r *FileLogWriter) rotate() error {
+	if err := r.closeFile(); nil != err { return err }
+	rotatedPath := r.getRotatedPath(r.now())
+	renameErr := os.Rename(r.path, rotatedPath)
+	if err := r.reopen(); nil != err { return err }
+	if nil != renameErr { return renameErr }
+
+	compress := r.compress
+	maxFiles := r.maxFiles
+	maxAge := r.maxAge
+	r.housekeeping.Add(1)
+	go func () {
+		defer r.housekeeping.Done()
+		r.housekeepingMutex.Lock()
+		defer r.housekeepingMutex.Unlock()
+		if compress { r.reportError(compressFile(rotatedPath)) }
+		r.reportError(r.removeExpired(maxFiles, maxAge))
+	}()
+	return nil
+}
+
+// A path for a file rotated at when that isn't taken already (compressed or not)
+func (r *FileLogWriter) getRotatedPath(when time.Time) string {
+	for {
+		rotatedPath := r.path + "." + when.UTC().Format(FILE_LOG_WRITER_TIMESTAMP_LAYOUT)
+		if ! fileExists(rotatedPath) && ! fileExists(rotatedPath + FILE_LOG_WRITER_COMPRESSED_EXTENSION) {
+			return rotatedPath
+		}
+		when = when.Add(time.Millisecond)
+	}
+}
+
+// Remove the oldest rotated files beyond maxFiles, and any older than maxAge
+func (r *FileLogWriter) removeExpired(maxFiles int, maxAge time.Duration) error {
+	if (maxFiles <= 0) && (maxAge <= 0) { return nil }
+	rotatedPaths, err := r.getRotatedPaths()
+	if nil != err { return err }
+	var firstErr error
+	for index, rotatedPath := range rotatedPaths {
+		expired := (maxFiles > 0) && (index >= maxFiles)
+		if ! expired && (maxAge > 0) {
+			info, err := os.Stat(rotatedPath)
+			expired = (nil == err) && (r.now().Sub(info.ModTime()) > maxAge)
+		}
+		if ! expired { continue }
+		if err := os.Remove(rotatedPath); (nil != err) && (nil == firstErr) { firstErr = err }
+	}
+	return firstErr
+}
+
+// Paths of our rotated files, newest first
+func (r *FileLogWriter) getRotatedPaths() ([]string, error) {
+	entries, err := os.ReadDir(filepath.Dir(r.path))
+	if nil != err { return nil, err }
+	prefix := filepath.Base(r.path) + "."
+	rotatedPaths := make([]string, 0)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || ! strings.HasPrefix(name, prefix) { continue }
+		timestamp := strings.TrimSuffix(strings.TrimPrefix(name, prefix), FILE_LOG_WRITER_COMPRESSED_EXTENSION)
+		if _, err := time.Parse(FILE_LOG_WRITER_TIMESTAMP_LAYOUT, timestamp); nil != err { continue }
+		rotatedPaths = append(rotatedPaths, filepath.Join(filepath.Dir(r.path), name))
+	}
+	// The timestamp layout sorts chronologically; strip any extension so it doesn't upset that
+	sort.Slice(rotatedPaths, func (i, j int) bool {
+		return strings.TrimSuffix(rotatedPaths[i], FILE_LOG_WRITER_COMPRESSED_EXTENSION) >
+			strings.TrimSuffix(rotatedPaths[j], FILE_LOG_WRITER_COMPRESSED_EXTENSION)
+	})
+	return rotatedPaths, nil
+}
+
+func (r *FileLogWriter) flusher(flushInterval time.Duration, stop chan bool) {
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+			case <-stop: return
+			case <-ticker.C: r.reportError(r.Flush())
+		}
+	}
+}
+
+func (r *FileLogWriter) signalListener(signals chan os.Signal, stop chan bool) {
+	for {
+		select {
+			case <-stop: return
+			case <-signals: r.reportError(r.Reopen())
+		}
+	}
+}
+
+func (r *FileLogWriter) reportError(err error) {
+	if nil == err { return }
+	fmt.Fprintf(os.Stderr, "FileLogWriter(%s): %s\n", r.path, err.Error())
+}
+
+// gzip the file at path to path.gz, then remove the original
+func compressFile(path string) error {
+	source, err := os.Open(path)
+	if nil != err { return err }
+	defer source.Close()
+	info, err := source.Stat()
+	if nil != err { return err }
+
+	// Write to a temporary file first so that a partial .gz is never mistaken for a finished one
+	compressedPath := path + FILE_LOG_WRITER_COMPRESSED_EXTENSION
+	temporaryPath := compressedPath + ".tmp"
+	target, err := os.OpenFile(temporaryPath, os.O_WRONLY | os.O_CREATE | os.O_TRUNC, 0644)
+	if nil != err { return err }
+	compressor := gzip.NewWriter(target)
+	compressor.Name = filepath.Base(path)
+	compressor.ModTime = info.ModTime()
+	_, err = io.Copy(compressor, source)
+	if nil == err { err = compressor.Close() }
+	if closeErr := target.Close(); nil == err { err = closeErr }
+	if nil == err { err = os.Chtimes(temporaryPath, info.ModTime(), info.ModTime()) }
+	if nil == err { err = os.Rename(temporaryPath, compressedPath) }
+	if nil != err {
+		os.Remove(temporaryPath)
+		return err
+	}
+	source.Close()
+	return os.Remove(path)
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return nil == err
+}
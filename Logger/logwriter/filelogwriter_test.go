@@ -0,0 +1,341 @@
+package logwriter
+
+import(
+	"io"
+	"os"
+	"time"
+	"strings"
+	"syscall"
+	"testing"
+	"path/filepath"
+	"compress/gzip"
+
+	. "github.com/DigiStratum/GoLib/Testing"
+)
+
+func newFileLogWriterForTest(t *testing.T) (*FileLogWriter, string) {
+	path := filepath.Join(t.TempDir(), "test.log")
+	sut, err := NewFileLogWriter(path)
+	if ! ExpectNoError(err, t) { t.FailNow() }
+	t.Cleanup(func () { sut.Close() })
+	return sut, path
+}
+
+func readFileForTest(path string) string {
+	content, _ := os.ReadFile(path)
+	return string(content)
+}
+
+func readCompressedFileForTest(path string) string {
+	file, err := os.Open(path)
+	if nil != err { return "" }
+	defer file.Close()
+	reader, err := gzip.NewReader(file)
+	if nil != err { return "" }
+	content, _ := io.ReadAll(reader)
+	return string(content)
+}
+
+func TestThat_NewFileLogWriter_ReturnsLogWriter(t *testing.T) {
+	// Test
+	sut, _ := newFileLogWriterForTest(t)
+
+	// Verify
+	var fileLogWriter FileLogWriterIfc = sut // <- ensures that we satisfy our interface
+	var logWriter LogWriterIfc = sut
+	ExpectNonNil(fileLogWriter, t)
+	ExpectNonNil(logWriter, t)
+}
+
+func TestThat_NewFileLogWriter_ReturnsError_WhenDirectoryIsMissing(t *testing.T) {
+	// Test
+	sut, err := NewFileLogWriter(filepath.Join(t.TempDir(), "missing", "test.log"))
+
+	// Verify
+	ExpectError(err, t)
+	ExpectNil(sut, t)
+}
+
+func TestThat_FileLogWriter_Log_Buffers_until_Flush(t *testing.T) {
+	// Setup
+	sut, path := newFileLogWriterForTest(t)
+
+	// Test
+	sut.Log("%s %d", "line", 1)
+	beforeFlush := readFileForTest(path)
+	err := sut.Flush()
+
+	// Verify
+	ExpectEmptyString(beforeFlush, t)
+	ExpectNoError(err, t)
+	ExpectString("line 1\n", readFileForTest(path), t)
+}
+
+func TestThat_FileLogWriter_Log_Writes_immediately_with_no_buffer(t *testing.T) {
+	// Setup
+	sut, path := newFileLogWriterForTest(t)
+	sut.SetBufferSize(0)
+
+	// Test
+	sut.Log("now")
+
+	// Verify
+	ExpectString("now\n", readFileForTest(path), t)
+}
+
+func TestThat_FileLogWriter_Log_Appends_to_existing_file(t *testing.T) {
+	// Setup
+	path := filepath.Join(t.TempDir(), "test.log")
+	os.WriteFile(path, []byte("before\n"), 0644)
+	sut, err := NewFileLogWriter(path)
+	if ! ExpectNoError(err, t) { return }
+
+	// Test
+	sut.Log("after")
+	err = sut.Close()
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectString("before\nafter\n", readFileForTest(path), t)
+}
+
+func TestThat_FileLogWriter_SetFlushInterval_Flushes_in_background(t *testing.T) {
+	// Setup
+	sut, path := newFileLogWriterForTest(t)
+	sut.SetFlushInterval(10 * time.Millisecond)
+
+	// Test
+	sut.Log("eventually")
+	deadline := time.Now().Add(2 * time.Second)
+	for (0 == len(readFileForTest(path))) && time.Now().Before(deadline) { time.Sleep(5 * time.Millisecond) }
+
+	// Verify
+	ExpectString("eventually\n", readFileForTest(path), t)
+}
+
+func TestThat_FileLogWriter_SetMaxSize_Rotates_before_exceeding_size(t *testing.T) {
+	// Setup
+	sut, path := newFileLogWriterForTest(t)
+	sut.SetMaxSize(10)
+
+	// Test
+	sut.Log("aaaa")		// 5 bytes
+	sut.Log("bbbb")		// 10 bytes
+	sut.Log("cccc")		// Would be 15 bytes; rotate first
+	sut.Close()
+	rotatedPaths, err := sut.getRotatedPaths()
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectString("cccc\n", readFileForTest(path), t)
+	if ExpectInt(1, len(rotatedPaths), t) { ExpectString("aaaa\nbbbb\n", readFileForTest(rotatedPaths[0]), t) }
+}
+
+func TestThat_FileLogWriter_SetRotateInterval_Rotates_at_time_boundary(t *testing.T) {
+	// Setup
+	sut, path := newFileLogWriterForTest(t)
+	now := time.Date(2024, 1, 1, 23, 59, 0, 0, time.UTC)
+	sut.now = func () time.Time { return now }
+	sut.SetRotateInterval(24 * time.Hour)
+
+	// Test
+	sut.Log("yesterday")
+	now = now.Add(2 * time.Minute)
+	sut.Log("today")
+	sut.Close()
+	rotatedPaths, _ := sut.getRotatedPaths()
+
+	// Verify
+	ExpectString("today\n", readFileForTest(path), t)
+	if ExpectInt(1, len(rotatedPaths), t) {
+		ExpectString(path + ".2024-01-02T00-01-00.000", rotatedPaths[0], t)
+		ExpectString("yesterday\n", readFileForTest(rotatedPaths[0]), t)
+	}
+}
+
+func TestThat_FileLogWriter_SetRotateInterval_Rotates_leftover_file_on_first_write(t *testing.T) {
+	// Setup
+	path := filepath.Join(t.TempDir(), "test.log")
+	os.WriteFile(path, []byte("old\n"), 0644)
+	old := time.Now().Add(-48 * time.Hour)
+	os.Chtimes(path, old, old)
+	sut, err := NewFileLogWriter(path)
+	if ! ExpectNoError(err, t) { return }
+	sut.SetRotateInterval(24 * time.Hour)
+
+	// Test
+	sut.Log("new")
+	sut.Close()
+	rotatedPaths, _ := sut.getRotatedPaths()
+
+	// Verify
+	ExpectString("new\n", readFileForTest(path), t)
+	if ExpectInt(1, len(rotatedPaths), t) { ExpectString("old\n", readFileForTest(rotatedPaths[0]), t) }
+}
+
+func TestThat_FileLogWriter_SetCompress_Compresses_rotated_files(t *testing.T) {
+	// Setup
+	sut, path := newFileLogWriterForTest(t)
+	sut.SetCompress(true)
+	sut.Log("compress me")
+
+	// Test
+	err := sut.Rotate()
+	sut.Close()
+	rotatedPaths, _ := sut.getRotatedPaths()
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectEmptyString(readFileForTest(path), t)
+	if ExpectInt(1, len(rotatedPaths), t) {
+		ExpectTrue(strings.HasSuffix(rotatedPaths[0], FILE_LOG_WRITER_COMPRESSED_EXTENSION), t)
+		ExpectString("compress me\n", readCompressedFileForTest(rotatedPaths[0]), t)
+	}
+}
+
+func TestThat_FileLogWriter_SetMaxFiles_Keeps_newest_rotated_files(t *testing.T) {
+	// Setup
+	sut, _ := newFileLogWriterForTest(t)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	sut.now = func () time.Time { return now }
+	sut.SetMaxFiles(2).SetCompress(true)
+
+	// Test
+	for _, line := range []string{ "one", "two", "three", "four" } {
+		sut.Log("%s", line)
+		sut.Rotate()
+		now = now.Add(time.Second)
+	}
+	sut.Close()
+	rotatedPaths, _ := sut.getRotatedPaths()
+
+	// Verify
+	if ExpectInt(2, len(rotatedPaths), t) {
+		ExpectString("four\n", readCompressedFileForTest(rotatedPaths[0]), t)
+		ExpectString("three\n", readCompressedFileForTest(rotatedPaths[1]), t)
+	}
+}
+
+func TestThat_FileLogWriter_SetMaxAge_Removes_old_rotated_files(t *testing.T) {
+	// Setup
+	sut, path := newFileLogWriterForTest(t)
+	stale := path + ".2020-01-01T00-00-00.000.gz"
+	unrelated := path + ".backup"
+	os.WriteFile(stale, []byte("stale"), 0644)
+	os.WriteFile(unrelated, []byte("keep"), 0644)
+	old := time.Now().Add(-72 * time.Hour)
+	os.Chtimes(stale, old, old)
+	os.Chtimes(unrelated, old, old)
+	sut.SetMaxAge(48 * time.Hour)
+	sut.Log("fresh")
+
+	// Test
+	sut.Rotate()
+	sut.Close()
+	rotatedPaths, _ := sut.getRotatedPaths()
+
+	// Verify
+	ExpectFalse(fileExists(stale), t)
+	ExpectTrue(fileExists(unrelated), t)
+	if ExpectInt(1, len(rotatedPaths), t) { ExpectString("fresh\n", readFileForTest(rotatedPaths[0]), t) }
+}
+
+func TestThat_FileLogWriter_ReopenOnSIGHUP_Reopens_moved_file(t *testing.T) {
+	// Setup
+	sut, path := newFileLogWriterForTest(t)
+	sut.SetBufferSize(0).ReopenOnSIGHUP()
+	sut.Log("before")
+	os.Rename(path, path + ".moved")
+
+	// Test
+	sut.signals <- syscall.SIGHUP
+	deadline := time.Now().Add(2 * time.Second)
+	for ! fileExists(path) && time.Now().Before(deadline) { time.Sleep(5 * time.Millisecond) }
+	sut.Log("after")
+
+	// Verify
+	ExpectString("before\n", readFileForTest(path + ".moved"), t)
+	ExpectString("after\n", readFileForTest(path), t)
+}
+
+func TestThat_FileLogWriter_Log_Reopens_after_rotation_fails_in_read_only_directory(t *testing.T) {
+	if 0 == os.Geteuid() { t.Skip("Directory permissions don't stop root") }
+
+	// Setup
+	sut, path := newFileLogWriterForTest(t)
+	now := time.Now()
+	sut.now = func () time.Time { return now }
+	sut.SetBufferSize(0).SetMaxSize(10)
+	sut.Log("aaaa")
+	sut.Log("bbbb")
+	// With the file gone and the directory read-only, rotation can rename nothing and create nothing
+	os.Remove(path)
+	dir := filepath.Dir(path)
+	os.Chmod(dir, 0555)
+	t.Cleanup(func () { os.Chmod(dir, 0755) })
+
+	// Test
+	sut.Log("cccc")				// Rotation fails to open a new file
+	os.Chmod(dir, 0755)
+	sut.Log("dddd")				// Still backing off
+	now = now.Add(FILE_LOG_WRITER_MIN_REOPEN_BACKOFF)
+	sut.Log("eeee")				// Reopens
+
+	// Verify
+	ExpectString("eeee\n", readFileForTest(path), t)
+}
+
+func TestThat_FileLogWriter_Log_Reopens_with_backoff_after_directory_returns(t *testing.T) {
+	// Setup
+	dir := filepath.Join(t.TempDir(), "logs")
+	os.Mkdir(dir, 0755)
+	path := filepath.Join(dir, "test.log")
+	sut, err := NewFileLogWriter(path)
+	if ! ExpectNoError(err, t) { return }
+	t.Cleanup(func () { sut.Close() })
+	now := time.Now()
+	sut.now = func () time.Time { return now }
+	sut.SetBufferSize(0).SetMaxSize(10)
+	sut.Log("aaaa")
+	sut.Log("bbbb")
+	os.RemoveAll(dir)
+
+	// Test
+	sut.Log("cccc")				// Rotation fails to open a new file
+	os.Mkdir(dir, 0755)
+	sut.Log("dddd")				// Still backing off
+	now = now.Add(FILE_LOG_WRITER_MIN_REOPEN_BACKOFF)
+	sut.Log("eeee")				// Reopens
+	reopened := readFileForTest(path)
+	os.RemoveAll(dir)
+	sut.Rotate()				// Fails to open a new file
+	now = now.Add(FILE_LOG_WRITER_MIN_REOPEN_BACKOFF)
+	sut.Log("ffff")				// Fails again, so backs off for twice as long
+	os.Mkdir(dir, 0755)
+	now = now.Add(FILE_LOG_WRITER_MIN_REOPEN_BACKOFF)
+	sut.Log("gggg")				// Still backing off
+	now = now.Add(FILE_LOG_WRITER_MIN_REOPEN_BACKOFF)
+	sut.Log("hhhh")				// Reopens
+
+	// Verify
+	ExpectString("eeee\n", reopened, t)
+	ExpectString("hhhh\n", readFileForTest(path), t)
+}
+
+func TestThat_FileLogWriter_Close_Is_idempotent_and_stops_logging(t *testing.T) {
+	// Setup
+	sut, path := newFileLogWriterForTest(t)
+	sut.SetFlushInterval(time.Millisecond).ReopenOnSIGHUP()
+	sut.Log("kept")
+
+	// Test
+	err1 := sut.Close()
+	err2 := sut.Close()
+	sut.Log("dropped")
+
+	// Verify
+	ExpectNoError(err1, t)
+	ExpectNoError(err2, t)
+	ExpectError(sut.Rotate(), t)
+	ExpectString("kept\n", readFileForTest(path), t)
+}
@@ -0,0 +1,317 @@
+package logwriter
+
+/*
+
+Network LogWriter
+
+Sends log lines over a network connection (TCP, UDP, or Unix socket), e.g. to a log collector that
+accepts newline-delimited JSON; pair it with a Logger using a JsonLogFormatter:
+
+	networkLogWriter := logwriter.NewNetworkLogWriter("tcp", "collector.internal:5170")
+	defer networkLogWriter.Close(5 * time.Second)
+	logger.GetLogger().SetLogFormatter(logger.NewJsonLogFormatter()).SetLogWriter(networkLogWriter)
+
+Lines are buffered locally and sent by a background goroutine, so logging never waits on the network.
+If the connection can't be made, or breaks, the goroutine reconnects with exponential backoff while
+lines wait in the buffer; once it is full, the oldest are dropped (and counted) to make room.
+
+Each line is framed according to our NetworkFraming: newline-delimited by default for stream sockets
+(tcp, unix), and as-is, one per datagram, for datagram sockets (udp, unixgram). Octet counting
+(RFC 6587) is there for syslog over TCP.
+
+Delivery is at most once: a line written just as a connection breaks may be lost without any error.
+
+*/
+
+import (
+	"net"
+	"fmt"
+	"sync"
+	"time"
+	"strings"
+)
+
+type NetworkFraming int
+
+const (
+	NETWORK_FRAMING_NEWLINE NetworkFraming = iota	// Each line followed by "\n"
+	NETWORK_FRAMING_OCTET_COUNTING			// Each line preceded by its length in bytes and a space
+	NETWORK_FRAMING_NONE				// Each line as it is, e.g. one per datagram
+)
+
+const NETWORK_LOG_WRITER_DEFAULT_BUFFER_SIZE = 10000
+const NETWORK_LOG_WRITER_DEFAULT_MIN_BACKOFF = 100 * time.Millisecond
+const NETWORK_LOG_WRITER_DEFAULT_MAX_BACKOFF = 30 * time.Second
+const NETWORK_LOG_WRITER_DEFAULT_TIMEOUT = 5 * time.Second
+
+type NetworkLogWriterIfc interface {
+	SetFraming(framing NetworkFraming) *NetworkLogWriter
+	SetBufferSize(bufferSize int) *NetworkLogWriter
+	SetBackoff(minBackoff, maxBackoff time.Duration) *NetworkLogWriter
+	SetTimeout(timeout time.Duration) *NetworkLogWriter
+	GetNetwork() string
+	GetAddress() string
+	IsConnected() bool
+	GetDroppedCount() uint64
+	GetBufferedCount() int
+	Log(format string, a ...interface{})
+	Close(timeout time.Duration) error
+}
+
+type NetworkLogWriter struct {
+	mutex		sync.Mutex
+	wake		*sync.Cond		// Signalled when there's something to send, or we're closing
+	network		string
+	address		string
+	framing		NetworkFraming
+	buffer		[]string		// Lines waiting to be sent, oldest first
+	bufferSize	int
+	dropped		uint64
+	minBackoff	time.Duration
+	maxBackoff	time.Duration
+	timeout		time.Duration		// For each dial and each write
+	connected	bool
+	closing		bool			// No more lines accepted; send what we have
+	abandoned	bool			// Close() gave up waiting; stop sending
+	abort		chan bool		// Closed when abandoned, to cut short any backoff
+	done		chan bool		// Closed when the sender has finished
+}
+
+// -------------------------------------------------------------------------------------------------
+// Factory Functions
+// -------------------------------------------------------------------------------------------------
+
+// Make a new LogWriter sending to address over network ("tcp", "udp", "unix", "unixgram", etc; see net.Dial())
+func NewNetworkLogWriter(network, address string) *NetworkLogWriter {
+	r := NetworkLogWriter{
+		network:	network,
+		address:	address,
+		framing:	NETWORK_FRAMING_NEWLINE,
+		buffer:		make([]string, 0),
+		bufferSize:	NETWORK_LOG_WRITER_DEFAULT_BUFFER_SIZE,
+		minBackoff:	NETWORK_LOG_WRITER_DEFAULT_MIN_BACKOFF,
+		maxBackoff:	NETWORK_LOG_WRITER_DEFAULT_MAX_BACKOFF,
+		timeout:	NETWORK_LOG_WRITER_DEFAULT_TIMEOUT,
+		abort:		make(chan bool),
+		done:		make(chan bool),
+	}
+	if isDatagramNetwork(network) { r.framing = NETWORK_FRAMING_NONE }
+	r.wake = sync.NewCond(&r.mutex)
+	go r.sender()
+	return &r
+}
+
+// -------------------------------------------------------------------------------------------------
+// NetworkLogWriterIfc Public Interface
+// -------------------------------------------------------------------------------------------------
+
+func (r *NetworkLogWriter) SetFraming(framing NetworkFraming) *NetworkLogWriter {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.framing = framing
+	return r
+}
+
+// Buffer up to bufferSize lines while we can't send them (default 10000)
+func (r *NetworkLogWriter) SetBufferSize(bufferSize int) *NetworkLogWriter {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if bufferSize < 1 { bufferSize = 1 }
+	r.bufferSize = bufferSize
+	r.dropOverflow()
+	return r
+}
+
+// Wait minBackoff after the first failure to connect or send, doubling with each failure up to maxBackoff
+func (r *NetworkLogWriter) SetBackoff(minBackoff, maxBackoff time.Duration) *NetworkLogWriter {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if minBackoff <= 0 { minBackoff = NETWORK_LOG_WRITER_DEFAULT_MIN_BACKOFF }
+	if maxBackoff < minBackoff { maxBackoff = minBackoff }
+	r.minBackoff = minBackoff
+	r.maxBackoff = maxBackoff
+	return r
+}
+
+// Give up on each attempt to connect, or to send a line, after timeout (default 5s)
+func (r *NetworkLogWriter) SetTimeout(timeout time.Duration) *NetworkLogWriter {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if timeout <= 0 { timeout = NETWORK_LOG_WRITER_DEFAULT_TIMEOUT }
+	r.timeout = timeout
+	return r
+}
+
+func (r *NetworkLogWriter) GetNetwork() string {
+	return r.network
+}
+
+func (r *NetworkLogWriter) GetAddress() string {
+	return r.address
+}
+
+func (r *NetworkLogWriter) IsConnected() bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.connected
+}
+
+// How many lines have been dropped, whether to overflow or to Close()
+func (r *NetworkLogWriter) GetDroppedCount() uint64 {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.dropped
+}
+
+// How many lines are waiting to be sent
+func (r *NetworkLogWriter) GetBufferedCount() int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return len(r.buffer)
+}
+
+// Stop accepting lines and wait up to timeout for those buffered to be sent; error if they weren't
+func (r *NetworkLogWriter) Close(timeout time.Duration) error {
+	r.mutex.Lock()
+	r.closing = true
+	r.wake.Broadcast()
+	r.mutex.Unlock()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+		case <-r.done: return nil
+		case <-timer.C:
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if ! r.abandoned {
+		r.abandoned = true
+		close(r.abort)
+	}
+	unsent := len(r.buffer)
+	r.dropped += uint64(unsent)
+	r.buffer = make([]string, 0)
+	return fmt.Errorf("NetworkLogWriter.Close(): Deadline passed with %d lines not sent to %s", unsent, r.address)
+}
+
+// -------------------------------------------------------------------------------------------------
+// LogWriterIfc Public Interface
+// -------------------------------------------------------------------------------------------------
+
+func (r *NetworkLogWriter) Log(format string, a ...interface{}) {
+	line := fmt.Sprintf(format, a...)
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.closing {
+		r.dropped++
+		return
+	}
+	r.buffer = append(r.buffer, line)
+	r.dropOverflow()
+	r.wake.Signal()
+}
+
+// -------------------------------------------------------------------------------------------------
+// NetworkLogWriter Implementation
+// -------------------------------------------------------------------------------------------------
+
+// Drop the oldest lines beyond our buffer size; caller must hold the mutex
+func (r *NetworkLogWriter) dropOverflow() {
+	if len(r.buffer) <= r.bufferSize { return }
+	overflow := len(r.buffer) - r.bufferSize
+	r.dropped += uint64(overflow)
+	r.buffer = append(make([]string, 0, r.bufferSize), r.buffer[overflow:]...)
+}
+
+// Take the oldest line, waiting for one if there's none; false once closed and drained
+func (r *NetworkLogWriter) take() (string, NetworkFraming, time.Duration, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for (0 == len(r.buffer)) && ! r.closing { r.wake.Wait() }
+	if (0 == len(r.buffer)) || r.abandoned { return "", r.framing, r.timeout, false }
+	line := r.buffer[0]
+	r.buffer = r.buffer[1:]
+	return line, r.framing, r.timeout, true
+}
+
+// Put a line that we failed to send back at the front of the buffer, if there's room for it
+func (r *NetworkLogWriter) putBack(line string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.abandoned || (len(r.buffer) >= r.bufferSize) {
+		r.dropped++
+		return
+	}
+	r.buffer = append([]string{ line }, r.buffer...)
+}
+
+func (r *NetworkLogWriter) setConnected(connected bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.connected = connected
+}
+
+// Wait for the current backoff, unless we're abandoned; return the next backoff
+func (r *NetworkLogWriter) backOff(backoff time.Duration) time.Duration {
+	r.mutex.Lock()
+	minBackoff, maxBackoff := r.minBackoff, r.maxBackoff
+	r.mutex.Unlock()
+	if backoff < minBackoff { backoff = minBackoff }
+	if backoff > maxBackoff { backoff = maxBackoff }
+	timer := time.NewTimer(backoff)
+	defer timer.Stop()
+	select {
+		case <-r.abort:
+		case <-timer.C:
+	}
+	return backoff * 2
+}
+
+func (r *NetworkLogWriter) sender() {
+	defer close(r.done)
+	var conn net.Conn
+	defer func () {
+		if nil != conn { conn.Close() }
+		r.setConnected(false)
+	}()
+	var backoff time.Duration
+	for {
+		line, framing, timeout, ok := r.take()
+		if ! ok { return }
+		if nil == conn {
+			var err error
+			conn, err = net.DialTimeout(r.network, r.address, timeout)
+			if nil != err {
+				conn = nil
+				r.putBack(line)
+				backoff = r.backOff(backoff)
+				continue
+			}
+			r.setConnected(true)
+		}
+		conn.SetWriteDeadline(time.Now().Add(timeout))
+		if _, err := conn.Write(frameLine(line, framing)); nil != err {
+			conn.Close()
+			conn = nil
+			r.setConnected(false)
+			r.putBack(line)
+			backoff = r.backOff(backoff)
+			continue
+		}
+		backoff = 0
+	}
+}
+
+func frameLine(line string, framing NetworkFraming) []byte {
+	switch framing {
+		case NETWORK_FRAMING_NEWLINE: return []byte(line + "\n")
+		case NETWORK_FRAMING_OCTET_COUNTING: return []byte(fmt.Sprintf("%d %s", len(line), line))
+	}
+	return []byte(line)
+}
+
+func isDatagramNetwork(network string) bool {
+	return strings.HasPrefix(network, "udp") || ("unixgram" == network)
+}
@@ -0,0 +1,153 @@
+package logwriter
+
+import(
+	"os"
+	"net"
+	"time"
+	"bufio"
+	"testing"
+	"path/filepath"
+
+	. "github.com/DigiStratum/GoLib/Testing"
+)
+
+// Accept one connection on listener and send each line read from it to lines
+func acceptLinesForTest(listener net.Listener, lines chan string) {
+	conn, err := listener.Accept()
+	if nil != err { return }
+	defer conn.Close()
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() { lines <- scanner.Text() }
+}
+
+func expectLineForTest(expected string, lines chan string, t *testing.T) bool {
+	select {
+		case actual := <-lines: return ExpectString(expected, actual, t)
+		case <-time.After(3 * time.Second): t.Errorf("Timed out waiting for '%s'", expected)
+	}
+	return false
+}
+
+func TestThat_NewNetworkLogWriter_ReturnsLogWriter(t *testing.T) {
+	// Test
+	sut := NewNetworkLogWriter("tcp", "127.0.0.1:1")
+	defer sut.Close(0)
+
+	// Verify
+	var networkLogWriter NetworkLogWriterIfc = sut // <- ensures that we satisfy our interface
+	var logWriter LogWriterIfc = sut
+	ExpectNonNil(networkLogWriter, t)
+	ExpectNonNil(logWriter, t)
+	ExpectString("tcp", sut.GetNetwork(), t)
+	ExpectString("127.0.0.1:1", sut.GetAddress(), t)
+}
+
+func TestThat_NetworkLogWriter_Log_Sends_newline_delimited_lines_over_tcp(t *testing.T) {
+	// Setup
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if ! ExpectNoError(err, t) { return }
+	defer listener.Close()
+	lines := make(chan string, 10)
+	go acceptLinesForTest(listener, lines)
+	sut := NewNetworkLogWriter("tcp", listener.Addr().String())
+
+	// Test
+	sut.Log("%s", `{"message":"one"}`)
+	sut.Log("%s", `{"message":"two"}`)
+
+	// Verify
+	expectLineForTest(`{"message":"one"}`, lines, t)
+	expectLineForTest(`{"message":"two"}`, lines, t)
+	ExpectTrue(sut.IsConnected(), t)
+	ExpectNoError(sut.Close(time.Second), t)
+	ExpectFalse(sut.IsConnected(), t)
+}
+
+func TestThat_NetworkLogWriter_Log_Sends_datagrams_over_udp(t *testing.T) {
+	// Setup
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if ! ExpectNoError(err, t) { return }
+	defer conn.Close()
+	sut := NewNetworkLogWriter("udp", conn.LocalAddr().String())
+	defer sut.Close(time.Second)
+	buf := make([]byte, 1024)
+
+	// Test
+	sut.Log("one datagram")
+	conn.SetReadDeadline(time.Now().Add(3 * time.Second))
+	n, _, err := conn.ReadFrom(buf)
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectString("one datagram", string(buf[:n]), t)
+}
+
+func TestThat_NetworkLogWriter_Log_Sends_octet_counted_lines_over_unix_socket(t *testing.T) {
+	// Setup; unix socket paths must be short, so not in t.TempDir()
+	dir, err := os.MkdirTemp("", "nlw")
+	if ! ExpectNoError(err, t) { return }
+	defer os.RemoveAll(dir)
+	listener, err := net.Listen("unix", filepath.Join(dir, "s"))
+	if ! ExpectNoError(err, t) { return }
+	defer listener.Close()
+	received := make(chan string, 1)
+	go func () {
+		conn, err := listener.Accept()
+		if nil != err { return }
+		defer conn.Close()
+		buf := make([]byte, 9)
+		n, _ := conn.Read(buf)
+		received <- string(buf[:n])
+	}()
+	sut := NewNetworkLogWriter("unix", listener.Addr().String()).SetFraming(NETWORK_FRAMING_OCTET_COUNTING)
+	defer sut.Close(time.Second)
+
+	// Test
+	sut.Log("hello!")
+
+	// Verify
+	expectLineForTest("6 hello!", received, t)
+}
+
+func TestThat_NetworkLogWriter_Reconnects_and_sends_buffered_lines(t *testing.T) {
+	// Setup: find a free port, then leave nothing listening there for now
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if ! ExpectNoError(err, t) { return }
+	address := listener.Addr().String()
+	listener.Close()
+	sut := NewNetworkLogWriter("tcp", address).SetBackoff(5 * time.Millisecond, 20 * time.Millisecond)
+	defer sut.Close(time.Second)
+
+	// Test
+	sut.Log("buffered")
+	time.Sleep(50 * time.Millisecond)
+	disconnected := ! sut.IsConnected()
+	listener, err = net.Listen("tcp", address)
+	if ! ExpectNoError(err, t) { return }
+	defer listener.Close()
+	lines := make(chan string, 10)
+	go acceptLinesForTest(listener, lines)
+
+	// Verify
+	ExpectTrue(disconnected, t)
+	expectLineForTest("buffered", lines, t)
+}
+
+func TestThat_NetworkLogWriter_Log_Drops_oldest_when_buffer_is_full(t *testing.T) {
+	// Setup
+	sut := NewNetworkLogWriter("tcp", "127.0.0.1:1").SetBackoff(time.Hour, time.Hour).SetBufferSize(2)
+
+	// Test
+	sut.Log("1")
+	sut.Log("2")
+	sut.Log("3")
+	sut.Log("4")
+	time.Sleep(20 * time.Millisecond) // <- Whichever line is in flight goes back in the buffer
+	bufferedCount := sut.GetBufferedCount()
+	err := sut.Close(20 * time.Millisecond)
+
+	// Verify
+	ExpectInt(2, bufferedCount, t)
+	ExpectError(err, t)
+	ExpectTrue(4 == sut.GetDroppedCount(), t) // <- 2 to overflow, 2 unsent at Close()
+}
@@ -0,0 +1,155 @@
+package logger
+
+/*
+
+A MemoryLogWriter keeps everything logged to it in memory, as LogRecords, for unit tests to examine
+rather than swapping out os.Stdout. With the Testing helpers ExpectLogged() and ExpectNotLogged():
+
+	func TestThat_Thing_DoesSomething_LogsWarning(t *testing.T) {
+		// Setup
+		logWriter := logger.CaptureLogger(t)	// GetLogger() writes here until the test is over
+		sut := NewThing()
+
+		// Test
+		sut.DoSomething()
+
+		// Verify
+		ExpectLogged(logWriter, logger.WARN, `Connection to \S+ refused`, t)
+		ExpectNotLogged(logWriter, logger.ERROR, ".", t)
+	}
+
+Patterns are regular expressions matched against each record's text (as the TextLogFormatter
+renders it, without the timestamp and streamId: prefix, message, then key=value fields). Plain lines
+logged with Log() have no LogLevel, so they are kept apart; see GetLines().
+
+CaptureLogger() changes the GetLogger() singleton, so tests using it must not run in parallel.
+
+*/
+
+import (
+	"fmt"
+	"sync"
+	"regexp"
+)
+
+type MemoryLogWriterIfc interface {
+	GetRecords() []LogRecord
+	GetLines() []string
+	GetLogged() []string
+	MatchLogged(level LogLevel, pattern string) ([]string, error)
+	Reset() *MemoryLogWriter
+	Log(format string, a ...interface{})
+	LogRecord(record *LogRecord)
+}
+
+type MemoryLogWriter struct {
+	mutex		sync.Mutex
+	records		[]LogRecord
+	lines		[]string
+}
+
+// Anything that can schedule cleanup at the end of a test, such as *testing.T
+type LogCleanupIfc interface {
+	Cleanup(cleanup func ())
+}
+
+// -------------------------------------------------------------------------------------------------
+// Factory Functions
+// -------------------------------------------------------------------------------------------------
+
+func NewMemoryLogWriter() *MemoryLogWriter {
+	return &MemoryLogWriter{
+		records:	make([]LogRecord, 0),
+		lines:		make([]string, 0),
+	}
+}
+
+// Send the GetLogger() singleton's output to a new MemoryLogWriter until the test is cleaned up
+func CaptureLogger(tb LogCleanupIfc) *MemoryLogWriter {
+	memoryLogWriter := NewMemoryLogWriter()
+	restore := GetLogger().SwapLogWriter(memoryLogWriter)
+	if nil != tb { tb.Cleanup(restore) }
+	return memoryLogWriter
+}
+
+// -------------------------------------------------------------------------------------------------
+// MemoryLogWriterIfc Public Interface
+// -------------------------------------------------------------------------------------------------
+
+// Get copies of the LogRecords logged so far, oldest first
+func (r *MemoryLogWriter) GetRecords() []LogRecord {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]LogRecord{}, r.records...)
+}
+
+// Get the plain lines logged so far (with Log(), rather than as LogRecords), oldest first
+func (r *MemoryLogWriter) GetLines() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]string{}, r.lines...)
+}
+
+// Get the text of every LogRecord logged so far, each with its level, for test failure output, etc
+func (r *MemoryLogWriter) GetLogged() []string {
+	logged := make([]string, 0)
+	for _, record := range r.GetRecords() {
+		logged = append(logged, fmt.Sprintf("%5s %s", record.Level.ToString(), getMemoryLogText(&record)))
+	}
+	return logged
+}
+
+// Get the text of each LogRecord at level that matches the regular expression pattern
+func (r *MemoryLogWriter) MatchLogged(level LogLevel, pattern string) ([]string, error) {
+	expression, err := regexp.Compile(pattern)
+	if nil != err { return nil, fmt.Errorf("MemoryLogWriter.MatchLogged(): %s", err.Error()) }
+	matches := make([]string, 0)
+	for _, record := range r.GetRecords() {
+		if record.Level != level { continue }
+		text := getMemoryLogText(&record)
+		if expression.MatchString(text) { matches = append(matches, text) }
+	}
+	return matches, nil
+}
+
+// Forget everything logged so far
+func (r *MemoryLogWriter) Reset() *MemoryLogWriter {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.records = make([]LogRecord, 0)
+	r.lines = make([]string, 0)
+	return r
+}
+
+// -------------------------------------------------------------------------------------------------
+// LogWriterIfc Public Interface
+// -------------------------------------------------------------------------------------------------
+
+func (r *MemoryLogWriter) Log(format string, a ...interface{}) {
+	line := fmt.Sprintf(format, a...)
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.lines = append(r.lines, line)
+}
+
+// -------------------------------------------------------------------------------------------------
+// LogRecordWriterIfc Public Interface
+// -------------------------------------------------------------------------------------------------
+
+func (r *MemoryLogWriter) LogRecord(record *LogRecord) {
+	if nil == record { return }
+	copied := *record
+	copied.Fields = append([]LogField{}, record.Fields...)
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.records = append(r.records, copied)
+}
+
+// -------------------------------------------------------------------------------------------------
+// MemoryLogWriter Implementation
+// -------------------------------------------------------------------------------------------------
+
+// Prefix, message, then key=value fields
+func getMemoryLogText(record *LogRecord) string {
+	return record.Prefix + record.Message + formatTextLogFields(record.Fields)
+}
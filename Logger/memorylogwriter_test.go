@@ -0,0 +1,119 @@
+package logger
+
+import(
+	"testing"
+
+	lw "github.com/DigiStratum/GoLib/Logger/logwriter"
+
+	. "github.com/DigiStratum/GoLib/Testing"
+)
+
+func TestThat_MemoryLogWriter_NewMemoryLogWriter_ReturnsSomething(t *testing.T) {
+	// Test
+	var sut MemoryLogWriterIfc = NewMemoryLogWriter() // <- ensures that we satisfy our interface
+	var logWriter lw.LogWriterIfc = NewMemoryLogWriter()
+	var recordWriter LogRecordWriterIfc = NewMemoryLogWriter()
+
+	// Verify
+	ExpectNonNil(sut, t)
+	ExpectNonNil(logWriter, t)
+	ExpectNonNil(recordWriter, t)
+}
+
+func TestThat_MemoryLogWriter_Keeps_records_and_lines(t *testing.T) {
+	// Setup
+	sut := NewMemoryLogWriter()
+	logger := NewLogger("1").SetLogWriter(sut).GetNewPrefixedLogger("[p] ")
+
+	// Test
+	logger.Info("hello %s", "world")
+	logger.With("k", "v v").Warn("careful")
+	sut.Log("plain %d", 1)
+
+	// Verify
+	records := sut.GetRecords()
+	if ExpectInt(2, len(records), t) {
+		ExpectTrue(INFO == records[0].Level, t)
+		ExpectString("hello world", records[0].Message, t)
+		ExpectInt(1, len(records[1].Fields), t)
+	}
+	ExpectString("plain 1", sut.GetLines()[0], t)
+	logged := sut.GetLogged()
+	if ExpectInt(2, len(logged), t) {
+		ExpectString(" INFO [p] hello world", logged[0], t)
+		ExpectString(" WARN [p] careful k=\"v v\"", logged[1], t)
+	}
+}
+
+func TestThat_MemoryLogWriter_MatchLogged_Matches_level_and_pattern(t *testing.T) {
+	// Setup
+	sut := NewMemoryLogWriter()
+	logger := NewLogger("1").SetLogWriter(sut)
+	logger.Warn("Connection to %s refused", "db1")
+	logger.Error("Giving up")
+
+	// Test
+	warnMatches, err := sut.MatchLogged(WARN, `Connection to \S+ refused`)
+	errorMatches, _ := sut.MatchLogged(ERROR, `Connection`)
+	_, badPatternErr := sut.MatchLogged(WARN, `(`)
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectInt(1, len(warnMatches), t)
+	ExpectInt(0, len(errorMatches), t)
+	ExpectError(badPatternErr, t)
+	ExpectLogged(sut, WARN, `refused$`, t)
+	ExpectLogged(sut, ERROR, `^Giving up$`, t)
+	ExpectNotLogged(sut, INFO, `.`, t)
+	ExpectNotLogged(sut, ERROR, `refused`, t)
+}
+
+func TestThat_MemoryLogWriter_Reset_Forgets_everything(t *testing.T) {
+	// Setup
+	sut := NewMemoryLogWriter()
+	sut.LogRecord(&LogRecord{ Level: INFO, Message: "x" })
+	sut.Log("y")
+
+	// Test
+	sut.Reset()
+
+	// Verify
+	ExpectInt(0, len(sut.GetRecords()), t)
+	ExpectInt(0, len(sut.GetLines()), t)
+}
+
+func TestThat_CaptureLogger_Captures_singleton_output_until_cleanup(t *testing.T) {
+	// Setup
+	original := GetLogger().logWriter
+	originalMinLogLevel := GetLogger().minLogLevel
+	defer GetLogger().SetMinLogLevel(originalMinLogLevel)
+	GetLogger().SetMinLogLevel(INFO)
+	var sut *MemoryLogWriter
+
+	// Test
+	t.Run("captured", func (t *testing.T) {
+		sut = CaptureLogger(t)
+		GetLogger().Error("captured %d", 1)
+		ExpectLogged(sut, ERROR, "captured 1", t)
+	})
+
+	// Verify
+	ExpectTrue(original == GetLogger().logWriter, t)
+	ExpectInt(1, len(sut.GetRecords()), t)
+}
+
+func TestThat_Logger_SwapLogWriter_Returns_restore_func(t *testing.T) {
+	// Setup
+	logger := NewLogger("1")
+	original := logger.logWriter
+	memoryLogWriter := NewMemoryLogWriter()
+
+	// Test
+	restore := logger.SwapLogWriter(memoryLogWriter)
+	swapped := logger.logWriter
+	restore()
+
+	// Verify
+	ExpectTrue(swapped == memoryLogWriter, t)
+	ExpectTrue(original == logger.logWriter, t)
+}
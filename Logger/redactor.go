@@ -0,0 +1,26 @@
+package logger
+
+/*
+
+Our Redactor (see GoLib/Redactor) is applied to every message that a Logger produces. It moved out
+of here so that Config could add secrets to it without depending on Logger; these reach it as before.
+
+*/
+
+import (
+	"github.com/DigiStratum/GoLib/Redactor"
+)
+
+const REDACTED = redactor.REDACTED
+const REDACTOR_MIN_SECRET_LENGTH = redactor.REDACTOR_MIN_SECRET_LENGTH
+
+type RedactorIfc = redactor.RedactorIfc
+
+// Get the Redactor singleton
+func GetRedactor() RedactorIfc {
+	return redactor.GetRedactor()
+}
+
+func NewRedactor() RedactorIfc {
+	return redactor.NewRedactor()
+}
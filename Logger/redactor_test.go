@@ -0,0 +1,35 @@
+package logger
+
+import(
+	"strings"
+	"testing"
+
+	. "github.com/DigiStratum/GoLib/Testing"
+)
+
+func TestThat_Logger_Any_RedactsSecrets_FromLogOutput(t *testing.T) {
+	// Setup
+	var sut *Logger = NewLogger("redacted").SetLogWriter(mockLogWriter{}).LogTimestamp(false)
+	GetRedactor().AddSecret("s3cr3t-for-logger-test")
+
+	// Test
+	err := sut.Error("password is '%s'", "s3cr3t-for-logger-test")
+
+	// Verify
+	ExpectString("thread:redacted ERROR password is '" + REDACTED + "'", LastMessage, t)
+	ExpectString("ERROR password is '" + REDACTED + "'", err.Error(), t)
+}
+
+func TestThat_StructuredLogger_RedactsSecrets_AddedAfterItsFields(t *testing.T) {
+	// Setup
+	sut := NewLogger("redacted").SetLogWriter(mockLogWriter{}).LogTimestamp(false).
+		With("token", "t0k3n-for-logger-test")
+	GetRedactor().AddSecret("t0k3n-for-logger-test")
+
+	// Test
+	err := sut.Error("denied")
+
+	// Verify
+	ExpectFalse(strings.Contains(LastMessage, "t0k3n-for-logger-test"), t)
+	ExpectTrue(strings.Contains(err.Error(), REDACTED), t)
+}
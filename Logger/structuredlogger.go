@@ -0,0 +1,118 @@
+package logger
+
+/*
+
+A StructuredLogger logs a plain message along with structured key/value fields instead of formatting
+everything into one string. Get one from any Logger with With(), adding fields that go with every
+message it logs; each message may add more of its own:
+
+	log := logger.GetLogger().With("request_id", requestId)
+	log.Info("Fetched user", "user_id", userId, "ms", elapsed.Milliseconds())
+
+Fields are key, value, key, value... (see NewLogFields()). The message is NOT a format string, so it
+is logged exactly as given; use the underlying Logger's methods for printf style logging.
+
+A StructuredLogger shares the Logger it came from, so that Logger's level, LogWriter and LogFormatter
+changes apply to it as well. Use SetLogFormatter(NewJsonLogFormatter()) on the Logger to get output
+that log pipelines can ingest without parsing.
+
+*/
+
+import (
+	"context"
+)
+
+type StructuredLoggerIfc interface {
+	With(keysAndValues ...interface{}) *StructuredLogger
+	WithContext(ctx context.Context) *StructuredLogger
+	GetFields() []LogField
+	Any(level LogLevel, message string, keysAndValues ...interface{}) error
+	Crazy(message string, keysAndValues ...interface{}) error
+	Trace(message string, keysAndValues ...interface{}) error
+	Debug(message string, keysAndValues ...interface{}) error
+	Info(message string, keysAndValues ...interface{}) error
+	Warn(message string, keysAndValues ...interface{}) error
+	Error(message string, keysAndValues ...interface{}) error
+	Fatal(message string, keysAndValues ...interface{}) error
+}
+
+type StructuredLogger struct {
+	logger		*Logger
+	fields		[]LogField
+}
+
+// -------------------------------------------------------------------------------------------------
+// Factory Functions
+// -------------------------------------------------------------------------------------------------
+
+// Make a new StructuredLogger which logs through logger with fields from keysAndValues
+func NewStructuredLogger(logger *Logger, keysAndValues ...interface{}) *StructuredLogger {
+	if nil == logger { logger = GetLogger() }
+	return &StructuredLogger{
+		logger:		logger,
+		fields:		NewLogFields(keysAndValues...),
+	}
+}
+
+// -------------------------------------------------------------------------------------------------
+// StructuredLoggerIfc Public Interface
+// -------------------------------------------------------------------------------------------------
+
+// Get a new StructuredLogger with our fields and more from keysAndValues
+func (r *StructuredLogger) With(keysAndValues ...interface{}) *StructuredLogger {
+	fields := NewLogFields(keysAndValues...)
+	withLogger := StructuredLogger{
+		logger:		r.logger,
+		fields:		make([]LogField, 0, len(r.fields) + len(fields)),
+	}
+	withLogger.fields = append(append(withLogger.fields, r.fields...), fields...)
+	return &withLogger
+}
+
+// Get a copy of the fields that go with every message we log
+func (r *StructuredLogger) GetFields() []LogField {
+	return append([]LogField{}, r.fields...)
+}
+
+// Log message with our fields and those from keysAndValues; return a matching error for
+// WARN|ERROR|FATAL, else nil
+func (r *StructuredLogger) Any(level LogLevel, message string, keysAndValues ...interface{}) error {
+	fields := r.fields
+	if len(keysAndValues) > 0 { fields = append(r.GetFields(), NewLogFields(keysAndValues...)...) }
+	return r.logger.log(level, message, message, fields)
+}
+
+// Log CRAZY output
+func (r *StructuredLogger) Crazy(message string, keysAndValues ...interface{}) error {
+	return r.Any(CRAZY, message, keysAndValues...)
+}
+
+// Log TRACE output
+func (r *StructuredLogger) Trace(message string, keysAndValues ...interface{}) error {
+	return r.Any(TRACE, message, keysAndValues...)
+}
+
+// Log DEBUG output
+func (r *StructuredLogger) Debug(message string, keysAndValues ...interface{}) error {
+	return r.Any(DEBUG, message, keysAndValues...)
+}
+
+// Log INFO output
+func (r *StructuredLogger) Info(message string, keysAndValues ...interface{}) error {
+	return r.Any(INFO, message, keysAndValues...)
+}
+
+// Log WARN output
+func (r *StructuredLogger) Warn(message string, keysAndValues ...interface{}) error {
+	return r.Any(WARN, message, keysAndValues...)
+}
+
+// Log ERROR output
+func (r *StructuredLogger) Error(message string, keysAndValues ...interface{}) error {
+	return r.Any(ERROR, message, keysAndValues...)
+}
+
+// Log FATAL output (caller should exit/panic after this)
+func (r *StructuredLogger) Fatal(message string, keysAndValues ...interface{}) error {
+	return r.Any(FATAL, message, keysAndValues...)
+}
@@ -0,0 +1,88 @@
+package logger
+
+import(
+	"testing"
+	gojson "encoding/json"
+
+	. "github.com/DigiStratum/GoLib/Testing"
+)
+
+func newStructuredLoggerTestLogger() *Logger {
+	LastMessage = ""
+	return NewLogger("7").SetLogWriter(mockLogWriter{}).LogTimestamp(false)
+}
+
+func TestThat_Logger_With_Returns_StructuredLogger(t *testing.T) {
+	// Setup
+	logger := newStructuredLoggerTestLogger()
+
+	// Test
+	var sut StructuredLoggerIfc = logger.With("a", 1) // <- ensures that we satisfy our interface
+
+	// Verify
+	ExpectNonNil(sut, t)
+	ExpectInt(1, len(sut.GetFields()), t)
+}
+
+func TestThat_StructuredLogger_Info_Logs_message_with_fields(t *testing.T) {
+	// Setup
+	sut := newStructuredLoggerTestLogger().With("request_id", "r1")
+
+	// Test
+	err := sut.Info("Fetched %s", "user_id", 5)
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectString("thread:7  INFO Fetched %s request_id=r1 user_id=5", LastMessage, t)
+}
+
+func TestThat_StructuredLogger_With_Does_not_change_original(t *testing.T) {
+	// Setup
+	original := newStructuredLoggerTestLogger().With("a", 1)
+
+	// Test
+	sut := original.With("b", 2)
+	original.Info("x", "c", 3)
+	originalMessage := LastMessage
+	sut.Info("y")
+
+	// Verify
+	ExpectString("thread:7  INFO x a=1 c=3", originalMessage, t)
+	ExpectString("thread:7  INFO y a=1 b=2", LastMessage, t)
+	ExpectInt(1, len(original.GetFields()), t)
+}
+
+func TestThat_StructuredLogger_Any_Respects_min_log_level_and_returns_errors(t *testing.T) {
+	// Setup
+	logger := newStructuredLoggerTestLogger().SetMinLogLevel(ERROR)
+	sut := logger.With("a", 1)
+
+	// Test
+	warnErr := sut.Warn("careful", "b", 2)
+	warnMessage := LastMessage
+	debugErr := sut.Debug("detail")
+
+	// Verify
+	ExpectEmptyString(warnMessage, t)
+	if ExpectError(warnErr, t) { ExpectString(" WARN careful a=1 b=2", warnErr.Error(), t) }
+	ExpectNoError(debugErr, t)
+}
+
+func TestThat_StructuredLogger_Follows_Logger_formatter_changes(t *testing.T) {
+	// Setup
+	logger := newStructuredLoggerTestLogger()
+	sut := logger.GetNewPrefixedLogger("[svc] ").With("a", "x y")
+
+	// Test
+	sut.Error("failed", "n", 3)
+	textMessage := LastMessage
+	logger.SetLogFormatter(NewJsonLogFormatter())
+	sut = logger.GetNewPrefixedLogger("[svc] ").With("a", "x y")
+	sut.Error("failed", "n", 3)
+
+	// Verify
+	ExpectString("thread:7 ERROR [svc] failed a=\"x y\" n=3", textMessage, t)
+	ExpectString(`{"level":"ERROR","streamId":"7","prefix":"[svc] ","message":"failed","fields":{"a":"x y","n":3}}`, LastMessage, t)
+	var decoded map[string]interface{}
+	ExpectNoError(gojson.Unmarshal([]byte(LastMessage), &decoded), t)
+}
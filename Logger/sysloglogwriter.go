@@ -0,0 +1,251 @@
+package logger
+
+/*
+
+A SyslogLogWriter sends LogRecords to a syslog server as RFC 5424 messages over UDP, TCP or a Unix
+socket, by way of a NetworkLogWriter (so it buffers, and reconnects with backoff, just the same):
+
+	syslogLogWriter := logger.NewSyslogLogWriter("udp", "localhost:514").
+		SetFacility(logger.SYSLOG_FACILITY_LOCAL0).
+		SetAppName("myapp")
+	defer syslogLogWriter.Close(5 * time.Second)
+	logger.GetLogger().SetLogWriter(syslogLogWriter)
+
+Messages look like this, with the LogLevel mapped to a syslog severity (see
+LogLevelToSyslogSeverity()), the streamId and any fields as structured data, and the prefixed
+message as the MSG:
+
+	<134>1 2024-01-02T03:04:05.000000Z host1 myapp 4242 - [fields@32473 streamId="42" k="v"] [db] Connected
+
+Datagram sockets (udp, unixgram) carry one message per datagram; stream sockets (tcp, unix) use octet
+counting framing (RFC 6587), which is what rsyslog, syslog-ng, etc expect on TCP. The structured data
+ID uses the example Private Enterprise Number from RFC 5612; set your own if you have one.
+
+Plain lines logged with Log() have no LogLevel, so they go as SYSLOG_SEVERITY_INFORMATIONAL.
+
+*/
+
+import (
+	"os"
+	"fmt"
+	"time"
+	"strings"
+	"path/filepath"
+
+	lw "github.com/DigiStratum/GoLib/Logger/logwriter"
+)
+
+// Syslog severities (RFC 5424 section 6.2.1)
+const (
+	SYSLOG_SEVERITY_EMERGENCY = iota
+	SYSLOG_SEVERITY_ALERT
+	SYSLOG_SEVERITY_CRITICAL
+	SYSLOG_SEVERITY_ERROR
+	SYSLOG_SEVERITY_WARNING
+	SYSLOG_SEVERITY_NOTICE
+	SYSLOG_SEVERITY_INFORMATIONAL
+	SYSLOG_SEVERITY_DEBUG
+)
+
+// Syslog facilities (RFC 5424 section 6.2.1), those most likely to be of use to us anyway
+const (
+	SYSLOG_FACILITY_USER = 1
+	SYSLOG_FACILITY_DAEMON = 3
+	SYSLOG_FACILITY_LOCAL0 = 16
+	SYSLOG_FACILITY_LOCAL1 = 17
+	SYSLOG_FACILITY_LOCAL2 = 18
+	SYSLOG_FACILITY_LOCAL3 = 19
+	SYSLOG_FACILITY_LOCAL4 = 20
+	SYSLOG_FACILITY_LOCAL5 = 21
+	SYSLOG_FACILITY_LOCAL6 = 22
+	SYSLOG_FACILITY_LOCAL7 = 23
+)
+
+const SYSLOG_NILVALUE = "-"
+const SYSLOG_DEFAULT_STRUCTURED_DATA_ID = "fields@32473"
+const SYSLOG_TIMESTAMP_LAYOUT = "2006-01-02T15:04:05.000000Z07:00"
+
+type SyslogLogWriterIfc interface {
+	SetFacility(facility int) *SyslogLogWriter
+	SetHostname(hostname string) *SyslogLogWriter
+	SetAppName(appName string) *SyslogLogWriter
+	SetStructuredDataId(structuredDataId string) *SyslogLogWriter
+	GetNetworkLogWriter() *lw.NetworkLogWriter
+	Log(format string, a ...interface{})
+	LogRecord(record *LogRecord)
+	Close(timeout time.Duration) error
+}
+
+type SyslogLogWriter struct {
+	networkLogWriter	*lw.NetworkLogWriter
+	facility		int
+	hostname		string
+	appName			string
+	procId			string
+	structuredDataId	string
+}
+
+// -------------------------------------------------------------------------------------------------
+// Factory Functions
+// -------------------------------------------------------------------------------------------------
+
+// Make a new LogWriter sending to the syslog server at address over network ("udp", "tcp", "unix", "unixgram")
+func NewSyslogLogWriter(network, address string) *SyslogLogWriter {
+	networkLogWriter := lw.NewNetworkLogWriter(network, address)
+	if ("tcp" == network) || ("tcp4" == network) || ("tcp6" == network) || ("unix" == network) {
+		networkLogWriter.SetFraming(lw.NETWORK_FRAMING_OCTET_COUNTING)
+	}
+	hostname, _ := os.Hostname()
+	return &SyslogLogWriter{
+		networkLogWriter:	networkLogWriter,
+		facility:		SYSLOG_FACILITY_USER,
+		hostname:		toSyslogHeaderValue(hostname, 255),
+		appName:		toSyslogHeaderValue(filepath.Base(os.Args[0]), 48),
+		procId:			fmt.Sprintf("%d", os.Getpid()),
+		structuredDataId:	SYSLOG_DEFAULT_STRUCTURED_DATA_ID,
+	}
+}
+
+// The syslog severity for a LogLevel
+func LogLevelToSyslogSeverity(level LogLevel) int {
+	switch level {
+		case CRAZY, TRACE, DEBUG: return SYSLOG_SEVERITY_DEBUG
+		case INFO: return SYSLOG_SEVERITY_INFORMATIONAL
+		case WARN: return SYSLOG_SEVERITY_WARNING
+		case ERROR: return SYSLOG_SEVERITY_ERROR
+		case FATAL: return SYSLOG_SEVERITY_CRITICAL
+	}
+	return SYSLOG_SEVERITY_NOTICE
+}
+
+// -------------------------------------------------------------------------------------------------
+// SyslogLogWriterIfc Public Interface
+// -------------------------------------------------------------------------------------------------
+
+// Set the facility, 0-23 (default SYSLOG_FACILITY_USER)
+func (r *SyslogLogWriter) SetFacility(facility int) *SyslogLogWriter {
+	if (facility >= 0) && (facility <= 23) { r.facility = facility }
+	return r
+}
+
+// Set the HOSTNAME (default from the OS)
+func (r *SyslogLogWriter) SetHostname(hostname string) *SyslogLogWriter {
+	r.hostname = toSyslogHeaderValue(hostname, 255)
+	return r
+}
+
+// Set the APP-NAME (default is the name of our executable)
+func (r *SyslogLogWriter) SetAppName(appName string) *SyslogLogWriter {
+	r.appName = toSyslogHeaderValue(appName, 48)
+	return r
+}
+
+// Set the SD-ID for the structured data holding streamId and fields (default "fields@32473")
+func (r *SyslogLogWriter) SetStructuredDataId(structuredDataId string) *SyslogLogWriter {
+	structuredDataId = toSyslogName(structuredDataId)
+	if SYSLOG_NILVALUE != structuredDataId { r.structuredDataId = structuredDataId }
+	return r
+}
+
+// Get our NetworkLogWriter, e.g. to change its buffer size or backoff
+func (r *SyslogLogWriter) GetNetworkLogWriter() *lw.NetworkLogWriter {
+	return r.networkLogWriter
+}
+
+// Stop accepting messages and wait up to timeout for those buffered to be sent; error if they weren't
+func (r *SyslogLogWriter) Close(timeout time.Duration) error {
+	return r.networkLogWriter.Close(timeout)
+}
+
+// -------------------------------------------------------------------------------------------------
+// LogWriterIfc Public Interface
+// -------------------------------------------------------------------------------------------------
+
+func (r *SyslogLogWriter) Log(format string, a ...interface{}) {
+	r.networkLogWriter.Log("%s", r.formatMessage(
+		SYSLOG_SEVERITY_INFORMATIONAL,
+		time.Now(),
+		SYSLOG_NILVALUE,
+		fmt.Sprintf(format, a...),
+	))
+}
+
+// -------------------------------------------------------------------------------------------------
+// LogRecordWriterIfc Public Interface
+// -------------------------------------------------------------------------------------------------
+
+func (r *SyslogLogWriter) LogRecord(record *LogRecord) {
+	if nil == record { return }
+	// Syslog wants a timestamp, even if the Logger doesn't
+	timestamp := record.Timestamp
+	if timestamp.IsZero() { timestamp = time.Now() }
+	r.networkLogWriter.Log("%s", r.formatMessage(
+		LogLevelToSyslogSeverity(record.Level),
+		timestamp,
+		r.formatStructuredData(record),
+		record.Prefix + record.Message,
+	))
+}
+
+// -------------------------------------------------------------------------------------------------
+// SyslogLogWriter Implementation
+// -------------------------------------------------------------------------------------------------
+
+// <PRI>VERSION TIMESTAMP HOSTNAME APP-NAME PROCID MSGID STRUCTURED-DATA MSG
+func (r *SyslogLogWriter) formatMessage(severity int, timestamp time.Time, structuredData, message string) string {
+	return fmt.Sprintf(
+		"<%d>1 %s %s %s %s %s %s %s",
+		r.facility * 8 + severity,
+		timestamp.UTC().Format(SYSLOG_TIMESTAMP_LAYOUT),
+		r.hostname,
+		r.appName,
+		r.procId,
+		SYSLOG_NILVALUE,
+		structuredData,
+		message,
+	)
+}
+
+// [SD-ID streamId="..." key="value"...]
+func (r *SyslogLogWriter) formatStructuredData(record *LogRecord) string {
+	var sb strings.Builder
+	sb.WriteString("[")
+	sb.WriteString(r.structuredDataId)
+	if len(record.StreamId) > 0 {
+		sb.WriteString(fmt.Sprintf(" streamId=\"%s\"", escapeSyslogParamValue(record.StreamId)))
+	}
+	for _, field := range uniqueLogFields(record.Fields) {
+		value := "null"
+		if nil != field.Value { value = fmt.Sprintf("%v", field.Value) }
+		sb.WriteString(fmt.Sprintf(" %s=\"%s\"", toSyslogName(field.Key), escapeSyslogParamValue(value)))
+	}
+	sb.WriteString("]")
+	if sb.Len() == len(r.structuredDataId) + 2 { return SYSLOG_NILVALUE }
+	return sb.String()
+}
+
+// Printable US-ASCII with no spaces, at most maxLength, or the NILVALUE if that leaves nothing
+func toSyslogHeaderValue(value string, maxLength int) string {
+	var sb strings.Builder
+	for _, c := range value {
+		if sb.Len() >= maxLength { break }
+		if (c > 32) && (c < 127) {
+			sb.WriteRune(c)
+			continue
+		}
+		sb.WriteRune('_')
+	}
+	if 0 == sb.Len() { return SYSLOG_NILVALUE }
+	return sb.String()
+}
+
+// An SD-NAME: a header value of at most 32 characters without '=', ']' or '"'
+func toSyslogName(name string) string {
+	name = strings.NewReplacer("=", "_", "]", "_", "\"", "_").Replace(name)
+	return toSyslogHeaderValue(name, 32)
+}
+
+// PARAM-VALUEs must escape '"', '\' and ']'
+func escapeSyslogParamValue(value string) string {
+	return strings.NewReplacer("\\", "\\\\", "\"", "\\\"", "]", "\\]").Replace(value)
+}
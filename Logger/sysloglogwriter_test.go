@@ -0,0 +1,132 @@
+package logger
+
+import(
+	"io"
+	"net"
+	"time"
+	"bufio"
+	"strconv"
+	"strings"
+	"testing"
+
+	lw "github.com/DigiStratum/GoLib/Logger/logwriter"
+
+	. "github.com/DigiStratum/GoLib/Testing"
+)
+
+func newSyslogLogWriterForTest(network, address string) *SyslogLogWriter {
+	sut := NewSyslogLogWriter(network, address).SetHostname("host1").SetAppName("my app")
+	sut.procId = "4242"
+	return sut
+}
+
+func TestThat_SyslogLogWriter_NewSyslogLogWriter_ReturnsSomething(t *testing.T) {
+	// Test
+	var sut SyslogLogWriterIfc = NewSyslogLogWriter("udp", "127.0.0.1:1") // <- ensures that we satisfy our interface
+	var logWriter lw.LogWriterIfc = sut.(*SyslogLogWriter)
+	var recordWriter LogRecordWriterIfc = sut.(*SyslogLogWriter)
+	defer sut.Close(0)
+
+	// Verify
+	ExpectNonNil(logWriter, t)
+	ExpectNonNil(recordWriter, t)
+	ExpectString("udp", sut.GetNetworkLogWriter().GetNetwork(), t)
+}
+
+func TestThat_LogLevelToSyslogSeverity_Maps_levels(t *testing.T) {
+	// Verify
+	ExpectInt(SYSLOG_SEVERITY_DEBUG, LogLevelToSyslogSeverity(CRAZY), t)
+	ExpectInt(SYSLOG_SEVERITY_DEBUG, LogLevelToSyslogSeverity(TRACE), t)
+	ExpectInt(SYSLOG_SEVERITY_DEBUG, LogLevelToSyslogSeverity(DEBUG), t)
+	ExpectInt(SYSLOG_SEVERITY_INFORMATIONAL, LogLevelToSyslogSeverity(INFO), t)
+	ExpectInt(SYSLOG_SEVERITY_WARNING, LogLevelToSyslogSeverity(WARN), t)
+	ExpectInt(SYSLOG_SEVERITY_ERROR, LogLevelToSyslogSeverity(ERROR), t)
+	ExpectInt(SYSLOG_SEVERITY_CRITICAL, LogLevelToSyslogSeverity(FATAL), t)
+}
+
+func TestThat_SyslogLogWriter_LogRecord_Sends_RFC5424_datagram_over_udp(t *testing.T) {
+	// Setup
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if ! ExpectNoError(err, t) { return }
+	defer conn.Close()
+	sut := newSyslogLogWriterForTest("udp", conn.LocalAddr().String()).SetFacility(SYSLOG_FACILITY_LOCAL0)
+	defer sut.Close(time.Second)
+	buf := make([]byte, 2048)
+
+	// Test
+	sut.LogRecord(&LogRecord{
+		Timestamp:	time.Date(2024, 1, 2, 3, 4, 5, 123456789, time.UTC),
+		Level:		WARN,
+		StreamId:	"42",
+		Prefix:		"[db] ",
+		Message:	"Slow query",
+		Fields:		NewLogFields("ms", 1500, "sql", `SELECT "x" ]`, "bad key=", nil),
+	})
+	conn.SetReadDeadline(time.Now().Add(3 * time.Second))
+	n, _, err := conn.ReadFrom(buf)
+
+	// Verify
+	ExpectNoError(err, t)
+	ExpectString(
+		`<132>1 2024-01-02T03:04:05.123456Z host1 my_app 4242 - [fields@32473 streamId="42" ms="1500" sql="SELECT \"x\" \]" bad_key_="null"] [db] Slow query`,
+		string(buf[:n]), t,
+	)
+}
+
+func TestThat_SyslogLogWriter_Sends_octet_counted_messages_over_tcp(t *testing.T) {
+	// Setup
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if ! ExpectNoError(err, t) { return }
+	defer listener.Close()
+	received := make(chan string, 1)
+	go func () {
+		conn, err := listener.Accept()
+		if nil != err { return }
+		defer conn.Close()
+		// MSG-LEN SP SYSLOG-MSG
+		reader := bufio.NewReader(conn)
+		length, err := reader.ReadString(' ')
+		if nil != err { return }
+		messageLength, err := strconv.Atoi(strings.TrimSpace(length))
+		if nil != err { return }
+		message := make([]byte, messageLength)
+		if _, err := io.ReadFull(reader, message); nil != err { return }
+		received <- string(message)
+	}()
+	sut := newSyslogLogWriterForTest("tcp", listener.Addr().String())
+	defer sut.Close(time.Second)
+
+	// Test
+	sut.Log("%s", "plain line")
+
+	// Verify
+	select {
+		case actual := <-received:
+			ExpectMatch(`^<14>1 \d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}\.\d{6}Z host1 my_app 4242 - - plain line$`, actual, t)
+		case <-time.After(3 * time.Second): t.Error("Timed out waiting for syslog message")
+	}
+}
+
+func TestThat_SyslogLogWriter_SetStructuredDataId_Replaces_SD_ID(t *testing.T) {
+	// Setup
+	sut := newSyslogLogWriterForTest("udp", "127.0.0.1:1").SetStructuredDataId("app@99999")
+	defer sut.Close(0)
+
+	// Test
+	actual := sut.formatStructuredData(&LogRecord{ Level: INFO, StreamId: "7" })
+
+	// Verify
+	ExpectString(`[app@99999 streamId="7"]`, actual, t)
+}
+
+func TestThat_SyslogLogWriter_LogRecord_Omits_empty_structured_data(t *testing.T) {
+	// Setup
+	sut := newSyslogLogWriterForTest("udp", "127.0.0.1:1")
+	defer sut.Close(0)
+
+	// Test
+	actual := sut.formatStructuredData(&LogRecord{ Level: INFO, Message: "x" })
+
+	// Verify
+	ExpectString(SYSLOG_NILVALUE, actual, t)
+}
@@ -7,10 +7,15 @@ Configurable, reusable client for making HTTP Requests
 REF:
  * https://pkg.go.dev/net/http
 
+ Configuration (all optional):
+ * maxBodyLenKb - integer, largest response body we will accept (default 10240)
+ * requestTimeout - duration, e.g. "60s", for the whole request; 0 = no timeout (default 60s)
+ * idleTimeout - duration for idle (keep-alive) connections to remain open (default 30s)
+ * disableCompression - boolean, true to disable transparent gzip (default false)
+
  TODO:
  * Can we use Go Routines to set up an async pool of sorts so that we can have multiple requests in
    flight?
- * Make Configurable to override defaults
 
 */
 
@@ -18,10 +23,10 @@ import (
 	"fmt"
 	"io"
 	gohttp "net/http"
-	"strconv"
 	"time"
 
-	cfg "github.com/DigiStratum/GoLib/Config"
+	"github.com/DigiStratum/GoLib/Data"
+	cfg "github.com/DigiStratum/GoLib/Data/config"
 	"github.com/DigiStratum/GoLib/Process/startable"
 	ver "github.com/DigiStratum/GoLib/Version"
 )
@@ -58,38 +63,27 @@ type HttpClient struct {
 func NewHttpClient() *HttpClient {
 	r := HttpClient{}
 
-	// TODO: This int/bool-as-string madness caused by Config only supporting string values; add multi-type support!
 	r.Configurable = cfg.NewConfigurable(
-		cfg.NewConfigItem("maxBodyLenKb").SetDefault("10240").CaptureWith(
-			func(value string) error {
-				v, err := strconv.Atoi(value)
-				if err != nil {
-					return err
-				}
-				r.maxBodyLenKb = v
-				return nil
-			},
-		),
-		cfg.NewConfigItem("requestTimeout").SetDefault("60s").CaptureWith(
-			func(value string) error {
-				var err error
-				r.requestTimeout, err = time.ParseDuration(value)
-				return err
-			},
-		),
-		cfg.NewConfigItem("idleTimeout").SetDefault("30s").CaptureWith(
-			func(value string) error {
-				var err error
-				r.idleTimeout, err = time.ParseDuration(value)
-				return err
-			},
-		),
-		cfg.NewConfigItem("disableCompression").SetDefault("false").CaptureWith(
-			func(value string) error {
-				r.disableCompression = (value == "true")
-				return nil
-			},
-		),
+		cfg.NewConfigItem("maxBodyLenKb").
+			SetType(cfg.CONFIG_ITEM_TYPE_INTEGER).
+			SetDescription("Largest response body we will accept, in KB").
+			SetDefault(data.NewInteger(10240)).
+			CaptureWith(cfg.CaptureInt(&r.maxBodyLenKb)),
+		cfg.NewConfigItem("requestTimeout").
+			SetType(cfg.CONFIG_ITEM_TYPE_DURATION).
+			SetDescription("Time limit for the whole request, including reading the response; 0 = no timeout").
+			SetDefault(data.NewString("60s")).
+			CaptureWith(cfg.CaptureDuration(&r.requestTimeout)),
+		cfg.NewConfigItem("idleTimeout").
+			SetType(cfg.CONFIG_ITEM_TYPE_DURATION).
+			SetDescription("How long an idle (keep-alive) connection remains open").
+			SetDefault(data.NewString("30s")).
+			CaptureWith(cfg.CaptureDuration(&r.idleTimeout)),
+		cfg.NewConfigItem("disableCompression").
+			SetType(cfg.CONFIG_ITEM_TYPE_BOOLEAN).
+			SetDescription("Disable transparent gzip compression of responses").
+			SetDefault(data.NewBoolean(false)).
+			CaptureWith(cfg.CaptureBoolean(&r.disableCompression)),
 	)
 
 	// Declare Starter funcs
@@ -9,8 +9,10 @@ TODO:
 
 import (
 	"testing"
+	"time"
 
-	cfg "github.com/DigiStratum/GoLib/Config"
+	"github.com/DigiStratum/GoLib/Data"
+	cfg "github.com/DigiStratum/GoLib/Data/config"
 
 	. "github.com/DigiStratum/GoLib/Testing"
 )
@@ -79,16 +81,78 @@ func TestThat_HttpClient_Config_SetsMaxBodyLenKb(t *testing.T) {
 	// Setup
 	sut := NewHttpClient()
 	c := cfg.NewConfig()
-	c.Set("maxBodyLenKb", "5120")
+	c.PrepareObject().SetObjectProperty("maxBodyLenKb", data.NewInteger(5120))
 
 	// Test
 	err := sut.Configure(c)
+	startErr := sut.Start()
 
 	// Verify
 	if !ExpectNoError(err, t) {
 		return
 	}
-	// Access private field through reflection or test behavior
+	if !ExpectNoError(startErr, t) {
+		return
+	}
+	if !ExpectInt(5120, sut.maxBodyLenKb, t) {
+		return
+	}
+}
+
+func TestThat_HttpClient_Config_AppliesDefaults_ForMissingItems(t *testing.T) {
+	// Setup
+	sut := NewHttpClient()
+
+	// Test
+	err := sut.Start()
+
+	// Verify
+	if !ExpectNoError(err, t) {
+		return
+	}
+	if !ExpectInt(10240, sut.maxBodyLenKb, t) {
+		return
+	}
+	if !ExpectTrue(60*time.Second == sut.requestTimeout, t) {
+		return
+	}
+	if !ExpectTrue(30*time.Second == sut.idleTimeout, t) {
+		return
+	}
+	if !ExpectFalse(sut.disableCompression, t) {
+		return
+	}
+}
+
+func TestThat_HttpClient_Config_AcceptsTypedValues(t *testing.T) {
+	// Setup
+	sut := NewHttpClient()
+	c := cfg.NewConfig()
+	c.PrepareObject().
+		SetObjectProperty("requestTimeout", data.NewInteger(5)).
+		SetObjectProperty("idleTimeout", data.NewString("1m")).
+		SetObjectProperty("disableCompression", data.NewBoolean(true))
+
+	// Test
+	err := sut.Configure(c)
+	startErr := sut.Start()
+
+	// Verify
+	if !ExpectNoError(err, t) {
+		return
+	}
+	if !ExpectNoError(startErr, t) {
+		return
+	}
+	if !ExpectTrue(5*time.Second == sut.requestTimeout, t) {
+		return
+	}
+	if !ExpectTrue(time.Minute == sut.idleTimeout, t) {
+		return
+	}
+	if !ExpectTrue(sut.disableCompression, t) {
+		return
+	}
 }
 
 func TestThat_HttpClient_Start_InitializesClient(t *testing.T) {
@@ -3,6 +3,7 @@ package http
 import (
 	"fmt"
 
+	"github.com/DigiStratum/GoLib/Data/serializable"
 	obj "github.com/DigiStratum/GoLib/Object"
 	ver "github.com/DigiStratum/GoLib/Version"
 )
@@ -107,6 +108,36 @@ func NewHttpResponseWithHeaders(status HttpStatus, body *string, headers HttpHea
 	return rb.GetHttpResponse()
 }
 
+// Produce an HTTP response with the value encoded by the Codec registered for the MIME type
+func NewHttpResponseEncoded(status HttpStatus, value interface{}, mimeType string) (*httpResponse, error) {
+	codec := serializable.GetCodecRegistry().Get(mimeType)
+	if nil == codec {
+		return nil, fmt.Errorf("NewHttpResponseEncoded(): no Codec registered for MIME type '%s'", mimeType)
+	}
+	body, err := serializable.SerializeAs(value, codec.GetMimeType())
+	if nil != err {
+		return nil, err
+	}
+	hdrs := NewHttpHeadersBuilder().
+		Set("content-type", codec.GetMimeType()).
+		GetHttpHeaders()
+	return NewHttpResponseBuilder().
+		SetStatus(status).
+		SetBinBody(&body).
+		SetHeaders(hdrs).
+		GetHttpResponse(), nil
+}
+
+// Produce an HTTP response with the value encoded by the best Codec for the request's Accept header
+// Returns an error if no registered Codec is acceptable; respond with STATUS_NOT_ACCEPTABLE then
+func NewHttpResponseNegotiated(status HttpStatus, value interface{}, accept string) (*httpResponse, error) {
+	codec := serializable.GetCodecRegistry().Negotiate(accept)
+	if nil == codec {
+		return nil, fmt.Errorf("NewHttpResponseNegotiated(): no Codec is acceptable for '%s'", accept)
+	}
+	return NewHttpResponseEncoded(status, value, codec.GetMimeType())
+}
+
 // Produce an HTTP redirect (TEMPORARY) response to the supplied URL
 func NewHttpResponseRedirect(URL string) *httpResponse {
 	hdrs := NewHttpHeadersBuilder().
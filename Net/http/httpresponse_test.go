@@ -405,3 +405,67 @@ func TestThat_HttpResponse_BuilderCreatesCompleteResponse(t *testing.T) {
 		return
 	}
 }
+
+// Factory Functions - Codec Responses
+
+func TestThat_HttpResponse_NewHttpResponseEncoded_EncodesWithRegisteredCodec(t *testing.T) {
+	// Setup
+	value := map[string]interface{}{"k": "v"}
+
+	// Test
+	sut, err := NewHttpResponseEncoded(STATUS_OK, value, "application/json; charset=utf-8")
+
+	// Verify
+	if !ExpectNoError(err, t) {
+		return
+	}
+	ExpectString("{\"k\":\"v\"}", *sut.GetBody(), t)
+	actual := sut.GetHeaders().Get("content-type")
+	if !ExpectNonNil(actual, t) {
+		return
+	}
+	ExpectString("application/json", (*actual)[0], t)
+}
+
+func TestThat_HttpResponse_NewHttpResponseEncoded_ReturnsError_ForUnknownMimeType(t *testing.T) {
+	// Test
+	sut, err := NewHttpResponseEncoded(STATUS_OK, "x", "text/html")
+
+	// Verify
+	ExpectError(err, t)
+	ExpectNil(sut, t)
+}
+
+func TestThat_HttpResponse_NewHttpResponseNegotiated_PicksAcceptableCodec(t *testing.T) {
+	// Setup
+	object := obj.NewObject()
+	content := "hello"
+	object.SetContent(&content)
+
+	// Test
+	sut, err := NewHttpResponseNegotiated(STATUS_OK, object, "text/html, application/cbor;q=0.8")
+
+	// Verify
+	if !ExpectNoError(err, t) {
+		return
+	}
+	actual := sut.GetHeaders().Get("content-type")
+	if !ExpectNonNil(actual, t) {
+		return
+	}
+	ExpectString("application/cbor", (*actual)[0], t)
+	restored := obj.NewObject()
+	if !ExpectNoError(restored.DeserializeAs("application/cbor", *sut.GetBinBody()), t) {
+		return
+	}
+	ExpectString(content, *restored.GetContent(), t)
+}
+
+func TestThat_HttpResponse_NewHttpResponseNegotiated_ReturnsError_WhenNothingAcceptable(t *testing.T) {
+	// Test
+	sut, err := NewHttpResponseNegotiated(STATUS_OK, "x", "text/html")
+
+	// Verify
+	ExpectError(err, t)
+	ExpectNil(sut, t)
+}
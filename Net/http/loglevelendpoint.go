@@ -0,0 +1,189 @@
+package http
+
+/*
+
+An admin endpoint to see and change log levels by hierarchical logger name (see
+Logger/loglevelregistry.go) at runtime, so that one subsystem can be made more verbose in production
+without a restart:
+
+	GET    /admin/loglevels                      -> {"":"INFO","db.mysql.pool":"DEBUG"}
+	GET    /admin/loglevels?name=db.mysql.pool    -> {"level":"DEBUG","name":"db.mysql.pool"}
+	POST   /admin/loglevels?name=db&level=DEBUG   (or PATCH) set some levels, leave the rest
+	PUT    /admin/loglevels                      replace all of the levels with those given
+	DELETE /admin/loglevels?name=db              clear one level (or, without a name, all of them)
+
+POST, PATCH and PUT take levels from the name and level query parameters or, failing that, from the
+body: a JSON object of names to levels, or a logger.ParseLogLevels() spec ("db=DEBUG, cache=WARN").
+Changes respond with all of the levels, as GET does. Every change is logged.
+
+There is no access control here; route to this endpoint only from behind the server's admin
+authentication.
+
+	endpoint := http.NewLogLevelEndpoint(nil) // The GetLogger() singleton's registry
+	response := endpoint.HandleRequest(request)
+
+*/
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/DigiStratum/GoLib/Logger"
+)
+
+type LogLevelEndpointIfc interface {
+	HandleRequest(request HttpRequestIfc) HttpResponseIfc
+}
+
+type LogLevelEndpoint struct {
+	registry *logger.LogLevelRegistry
+}
+
+// -------------------------------------------------------------------------------------------------
+// Factory Functions
+// -------------------------------------------------------------------------------------------------
+
+// Make a new endpoint for registry (nil for the GetLogger() singleton's)
+func NewLogLevelEndpoint(registry *logger.LogLevelRegistry) *LogLevelEndpoint {
+	if nil == registry {
+		registry = logger.GetLogLevelRegistry()
+	}
+	return &LogLevelEndpoint{
+		registry: registry,
+	}
+}
+
+// -------------------------------------------------------------------------------------------------
+// LogLevelEndpointIfc Public Interface
+// -------------------------------------------------------------------------------------------------
+
+func (r *LogLevelEndpoint) HandleRequest(request HttpRequestIfc) HttpResponseIfc {
+	if nil == request {
+		return newLogLevelErrorResponse(STATUS_BAD_REQUEST, "No request")
+	}
+	query, err := url.ParseQuery(request.GetQueryString())
+	if nil != err {
+		return newLogLevelErrorResponse(STATUS_BAD_REQUEST, err.Error())
+	}
+	_, hasName := query["name"]
+	name := query.Get("name")
+
+	switch request.GetMethod() {
+	case METHOD_GET, METHOD_HEAD:
+		if !hasName {
+			return r.newLevelsResponse()
+		}
+		level, ok := r.registry.GetLogLevel(name)
+		if !ok {
+			return newLogLevelErrorResponse(STATUS_NOT_FOUND, fmt.Sprintf("No log level for '%s'", name))
+		}
+		return newLogLevelJsonResponse(map[string]string{
+			"name":  logger.NormalizeLogName(name),
+			"level": level.ToString(),
+		})
+
+	case METHOD_POST, METHOD_PATCH, METHOD_PUT:
+		levels, err := getRequestLogLevels(query, request.GetBody())
+		if nil != err {
+			return newLogLevelErrorResponse(STATUS_BAD_REQUEST, err.Error())
+		}
+		if METHOD_PUT == request.GetMethod() {
+			r.registry.ReplaceLogLevels(levels)
+			logger.GetLogger().Info("LogLevelEndpoint: Log levels replaced: %s", formatLogLevels(levels))
+		} else {
+			r.registry.SetLogLevels(levels)
+			logger.GetLogger().Info("LogLevelEndpoint: Log levels set: %s", formatLogLevels(levels))
+		}
+		return r.newLevelsResponse()
+
+	case METHOD_DELETE:
+		if hasName {
+			r.registry.ClearLogLevel(name)
+			logger.GetLogger().Info("LogLevelEndpoint: Log level cleared for '%s'", logger.NormalizeLogName(name))
+		} else {
+			r.registry.ReplaceLogLevels(nil)
+			logger.GetLogger().Info("LogLevelEndpoint: All log levels cleared")
+		}
+		return r.newLevelsResponse()
+	}
+
+	return NewHttpResponseWithHeaders(
+		STATUS_METHOD_NOT_ALLOWED,
+		nil,
+		NewHttpHeadersBuilder().Set("allow", "GET, HEAD, POST, PATCH, PUT, DELETE").GetHttpHeaders(),
+	)
+}
+
+// -------------------------------------------------------------------------------------------------
+// LogLevelEndpoint Implementation
+// -------------------------------------------------------------------------------------------------
+
+func (r *LogLevelEndpoint) newLevelsResponse() HttpResponseIfc {
+	levels := make(map[string]string)
+	for name, level := range r.registry.GetLogLevels() {
+		levels[name] = level.ToString()
+	}
+	return newLogLevelJsonResponse(levels)
+}
+
+// Levels from the name and level query parameters, else from the body (JSON object or spec)
+func getRequestLogLevels(query url.Values, body *string) (map[string]logger.LogLevel, error) {
+	if _, ok := query["level"]; ok {
+		level, err := logger.StringToLogLevel(query.Get("level"))
+		if nil != err {
+			return nil, err
+		}
+		return map[string]logger.LogLevel{query.Get("name"): *level}, nil
+	}
+	if (nil == body) || (0 == len(strings.TrimSpace(*body))) {
+		return nil, fmt.Errorf("No log levels given")
+	}
+	if !strings.HasPrefix(strings.TrimSpace(*body), "{") {
+		return logger.ParseLogLevels(*body)
+	}
+	levelStrs := make(map[string]string)
+	if err := json.Unmarshal([]byte(*body), &levelStrs); nil != err {
+		return nil, fmt.Errorf("Expected a JSON object of names to levels: %s", err.Error())
+	}
+	levels := make(map[string]logger.LogLevel)
+	for name, levelStr := range levelStrs {
+		level, err := logger.StringToLogLevel(levelStr)
+		if nil != err {
+			return nil, fmt.Errorf("Log level for '%s': %s", name, err.Error())
+		}
+		levels[name] = *level
+	}
+	return levels, nil
+}
+
+// "db=DEBUG, cache=WARN", sorted by name
+func formatLogLevels(levels map[string]logger.LogLevel) string {
+	pairs := make([]string, 0, len(levels))
+	for name, level := range levels {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", logger.NormalizeLogName(name), level.ToString()))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ", ")
+}
+
+func newLogLevelJsonResponse(value map[string]string) HttpResponseIfc {
+	// Marshalling a map of strings cannot fail (and sorts the keys for us)
+	encoded, _ := json.Marshal(value)
+	body := string(encoded)
+	return NewHttpResponseWithHeaders(
+		STATUS_OK,
+		&body,
+		NewHttpHeadersBuilder().Set("content-type", "application/json").GetHttpHeaders(),
+	)
+}
+
+func newLogLevelErrorResponse(status HttpStatus, message string) HttpResponseIfc {
+	return NewHttpResponseWithHeaders(
+		status,
+		&message,
+		NewHttpHeadersBuilder().Set("content-type", "text/plain").GetHttpHeaders(),
+	)
+}
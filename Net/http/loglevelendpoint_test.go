@@ -0,0 +1,102 @@
+package http
+
+import (
+	"testing"
+
+	"github.com/DigiStratum/GoLib/Logger"
+
+	. "github.com/DigiStratum/GoLib/Testing"
+)
+
+func newLogLevelEndpointTestRequest(method HttpRequestMethod, url string, body string) HttpRequestIfc {
+	builder := NewHttpRequestBuilder(method, url)
+	if len(body) > 0 {
+		builder.SetBody(&body)
+	}
+	return builder.GetHttpRequest()
+}
+
+func TestThat_LogLevelEndpoint_NewLogLevelEndpoint_UsesSingletonRegistry_ForNil(t *testing.T) {
+	// Test
+	var sut LogLevelEndpointIfc = NewLogLevelEndpoint(nil) // <- ensures that we satisfy our interface
+
+	// Verify
+	ExpectNonNil(sut, t)
+	ExpectTrue(logger.GetLogLevelRegistry() == sut.(*LogLevelEndpoint).registry, t)
+}
+
+func TestThat_LogLevelEndpoint_HandleRequest_GetsLevels(t *testing.T) {
+	// Setup
+	registry := logger.NewLogLevelRegistry().SetLogLevel("db", logger.WARN).SetLogLevel("db.mysql.pool", logger.DEBUG)
+	sut := NewLogLevelEndpoint(registry)
+
+	// Test
+	all := sut.HandleRequest(newLogLevelEndpointTestRequest(METHOD_GET, "http://localhost/admin/loglevels", ""))
+	one := sut.HandleRequest(newLogLevelEndpointTestRequest(METHOD_GET, "http://localhost/admin/loglevels?name=db.cache", ""))
+	none := sut.HandleRequest(newLogLevelEndpointTestRequest(METHOD_GET, "http://localhost/admin/loglevels?name=cache", ""))
+
+	// Verify
+	ExpectTrue(STATUS_OK == all.GetStatus(), t)
+	ExpectString(`{"db":"WARN","db.mysql.pool":"DEBUG"}`, *all.GetBody(), t)
+	ExpectString(`{"level":"WARN","name":"db.cache"}`, *one.GetBody(), t)
+	ExpectTrue(STATUS_NOT_FOUND == none.GetStatus(), t)
+}
+
+func TestThat_LogLevelEndpoint_HandleRequest_SetsLevels_FromQueryAndBody(t *testing.T) {
+	// Setup
+	registry := logger.NewLogLevelRegistry().SetLogLevel("db", logger.WARN)
+	sut := NewLogLevelEndpoint(registry)
+
+	// Test
+	sut.HandleRequest(newLogLevelEndpointTestRequest(METHOD_POST, "http://localhost/admin/loglevels?name=db.mysql&level=debug", ""))
+	sut.HandleRequest(newLogLevelEndpointTestRequest(METHOD_PATCH, "http://localhost/admin/loglevels", `{"cache":"ERROR"}`))
+	response := sut.HandleRequest(newLogLevelEndpointTestRequest(METHOD_POST, "http://localhost/admin/loglevels", "auth=TRACE"))
+
+	// Verify
+	ExpectTrue(STATUS_OK == response.GetStatus(), t)
+	ExpectString(`{"auth":"TRACE","cache":"ERROR","db":"WARN","db.mysql":"DEBUG"}`, *response.GetBody(), t)
+}
+
+func TestThat_LogLevelEndpoint_HandleRequest_PutReplacesLevels(t *testing.T) {
+	// Setup
+	registry := logger.NewLogLevelRegistry().SetLogLevel("db", logger.WARN)
+	sut := NewLogLevelEndpoint(registry)
+
+	// Test
+	response := sut.HandleRequest(newLogLevelEndpointTestRequest(METHOD_PUT, "http://localhost/admin/loglevels", "cache=INFO"))
+
+	// Verify
+	ExpectString(`{"cache":"INFO"}`, *response.GetBody(), t)
+}
+
+func TestThat_LogLevelEndpoint_HandleRequest_DeleteClearsLevels(t *testing.T) {
+	// Setup
+	registry := logger.NewLogLevelRegistry().SetLogLevel("db", logger.WARN).SetLogLevel("cache", logger.INFO)
+	sut := NewLogLevelEndpoint(registry)
+
+	// Test
+	one := sut.HandleRequest(newLogLevelEndpointTestRequest(METHOD_DELETE, "http://localhost/admin/loglevels?name=DB", ""))
+	oneBody := *one.GetBody()
+	all := sut.HandleRequest(newLogLevelEndpointTestRequest(METHOD_DELETE, "http://localhost/admin/loglevels", ""))
+
+	// Verify
+	ExpectString(`{"cache":"INFO"}`, oneBody, t)
+	ExpectString(`{}`, *all.GetBody(), t)
+}
+
+func TestThat_LogLevelEndpoint_HandleRequest_ReturnsErrors_ForBadRequests(t *testing.T) {
+	// Setup
+	sut := NewLogLevelEndpoint(logger.NewLogLevelRegistry())
+
+	// Test
+	badLevel := sut.HandleRequest(newLogLevelEndpointTestRequest(METHOD_POST, "http://localhost/admin/loglevels?name=db&level=LOUD", ""))
+	noLevels := sut.HandleRequest(newLogLevelEndpointTestRequest(METHOD_POST, "http://localhost/admin/loglevels", ""))
+	badJson := sut.HandleRequest(newLogLevelEndpointTestRequest(METHOD_PUT, "http://localhost/admin/loglevels", `{"db":`))
+	badMethod := sut.HandleRequest(newLogLevelEndpointTestRequest(METHOD_OPTIONS, "http://localhost/admin/loglevels", ""))
+
+	// Verify
+	ExpectTrue(STATUS_BAD_REQUEST == badLevel.GetStatus(), t)
+	ExpectTrue(STATUS_BAD_REQUEST == noLevels.GetStatus(), t)
+	ExpectTrue(STATUS_BAD_REQUEST == badJson.GetStatus(), t)
+	ExpectTrue(STATUS_METHOD_NOT_ALLOWED == badMethod.GetStatus(), t)
+}
@@ -0,0 +1,40 @@
+package http
+
+/*
+
+Connects an HTTP request to logging: ContextWithRequest() puts the request's ID (from its
+RequestContext) and its trace/span IDs (from a W3C Trace Context traceparent header, if any) into a
+context.Context, so that everything handling the request can log with them:
+
+	ctx := http.ContextWithRequest(context.Background(), request)
+	logger.GetLogger().WithContext(ctx).Info("Handling request", "uri", request.GetURI())
+
+*/
+
+import (
+	"context"
+
+	"github.com/DigiStratum/GoLib/Logger"
+)
+
+const TRACEPARENT_HEADER = "traceparent"
+
+// Get a context.Context from ctx with the request, trace and span IDs of request for logging
+func ContextWithRequest(ctx context.Context, request HttpRequestIfc) context.Context {
+	if nil == ctx {
+		ctx = context.Background()
+	}
+	if nil == request {
+		return ctx
+	}
+	ctx = logger.ContextWithRequestId(ctx, request.GetContext().GetRequestId())
+	if headers := request.GetHeaders(); nil != headers {
+		// Header names are as the client sent them (or as net/http canonicalized them)
+		for _, name := range []string{TRACEPARENT_HEADER, "Traceparent", "TraceParent"} {
+			if values := headers.Get(name); (nil != values) && (len(*values) > 0) {
+				return logger.ContextWithTraceParent(ctx, (*values)[0])
+			}
+		}
+	}
+	return ctx
+}
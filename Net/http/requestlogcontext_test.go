@@ -0,0 +1,40 @@
+package http
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DigiStratum/GoLib/Logger"
+
+	. "github.com/DigiStratum/GoLib/Testing"
+)
+
+func TestThat_ContextWithRequest_AddsRequestAndTraceIds(t *testing.T) {
+	// Setup
+	requestContext := NewRequestContext()
+	requestContext.SetRequestId("req-1")
+	request := NewHttpRequestBuilder(METHOD_GET, "http://localhost/x").
+		SetHeaders(NewHttpHeadersBuilder().Set("Traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01").GetHttpHeaders()).
+		SetContext(requestContext).
+		GetHttpRequest()
+
+	// Test
+	ctx := ContextWithRequest(context.Background(), request)
+
+	// Verify
+	ExpectString("req-1", logger.GetRequestId(ctx), t)
+	ExpectString("4bf92f3577b34da6a3ce929d0e0e4736", logger.GetTraceId(ctx), t)
+	ExpectString("00f067aa0ba902b7", logger.GetSpanId(ctx), t)
+}
+
+func TestThat_ContextWithRequest_ReturnsContext_ForNilRequest(t *testing.T) {
+	// Test
+	ctx := ContextWithRequest(nil, nil)
+
+	// Verify
+	if !ExpectTrue(nil != ctx, t) {
+		return
+	}
+	ExpectEmptyString(logger.GetRequestId(ctx), t)
+	ExpectEmptyString(logger.GetTraceId(ctx), t)
+}
@@ -36,6 +36,8 @@ import (
 	"fmt"
 	"encoding/json"
 
+	"github.com/DigiStratum/GoLib/Data"
+	datajson "github.com/DigiStratum/GoLib/Data/json"
 	"github.com/DigiStratum/GoLib/Data/serializable"
 	objf "github.com/DigiStratum/GoLib/Object/field"
 	xc "github.com/DigiStratum/GoLib/Data/transcoder"
@@ -192,3 +194,34 @@ func (r *Object) Deserialize(data *string) error {
 	return r.FromJson(udata)
 }
 
+
+// -------------------------------------------------------------------------------------------------
+// CodecSerializableIfc Public Interface
+// -------------------------------------------------------------------------------------------------
+
+// Our JSON form goes through a DataValue so that every registered Codec (even gob) can carry it
+func (r *Object) SerializeAs(mimeType string) ([]byte, error) {
+	codec := serializable.GetCodecRegistry().Get(mimeType)
+	if nil == codec { return nil, fmt.Errorf("No Codec registered for MIME type '%s'", mimeType) }
+	dataValue := data.NewNull()
+	if (len(r.fields) > 0) || (nil != r.content) {
+		jsonString, err := r.ToJson()
+		if nil != err { return nil, err }
+		if dataValue, err = datajson.NewJson(jsonString).ToDataValue(); nil != err { return nil, err }
+	}
+	return codec.Marshal(dataValue)
+}
+
+// -------------------------------------------------------------------------------------------------
+// CodecDeserializableIfc Public Interface
+// -------------------------------------------------------------------------------------------------
+
+func (r *Object) DeserializeAs(mimeType string, encoded []byte) error {
+	codec := serializable.GetCodecRegistry().Get(mimeType)
+	if nil == codec { return fmt.Errorf("No Codec registered for MIME type '%s'", mimeType) }
+	dataValue := data.NewDataValue()
+	if err := codec.Unmarshal(encoded, dataValue); nil != err { return err }
+	if dataValue.IsNull() { return r.FromJson(nil) }
+	jsonString := dataValue.ToJson()
+	return r.FromJson(&jsonString)
+}
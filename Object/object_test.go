@@ -193,3 +193,65 @@ func TestThat_Object_GetFieldType_ReturnsFieldType_ForGoodField(t *testing.T) {
 	ExpectTrue(expectedFieldType == actualFieldType, t)
 }
 
+
+func TestThat_Object_Serialize_RoundTripsContent_WithBinaryEncodingSchemes(t *testing.T) {
+	// Setup
+	schemes := []func () enc.EncodingSchemeIfc{
+		func () enc.EncodingSchemeIfc { return enc.NewEncodingSchemeMsgPack() },
+		func () enc.EncodingSchemeIfc { return enc.NewEncodingSchemeCBOR() },
+	}
+	expected := "testcontent"
+
+	for _, scheme := range schemes {
+		transcoder := xc.NewTranscoder()
+		transcoder.SetEncoderScheme(scheme())
+		transcoder.SetDecoderScheme(scheme())
+		sut := NewObject()
+		sut.SetTranscoder(transcoder)
+		sut.SetContent(&expected)
+		restored := NewObject()
+		restored.SetTranscoder(transcoder)
+
+		// Test
+		serialized, err1 := sut.Serialize()
+		if ! ExpectNoError(err1, t) { return }
+		err2 := restored.Deserialize(serialized)
+
+		// Verify
+		ExpectNoError(err2, t)
+		if ! ExpectNonNil(restored.GetContent(), t) { return }
+		ExpectString(expected, *restored.GetContent(), t)
+	}
+}
+
+func TestThat_Object_SerializeAs_RoundTripsContent_WithEveryRegisteredCodec(t *testing.T) {
+	// Setup
+	expected := "testcontent"
+	for _, mimeType := range []string{ "application/json", "application/x-gob", "application/msgpack", "application/cbor" } {
+		sut := NewObject()
+		sut.SetContent(&expected)
+		restored := NewObject()
+
+		// Test
+		encoded, err1 := sut.SerializeAs(mimeType)
+		if ! ExpectNoError(err1, t) { return }
+		err2 := restored.DeserializeAs(mimeType, encoded)
+
+		// Verify
+		ExpectNoError(err2, t)
+		if ! ExpectNonNil(restored.GetContent(), t) { return }
+		ExpectString(expected, *restored.GetContent(), t)
+	}
+}
+
+func TestThat_Object_SerializeAs_ReturnsError_ForUnknownMimeType(t *testing.T) {
+	// Setup
+	sut := NewObject()
+
+	// Test
+	_, err := sut.SerializeAs("text/html")
+
+	// Verify
+	ExpectError(err, t)
+	ExpectError(sut.DeserializeAs("text/html", []byte{}), t)
+}
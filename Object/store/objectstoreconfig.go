@@ -2,7 +2,7 @@ package store
 
 /*
 
-Produce a new GoLib.Config instance from a JSON Object in an ObjectStore.
+Produce a new GoLib/Data/config Config instance from a JSON Object in an ObjectStore.
 
 This lets us retain certain JSON configuration data within an ObjectStore. One way we use this is
 to compile configuration details into the build as a generated ObjectStore which pulls the asset
@@ -13,14 +13,15 @@ right out of our compiled binary at runtime.
 import (
 	"fmt"
 
-	cfg "github.com/DigiStratum/GoLib/Config"
+	cfg "github.com/DigiStratum/GoLib/Data/config"
+	"github.com/DigiStratum/GoLib/Data/json"
 )
 
 // -------------------------------------------------------------------------------------------------
 // Factory Functions
 // -------------------------------------------------------------------------------------------------
 
-// Make a new Config initialized with properties from a JSON Object
+// Make a new Config initialized with the structure of a JSON Object
 func NewObjectStoreConfig(objectStore *ObjectStore, objectPath string) (*cfg.Config, error) {
 
 	// Check the ObjectStore
@@ -44,11 +45,10 @@ func NewObjectStoreConfig(objectStore *ObjectStore, objectPath string) (*cfg.Con
 	//lib.GetLogger().Trace(fmt.Sprintf("configJson: %s", *configJson))
 
 	// Load up a Config structure from the JSON
-	config := cfg.NewConfig()
-	if err := config.LoadFromJsonString(configJson); nil != err {
+	dataValue, err := json.NewJson(configJson).ToDataValue()
+	if nil != err {
 		return nil, fmt.Errorf("Config: Error parsing ObjectStore JSON ('%s'): %s", objectPath, err.Error())
 	}
-//config.Dump()
 
-	return config, nil
+	return cfg.FromDataValue(dataValue), nil
 }
@@ -21,8 +21,7 @@ separating the collections into different tables.
 Configuration:
 	* awsregion	- AWS Region identifier e.g. "us-west-1"
 	* tablename	- AWS Dynamo table to retrieve content from
-
-TODO: Add a check for whether we have been Configure()'d before allowing usage
+	* primarykey	- Name of the table's (string) partition key attribute which holds the Object path
 
 */
 
@@ -34,13 +33,17 @@ import (
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
 
 	cloud "github.com/DigiStratum/GoLib/Cloud/aws"
-	cfg "github.com/DigiStratum/GoLib/Config"
+	"github.com/DigiStratum/GoLib/Data"
+	cfg "github.com/DigiStratum/GoLib/Data/config"
 	obj "github.com/DigiStratum/GoLib/Object"
 	objs "github.com/DigiStratum/GoLib/Object/store"
 )
 
 type ObjectStoreDynamo struct {
-	storeConfig cfg.ConfigIfc
+	*cfg.Configurable
+	awsRegion   string
+	tableName   string
+	primaryKey  string
 	readCache   objs.MutableObjectStoreIfc
 	awsHelper   cloud.AWSHelperIfc
 	awsDynamoDB *dynamodb.DynamoDB // TODO: change to IFC
@@ -55,6 +58,23 @@ func NewObjectStoreDynamo() *ObjectStoreDynamo {
 	r := ObjectStoreDynamo{
 		readCache: objs.NewMutableObjectStore(),
 	}
+	r.Configurable = cfg.NewConfigurable(
+		cfg.NewConfigItem("awsregion").
+			SetRequired().
+			SetType(cfg.CONFIG_ITEM_TYPE_STRING).
+			SetDescription("AWS Region identifier e.g. \"us-west-1\"").
+			CaptureWith(cfg.CaptureString(&r.awsRegion)),
+		cfg.NewConfigItem("tablename").
+			SetRequired().
+			SetType(cfg.CONFIG_ITEM_TYPE_STRING).
+			SetDescription("AWS Dynamo table to retrieve content from").
+			CaptureWith(cfg.CaptureString(&r.tableName)),
+		cfg.NewConfigItem("primarykey").
+			SetRequired().
+			SetType(cfg.CONFIG_ITEM_TYPE_STRING).
+			SetDescription("Name of the table's (string) partition key attribute which holds the Object path").
+			CaptureWith(cfg.CaptureString(&r.primaryKey)),
+	)
 	return &r
 }
 
@@ -65,17 +85,15 @@ func NewObjectStoreDynamo() *ObjectStoreDynamo {
 func (r *ObjectStoreDynamo) Configure(config cfg.ConfigIfc) error {
 
 	// Validate that the config has what we need for AWS Dynamo!
-	requiredConfig := []string{"awsregion", "tablename", "primarykey"}
-	if !(config.HasAll(requiredConfig...)) {
-		return fmt.Errorf("Incomplete ObjectStoreDynamo configuration provided")
+	if err := r.Configurable.Reconfigure(config); nil != err {
+		return fmt.Errorf("Incomplete ObjectStoreDynamo configuration provided: %s", err.Error())
 	}
-	r.storeConfig = config
 
 	// Light up our AWS Helper with the region from our configuration data
+	helperConfig := cfg.NewConfig()
+	helperConfig.PrepareObject().SetObjectProperty("awsRegion", data.NewString(r.awsRegion))
 	r.awsHelper = cloud.NewAWSHelper()
-	awsHelperConfigKeys := []string{"awsregion"}
-	r.awsHelper.Configure(config.GetSubsetKeys(&awsHelperConfigKeys))
-	return nil
+	return r.awsHelper.Configure(helperConfig)
 }
 
 // -------------------------------------------------------------------------------------------------
@@ -87,23 +105,21 @@ func (r *ObjectStoreDynamo) Configure(config cfg.ConfigIfc) error {
 // Use read-through cache which requires us to mutate state
 func (r *ObjectStoreDynamo) GetObject(path string) (*obj.Object, error) {
 	// Require configuration
-	if nil == r.storeConfig {
+	if ! r.IsStarted() {
 		return nil, fmt.Errorf("Not Configured!")
 	}
 
 	// If it's not yet in the cache
 	if !r.readCache.HasObject(path) {
 		// TODO: Read the Object from our Dynamo Table into cache
-		primaryKey := r.storeConfig.Get("primarykey")
 		key := map[string]*dynamodb.AttributeValue{
-			*primaryKey: {
+			r.primaryKey: {
 				S: aws.String(path),
 			},
 		}
-		tableName := r.storeConfig.Get("tablename")
 		input := &dynamodb.GetItemInput{
 			Key:       key,
-			TableName: aws.String(*tableName),
+			TableName: aws.String(r.tableName),
 		}
 		result, err := r.awsDynamoDB.GetItem(input)
 		if nil != err {
@@ -133,7 +149,7 @@ func (r *ObjectStoreDynamo) GetObject(path string) (*obj.Object, error) {
 
 func (r ObjectStoreDynamo) HasObject(path string) (bool, error) {
 	// Require configuration
-	if nil == r.storeConfig {
+	if ! r.IsStarted() {
 		return false, fmt.Errorf("Not Configured!")
 	}
 
@@ -153,7 +169,7 @@ func (r ObjectStoreDynamo) HasObject(path string) (bool, error) {
 
 func (r *ObjectStoreDynamo) PutObject(path string, object *obj.Object) error {
 	// Require configuration
-	if nil == r.storeConfig {
+	if ! r.IsStarted() {
 		return fmt.Errorf("Not Configured!")
 	}
 
@@ -20,8 +20,8 @@ import (
 	"strings"
 
 	cloud "github.com/DigiStratum/GoLib/Cloud/aws"
-	cfg "github.com/DigiStratum/GoLib/Config"
-	mysql "github.com/DigiStratum/GoLib/DB/MySQL"
+	cfg "github.com/DigiStratum/GoLib/Data/config"
+	db "github.com/DigiStratum/GoLib/DB"
 	obj "github.com/DigiStratum/GoLib/Object"
 	objs "github.com/DigiStratum/GoLib/Object/store"
 )
@@ -45,12 +45,13 @@ type querySpec struct {
 // A given database object spec couples access queries with matching field definitions
 type objectSpec struct {
 	//template		ObjectTemplate
-	queries map[string]mysql.QueryIfc
+	queries map[string]db.QueryIfc
 }
 
 // FIXME: Don't export this
 type ObjectStoreMySQL struct {
-	storeConfig cfg.ConfigIfc
+	*cfg.Configurable
+	dsn         string
 	readCache   *objs.MutableObjectStore
 	awsHelper   cloud.AWSHelperIfc
 	objectSpecs map[string]objectSpec // Object spec names must be part of object "path"
@@ -66,6 +67,13 @@ func NewObjectStoreMySQL() *ObjectStoreMySQL {
 		readCache:   objs.NewMutableObjectStore(),
 		objectSpecs: make(map[string]objectSpec),
 	}
+	r.Configurable = cfg.NewConfigurable(
+		cfg.NewConfigItem("dsn").
+			SetRequired().
+			SetType(cfg.CONFIG_ITEM_TYPE_STRING).
+			SetDescription("Data Source Name (DSN) for MySQL database connection").
+			CaptureWith(cfg.CaptureString(&r.dsn)),
+	)
 	return &r
 }
 
@@ -76,11 +84,9 @@ func NewObjectStoreMySQL() *ObjectStoreMySQL {
 func (r *ObjectStoreMySQL) Configure(config cfg.ConfigIfc) error {
 
 	// Validate that the config has what we need for MySQL!
-	requiredConfig := []string{"dsn"}
-	if !(config.HasAll(requiredConfig...)) {
-		return fmt.Errorf("Incomplete ObjectStoreMySQL configuration provided")
+	if err := r.Configurable.Reconfigure(config); nil != err {
+		return fmt.Errorf("Incomplete ObjectStoreMySQL configuration provided: %s", err.Error())
 	}
-	r.storeConfig = config
 
 	// Light up our AWS Helper with the region from our configuration data
 	//os.awsHelper = cloud.NewAWSHelper(config.Get("awsregion"))
@@ -94,7 +100,7 @@ func (r *ObjectStoreMySQL) Configure(config cfg.ConfigIfc) error {
 // path format: "objectspecname?key1=value1&key2=value2&keyN=valueN
 func (r *ObjectStoreMySQL) GetObject(path string) (*obj.Object, error) {
 	// Require configuration
-	if nil == r.storeConfig {
+	if ! r.IsStarted() {
 		return nil, fmt.Errorf("Not Configured!")
 	}
 
@@ -108,7 +114,7 @@ func (r *ObjectStoreMySQL) GetObject(path string) (*obj.Object, error) {
 // path format: "objectspecname?key1=value1&key2=value2&keyN=valueN
 func (r *ObjectStoreMySQL) HasObject(path string) (bool, error) {
 	// Require configuration
-	if nil == r.storeConfig {
+	if ! r.IsStarted() {
 		return false, fmt.Errorf("Not Configured!")
 	}
 
@@ -153,7 +159,7 @@ func (r *ObjectStoreMySQL) HasObject(path string) (bool, error) {
 // blank keys to create with autoincrement; INSERT ... ON DUPLICATE KEY UPDATE syntax for create/update
 func (r *ObjectStoreMySQL) PutObject(path string, object *obj.Object) error {
 	// Require configuration
-	if nil == r.storeConfig {
+	if ! r.IsStarted() {
 		return fmt.Errorf("Not Configured!")
 	}
 
@@ -31,13 +31,17 @@ import (
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 
 	cloud "github.com/DigiStratum/GoLib/Cloud/aws"
-	cfg "github.com/DigiStratum/GoLib/Config"
+	"github.com/DigiStratum/GoLib/Data"
+	cfg "github.com/DigiStratum/GoLib/Data/config"
 	obj "github.com/DigiStratum/GoLib/Object"
 	objs "github.com/DigiStratum/GoLib/Object/store"
 )
 
 type ObjectStoreS3 struct {
-	storeConfig     cfg.ConfigIfc
+	*cfg.Configurable
+	awsRegion       string
+	s3Bucket        string
+	s3Folder        string
 	awsS3           *s3.S3
 	awsS3Downloader *s3manager.Downloader
 	readCache       *objs.MutableObjectStore
@@ -53,6 +57,23 @@ func NewObjectStoreS3() *ObjectStoreS3 {
 	r := ObjectStoreS3{
 		readCache: objs.NewMutableObjectStore(),
 	}
+	r.Configurable = cfg.NewConfigurable(
+		cfg.NewConfigItem("awsregion").
+			SetRequired().
+			SetType(cfg.CONFIG_ITEM_TYPE_STRING).
+			SetDescription("AWS Region identifier e.g. \"us-west-1\"").
+			CaptureWith(cfg.CaptureString(&r.awsRegion)),
+		cfg.NewConfigItem("s3bucket").
+			SetRequired().
+			SetType(cfg.CONFIG_ITEM_TYPE_STRING).
+			SetDescription("AWS S3 Bucket to retrieve content from").
+			CaptureWith(cfg.CaptureString(&r.s3Bucket)),
+		cfg.NewConfigItem("s3folder").
+			SetRequired().
+			SetType(cfg.CONFIG_ITEM_TYPE_STRING).
+			SetDescription("AWS S3 Folder to prepend to any path (no trailing slash)").
+			CaptureWith(cfg.CaptureString(&r.s3Folder)),
+	)
 	return &r
 }
 
@@ -63,19 +84,15 @@ func NewObjectStoreS3() *ObjectStoreS3 {
 func (r *ObjectStoreS3) Configure(config cfg.ConfigIfc) error {
 
 	// Validate that the config has what we need for S3!
-	requiredConfig := []string{"awsregion", "s3bucket", "s3folder"}
-	if !(config.HasAll(requiredConfig...)) {
-		return fmt.Errorf("Incomplete ObjectStoreS3 configuration provided")
+	if err := r.Configurable.Reconfigure(config); nil != err {
+		return fmt.Errorf("Incomplete ObjectStoreS3 configuration provided: %s", err.Error())
 	}
-	r.storeConfig = config
 
 	// Light up our AWS Helper with the region from our configuration data
-	helperConfigKeys := []string{"awsregion"}
-	helperConfig := config.GetSubsetKeys(&helperConfigKeys)
+	helperConfig := cfg.NewConfig()
+	helperConfig.PrepareObject().SetObjectProperty("awsRegion", data.NewString(r.awsRegion))
 	r.awsHelper = cloud.NewAWSHelper()
-	r.awsHelper.Configure(helperConfig)
-
-	return nil
+	return r.awsHelper.Configure(helperConfig)
 }
 
 // -------------------------------------------------------------------------------------------------
@@ -85,7 +102,7 @@ func (r *ObjectStoreS3) Configure(config cfg.ConfigIfc) error {
 // Ref: https://stackoverflow.com/questions/41645377/golang-s3-download-to-buffer-using-s3manager-downloader
 func (r *ObjectStoreS3) GetObject(path string) (*obj.Object, error) {
 	// Require configuration
-	if nil == r.storeConfig {
+	if ! r.IsStarted() {
 		return nil, fmt.Errorf("Not Configured!")
 	}
 
@@ -96,17 +113,16 @@ func (r *ObjectStoreS3) GetObject(path string) (*obj.Object, error) {
 		downloader := r.getS3Downloader()
 
 		// The S3 key is the path prefixed with our configured folder for this store, if any
-		s3Folder := r.storeConfig.Get("s3folder")
 		key := path
-		if len(*s3Folder) > 0 {
-			key = fmt.Sprintf("%s/%s", *s3Folder, path)
+		if len(r.s3Folder) > 0 {
+			key = fmt.Sprintf("%s/%s", r.s3Folder, path)
 		}
 
 		// Now try to download the object from S3
 		_, err := downloader.Download(
 			buff,
 			&s3.GetObjectInput{
-				Bucket: aws.String(*r.storeConfig.Get("s3bucket")),
+				Bucket: aws.String(r.s3Bucket),
 				Key:    aws.String(key),
 			},
 		)
@@ -131,7 +147,7 @@ func (r *ObjectStoreS3) GetObject(path string) (*obj.Object, error) {
 
 func (r ObjectStoreS3) HasObject(path string) (bool, error) {
 	// Require configuration
-	if nil == r.storeConfig {
+	if ! r.IsStarted() {
 		return false, fmt.Errorf("Not Configured!")
 	}
 
@@ -144,8 +160,8 @@ func (r ObjectStoreS3) HasObject(path string) (bool, error) {
 	// ref: github.com/aws/aws-sdk-go/service/s3/examples_test.go ("HeadObject")
 	awsS3 := r.getS3()
 	_, err := awsS3.HeadObject(&s3.HeadObjectInput{
-		Bucket: aws.String(*r.storeConfig.Get("s3bucket")),
-		Key:    aws.String(*r.storeConfig.Get("s3folder") + "/" + path),
+		Bucket: aws.String(r.s3Bucket),
+		Key:    aws.String(r.s3Folder + "/" + path),
 	},
 	)
 	return nil == err, err
@@ -157,7 +173,7 @@ func (r ObjectStoreS3) HasObject(path string) (bool, error) {
 
 func (r *ObjectStoreS3) PutObject(path string, object *obj.Object) error {
 	// Require configuration
-	if nil == r.storeConfig {
+	if ! r.IsStarted() {
 		return fmt.Errorf("Not Configured!")
 	}
 
@@ -0,0 +1,77 @@
+package redactor
+
+/*
+
+A Redactor masks known secret values (such as passwords resolved from a secret store) wherever they
+turn up in a string. Our singleton is applied to every message that a Logger produces, so anything
+that obtains a secret should add it here to keep it out of the logs. It lives apart from Logger so
+that such things (Config, for one) needn't depend on Logger to do so.
+
+Secrets shorter than REDACTOR_MIN_SECRET_LENGTH are ignored; masking every occurrence of "1" would
+make a mess of the logs while protecting nothing.
+
+*/
+
+import (
+	"sort"
+	"sync"
+	"strings"
+)
+
+const REDACTED = "[REDACTED]"
+const REDACTOR_MIN_SECRET_LENGTH = 4
+
+type RedactorIfc interface {
+	AddSecret(secret string) *redactor
+	Redact(message string) string
+}
+
+type redactor struct {
+	mutex		sync.RWMutex
+	secrets		[]string	// Longest first so that a secret containing another is masked whole
+}
+
+var redactorInstance *redactor
+
+// Automagically set up our default singleton
+func init() {
+	redactorInstance = NewRedactor()
+}
+
+// Get our singleton instance
+func GetRedactor() *redactor {
+	return redactorInstance
+}
+
+// -------------------------------------------------------------------------------------------------
+// Factory Functions
+// -------------------------------------------------------------------------------------------------
+
+func NewRedactor() *redactor {
+	return &redactor{ secrets: make([]string, 0) }
+}
+
+// -------------------------------------------------------------------------------------------------
+// RedactorIfc Public Interface
+// -------------------------------------------------------------------------------------------------
+
+func (r *redactor) AddSecret(secret string) *redactor {
+	if len(secret) < REDACTOR_MIN_SECRET_LENGTH { return r }
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, existing := range r.secrets {
+		if existing == secret { return r }
+	}
+	r.secrets = append(r.secrets, secret)
+	sort.SliceStable(r.secrets, func(i, j int) bool { return len(r.secrets[i]) > len(r.secrets[j]) })
+	return r
+}
+
+func (r *redactor) Redact(message string) string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	for _, secret := range r.secrets {
+		message = strings.ReplaceAll(message, secret, REDACTED)
+	}
+	return message
+}
@@ -0,0 +1,29 @@
+package redactor
+
+import(
+	"testing"
+
+	. "github.com/DigiStratum/GoLib/Testing"
+)
+
+func TestThat_Redactor_NewRedactor_ReturnsSomething(t *testing.T) {
+	// Test
+	var sut RedactorIfc = NewRedactor() // <- ensures that we satisfy our interface
+
+	// Verify
+	ExpectNonNil(sut, t)
+}
+
+func TestThat_Redactor_Redact_MasksSecrets_LongestFirst(t *testing.T) {
+	// Setup
+	sut := NewRedactor().
+		AddSecret("hunter2").
+		AddSecret("hunter2hunter2").
+		AddSecret("abc")
+
+	// Test
+	actual := sut.Redact("a=hunter2hunter2 b=hunter2 c=abc")
+
+	// Verify
+	ExpectString("a=" + REDACTED + " b=" + REDACTED + " c=abc", actual, t)
+}
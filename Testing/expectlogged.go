@@ -0,0 +1,70 @@
+package testing
+
+/*
+
+Expect*() helpers for log output captured in unit tests, e.g. by a logger.MemoryLogWriter:
+
+	logWriter := logger.CaptureLogger(t)
+	// ...
+	ExpectLogged(logWriter, logger.WARN, `Connection to \S+ refused`, t)
+
+These are generic over the log level type so that we needn't import the Logger (whose own tests use
+us); it is settled by the level given.
+
+*/
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// Anything that can tell us what was logged at a level, matching a regular expression pattern
+type LoggedMatcherIfc[L any] interface {
+	MatchLogged(level L, pattern string) ([]string, error)
+	GetLogged() []string
+}
+
+// Expect at least one thing logged at level matching pattern
+func ExpectLogged[L any](logged LoggedMatcherIfc[L], level L, pattern string, t *testing.T) bool {
+	matches, err := logged.MatchLogged(level, pattern)
+	if (nil == err) && (len(matches) > 0) {
+		return true
+	}
+	t.Errorf(
+		"\n\n%s:\nExpect: logged at %s matching [%s], Actual: %s",
+		getCaller(), describeLevel(level), pattern, describeLogged(logged.GetLogged(), err),
+	)
+	return false
+}
+
+// Expect nothing logged at level matching pattern
+func ExpectNotLogged[L any](logged LoggedMatcherIfc[L], level L, pattern string, t *testing.T) bool {
+	matches, err := logged.MatchLogged(level, pattern)
+	if (nil == err) && (0 == len(matches)) {
+		return true
+	}
+	t.Errorf(
+		"\n\n%s:\nExpect: nothing logged at %s matching [%s], Actual: %s",
+		getCaller(), describeLevel(level), pattern, describeLogged(matches, err),
+	)
+	return false
+}
+
+// Levels such as logger.LogLevel know their own names
+func describeLevel(level interface{}) string {
+	if named, ok := level.(interface{ ToString() string }); ok {
+		return named.ToString()
+	}
+	return fmt.Sprintf("%v", level)
+}
+
+func describeLogged(logged []string, err error) string {
+	if nil != err {
+		return "error('" + err.Error() + "')"
+	}
+	if 0 == len(logged) {
+		return "nothing logged"
+	}
+	return "\n\t" + strings.Join(logged, "\n\t")
+}
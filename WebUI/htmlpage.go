@@ -12,10 +12,12 @@ import(
 	"fmt"
 
 	log "github.com/DigiStratum/GoLib/Logger"
-	cfg "github.com/DigiStratum/GoLib/Config"
+	"github.com/DigiStratum/GoLib/Data"
+	cfg "github.com/DigiStratum/GoLib/Data/config"
 )
 
-const DEREFERENCE_MAX_LOOPS = 5
+// Fragments and context values may reference each other this deep
+const DEREFERENCE_MAX_DEPTH = 5
 
 type HtmlPage struct {
 	context			*cfg.Config	// Contextual/Configuration data available for injection into Document
@@ -60,13 +62,19 @@ func (page *HtmlPage) renderDocument() *string {
 	if nil == page.context {
 		page.context = cfg.NewConfig()
 	}
-	page.context.Set("stylesheet", page.scheme.GetStylesheet())
+	if ! page.context.IsObject() {
+		page.context.PrepareObject()
+	}
+	page.context.SetObjectProperty("stylesheet", data.NewString(page.scheme.GetStylesheet()))
 
 	// TODO: Any string translations needed?
 
 	// Dereference Context/Config
-	page.context.DereferenceLoop(DEREFERENCE_MAX_LOOPS, page.context)
-	page.document = page.context.DereferenceString(*tmp)
+	page.context.SetMaxDepth(DEREFERENCE_MAX_DEPTH).Dereference()
+	if err := page.context.GetDereferenceError(); nil != err {
+		log.GetLogger().Warn("Incomplete dereferencing of page context: %s", err.Error())
+	}
+	page.document, _ = page.context.DereferenceString(*tmp)
 
 	// Return the final, rendered document
 	return page.document
@@ -74,20 +82,18 @@ func (page *HtmlPage) renderDocument() *string {
 
 // Dereference all the Scheme's page Fragments, then Dereference the supplied document against them
 func (page *HtmlPage) dereferenceFragments(document *string) *string {
-	fragments := cfg.NewConfig()
+	fragments := cfg.NewConfig().SetMaxDepth(DEREFERENCE_MAX_DEPTH)
+	fragments.PrepareObject()
 	fragmap := page.scheme.GetFragMap()
 	for fragname, fragment := range fragmap {
 		// Fragment magic tags are as '%frag:fragment_name%'
-		fragments.Set(fmt.Sprintf("frag:%s", fragname), fragment.Content)
+		fragments.SetObjectProperty(fmt.Sprintf("frag:%s", fragname), data.NewString(fragment.Content))
 	}
-	fullyResolved := fragments.DereferenceLoop(DEREFERENCE_MAX_LOOPS, fragments)
-	if ! fullyResolved {
-		log.GetLogger().Warn("%s", fmt.Sprintf(
-			"Possible incomplete dereferencing of page fragments with %d loops",
-			DEREFERENCE_MAX_LOOPS,
-		));
+	fragments.Dereference()
+	if err := fragments.GetDereferenceError(); nil != err {
+		log.GetLogger().Warn("Incomplete dereferencing of page fragments: %s", err.Error())
 	}
-	rv := fragments.DereferenceString(*document)
+	rv, _ := fragments.DereferenceString(*document)
 	return rv
 }
 